@@ -1,22 +1,46 @@
 package main
 
 import (
+	"context"
+	"log"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/config"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
 	authinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/carrier"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/gateway"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/mongo"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/pdf"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/routes"
 
+	addressusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/address"
+	auctionusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/auction"
 	authusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/auth"
 	cartitemusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/cartitem"
+	creditusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/credit"
+	disputeusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/dispute"
+	feeusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/fee"
+	invoiceusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/invoice"
+	jobusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/job"
+	ledgerusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/ledger"
+	moneyusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/money"
+	notificationusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/notification"
+	offerusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/offer"
+	payoutusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/payout"
+	promotionusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/promotion"
+	returnsusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/returns"
+	taxusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/tax"
 
 	bundleusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/bundle"
 	orderusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/order"
 	productusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/product"
 	reviewusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/review"
+	shipmentusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/shipment"
 	trustusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/trust"
 	userusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/user"
 	warehouse_usecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/warehouse"
@@ -38,6 +62,8 @@ func main() {
 	// Init shared services
 	jwtSvc := authinfra.NewJWTService(appConfig.JWTSecret)
 	passSvc := authinfra.NewPasswordService()
+	paymentGateway := gateway.NewFakeGateway(appConfig.PaymentWebhookSecret)
+	payoutProvider := gateway.NewFakePayoutProvider()
 
 	// Init Repositories
 	userRepo := mongo.NewMongoUserRepository(db)
@@ -48,30 +74,127 @@ func main() {
 	reviewRepo := mongo.NewReviewRepository(db)            // Add review repository
 	warehouseRepo := mongo.NewMongoWarehouseRepository(db) // Add warehouse repository
 	paymentRepo := mongo.NewMongoPaymentRepository(db)     // Add payment repository
+	unitOfWork := mongo.NewMongoUnitOfWork(db)
+	jobRepo := mongo.NewMongoJobRepository(db)
+	shipmentRepo := mongo.NewMongoShipmentRepository(db)
+	addressRepo := mongo.NewMongoAddressRepository(db)
+	feeRepo := mongo.NewMongoFeeRepository(db)
+	ledgerRepo := mongo.NewMongoLedgerRepository(db)
+	payoutRepo := mongo.NewMongoPayoutRepository(db)
+	escrowRepo := mongo.NewMongoEscrowRepository(db)
+	disputeRepo := mongo.NewMongoDisputeRepository(db)
+	idempotencyRepo := mongo.NewMongoIdempotencyRepository(db)
+	promotionRepo := mongo.NewMongoPromotionRepository(db)
+	creditRepo := mongo.NewMongoCreditRepository(db)
+	invoiceRepo := mongo.NewMongoInvoiceRepository(db)
+	moneyRepo := mongo.NewMongoMoneyRepository(db)
+	taxRepo := mongo.NewMongoTaxRepository(db)
+	returnsRepo := mongo.NewMongoReturnsRepository(db)
+	notificationRepo := mongo.NewMongoNotificationRepository(db)
+	auctionRepo := mongo.NewMongoAuctionRepository(db)
+	offerRepo := mongo.NewMongoOfferRepository(db)
+	if err := bundleRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Println("Failed to create bundle search indexes:", err)
+	}
+	if err := bundleRepo.BackfillSupplierTrust(context.Background()); err != nil {
+		log.Println("Failed to backfill bundle supplier trust:", err)
+	}
+	if err := mongo.EnsureProductIndexes(context.Background(), db); err != nil {
+		log.Println("Failed to create product search indexes:", err)
+	}
+	if err := mongo.EnsurePromotionIndexes(context.Background(), db); err != nil {
+		log.Println("Failed to create promotion indexes:", err)
+	}
+	if err := mongo.EnsureReturnsIndexes(context.Background(), db); err != nil {
+		log.Println("Failed to create returns indexes:", err)
+	}
+	if err := mongo.EnsureJobIndexes(context.Background(), db); err != nil {
+		log.Println("Failed to create job indexes:", err)
+	}
+	if err := mongo.MigrateMoney(context.Background(), db); err != nil {
+		log.Println("Failed to migrate stored amounts to money:", err)
+	}
+	if err := mongo.MigratePaymentTimes(context.Background(), db); err != nil {
+		log.Println("Failed to migrate payment times to dates:", err)
+	}
 
 	// Init Usecases
+	clock := job.SystemClock{}
+	jobUC := jobusecase.NewJobUsecase(jobRepo, clock)
 	userUC := userusecase.NewUserUsecase(userRepo)
 	authUC := authusecase.NewAuthUsecase(userRepo, passSvc, jwtSvc)
 	productUC := productusecase.NewProductUsecase(productRepo, bundleRepo)
 	bundleUC := bundleusecase.NewBundleUsecase(bundleRepo)
 	trustUC := trustusecase.NewTrustUsecase(productRepo, bundleRepo, userRepo)
-	cartItemUC := cartitemusecase.NewCartItemUsecase(cartItemRepo, productRepo)
-
-	reviewUC := reviewusecase.NewReviewUsecase(reviewRepo, orderRepo)                                     // Add review usecase
-	orderSvc := orderusecase.NewOrderUsecase(bundleRepo, orderRepo, warehouseRepo, paymentRepo, userRepo) // Add order service
-	warehouseSvc := warehouse_usecase.NewWarehouseUseCase(warehouseRepo)
+	addressUC := addressusecase.NewAddressUsecase(addressRepo, unitOfWork)
+	feeUC := feeusecase.NewFeeUsecase(feeRepo, userRepo)
+	ledgerUC := ledgerusecase.NewLedgerUsecase(ledgerRepo)
+	promotionUC := promotionusecase.NewPromotionUsecase(promotionRepo, clock)
+	creditUC := creditusecase.NewCreditUsecase(creditRepo, userRepo, unitOfWork, ledgerUC, clock)
+	moneyUC := moneyusecase.NewMoneyUsecase(moneyRepo, clock)
+	taxUC := taxusecase.NewTaxUsecase(taxRepo, userRepo, paymentRepo, clock)
+	invoiceUC := invoiceusecase.NewInvoiceUsecase(invoiceRepo, orderRepo, paymentRepo, userRepo, unitOfWork, pdf.NewInvoiceRenderer(), clock)
+	cartItemUC := cartitemusecase.NewCartItemUsecase(cartItemRepo, productRepo, orderRepo, paymentRepo, paymentGateway, addressUC, feeUC, ledgerUC, promotionUC, creditUC, moneyUC, taxUC, invoiceUC, jobUC, unitOfWork)
+
+	reviewUC := reviewusecase.NewReviewUsecase(reviewRepo, orderRepo)                                                                                                                                                       // Add review usecase
+	orderSvc := orderusecase.NewOrderUsecase(bundleRepo, orderRepo, warehouseRepo, paymentRepo, userRepo, productRepo, unitOfWork, paymentGateway, jobUC, feeUC, ledgerUC, escrowRepo, creditUC, moneyUC, taxUC, invoiceUC) // Add order service
+	warehouseSvc := warehouse_usecase.NewWarehouseUseCase(warehouseRepo, orderSvc, unitOfWork)
+	payoutUC := payoutusecase.NewPayoutUsecase(payoutRepo, paymentRepo, orderRepo, unitOfWork, jobUC, clock, payoutProvider, ledgerUC, appConfig.PayoutHoldPeriod)
+	disputeUC := disputeusecase.NewDisputeUsecase(disputeRepo, orderSvc, unitOfWork, jobUC)
+	returnsUC := returnsusecase.NewReturnsUsecase(returnsRepo, orderSvc, productRepo, unitOfWork, jobUC, clock)
+	notificationUC := notificationusecase.NewNotificationUsecase(notificationRepo, clock)
+	auctionUC := auctionusecase.NewAuctionUsecase(auctionRepo, bundleRepo, orderSvc, notificationUC, unitOfWork, jobUC, clock)
+	offerUC := offerusecase.NewOfferUsecase(offerRepo, bundleRepo, productRepo, unitOfWork, jobUC, clock)
+	shipmentUC := shipmentusecase.NewShipmentUsecase(shipmentRepo, orderRepo, unitOfWork, jobUC, clock, carrier.NewLocalCarrier(clock))
+
+	// Init background workers
+	workerPool := jobusecase.NewWorkerPool(jobRepo, clock, appConfig.JobWorkers, 5*time.Second)
+	workerPool.Register(job.TypeListWarehouseItem, jobusecase.NewListWarehouseItemHandler(warehouseRepo))
+	workerPool.Register(job.TypeReleaseEscrow, jobusecase.NewReleaseEscrowHandler(orderSvc))
+	workerPool.Register(job.TypeSettleRefund, jobusecase.NewSettleRefundHandler(orderSvc))
+	workerPool.Register(job.TypeRefundCharge, jobusecase.NewRefundChargeHandler(paymentGateway))
+	workerPool.Register(job.TypeReleasePromotion, jobusecase.NewReleasePromotionHandler(promotionUC))
+	workerPool.Register(job.TypeRestoreCredit, jobusecase.NewRestoreCreditHandler(creditUC))
+	workerPool.Register(job.TypeTrackShipment, jobusecase.NewTrackShipmentHandler(shipmentUC))
+	workerPool.Register(job.TypeSettlePayouts, jobusecase.NewRecurringHandler(jobUC, appConfig.PayoutInterval, jobusecase.NewSettlePayoutsHandler(payoutUC)))
+	workerPool.Register(job.TypeSendPayout, jobusecase.NewSendPayoutHandler(payoutUC))
+	workerPool.Register(job.TypeApproveReturn, jobusecase.NewApproveReturnHandler(returnsUC))
+	workerPool.Register(job.TypeRecordLostDispute, jobusecase.NewRecordLostDisputeHandler(trustUC))
+	workerPool.Register(job.TypeCloseAuction, jobusecase.NewCloseAuctionHandler(auctionUC))
+	workerPool.Register(job.TypeExpireOffer, jobusecase.NewExpireOfferHandler(offerUC))
+	workerPool.Register(job.TypeReleaseOffer, jobusecase.NewReleaseOfferHandler(offerUC))
+	if _, err := jobUC.ScheduleRecurring(context.Background(), job.TypeSettlePayouts, appConfig.PayoutInterval); err != nil {
+		log.Println("Failed to schedule payout settlement:", err)
+	}
+	go workerPool.Start(context.Background())
 
 	// Init Controllers
 	authCtrl := controllers.NewAuthController(authUC)
 	adminCtrl := controllers.NewAdminController(userUC, orderSvc)
-	productCtrl := controllers.NewProductController(productUC, trustUC, bundleUC, warehouseRepo)
-	bundleCtrl := controllers.NewBundleController(bundleUC, userUC)
-	consumerCtrl := controllers.NewConsumerController(orderRepo)
+	productCtrl := controllers.NewProductController(productUC, trustUC, bundleUC, warehouseRepo, moneyUC)
+	bundleCtrl := controllers.NewBundleController(bundleUC, userUC, moneyUC)
+	consumerCtrl := controllers.NewConsumerController(orderRepo, productRepo, shipmentRepo)
 	supplierCtrl := controllers.NewSupplierController(orderSvc) // Add consumer controller
 	cartItemCtrl := controllers.NewCartItemController(cartItemUC)
 	reviewCtrl := controllers.NewReviewController(reviewUC) // Add review controller
 	warehouseCtrl := controllers.NewWarehouseController(warehouseSvc)
 	orderCtrl := controllers.NewOrderController(orderSvc) // Add order controller
+	jobCtrl := controllers.NewJobController(jobUC)
+	shipmentCtrl := controllers.NewShipmentController(shipmentUC)
+	addressCtrl := controllers.NewAddressController(addressUC)
+	feeCtrl := controllers.NewFeeController(feeUC)
+	taxCtrl := controllers.NewTaxController(taxUC)
+	promotionCtrl := controllers.NewPromotionController(promotionUC)
+	creditCtrl := controllers.NewCreditController(creditUC)
+	invoiceCtrl := controllers.NewInvoiceController(invoiceUC)
+	exchangeRateCtrl := controllers.NewExchangeRateController(moneyUC)
+	ledgerCtrl := controllers.NewLedgerController(ledgerUC)
+	payoutCtrl := controllers.NewPayoutController(payoutUC)
+	disputeCtrl := controllers.NewDisputeController(disputeUC)
+	returnsCtrl := controllers.NewReturnsController(returnsUC)
+	auctionCtrl := controllers.NewAuctionController(auctionUC)
+	offerCtrl := controllers.NewOfferController(offerUC)
+	notificationCtrl := controllers.NewNotificationController(notificationUC)
 
 	// Init Gin Engine and Routes
 	r := gin.Default()
@@ -79,10 +202,26 @@ func main() {
 	routes.RegisterAuthRoutes(r, authCtrl)
 	routes.RegisterProductRoutes(r, productCtrl, jwtSvc, reviewCtrl) // Register product routes with review controller
 	routes.RegisterAdminRoutes(r, adminCtrl, jwtSvc)
+	routes.RegisterJobRoutes(r, jobCtrl, jwtSvc)
+	routes.RegisterFeeRoutes(r, feeCtrl, jwtSvc)
+	routes.RegisterTaxRoutes(r, taxCtrl, jwtSvc)
+	routes.RegisterPromotionRoutes(r, promotionCtrl, jwtSvc)
+	routes.RegisterCreditRoutes(r, creditCtrl, jwtSvc)
+	routes.RegisterLedgerRoutes(r, ledgerCtrl, jwtSvc)
+	routes.RegisterPayoutRoutes(r, payoutCtrl, jwtSvc)
 	routes.RegisterBundleRoutes(r, bundleCtrl, jwtSvc)
-	routes.RegisterCartItemRoutes(r, cartItemCtrl, jwtSvc) // Register cart item routes
-
-	routes.RegisterOrderRoutes(r, orderCtrl, consumerCtrl, jwtSvc) // Register order routes
+	routes.RegisterCartItemRoutes(r, cartItemCtrl, jwtSvc, idempotencyRepo) // Register cart item routes
+
+	routes.RegisterOrderRoutes(r, orderCtrl, consumerCtrl, jwtSvc, idempotencyRepo) // Register order routes
+	routes.RegisterInvoiceRoutes(r, invoiceCtrl, jwtSvc)
+	routes.RegisterExchangeRateRoutes(r, exchangeRateCtrl, jwtSvc)
+	routes.RegisterShipmentRoutes(r, shipmentCtrl, jwtSvc)
+	routes.RegisterDisputeRoutes(r, disputeCtrl, jwtSvc)
+	routes.RegisterReturnsRoutes(r, returnsCtrl, jwtSvc)
+	routes.RegisterAuctionRoutes(r, auctionCtrl, jwtSvc)
+	routes.RegisterOfferRoutes(r, offerCtrl, jwtSvc)
+	routes.RegisterNotificationRoutes(r, notificationCtrl, jwtSvc)
+	routes.RegisterAddressRoutes(r, addressCtrl, jwtSvc)
 	routes.RegisterSupplierRoutes(r, supplierCtrl, jwtSvc)
 	routes.RegisterWarehouseRoutes(r, warehouseCtrl, jwtSvc)
 	routes.RegisterResellerRoutes(r, supplierCtrl, jwtSvc)
@@ -1,20 +1,30 @@
 package config
 
+import "time"
+
 // This file is intentionally left minimal.
 // All env loading is done in env.go.
 
 // You can optionally define structs or constants here for grouped configs.
 
 type AppConfig struct {
-	DBURI     string
-	DBName    string
-	JWTSecret string
+	DBURI                string
+	DBName               string
+	JWTSecret            string
+	PaymentWebhookSecret string
+	JobWorkers           int
+	PayoutInterval       time.Duration
+	PayoutHoldPeriod     time.Duration
 }
 
 func LoadAppConfig() AppConfig {
 	return AppConfig{
-		DBURI:     GetEnv("MONGO_URI", "mongodb://localhost:27017"),
-		DBName:    GetEnv("DB_NAME", "afro_vintage"),
-		JWTSecret: GetEnv("JWT_SECRET", "fallback-secret"),
+		DBURI:                GetEnv("MONGO_URI", "mongodb://localhost:27017"),
+		DBName:               GetEnv("DB_NAME", "afro_vintage"),
+		JWTSecret:            GetEnv("JWT_SECRET", "fallback-secret"),
+		PaymentWebhookSecret: GetEnv("PAYMENT_WEBHOOK_SECRET", "fallback-webhook-secret"),
+		JobWorkers:           GetEnvInt("JOB_WORKERS", 4),
+		PayoutInterval:       GetEnvDuration("PAYOUT_INTERVAL", 24*time.Hour),
+		PayoutHoldPeriod:     GetEnvDuration("PAYOUT_HOLD_PERIOD", 7*24*time.Hour),
 	}
 }
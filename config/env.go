@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -21,3 +23,21 @@ func GetEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// GetEnvInt returns an integer environment variable or a fallback value when
+// it is unset or not a number.
+func GetEnvInt(key string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return n
+	}
+	return fallback
+}
+
+// GetEnvDuration returns a duration environment variable such as "24h", or a
+// fallback value when it is unset or invalid.
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
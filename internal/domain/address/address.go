@@ -0,0 +1,56 @@
+package address
+
+import (
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+)
+
+// Address is an entry in a consumer's address book.
+type Address struct {
+	ID         string    `bson:"_id" json:"id"`
+	UserID     string    `bson:"user_id" json:"user_id"`
+	Label      string    `bson:"label" json:"label"` // e.g. "Home", "Office"
+	FullName   string    `bson:"full_name" json:"full_name"`
+	Phone      string    `bson:"phone" json:"phone"`
+	Line1      string    `bson:"line1" json:"line1"`
+	Line2      string    `bson:"line2" json:"line2"`
+	City       string    `bson:"city" json:"city"`
+	Region     string    `bson:"region" json:"region"`
+	PostalCode string    `bson:"postal_code" json:"postal_code"`
+	Country    string    `bson:"country" json:"country"`
+	IsDefault  bool      `bson:"is_default" json:"is_default"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Validate checks that the address has everything a courier needs.
+func (a *Address) Validate() error {
+	required := []struct{ name, value string }{
+		{"full_name", a.FullName},
+		{"phone", a.Phone},
+		{"line1", a.Line1},
+		{"city", a.City},
+		{"country", a.Country},
+	}
+	for _, f := range required {
+		if f.value == "" {
+			return &ValidationError{Field: f.name}
+		}
+	}
+	return nil
+}
+
+// Snapshot copies the address for storing on an order, so later edits to the
+// address book do not change where an order was sent.
+func (a *Address) Snapshot() *order.ShippingAddress {
+	return &order.ShippingAddress{
+		FullName:   a.FullName,
+		Phone:      a.Phone,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
@@ -0,0 +1,25 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAddressNotFound = errors.New("address not found")
+	ErrAddressRequired = errors.New("a shipping address is required; add one to your address book")
+	ErrInvalidAddress  = errors.New("invalid address")
+)
+
+// ValidationError names the address field that is missing.
+type ValidationError struct {
+	Field string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s is required", e.Field)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidAddress
+}
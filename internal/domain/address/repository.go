@@ -0,0 +1,14 @@
+package address
+
+import "context"
+
+type Repository interface {
+	CreateAddress(ctx context.Context, a *Address) error
+	// GetAddressByID returns nil if there is no address with the given ID.
+	GetAddressByID(ctx context.Context, id string) (*Address, error)
+	ListAddressesByUser(ctx context.Context, userID string) ([]*Address, error)
+	UpdateAddress(ctx context.Context, a *Address) error
+	DeleteAddress(ctx context.Context, id string) error
+	// SetDefaultAddress makes id the user's only default address.
+	SetDefaultAddress(ctx context.Context, userID, id string) error
+}
@@ -0,0 +1,14 @@
+package address
+
+import "context"
+
+type Usecase interface {
+	AddAddress(ctx context.Context, userID string, a *Address) (*Address, error)
+	ListAddresses(ctx context.Context, userID string) ([]*Address, error)
+	UpdateAddress(ctx context.Context, userID, id string, a *Address) (*Address, error)
+	DeleteAddress(ctx context.Context, userID, id string) error
+	SetDefaultAddress(ctx context.Context, userID, id string) (*Address, error)
+	// ResolveShippingAddress returns the user's address with the given ID,
+	// or their default address when id is empty.
+	ResolveShippingAddress(ctx context.Context, userID, id string) (*Address, error)
+}
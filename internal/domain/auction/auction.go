@@ -0,0 +1,150 @@
+package auction
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// Status is a step in an auction's life. An open auction takes bids until
+// it ends. If the reserve was met it is closing while the winner's purchase
+// runs and then sold; otherwise it ends unsold.
+type Status string
+
+const (
+	StatusOpen    Status = "open"
+	StatusClosing Status = "closing"
+	StatusSold    Status = "sold"
+	StatusUnsold  Status = "unsold"
+)
+
+const (
+	MinDuration = time.Hour
+	MaxDuration = 30 * 24 * time.Hour
+	// MaxExtensionMinutes bounds the anti-sniping extension.
+	MaxExtensionMinutes = 60
+)
+
+// Listing is what a supplier fills in to auction a bundle. Prices are in the
+// bundle's listing currency.
+type Listing struct {
+	BundleID      string
+	StartingPrice money.Money
+	// ReservePrice is the lowest winning bid the supplier accepts. Zero
+	// means no reserve.
+	ReservePrice     money.Money
+	BidIncrement     money.Money
+	EndsAt           time.Time
+	ExtensionMinutes int
+}
+
+// Validate checks the listing's prices and that it ends between MinDuration
+// and MaxDuration after now.
+func (l *Listing) Validate(now time.Time) error {
+	switch {
+	case l.BundleID == "":
+		return fmt.Errorf("%w: bundle_id is required", ErrInvalidAuction)
+	case l.StartingPrice.Amount <= 0:
+		return fmt.Errorf("%w: starting_price must be positive", ErrInvalidAuction)
+	case l.ReservePrice.Amount < 0:
+		return fmt.Errorf("%w: reserve_price cannot be negative", ErrInvalidAuction)
+	case l.ReservePrice.Amount > 0 && l.ReservePrice.Amount < l.StartingPrice.Amount:
+		return fmt.Errorf("%w: reserve_price is below starting_price", ErrInvalidAuction)
+	case l.BidIncrement.Amount <= 0:
+		return fmt.Errorf("%w: bid_increment must be positive", ErrInvalidAuction)
+	case l.EndsAt.Before(now.Add(MinDuration)) || l.EndsAt.After(now.Add(MaxDuration)):
+		return fmt.Errorf("%w: ends_at must be between %v and %v from now", ErrInvalidAuction, MinDuration, MaxDuration)
+	case l.ExtensionMinutes < 0 || l.ExtensionMinutes > MaxExtensionMinutes:
+		return fmt.Errorf("%w: extension_minutes must be between 0 and %d", ErrInvalidAuction, MaxExtensionMinutes)
+	case l.BidIncrement.Currency != l.StartingPrice.Currency || (!l.ReservePrice.IsZero() && l.ReservePrice.Currency != l.StartingPrice.Currency):
+		return fmt.Errorf("%w: prices must all be in one currency", ErrInvalidAuction)
+	}
+	return nil
+}
+
+type Auction struct {
+	ID            string         `bson:"_id" json:"id"`
+	BundleID      string         `bson:"bundle_id" json:"bundle_id"`
+	SupplierID    string         `bson:"supplier_id" json:"supplier_id"`
+	Currency      money.Currency `bson:"currency" json:"currency"`
+	StartingPrice money.Money    `bson:"starting_price" json:"starting_price"`
+	// ReservePrice is kept from bidders; they only see whether it is met.
+	ReservePrice money.Money `bson:"reserve_price" json:"-"`
+	ReserveMet   bool        `bson:"reserve_met" json:"reserve_met"`
+	BidIncrement money.Money `bson:"bid_increment" json:"bid_increment"`
+	// ExtensionMinutes turns on anti-sniping: a bid in the last
+	// ExtensionMinutes moves the end to ExtensionMinutes after the bid.
+	ExtensionMinutes int         `bson:"extension_minutes" json:"extension_minutes,omitempty"`
+	EndsAt           time.Time   `bson:"ends_at" json:"ends_at"`
+	HighBid          money.Money `bson:"high_bid" json:"high_bid"`
+	HighBidderID     string      `bson:"high_bidder_id,omitempty" json:"high_bidder_id,omitempty"`
+	BidCount         int         `bson:"bid_count" json:"bid_count"`
+	Status           Status      `bson:"status" json:"status"`
+	// OrderID is the winner's purchase once the auction is sold.
+	OrderID   string     `bson:"order_id,omitempty" json:"order_id,omitempty"`
+	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
+	ClosedAt  *time.Time `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+}
+
+// Bid is one offer on an auction. Bids are kept after they are outbid so
+// anyone can see an auction's history.
+type Bid struct {
+	ID        string      `bson:"_id" json:"id"`
+	AuctionID string      `bson:"auction_id" json:"auction_id"`
+	BidderID  string      `bson:"bidder_id" json:"bidder_id"`
+	Amount    money.Money `bson:"amount" json:"amount"`
+	PlacedAt  time.Time   `bson:"placed_at" json:"placed_at"`
+}
+
+// MinimumBid is the lowest amount the next bid may be.
+func (a *Auction) MinimumBid() money.Money {
+	if a.BidCount == 0 {
+		return a.StartingPrice
+	}
+	return a.HighBid.Add(a.BidIncrement)
+}
+
+// RunnerUp is the best bid, among bids sorted highest first, from a bidder
+// who has not been offered the bundle yet and that meets the reserve. It is
+// nil when nobody is left.
+func (a *Auction) RunnerUp(bids []*Bid) *Bid {
+	offered := map[string]bool{}
+	for _, b := range bids {
+		if b.Amount.Amount >= a.HighBid.Amount {
+			offered[b.BidderID] = true
+			continue
+		}
+		if !offered[b.BidderID] && b.Amount.Amount >= a.ReservePrice.Amount {
+			return b
+		}
+	}
+	return nil
+}
+
+// Ended reports whether bidding is over at now.
+func (a *Auction) Ended(now time.Time) bool {
+	return a.Status != StatusOpen || !now.Before(a.EndsAt)
+}
+
+// PlaceBid makes amount, in the auction's currency, the auction's high bid,
+// extending the auction if the bid comes in its last ExtensionMinutes.
+func (a *Auction) PlaceBid(bidderID string, amount money.Money, now time.Time) (*Bid, error) {
+	if a.Ended(now) {
+		return nil, ErrAuctionEnded
+	}
+	if bidderID == a.SupplierID {
+		return nil, ErrOwnAuction
+	}
+	if min := a.MinimumBid(); amount.Amount < min.Amount {
+		return nil, fmt.Errorf("%w: the next bid must be at least %.2f %s", ErrBidTooLow, min.Major(), a.Currency)
+	}
+
+	a.HighBid, a.HighBidderID = amount, bidderID
+	a.BidCount++
+	a.ReserveMet = amount.Amount >= a.ReservePrice.Amount
+	if ext := time.Duration(a.ExtensionMinutes) * time.Minute; ext > 0 && a.EndsAt.Sub(now) < ext {
+		a.EndsAt = now.Add(ext)
+	}
+	return &Bid{AuctionID: a.ID, BidderID: bidderID, Amount: amount, PlacedAt: now}, nil
+}
@@ -0,0 +1,14 @@
+package auction
+
+import "errors"
+
+var (
+	ErrAuctionNotFound  = errors.New("auction not found")
+	ErrInvalidAuction   = errors.New("invalid auction")
+	ErrNotSupplier      = errors.New("only the bundle's supplier can auction it")
+	ErrAlreadyAuctioned = errors.New("bundle is already up for auction")
+	ErrAuctionEnded     = errors.New("auction has ended")
+	ErrOwnAuction       = errors.New("suppliers cannot bid on their own auction")
+	ErrBidTooLow        = errors.New("bid is too low")
+	ErrAuctionChanged   = errors.New("auction was updated by another request")
+)
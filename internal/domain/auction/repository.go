@@ -0,0 +1,18 @@
+package auction
+
+import "context"
+
+type Repository interface {
+	CreateAuction(ctx context.Context, a *Auction) error
+	// GetAuctionByID returns ErrAuctionNotFound if there is no such auction.
+	GetAuctionByID(ctx context.Context, id string) (*Auction, error)
+	// ListAuctions lists the auctions in status, or all auctions if status
+	// is empty, ending soonest first.
+	ListAuctions(ctx context.Context, status Status) ([]*Auction, error)
+	// UpdateAuction stores a only if the auction is still in status from
+	// with bids bids, and returns ErrAuctionChanged otherwise.
+	UpdateAuction(ctx context.Context, a *Auction, from Status, bids int) error
+	AddBid(ctx context.Context, b *Bid) error
+	// ListBids lists the auction's bids, highest first.
+	ListBids(ctx context.Context, auctionID string) ([]*Bid, error)
+}
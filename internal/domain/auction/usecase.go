@@ -0,0 +1,33 @@
+package auction
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+)
+
+// Purchaser is the part of the order usecase that buys a won bundle.
+type Purchaser interface {
+	// PurchaseAuctionedBundle buys the bundle once per auction: calling it
+	// again for the same auction returns the order it already placed.
+	PurchaseAuctionedBundle(ctx context.Context, auctionID, bundleID, resellerID string, price money.Money) (*order.Order, error)
+}
+
+type Usecase interface {
+	// CreateAuction puts one of the supplier's available bundles up for
+	// auction instead of at its fixed price, and queues the auction's close.
+	CreateAuction(ctx context.Context, supplierID string, l Listing) (*Auction, error)
+	GetAuction(ctx context.Context, id string) (*Auction, error)
+	ListAuctions(ctx context.Context, status Status) ([]*Auction, error)
+	// PlaceBid bids amount, in whole units of the auction's currency.
+	PlaceBid(ctx context.Context, id, resellerID string, amount float64) (*Auction, error)
+	ListBids(ctx context.Context, id string) ([]*Bid, error)
+	// CloseAuction ends an auction that is due. If the reserve was met the
+	// high bidder buys the bundle and every other bidder is told they lost;
+	// otherwise the bundle goes back on sale at its fixed price. A winner
+	// whose payment is declined loses the bundle to the next bidder who met
+	// the reserve. Auctions extended past their first end are closed again
+	// when they end.
+	CloseAuction(ctx context.Context, id string) error
+}
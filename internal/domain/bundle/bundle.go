@@ -1,6 +1,11 @@
 package bundle
 
-import "time"
+import (
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+)
 
 type SortingLevel string
 
@@ -10,6 +15,16 @@ const (
 	Unsorted   SortingLevel = "unsorted"
 )
 
+// ListingMode is how a bundle is sold. Bundles without one sell at their
+// fixed price.
+type ListingMode string
+
+const (
+	FixedPrice ListingMode = "fixed_price"
+	// Auctioned bundles can only be bought by winning their auction.
+	Auctioned ListingMode = "auction"
+)
+
 type Bundle struct {
 	ID                 string         `bson:"_id"`
 	SupplierID         string         `bson:"supplierid"`
@@ -21,11 +36,23 @@ type Bundle struct {
 	SortingLevel       SortingLevel   `bson:"sortinglevel"`
 	EstimatedBreakdown map[string]int `bson:"estimatedBreakdown,omitempty"`
 	Type               string         `bson:"type,omitempty"`
-	Price              float64        `bson:"price"`
+	Price              money.Money    `bson:"price"` // in the currency the bundle was listed in
 	Status             string         `bson:"status"`
+	ListingMode        ListingMode    `bson:"listing_mode,omitempty"`
+	ResellerID         string         `bson:"resellerid,omitempty"` // the buyer, once purchased
 	CreatedAt          string         `bson:"createdat"`
 	DateListed         time.Time      `json:"dateListed" bson:"datelisted"`
 	DeclaredRating     int            `bson:"declared_rating"`
 	EstimatedItemCount int            `bson:"estimated_item_count"`
 	RemainingItemCount int            `bson:"remaining_item_count"`
+	// SupplierTrust is a copy of the supplier's trust score, kept on the
+	// bundle so searches can filter and sort by it.
+	SupplierTrust int `bson:"supplier_trust"`
+	// Reservation holds the bundle for the reseller whose offer was accepted.
+	Reservation *offer.Reservation `bson:"reservation,omitempty"`
+}
+
+// IsAuctioned reports whether the bundle is sold by auction.
+func (b *Bundle) IsAuctioned() bool {
+	return b.ListingMode == Auctioned
 }
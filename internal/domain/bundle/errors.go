@@ -0,0 +1,18 @@
+package bundle
+
+import "errors"
+
+// ErrBundleNotFound is returned when no bundle has the requested ID.
+var ErrBundleNotFound = errors.New("bundle not found")
+
+// ErrNotAvailable is returned when a bundle is no longer listed for sale,
+// for example because another reseller bought it first.
+var ErrNotAvailable = errors.New("bundle not available")
+
+// ErrAuctioned is returned when buying an auctioned bundle at its fixed price.
+var ErrAuctioned = errors.New("bundle is sold by auction")
+
+var (
+	ErrInvalidQuery  = errors.New("invalid bundle search")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
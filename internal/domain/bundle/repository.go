@@ -1,17 +1,35 @@
 package bundle
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+)
 
 type Repository interface {
 	CreateBundle(ctx context.Context, b *Bundle) error
 	GetBundleByID(ctx context.Context, id string) (*Bundle, error) // Already present
 	ListBundles(ctx context.Context, supplierID string) ([]*Bundle, error)
-	ListAvailableBundles(ctx context.Context) ([]*Bundle, error)
+	// ListAvailableBundles returns up to limit available bundles matching q
+	// in its order, starting after the cursor when one is given.
+	ListAvailableBundles(ctx context.Context, q *Query, after *Cursor, limit int) ([]*Bundle, error)
 	ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*Bundle, error)
 	UpdateBundleStatus(ctx context.Context, id string, status string) error
 	MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error
+	// SetListingMode switches an available bundle to mode. It returns
+	// ErrNotAvailable if the bundle was sold or is already listed that way,
+	// or, when auctioning it, if an accepted offer still holds it at now.
+	SetListingMode(ctx context.Context, bundleID string, mode ListingMode, now time.Time) error
+	// Reserve holds an available bundle for r's buyer, or fails with
+	// offer.ErrReserved if another reservation still holds it at now.
+	Reserve(ctx context.Context, bundleID string, r *offer.Reservation, now time.Time) error
+	// Unreserve releases the bundle if offerID still holds it.
+	Unreserve(ctx context.Context, bundleID, offerID string) error
 	DeleteBundle(ctx context.Context, bundleID string) error
 	UpdateBundle(ctx context.Context, id string, updatedData map[string]interface{}) error // Added
 	DecreaseBundleQuantity(ctx context.Context, bundleID string) error
 	CountBundles(ctx context.Context) (int, error)
+	// UpdateSupplierTrust copies a supplier's new trust score onto their bundles.
+	UpdateSupplierTrust(ctx context.Context, supplierID string, score int) error
 }
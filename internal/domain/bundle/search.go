@@ -0,0 +1,147 @@
+package bundle
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// SortField is what a bundle search is ordered by. Ties are broken by bundle
+// ID so that pages never overlap.
+type SortField string
+
+const (
+	SortDateListed    SortField = "date_listed"
+	SortPrice         SortField = "price"
+	SortSupplierTrust SortField = "supplier_trust"
+)
+
+const (
+	// DefaultPageSize is used for searches that do not ask for a page size.
+	DefaultPageSize = 20
+	// MaxPageSize bounds how many bundles one page may hold.
+	MaxPageSize = 100
+)
+
+// Query narrows and orders the available bundles a reseller browses. Zero
+// values leave a filter off. Price bounds are in Currency; they and price
+// ordering compare prices converted to the settlement currency at Rates, so
+// bundles listed in any currency are considered.
+type Query struct {
+	Grade            string
+	SortingLevel     SortingLevel
+	Type             string
+	MinPrice         *float64
+	MaxPrice         *float64
+	Currency         money.Currency
+	Rates            money.Rates
+	MinRating        *int // declared rating
+	MaxRating        *int
+	MinSupplierTrust *int
+	// Text is matched against title and description.
+	Text  string
+	Sort  SortField
+	Desc  bool
+	Limit int
+	// After continues a previous search from its NextCursor.
+	After string
+}
+
+// Page is one page of search results. NextCursor is empty on the last page.
+type Page struct {
+	Bundles    []*Bundle `json:"bundles"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+// Priced reports whether the search compares prices.
+func (q *Query) Priced() bool {
+	return q.MinPrice != nil || q.MaxPrice != nil || q.Sort == SortPrice
+}
+
+// Normalize fills in defaults and checks the query. Without a sort the
+// newest bundles come first.
+func (q *Query) Normalize() error {
+	q.Text = strings.TrimSpace(q.Text)
+	q.Currency = money.OrSettlement(q.Currency)
+	if q.Sort == "" {
+		q.Sort, q.Desc = SortDateListed, true
+	}
+	switch q.Sort {
+	case SortDateListed, SortPrice, SortSupplierTrust:
+	default:
+		return fmt.Errorf("%w: sort must be date_listed, price or supplier_trust", ErrInvalidQuery)
+	}
+	switch q.SortingLevel {
+	case "", Sorted, SemiSorted, Unsorted:
+	default:
+		return fmt.Errorf("%w: sorting level must be sorted, semi_sorted or unsorted", ErrInvalidQuery)
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil && *q.MinPrice > *q.MaxPrice {
+		return fmt.Errorf("%w: min_price is above max_price", ErrInvalidQuery)
+	}
+	if q.MinRating != nil && q.MaxRating != nil && *q.MinRating > *q.MaxRating {
+		return fmt.Errorf("%w: min_rating is above max_rating", ErrInvalidQuery)
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxPageSize)
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	return nil
+}
+
+// Cursor marks the last bundle of a page by the value it was sorted on.
+type Cursor struct {
+	Sort     SortField `json:"s"`
+	Desc     bool      `json:"d,omitempty"`
+	Price    int64     `json:"p,omitempty"` // in minor units of money.Settlement
+	ListedAt time.Time `json:"t,omitempty"`
+	Trust    int       `json:"r,omitempty"`
+	ID       string    `json:"id"`
+}
+
+// CursorAfter returns the cursor that continues a search ordered as q after b.
+func CursorAfter(q *Query, b *Bundle) (Cursor, error) {
+	c := Cursor{Sort: q.Sort, Desc: q.Desc, ID: b.ID}
+	switch q.Sort {
+	case SortPrice:
+		price, err := q.Rates.SettlementMinor(b.Price)
+		if err != nil {
+			return c, err
+		}
+		c.Price = price
+	case SortSupplierTrust:
+		c.Trust = b.SupplierTrust
+	default:
+		c.ListedAt = b.DateListed
+	}
+	return c, nil
+}
+
+// Encode turns the cursor into an opaque token for clients.
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor reads a token made by Encode and checks that it belongs to a
+// search ordered the same way as q.
+func DecodeCursor(token string, q *Query) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != q.Sort || c.Desc != q.Desc {
+		return nil, fmt.Errorf("%w: cursor is from a search with a different order", ErrInvalidCursor)
+	}
+	return &c, nil
+}
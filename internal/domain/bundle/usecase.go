@@ -8,7 +8,8 @@ type Usecase interface {
 	DeleteBundle(ctx context.Context, supplierID string, bundleID string) error
 	GetBundleByID(ctx context.Context, supplierID string, id string) (*Bundle, error)                         // Added
 	UpdateBundle(ctx context.Context, supplierID string, id string, updatedData map[string]interface{}) error // Added
-	ListAvailableBundles(ctx context.Context) ([]*Bundle, error)
+	// ListAvailableBundles searches the bundles on sale one page at a time.
+	ListAvailableBundles(ctx context.Context, q Query) (*Page, error)
 	DecreaseRemainingItemCount(ctx context.Context, bundleID string) error
 	GetBundlePublicByID(ctx context.Context, bundleID string) (*Bundle, error)
 }
@@ -17,6 +17,10 @@ type Usecase interface {
 	// RemoveCartItem deletes a specific item from the user's cart.
 	RemoveCartItem(ctx context.Context, userID string, listingID string) error
 
-	CheckoutCart(ctx context.Context, userID string) (*models.CheckoutResponse, error)
-	CheckoutSingleItem(ctx context.Context, userID, listingID string) (*models.CheckoutResponse, error)
+	// CheckoutCart buys every item in the cart and ships it to the user's
+	// address with req.AddressID, or to their default address if it is empty.
+	// A non-empty promo code is applied to the items it covers, and store
+	// credit pays first if the request asks for it.
+	CheckoutCart(ctx context.Context, userID string, req models.CheckoutRequest) (*models.CheckoutResponse, error)
+	CheckoutSingleItem(ctx context.Context, userID, listingID string, req models.CheckoutRequest) (*models.CheckoutResponse, error)
 }
@@ -0,0 +1,99 @@
+package credit
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// EntryType says why a consumer's store credit changed.
+type EntryType string
+
+const (
+	EntryIssued   EntryType = "issued"    // granted by an admin
+	EntryGiftCard EntryType = "gift_card" // a gift card was redeemed
+	EntryCheckout EntryType = "checkout"  // spent on orders
+	EntryRefund   EntryType = "refund"    // an order paid with credit was refunded
+	EntryReversal EntryType = "reversal"  // a checkout that failed gave back what it spent
+)
+
+// Wallet is a consumer's store credit balance, in money.Settlement.
+type Wallet struct {
+	UserID    string      `bson:"_id" json:"user_id"`
+	Balance   money.Money `bson:"balance" json:"balance"`
+	UpdatedAt time.Time   `bson:"updated_at" json:"updated_at"`
+}
+
+// Entry is one change to a wallet. Amount is positive for credit added and
+// negative for credit spent.
+type Entry struct {
+	ID           string      `bson:"_id" json:"id"`
+	UserID       string      `bson:"user_id" json:"user_id"`
+	Type         EntryType   `bson:"type" json:"type"`
+	Amount       money.Money `bson:"amount" json:"amount"`
+	Reason       string      `bson:"reason,omitempty" json:"reason,omitempty"`
+	OrderIDs     []string    `bson:"order_ids,omitempty" json:"order_ids,omitempty"`
+	GiftCardCode string      `bson:"gift_card_code,omitempty" json:"gift_card_code,omitempty"`
+	CreatedBy    string      `bson:"created_by" json:"created_by"` // admin or consumer who made the change
+	CreatedAt    time.Time   `bson:"created_at" json:"created_at"`
+}
+
+// History is a consumer's balance together with every change that led to it,
+// newest first.
+type History struct {
+	UserID  string      `json:"user_id"`
+	Balance money.Money `json:"balance"`
+	Entries []*Entry    `json:"entries"`
+}
+
+type GiftCardStatus string
+
+const (
+	GiftCardActive   GiftCardStatus = "active"
+	GiftCardRedeemed GiftCardStatus = "redeemed"
+)
+
+// GiftCard is a code worth Amount of store credit to whichever consumer
+// redeems it first.
+type GiftCard struct {
+	Code       string         `bson:"_id" json:"code"`
+	Amount     money.Money    `bson:"amount" json:"amount"`
+	Status     GiftCardStatus `bson:"status" json:"status"`
+	Note       string         `bson:"note,omitempty" json:"note,omitempty"`
+	IssuedBy   string         `bson:"issued_by" json:"issued_by"`
+	ExpiresAt  *time.Time     `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	RedeemedBy string         `bson:"redeemed_by,omitempty" json:"redeemed_by,omitempty"`
+	RedeemedAt *time.Time     `bson:"redeemed_at,omitempty" json:"redeemed_at,omitempty"`
+	CreatedAt  time.Time      `bson:"created_at" json:"created_at"`
+}
+
+// Expired reports whether the card can no longer be redeemed at now.
+func (g *GiftCard) Expired(now time.Time) bool {
+	return g.ExpiresAt != nil && !now.Before(*g.ExpiresAt)
+}
+
+// NormalizeCode makes gift card codes case and whitespace insensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// codeAlphabet leaves out characters that are easily misread: 0, O, 1 and I.
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NewGiftCardCode returns a random code such as "K7QM-2XHP-9RTA-WC4E".
+func NewGiftCardCode() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for i, c := range b {
+		if i > 0 && i%4 == 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteByte(codeAlphabet[int(c)%len(codeAlphabet)])
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,13 @@
+package credit
+
+import "errors"
+
+var (
+	ErrInvalidAmount      = errors.New("credit amount must be greater than zero")
+	ErrReasonRequired     = errors.New("a reason is required to issue credit")
+	ErrConsumerNotFound   = errors.New("store credit can only be issued to an existing consumer")
+	ErrInsufficientCredit = errors.New("not enough store credit")
+	ErrGiftCardNotFound   = errors.New("gift card not found")
+	ErrGiftCardRedeemed   = errors.New("gift card has already been redeemed")
+	ErrGiftCardExpired    = errors.New("gift card has expired")
+)
@@ -0,0 +1,24 @@
+package credit
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// GetWallet returns nil if the consumer has never had store credit.
+	GetWallet(ctx context.Context, userID string) (*Wallet, error)
+	// PostEntry stores e and adds its amount to the consumer's balance. A
+	// negative amount fails with ErrInsufficientCredit if the balance does
+	// not cover it.
+	PostEntry(ctx context.Context, e *Entry) error
+	// ListEntries returns a consumer's wallet changes, newest first.
+	ListEntries(ctx context.Context, userID string) ([]*Entry, error)
+
+	CreateGiftCard(ctx context.Context, g *GiftCard) error
+	ListGiftCards(ctx context.Context) ([]*GiftCard, error)
+	// ClaimGiftCard marks an active, unexpired gift card as redeemed by
+	// userID and returns it. It fails with ErrGiftCardNotFound,
+	// ErrGiftCardRedeemed or ErrGiftCardExpired otherwise.
+	ClaimGiftCard(ctx context.Context, code, userID string, now time.Time) (*GiftCard, error)
+}
@@ -0,0 +1,29 @@
+package credit
+
+import (
+	"context"
+	"time"
+)
+
+// Payer moves store credit for checkouts and refunds.
+type Payer interface {
+	// Spend takes up to amount from the consumer's credit to pay for the
+	// given orders and returns how much it took.
+	Spend(ctx context.Context, userID string, amount float64, orderIDs []string) (float64, error)
+	// Restore gives credit back to the consumer, for an order refund or a
+	// checkout that failed after spending it.
+	Restore(ctx context.Context, userID string, amount float64, t EntryType, orderIDs []string) error
+}
+
+type Usecase interface {
+	Payer
+	GetHistory(ctx context.Context, userID string) (*History, error)
+	// IssueCredit grants a consumer store credit, e.g. as compensation.
+	IssueCredit(ctx context.Context, adminID, userID string, amount float64, reason string) (*Entry, error)
+	// IssueGiftCard creates a gift card with a new random code. A nil
+	// expiresAt never expires.
+	IssueGiftCard(ctx context.Context, adminID string, amount float64, note string, expiresAt *time.Time) (*GiftCard, error)
+	ListGiftCards(ctx context.Context) ([]*GiftCard, error)
+	// RedeemGiftCard adds the card's amount to the consumer's credit.
+	RedeemGiftCard(ctx context.Context, userID, code string) (*Entry, error)
+}
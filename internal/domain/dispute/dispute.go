@@ -0,0 +1,146 @@
+package dispute
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+// Status is a step in a dispute's lifecycle. A dispute starts open, waits on
+// the seller's side of the story when staff ask for it, is reviewed by staff
+// and ends resolved for one of the parties.
+type Status string
+
+const (
+	StatusOpen           Status = "open"
+	StatusAwaitingSeller Status = "awaiting_seller"
+	StatusUnderReview    Status = "under_review"
+	StatusResolvedBuyer  Status = "resolved_buyer"
+	StatusResolvedSeller Status = "resolved_seller"
+)
+
+// transitions lists the statuses a dispute may move to from each status.
+// Resolved disputes are final.
+var transitions = map[Status][]Status{
+	StatusOpen:           {StatusAwaitingSeller, StatusUnderReview, StatusResolvedBuyer, StatusResolvedSeller},
+	StatusAwaitingSeller: {StatusUnderReview, StatusResolvedBuyer, StatusResolvedSeller},
+	StatusUnderReview:    {StatusAwaitingSeller, StatusResolvedBuyer, StatusResolvedSeller},
+}
+
+// CanTransition reports whether a dispute may move from one status to another.
+func CanTransition(from, to Status) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsResolved reports whether staff have decided the dispute.
+func (s Status) IsResolved() bool {
+	return s == StatusResolvedBuyer || s == StatusResolvedSeller
+}
+
+// Reason is what the buyer claims went wrong.
+type Reason string
+
+const (
+	ReasonNotReceived    Reason = "not_received"
+	ReasonNotAsDescribed Reason = "not_as_described"
+	ReasonMisrepresented Reason = "misrepresented"
+)
+
+// IsValid reports whether r is a known reason.
+func (r Reason) IsValid() bool {
+	switch r {
+	case ReasonNotReceived, ReasonNotAsDescribed, ReasonMisrepresented:
+		return true
+	}
+	return false
+}
+
+// maxAttachments bounds the evidence attached to a single message.
+const maxAttachments = 10
+
+// Dispute is a buyer's claim against the seller of an order. Consumers
+// dispute reseller orders and resellers dispute bundle purchases.
+type Dispute struct {
+	ID         string      `bson:"_id" json:"id"`
+	OrderID    string      `bson:"order_id" json:"order_id"`
+	BuyerID    string      `bson:"buyer_id" json:"buyer_id"`
+	SellerID   string      `bson:"seller_id" json:"seller_id"`
+	SellerRole user.Role   `bson:"seller_role" json:"seller_role"`
+	Reason     Reason      `bson:"reason" json:"reason"`
+	Status     Status      `bson:"status" json:"status"`
+	Messages   []Message   `bson:"messages" json:"messages"`
+	Resolution *Resolution `bson:"resolution,omitempty" json:"resolution,omitempty"`
+	CreatedAt  time.Time   `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time   `bson:"updated_at" json:"updated_at"`
+}
+
+// Message is a statement or piece of evidence posted to a dispute by one of
+// its parties or by staff.
+type Message struct {
+	ID          string       `bson:"id" json:"id"`
+	AuthorID    string       `bson:"author_id" json:"author_id"`
+	AuthorRole  user.Role    `bson:"author_role" json:"author_role"`
+	Body        string       `bson:"body" json:"body"`
+	Attachments []Attachment `bson:"attachments,omitempty" json:"attachments,omitempty"`
+	CreatedAt   time.Time    `bson:"created_at" json:"created_at"`
+}
+
+// Attachment links to a file, such as a photo of the goods, hosted elsewhere.
+type Attachment struct {
+	Name string `bson:"name" json:"name"`
+	URL  string `bson:"url" json:"url"`
+}
+
+// Resolution records the decision on a dispute.
+type Resolution struct {
+	ResolvedBy string `bson:"resolved_by" json:"resolved_by"`
+	Note       string `bson:"note,omitempty" json:"note,omitempty"`
+	// RefundAmount is what the buyer got back; zero when the seller won.
+	RefundAmount float64   `bson:"refund_amount" json:"refund_amount"`
+	ResolvedAt   time.Time `bson:"resolved_at" json:"resolved_at"`
+}
+
+// Validate checks that the message says something and that its attachments
+// are web links.
+func (m *Message) Validate() error {
+	m.Body = strings.TrimSpace(m.Body)
+	if m.Body == "" && len(m.Attachments) == 0 {
+		return ErrEmptyMessage
+	}
+	if len(m.Attachments) > maxAttachments {
+		return fmt.Errorf("%w: at most %d attachments per message", ErrInvalidAttachment, maxAttachments)
+	}
+	for _, a := range m.Attachments {
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %q is not a web link", ErrInvalidAttachment, a.URL)
+		}
+	}
+	return nil
+}
+
+// IsParty reports whether the actor may see and post to the dispute: its
+// buyer, its seller or staff.
+func (d *Dispute) IsParty(actorID string, role user.Role) bool {
+	if role == user.RoleAdmin {
+		return true
+	}
+	return actorID == d.BuyerID || actorID == d.SellerID
+}
+
+// StatusAfter returns the status the dispute moves to once m is posted. The
+// seller answering hands the dispute to staff for review.
+func (d *Dispute) StatusAfter(m Message) Status {
+	if m.AuthorID == d.SellerID && (d.Status == StatusOpen || d.Status == StatusAwaitingSeller) {
+		return StatusUnderReview
+	}
+	return d.Status
+}
@@ -0,0 +1,15 @@
+package dispute
+
+import "errors"
+
+var (
+	ErrDisputeNotFound   = errors.New("dispute not found")
+	ErrDisputeExists     = errors.New("order already has an open dispute")
+	ErrCannotDispute     = errors.New("order cannot be disputed in its current status")
+	ErrInvalidReason     = errors.New("reason must be not_received, not_as_described or misrepresented")
+	ErrEmptyMessage      = errors.New("a message needs text or an attachment")
+	ErrInvalidAttachment = errors.New("invalid attachment")
+	ErrInvalidTransition = errors.New("dispute cannot move to the requested status")
+	ErrDisputeResolved   = errors.New("dispute is already resolved")
+	ErrDisputeChanged    = errors.New("dispute was updated by another request")
+)
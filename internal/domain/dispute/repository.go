@@ -0,0 +1,21 @@
+package dispute
+
+import "context"
+
+type Repository interface {
+	CreateDispute(ctx context.Context, d *Dispute) error
+	GetDisputeByID(ctx context.Context, id string) (*Dispute, error)
+	// GetOpenDisputeByOrder returns the order's unresolved dispute, if any.
+	GetOpenDisputeByOrder(ctx context.Context, orderID string) (*Dispute, error)
+	// ListDisputesByUser lists the disputes the user is buyer or seller in,
+	// newest first.
+	ListDisputesByUser(ctx context.Context, userID string) ([]*Dispute, error)
+	// ListDisputes lists disputes in the given status, or all of them when
+	// status is empty, newest first.
+	ListDisputes(ctx context.Context, status Status) ([]*Dispute, error)
+	// AddMessage appends m and sets the dispute's status.
+	AddMessage(ctx context.Context, id string, m Message, status Status) error
+	// UpdateDispute stores d only if the dispute is still in status from and
+	// returns ErrDisputeChanged otherwise.
+	UpdateDispute(ctx context.Context, d *Dispute, from Status) error
+}
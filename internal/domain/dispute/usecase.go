@@ -0,0 +1,36 @@
+package dispute
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+// Orders is the part of the order usecase disputes act through: reading the
+// disputed order, freezing its escrow and moving its money.
+type Orders interface {
+	GetOrderByID(ctx context.Context, orderID string) (*order.Order, error)
+	RefundOrder(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error)
+	GetEscrow(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error)
+	DisputeEscrow(ctx context.Context, orderID, resellerID, reason string) (*escrow.Escrow, error)
+	ResolveEscrow(ctx context.Context, orderID, adminID string, refund bool, note string) (*escrow.Escrow, error)
+}
+
+type Usecase interface {
+	// OpenDispute files the buyer's claim against an order. The first message
+	// carries the buyer's account and evidence.
+	OpenDispute(ctx context.Context, orderID, buyerID string, reason Reason, first Message) (*Dispute, error)
+	GetDispute(ctx context.Context, id, actorID string, role user.Role) (*Dispute, error)
+	ListMyDisputes(ctx context.Context, userID string) ([]*Dispute, error)
+	AddMessage(ctx context.Context, id, actorID string, role user.Role, m Message) (*Dispute, error)
+	ListDisputes(ctx context.Context, status Status) ([]*Dispute, error)
+	// UpdateStatus moves an unresolved dispute between open, awaiting seller
+	// and under review.
+	UpdateStatus(ctx context.Context, id, adminID string, status Status) (*Dispute, error)
+	// Resolve decides the dispute. Deciding for the buyer refunds amount, or
+	// everything still refundable when amount is zero.
+	Resolve(ctx context.Context, id, adminID string, forBuyer bool, amount float64, note string) (*Dispute, error)
+}
@@ -0,0 +1,12 @@
+package escrow
+
+import "errors"
+
+var (
+	ErrEscrowNotFound        = errors.New("order has no escrow")
+	ErrNotHeld               = errors.New("funds are no longer held in escrow")
+	ErrNotDisputed           = errors.New("escrow is not disputed")
+	ErrDisputeReasonRequired = errors.New("a reason is required to dispute a purchase")
+	ErrFundsHeld             = errors.New("funds are held in escrow; cancel the order or resolve the dispute instead")
+	ErrEscrowChanged         = errors.New("escrow was updated by another request")
+)
@@ -0,0 +1,66 @@
+package escrow
+
+import (
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+// Status is a step in an escrow's lifecycle. Money is held until it is
+// released to the seller or refunded to the buyer; a dispute freezes it until
+// an admin decides which.
+type Status string
+
+const (
+	StatusHeld     Status = "held"
+	StatusDisputed Status = "disputed"
+	StatusReleased Status = "released"
+	StatusRefunded Status = "refunded"
+)
+
+// Escrow holds a reseller's payment for a bundle until the bundle's arrival at
+// the warehouse is confirmed or the dispute window has ended, whichever comes
+// first.
+type Escrow struct {
+	OrderID         string      `bson:"_id" json:"order_id"`
+	PaymentID       string      `bson:"payment_id" json:"payment_id"`
+	SupplierID      string      `bson:"supplier_id" json:"supplier_id"`
+	ResellerID      string      `bson:"reseller_id" json:"reseller_id"`
+	WarehouseItemID string      `bson:"warehouse_item_id" json:"warehouse_item_id"`
+	Amount          money.Money `bson:"amount" json:"amount"`
+	Status          Status      `bson:"status" json:"status"`
+	ReleaseBy       time.Time   `bson:"release_by" json:"release_by"`
+	Dispute         *Dispute    `bson:"dispute,omitempty" json:"dispute,omitempty"`
+	CreatedAt       time.Time   `bson:"created_at" json:"created_at"`
+	// ClosedAt is when the money was released or refunded.
+	ClosedAt *time.Time `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+}
+
+// Dispute records why a reseller froze an escrow and how it was resolved.
+type Dispute struct {
+	Reason     string     `bson:"reason" json:"reason"`
+	OpenedAt   time.Time  `bson:"opened_at" json:"opened_at"`
+	ResolvedBy string     `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	Resolution string     `bson:"resolution,omitempty" json:"resolution,omitempty"`
+	ResolvedAt *time.Time `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+// IsOpen reports whether the money is still in escrow.
+func (e *Escrow) IsOpen() bool {
+	return e.Status == StatusHeld || e.Status == StatusDisputed
+}
+
+// IsParty reports whether the actor may see the escrow: its buyer, its
+// seller or an admin.
+func (e *Escrow) IsParty(actorID string, role user.Role) bool {
+	switch role {
+	case user.RoleAdmin:
+		return true
+	case user.RoleReseller:
+		return e.ResellerID == actorID
+	case user.RoleSupplier:
+		return e.SupplierID == actorID
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package escrow
+
+import "context"
+
+// Releaser pays out an order's escrow to the seller once the dispute window
+// has passed without a dispute.
+type Releaser interface {
+	ReleaseEscrow(ctx context.Context, orderID string) error
+}
+
+// ArrivalReleaser pays out the escrow held for a warehouse item once the
+// item has arrived. Callers run it inside the unit of work that records the
+// arrival.
+type ArrivalReleaser interface {
+	ReleaseOnArrival(ctx context.Context, warehouseItemID string) error
+}
@@ -0,0 +1,12 @@
+package escrow
+
+import "context"
+
+type Repository interface {
+	CreateEscrow(ctx context.Context, e *Escrow) error
+	GetEscrowByOrder(ctx context.Context, orderID string) (*Escrow, error)
+	GetEscrowByWarehouseItem(ctx context.Context, itemID string) (*Escrow, error)
+	// UpdateEscrow stores e only if the escrow is still in status from and
+	// returns ErrEscrowChanged otherwise.
+	UpdateEscrow(ctx context.Context, e *Escrow, from Status) error
+}
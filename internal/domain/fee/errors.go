@@ -0,0 +1,8 @@
+package fee
+
+import "errors"
+
+var (
+	ErrInvalidPolicy  = errors.New("invalid fee policy")
+	ErrPolicyConflict = errors.New("fee policy was changed by someone else; reload and try again")
+)
@@ -0,0 +1,166 @@
+package fee
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+// Names of the base rules, recorded on payments that no override matched.
+const (
+	RuleB2BDefault = "b2b_default"
+	RuleB2CDefault = "b2c_default"
+)
+
+// Rate is a percentage fee with optional caps. A zero MinFee or MaxFee
+// means the fee is not capped on that side.
+type Rate struct {
+	Rate   float64 `bson:"rate" json:"rate"` // fraction of the sale, e.g. 0.02 for 2%
+	MinFee float64 `bson:"min_fee" json:"min_fee"`
+	MaxFee float64 `bson:"max_fee" json:"max_fee"`
+}
+
+func (r Rate) validate(name string) error {
+	if r.Rate < 0 || r.Rate >= 1 {
+		return fmt.Errorf("%w: %s rate must be at least 0 and below 1", ErrInvalidPolicy, name)
+	}
+	if r.MinFee < 0 || r.MaxFee < 0 {
+		return fmt.Errorf("%w: %s caps cannot be negative", ErrInvalidPolicy, name)
+	}
+	if r.MaxFee > 0 && r.MinFee > r.MaxFee {
+		return fmt.Errorf("%w: %s min_fee is above max_fee", ErrInvalidPolicy, name)
+	}
+	return nil
+}
+
+// Apply returns the fee for amount. The fee never exceeds the amount itself.
+func (r Rate) Apply(amount float64) float64 {
+	f := amount * r.Rate
+	if r.MinFee > 0 && f < r.MinFee {
+		f = r.MinFee
+	}
+	if r.MaxFee > 0 && f > r.MaxFee {
+		f = r.MaxFee
+	}
+	return math.Min(f, amount)
+}
+
+// Override replaces the base rate for sales matching all of its non-empty
+// criteria.
+type Override struct {
+	Name       string              `bson:"name" json:"name"`
+	SaleType   payment.PaymentType `bson:"sale_type,omitempty" json:"sale_type,omitempty"`
+	Category   string              `bson:"category,omitempty" json:"category,omitempty"`
+	SellerTier SellerTier          `bson:"seller_tier,omitempty" json:"seller_tier,omitempty"`
+	Rate       `bson:",inline"`
+}
+
+func (o Override) matches(s Sale, tier SellerTier) bool {
+	return (o.SaleType == "" || o.SaleType == s.Type) &&
+		(o.Category == "" || strings.EqualFold(o.Category, s.Category)) &&
+		(o.SellerTier == "" || o.SellerTier == tier)
+}
+
+// specificity counts the criteria an override sets; the most specific
+// matching override wins.
+func (o Override) specificity() int {
+	n := 0
+	for _, set := range []bool{o.SaleType != "", o.Category != "", o.SellerTier != ""} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// Policy is one version of the platform fee schedule. Every change an admin
+// makes is stored as a new version so that past payments can be explained.
+type Policy struct {
+	Version   int        `bson:"_id" json:"version"`
+	B2B       Rate       `bson:"b2b" json:"b2b"`
+	B2C       Rate       `bson:"b2c" json:"b2c"`
+	Overrides []Override `bson:"overrides" json:"overrides"`
+	Note      string     `bson:"note" json:"note"`
+	UpdatedBy string     `bson:"updated_by" json:"updated_by"`
+	UpdatedAt time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// DefaultPolicy is the flat 2% fee charged before any policy is saved.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		B2B:       Rate{Rate: 0.02},
+		B2C:       Rate{Rate: 0.02},
+		Overrides: []Override{},
+	}
+}
+
+// Validate checks the rates and that every override is named and narrows
+// the sales it applies to.
+func (p *Policy) Validate() error {
+	if err := p.B2B.validate("b2b"); err != nil {
+		return err
+	}
+	if err := p.B2C.validate("b2c"); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+	for _, o := range p.Overrides {
+		if o.Name == "" || o.Name == RuleB2BDefault || o.Name == RuleB2CDefault || names[o.Name] {
+			return fmt.Errorf("%w: override names must be unique and not reuse a default rule name", ErrInvalidPolicy)
+		}
+		names[o.Name] = true
+		if o.specificity() == 0 {
+			return fmt.Errorf("%w: override %q matches every sale", ErrInvalidPolicy, o.Name)
+		}
+		if o.SaleType != "" && o.SaleType != payment.B2B && o.SaleType != payment.B2C {
+			return fmt.Errorf("%w: override %q has unknown sale type %q", ErrInvalidPolicy, o.Name, o.SaleType)
+		}
+		switch o.SellerTier {
+		case "", TierLowTrust, TierStandard, TierTopRated:
+		default:
+			return fmt.Errorf("%w: override %q has unknown seller tier %q", ErrInvalidPolicy, o.Name, o.SellerTier)
+		}
+		if err := o.Rate.validate(o.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NeedsSellerTier reports whether any override depends on the seller's tier.
+func (p *Policy) NeedsSellerTier() bool {
+	for _, o := range p.Overrides {
+		if o.SellerTier != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Quote prices a sale by seller tier. Earlier overrides win ties.
+func (p *Policy) Quote(s Sale, tier SellerTier) Quote {
+	rule, rate := RuleB2CDefault, p.B2C
+	if s.Type == payment.B2B {
+		rule, rate = RuleB2BDefault, p.B2B
+	}
+
+	best := -1
+	for _, o := range p.Overrides {
+		if o.matches(s, tier) && o.specificity() > best {
+			best = o.specificity()
+			rule, rate = o.Name, o.Rate
+		}
+	}
+
+	f := rate.Apply(s.Amount)
+	return Quote{
+		Fee:           f,
+		SellerEarning: s.Amount - f,
+		Rule:          rule,
+		PolicyVersion: p.Version,
+	}
+}
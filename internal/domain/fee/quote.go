@@ -0,0 +1,50 @@
+package fee
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+// SellerTier groups sellers by trust score for fee overrides.
+type SellerTier string
+
+const (
+	TierLowTrust SellerTier = "low_trust"
+	TierStandard SellerTier = "standard"
+	TierTopRated SellerTier = "top_rated"
+)
+
+// TierFor places a seller in a tier. Below 60 is the same threshold admins
+// use to flag sellers for review.
+func TierFor(trustScore int) SellerTier {
+	switch {
+	case trustScore < 60:
+		return TierLowTrust
+	case trustScore >= 90:
+		return TierTopRated
+	default:
+		return TierStandard
+	}
+}
+
+// Sale is what a fee is charged on.
+type Sale struct {
+	Type     payment.PaymentType
+	Category string // bundle or product type
+	SellerID string
+	Amount   float64
+}
+
+// Quote is the platform's cut of a sale and the rule that produced it.
+type Quote struct {
+	Fee           float64
+	SellerEarning float64
+	Rule          string
+	PolicyVersion int
+}
+
+// Quoter prices sales with the current fee policy.
+type Quoter interface {
+	Quote(ctx context.Context, s Sale) (Quote, error)
+}
@@ -0,0 +1,11 @@
+package fee
+
+import "context"
+
+type Usecase interface {
+	Quoter
+	GetPolicy(ctx context.Context) (*Policy, error)
+	// UpdatePolicy saves p as the next version of the policy.
+	UpdatePolicy(ctx context.Context, adminID string, p *Policy) (*Policy, error)
+	GetPolicyHistory(ctx context.Context) ([]*Policy, error)
+}
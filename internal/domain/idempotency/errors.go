@@ -0,0 +1,10 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrKeyInUse        = errors.New("idempotency key has already been used")
+	ErrInvalidKey      = errors.New("Idempotency-Key must be between 1 and 255 characters")
+	ErrKeyReused       = errors.New("Idempotency-Key was already used with a different request")
+	ErrRequestInFlight = errors.New("a request with this Idempotency-Key is still being processed")
+)
@@ -0,0 +1,42 @@
+package idempotency
+
+import "time"
+
+// Header is the request header clients send to make a request safe to retry.
+const Header = "Idempotency-Key"
+
+// Retention is how long a key is remembered. After that it may be reused for
+// a new request.
+const Retention = 24 * time.Hour
+
+// MaxKeyLength bounds the keys clients may send.
+const MaxKeyLength = 255
+
+type Status string
+
+const (
+	StatusInProgress Status = "in_progress"
+	StatusCompleted  Status = "completed"
+)
+
+// Record remembers a request made with an idempotency key and, once it has
+// finished, the response that was sent for it. Keys are scoped to the user
+// who sent them.
+type Record struct {
+	ID          string `bson:"_id" json:"id"`
+	UserID      string `bson:"user_id" json:"user_id"`
+	Key         string `bson:"key" json:"key"`
+	RequestHash string `bson:"request_hash" json:"request_hash"`
+	Status      Status `bson:"status" json:"status"`
+	// The stored response, set once the request has completed.
+	ResponseStatus int       `bson:"response_status,omitempty" json:"response_status,omitempty"`
+	ContentType    string    `bson:"content_type,omitempty" json:"content_type,omitempty"`
+	ResponseBody   []byte    `bson:"response_body,omitempty" json:"-"`
+	CreatedAt      time.Time `bson:"created_at" json:"created_at"`
+	ExpiresAt      time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+// RecordID returns the ID of the record for a user's key.
+func RecordID(userID, key string) string {
+	return userID + ":" + key
+}
@@ -0,0 +1,17 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// Reserve stores a new in-progress record. It returns ErrKeyInUse if the
+	// user's key is already recorded and has not expired by now.
+	Reserve(ctx context.Context, r *Record, now time.Time) error
+	GetRecord(ctx context.Context, userID, key string) (*Record, error)
+	// Complete stores the response of a reserved request.
+	Complete(ctx context.Context, r *Record) error
+	// Release forgets a key so that the request can be retried.
+	Release(ctx context.Context, userID, key string) error
+}
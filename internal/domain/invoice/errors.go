@@ -0,0 +1,8 @@
+package invoice
+
+import "errors"
+
+var (
+	ErrInvoiceNotFound = errors.New("order has not been invoiced")
+	ErrInvoiceExists   = errors.New("order has already been invoiced")
+)
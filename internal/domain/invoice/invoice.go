@@ -0,0 +1,81 @@
+package invoice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+)
+
+// Kind says what an invoice is called on paper. Resellers buying bundles get
+// invoices for their books and consumers get receipts; both share one
+// numbering sequence.
+type Kind string
+
+const (
+	KindInvoice Kind = "invoice"
+	KindReceipt Kind = "receipt"
+)
+
+// FormatNumber turns a sequence number into an invoice number such as
+// "INV-000042".
+func FormatNumber(seq int64) string {
+	return fmt.Sprintf("INV-%06d", seq)
+}
+
+// Party is the buyer or the seller named on an invoice.
+type Party struct {
+	UserID string `bson:"user_id" json:"user_id"`
+	Name   string `bson:"name" json:"name"`
+	Email  string `bson:"email" json:"email"`
+}
+
+// Line is one item sold, at what was paid for it after any discount and
+// before tax added on top.
+type Line struct {
+	Reference   string  `bson:"reference" json:"reference"` // listing or bundle ID
+	Description string  `bson:"description" json:"description"`
+	Quantity    int     `bson:"quantity" json:"quantity"`
+	UnitPrice   float64 `bson:"unit_price" json:"unit_price"`
+	Amount      float64 `bson:"amount" json:"amount"`
+}
+
+// Invoice is the bill for an order and the payment that settled it. It is
+// issued once and never changes; later refunds do not alter it.
+type Invoice struct {
+	ID        string    `bson:"_id" json:"id"` // the order ID; an order has one invoice
+	Number    string    `bson:"number" json:"number"`
+	Kind      Kind      `bson:"kind" json:"kind"`
+	OrderID   string    `bson:"order_id" json:"order_id"`
+	PaymentID string    `bson:"payment_id" json:"payment_id"`
+	OrderDate string    `bson:"order_date" json:"order_date"`
+	IssuedAt  time.Time `bson:"issued_at" json:"issued_at"`
+
+	Buyer  Party                  `bson:"buyer" json:"buyer"`
+	Seller Party                  `bson:"seller" json:"seller"`
+	ShipTo *order.ShippingAddress `bson:"ship_to,omitempty" json:"ship_to,omitempty"`
+
+	// Subtotal is the sum of Items. Discount is what a promo code already
+	// took off them.
+	Items     []Line  `bson:"items" json:"items"`
+	Subtotal  float64 `bson:"subtotal" json:"subtotal"`
+	Discount  float64 `bson:"discount,omitempty" json:"discount,omitempty"`
+	PromoCode string  `bson:"promo_code,omitempty" json:"promo_code,omitempty"`
+	// Tax is included in Total. Lines marked inclusive were already part of
+	// the item prices; the others were added on top of the subtotal.
+	Tax            float64    `bson:"tax,omitempty" json:"tax,omitempty"`
+	TaxLines       []tax.Line `bson:"tax_lines,omitempty" json:"tax_lines,omitempty"`
+	Total          float64    `bson:"total" json:"total"`
+	PaidWithCredit float64    `bson:"paid_with_credit,omitempty" json:"paid_with_credit,omitempty"`
+	PlatformFee    float64    `bson:"platform_fee" json:"platform_fee"`
+	SellerEarning  float64    `bson:"seller_earning" json:"seller_earning"`
+	// Charged is Total in the currency the buyer paid in.
+	Charged *money.Money `bson:"charged,omitempty" json:"charged,omitempty"`
+}
+
+// Filename is what a downloaded copy of the invoice is called.
+func (inv *Invoice) Filename(ext string) string {
+	return inv.Number + "." + ext
+}
@@ -0,0 +1,12 @@
+package invoice
+
+import "context"
+
+type Repository interface {
+	// GetInvoiceByOrder returns nil if the order has not been invoiced yet.
+	GetInvoiceByOrder(ctx context.Context, orderID string) (*Invoice, error)
+	// NextNumber takes the next number of the invoice sequence.
+	NextNumber(ctx context.Context) (int64, error)
+	// CreateInvoice fails with ErrInvoiceExists if the order already has one.
+	CreateInvoice(ctx context.Context, inv *Invoice) error
+}
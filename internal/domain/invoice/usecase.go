@@ -0,0 +1,31 @@
+package invoice
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+// Renderer lays an invoice out as a printable document.
+type Renderer interface {
+	RenderPDF(inv *Invoice) ([]byte, error)
+}
+
+// Issuer issues the invoice for an order as it is paid. It is called in the
+// unit of work that places the order, so the invoice number is only taken
+// if the order commits.
+type Issuer interface {
+	IssueInvoice(ctx context.Context, o *order.Order, paid *payment.Payment) (*Invoice, error)
+}
+
+type Usecase interface {
+	Issuer
+	// GetInvoice returns the invoice issued when the order was paid, issuing
+	// it now for orders paid before invoices were. Only the order's parties
+	// and admins may see it.
+	GetInvoice(ctx context.Context, orderID, actorID string, role user.Role) (*Invoice, error)
+	// GetInvoicePDF is GetInvoice rendered as a PDF.
+	GetInvoicePDF(ctx context.Context, orderID, actorID string, role user.Role) (*Invoice, []byte, error)
+}
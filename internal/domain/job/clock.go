@@ -0,0 +1,16 @@
+package job
+
+import "time"
+
+// Clock tells the scheduler and the workers what time it is, so tests can
+// control when jobs become due.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock reads the wall clock.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
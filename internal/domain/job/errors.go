@@ -0,0 +1,10 @@
+package job
+
+import "errors"
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrNotRetryable = errors.New("only failed jobs can be retried")
+	ErrDuplicateJob = errors.New("a job with this id is already queued")
+	ErrLeaseLost    = errors.New("job lease expired and the job was claimed again")
+)
@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Job types handled by the worker pool.
+const (
+	TypeListWarehouseItem = "warehouse.list_item"
+	TypeTrackShipment     = "shipment.track"
+	TypeSettlePayouts     = "payout.settle"
+	TypeSendPayout        = "payout.send"
+	TypeReleaseEscrow     = "escrow.release"
+	TypeSettleRefund      = "payment.settle_refund"
+	TypeRefundCharge      = "payment.refund_charge"
+	TypeReleasePromotion  = "promotion.release"
+	TypeRestoreCredit     = "credit.restore"
+	TypeApproveReturn     = "return.approve_overdue"
+	TypeRecordLostDispute = "trust.record_lost_dispute"
+	TypeCloseAuction      = "auction.close"
+	TypeExpireOffer       = "offer.expire"
+	TypeReleaseOffer      = "offer.release"
+)
+
+// DefaultMaxAttempts is used for jobs scheduled without an explicit limit.
+const DefaultMaxAttempts = 5
+
+// Job is a unit of delayed work stored in the job queue.
+type Job struct {
+	ID          string            `bson:"_id" json:"id"`
+	Type        string            `bson:"type" json:"type"`
+	Payload     map[string]string `bson:"payload" json:"payload"`
+	Status      Status            `bson:"status" json:"status"`
+	RunAt       time.Time         `bson:"run_at" json:"run_at"`
+	Attempts    int               `bson:"attempts" json:"attempts"`
+	MaxAttempts int               `bson:"max_attempts" json:"max_attempts"`
+	LastError   string            `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	LockedUntil time.Time         `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+	Lease       string            `bson:"lease,omitempty" json:"-"` // set anew by every claim
+	CreatedAt   time.Time         `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time         `bson:"updated_at" json:"updated_at"`
+}
+
+// Handler performs the work of one job type. Returning an error schedules a
+// retry until the job runs out of attempts.
+type Handler func(ctx context.Context, j *Job) error
+
+// Scheduler queues work to run after a delay. Jobs scheduled with a context
+// from a unit of work are only queued if the unit of work commits.
+type Scheduler interface {
+	Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*Job, error)
+}
@@ -0,0 +1,28 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// Enqueue stores a new job. A job whose ID is already taken is rejected
+	// with ErrDuplicateJob.
+	Enqueue(ctx context.Context, j *Job) error
+	// ClaimDue marks the next due job as running until now+lease and returns
+	// it with a new Lease, or returns nil when nothing is due. Running jobs
+	// whose lease has expired are claimed again, so work survives a crashed
+	// worker.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*Job, error)
+	// Complete, Retry and Fail record the outcome of a claimed job. They only
+	// apply while the job still holds lease and return ErrLeaseLost once
+	// another worker has claimed it again.
+	Complete(ctx context.Context, id, lease string, now time.Time) error
+	Retry(ctx context.Context, id, lease string, now, runAt time.Time, lastError string) error
+	Fail(ctx context.Context, id, lease string, now time.Time, lastError string) error
+	// Requeue puts a failed job back in the queue with its attempts reset and
+	// returns ErrNotRetryable if the job has not failed.
+	Requeue(ctx context.Context, id string, now time.Time) error
+	GetJobByID(ctx context.Context, id string) (*Job, error)
+	ListJobs(ctx context.Context, status Status, page, limit int) ([]*Job, error)
+}
@@ -0,0 +1,9 @@
+package job
+
+import "context"
+
+type Usecase interface {
+	ListJobs(ctx context.Context, status Status, page, limit int) ([]*Job, error)
+	GetJob(ctx context.Context, id string) (*Job, error)
+	RetryJob(ctx context.Context, id string) (*Job, error)
+}
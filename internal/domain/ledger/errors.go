@@ -0,0 +1,5 @@
+package ledger
+
+import "errors"
+
+var ErrUnbalancedEntry = errors.New("unbalanced ledger entry")
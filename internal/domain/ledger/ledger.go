@@ -0,0 +1,134 @@
+package ledger
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Platform accounts. Every seller also has an account of their own, named
+// by SellerAccount, holding what the platform owes them.
+const (
+	// CashAccount is the money the platform holds with the payment gateway.
+	CashAccount = "platform:cash"
+	// RevenueAccount collects the platform's fees.
+	RevenueAccount = "platform:revenue"
+	// EscrowAccount holds buyers' money until their purchase is confirmed.
+	EscrowAccount = "platform:escrow"
+	// StoreCreditAccount is the store credit consumers hold and can spend.
+	StoreCreditAccount = "platform:store_credit"
+	// GoodwillAccount is what the platform gives away as store credit.
+	GoodwillAccount = "platform:goodwill"
+	// GiftCardAccount is the value of gift cards turned into store credit.
+	GiftCardAccount = "platform:gift_cards"
+	// TaxAccount is the tax collected on sales and owed to tax authorities.
+	TaxAccount = "platform:tax"
+)
+
+// SellerAccount names the account of a supplier or reseller.
+func SellerAccount(userID string) string {
+	return "seller:" + userID
+}
+
+// Kind says what moved money in a journal entry.
+type Kind string
+
+const (
+	KindSale        Kind = "sale"
+	KindFee         Kind = "fee"
+	KindRefund      Kind = "refund"
+	KindFeeReversal Kind = "fee_reversal"
+	KindPayout      Kind = "payout"
+	KindEscrowHold  Kind = "escrow_hold"
+	KindCreditIssue Kind = "credit_issue"
+	KindGiftCard    Kind = "gift_card"
+	KindTax         Kind = "tax"
+	KindTaxReversal Kind = "tax_reversal"
+)
+
+// Line debits or credits one account. Exactly one of Debit and Credit is set.
+type Line struct {
+	Account string  `bson:"account" json:"account"`
+	Debit   float64 `bson:"debit" json:"debit"`
+	Credit  float64 `bson:"credit" json:"credit"`
+}
+
+// Entry is a balanced journal entry: its debits and credits add up to the
+// same amount.
+type Entry struct {
+	ID        string    `bson:"_id" json:"id"`
+	Kind      Kind      `bson:"kind" json:"kind"`
+	PaymentID string    `bson:"payment_id,omitempty" json:"payment_id,omitempty"`
+	PayoutID  string    `bson:"payout_id,omitempty" json:"payout_id,omitempty"`
+	OrderID   string    `bson:"order_id,omitempty" json:"order_id,omitempty"`
+	Memo      string    `bson:"memo" json:"memo"`
+	Lines     []Line    `bson:"lines" json:"lines"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Transfer builds an entry moving amount from the debited account to the
+// credited one.
+func Transfer(kind Kind, debit, credit string, amount float64) Entry {
+	return Entry{
+		Kind: kind,
+		Lines: []Line{
+			{Account: debit, Debit: amount},
+			{Account: credit, Credit: amount},
+		},
+	}
+}
+
+// balanceTolerance absorbs floating point noise below half a cent.
+const balanceTolerance = 0.005
+
+func (e *Entry) Validate() error {
+	if len(e.Lines) < 2 {
+		return fmt.Errorf("%w: an entry needs at least two lines", ErrUnbalancedEntry)
+	}
+	var debits, credits float64
+	for _, l := range e.Lines {
+		if l.Account == "" || l.Debit < 0 || l.Credit < 0 || (l.Debit > 0) == (l.Credit > 0) {
+			return fmt.Errorf("%w: each line must debit or credit one account by a positive amount", ErrUnbalancedEntry)
+		}
+		debits += l.Debit
+		credits += l.Credit
+	}
+	if math.Abs(debits-credits) > balanceTolerance {
+		return fmt.Errorf("%w: debits %.2f do not equal credits %.2f", ErrUnbalancedEntry, debits, credits)
+	}
+	return nil
+}
+
+// Totals are the debits and credits posted to one account.
+type Totals struct {
+	Debit  float64 `bson:"debit"`
+	Credit float64 `bson:"credit"`
+}
+
+// Balance is a seller's account summarised by what moved money. Available is
+// what the platform owes the seller right now.
+type Balance struct {
+	UserID    string  `json:"userId"`
+	Sales     float64 `json:"sales"`
+	Fees      float64 `json:"fees"`
+	Taxes     float64 `json:"taxes"`
+	Refunds   float64 `json:"refunds"`
+	Payouts   float64 `json:"payouts"`
+	Earnings  float64 `json:"earnings"` // sales less fees, taxes and refunds
+	Available float64 `json:"available"`
+}
+
+// NewBalance summarises a seller account from its totals per kind.
+func NewBalance(userID string, byKind map[Kind]Totals) *Balance {
+	b := &Balance{UserID: userID}
+	b.Sales = byKind[KindSale].Credit
+	b.Fees = byKind[KindFee].Debit - byKind[KindFeeReversal].Credit
+	b.Taxes = byKind[KindTax].Debit - byKind[KindTaxReversal].Credit
+	b.Refunds = byKind[KindRefund].Debit
+	b.Payouts = byKind[KindPayout].Debit
+	b.Earnings = b.Sales - b.Fees - b.Taxes - b.Refunds
+	for _, t := range byKind {
+		b.Available += t.Credit - t.Debit
+	}
+	return b
+}
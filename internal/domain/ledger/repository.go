@@ -0,0 +1,9 @@
+package ledger
+
+import "context"
+
+type Repository interface {
+	PostEntries(ctx context.Context, entries []Entry) error
+	// AccountTotals sums the lines posted to account, grouped by entry kind.
+	AccountTotals(ctx context.Context, account string) (map[Kind]Totals, error)
+}
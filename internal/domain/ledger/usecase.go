@@ -0,0 +1,28 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+// Recorder posts the journal entries for a recorded payment. A payment held
+// in escrow is only booked as a sale once RecordRelease is called for it.
+type Recorder interface {
+	RecordPayment(ctx context.Context, p *payment.Payment) error
+}
+
+type Usecase interface {
+	Recorder
+	// RecordRelease books an escrowed payment as a sale now that the seller
+	// is entitled to it.
+	RecordRelease(ctx context.Context, p *payment.Payment) error
+	// RecordPayout posts money sent to a seller by a payout batch.
+	RecordPayout(ctx context.Context, sellerID, payoutID string, amount float64) error
+	// RecordCreditIssued posts store credit the platform granted a consumer.
+	RecordCreditIssued(ctx context.Context, userID string, amount float64, memo string) error
+	// RecordGiftCardRedeemed posts the store credit a consumer got for a gift
+	// card.
+	RecordGiftCardRedeemed(ctx context.Context, userID, code string, amount float64) error
+	GetBalance(ctx context.Context, userID string) (*Balance, error)
+}
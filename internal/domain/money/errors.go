@@ -0,0 +1,10 @@
+package money
+
+import "errors"
+
+var (
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrNoRate              = errors.New("no exchange rate")
+	ErrInvalidRate         = errors.New("exchange rate must be positive")
+	ErrSettlementRate      = errors.New("the settlement currency always has a rate of 1")
+)
@@ -0,0 +1,104 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code.
+type Currency string
+
+const (
+	ETB Currency = "ETB"
+	USD Currency = "USD"
+)
+
+// Settlement is the currency the platform keeps its books in. Order totals,
+// fees, payouts, escrow and store credit are all in it.
+const Settlement = ETB
+
+// minorUnits is how many minor units make one unit of each supported currency.
+var minorUnits = map[Currency]int64{
+	ETB: 100, // santim
+	USD: 100, // cents
+}
+
+// ParseCurrency reads a currency code, ignoring case and surrounding spaces.
+func ParseCurrency(s string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	if _, ok := minorUnits[c]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedCurrency, s)
+	}
+	return c, nil
+}
+
+// OrSettlement returns c, or Settlement for prices and payments recorded
+// before they carried a currency.
+func OrSettlement(c Currency) Currency {
+	if c == "" {
+		return Settlement
+	}
+	return c
+}
+
+// Money is an amount in minor units of a currency, e.g. 1250 USD is $12.50.
+type Money struct {
+	Amount   int64    `bson:"amount" json:"amount"`
+	Currency Currency `bson:"currency" json:"currency"`
+}
+
+// FromMajor converts an amount in whole units with a fractional part, such
+// as a stored float64 price, rounding to the nearest minor unit.
+func FromMajor(amount float64, c Currency) Money {
+	return Money{Amount: int64(math.Round(amount * units(c))), Currency: c}
+}
+
+// InSettlement is an amount of the settlement currency, such as a fee or tax
+// worked out in float64, rounded to the nearest minor unit.
+func InSettlement(amount float64) Money {
+	return FromMajor(amount, Settlement)
+}
+
+// Major is the amount in whole units, for arithmetic on the float64 amounts
+// the books are kept in.
+func (m Money) Major() float64 {
+	return float64(m.Amount) / units(m.Currency)
+}
+
+// units is how many minor units make one unit of c. Money without a
+// currency, such as an unset amount, counts in the settlement currency.
+func units(c Currency) float64 {
+	return float64(minorUnits[OrSettlement(c)])
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%s %.2f", m.Currency, m.Major())
+}
+
+// Add returns m plus o, which must be in the same currency. A zero Money
+// without a currency takes o's.
+func (m Money) Add(o Money) Money {
+	if m.Currency == "" {
+		m.Currency = o.Currency
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}
+}
+
+// Sub returns m minus o, which must be in the same currency.
+func (m Money) Sub(o Money) Money {
+	return m.Add(o.Neg())
+}
+
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Times scales m by f, rounding to the nearest minor unit.
+func (m Money) Times(f float64) Money {
+	return Money{Amount: int64(math.Round(float64(m.Amount) * f)), Currency: m.Currency}
+}
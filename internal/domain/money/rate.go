@@ -0,0 +1,78 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Rate is what one unit of a currency is worth in the settlement currency.
+// Admins update it by hand.
+type Rate struct {
+	Currency  Currency  `bson:"_id" json:"currency"`
+	Rate      float64   `bson:"rate" json:"rate"` // units of Settlement per unit of Currency
+	UpdatedBy string    `bson:"updated_by" json:"updated_by"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Rates is the exchange-rate table, keyed by currency.
+type Rates map[Currency]float64
+
+// NewRates builds the table from the stored rates.
+func NewRates(rates []*Rate) Rates {
+	r := Rates{}
+	for _, rate := range rates {
+		r[rate.Currency] = rate.Rate
+	}
+	return r
+}
+
+// Of returns what one unit of c is worth in the settlement currency.
+func (r Rates) Of(c Currency) (float64, error) {
+	if c == Settlement {
+		return 1, nil
+	}
+	rate, ok := r[c]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("%w for %s", ErrNoRate, c)
+	}
+	return rate, nil
+}
+
+// Convert prices m in another currency, going through the settlement
+// currency and rounding to the nearest minor unit.
+func (r Rates) Convert(m Money, to Currency) (Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	from, err := r.Of(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	into, err := r.Of(to)
+	if err != nil {
+		return Money{}, err
+	}
+	return FromMajor(m.Major()*from/into, to), nil
+}
+
+// MinorFactor returns how many minor units of the settlement currency one
+// minor unit of c is worth.
+func (r Rates) MinorFactor(c Currency) (float64, error) {
+	rate, err := r.Of(c)
+	if err != nil {
+		return 0, err
+	}
+	return rate * units(Settlement) / units(c), nil
+}
+
+// SettlementMinor is m in minor units of the settlement currency. It rounds
+// half to even like MongoDB's $round, so it matches the prices searches
+// normalize in the database.
+func (r Rates) SettlementMinor(m Money) (int64, error) {
+	f, err := r.MinorFactor(m.Currency)
+	if err != nil {
+		return 0, err
+	}
+	return int64(math.RoundToEven(float64(m.Amount) * f)), nil
+}
@@ -0,0 +1,9 @@
+package money
+
+import "context"
+
+type Repository interface {
+	ListRates(ctx context.Context) ([]*Rate, error)
+	// SaveRate replaces the rate for r.Currency, adding it if there was none.
+	SaveRate(ctx context.Context, r *Rate) error
+}
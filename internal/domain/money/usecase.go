@@ -0,0 +1,14 @@
+package money
+
+import "context"
+
+// Converter gives the current exchange-rate table.
+type Converter interface {
+	Rates(ctx context.Context) (Rates, error)
+}
+
+type Usecase interface {
+	Converter
+	ListRates(ctx context.Context) ([]*Rate, error)
+	SetRate(ctx context.Context, adminID string, c Currency, rate float64) (*Rate, error)
+}
@@ -0,0 +1,21 @@
+package notification
+
+import "time"
+
+// Notification types.
+const (
+	TypeAuctionWon  = "auction.won"
+	TypeAuctionLost = "auction.lost"
+)
+
+// Notification is a message left in a user's inbox by something that
+// happened on the platform.
+type Notification struct {
+	ID        string            `bson:"_id" json:"id"`
+	UserID    string            `bson:"user_id" json:"user_id"`
+	Type      string            `bson:"type" json:"type"`
+	Message   string            `bson:"message" json:"message"`
+	Data      map[string]string `bson:"data,omitempty" json:"data,omitempty"`
+	Read      bool              `bson:"read" json:"read"`
+	CreatedAt time.Time         `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,12 @@
+package notification
+
+import "context"
+
+type Repository interface {
+	CreateNotification(ctx context.Context, n *Notification) error
+	// ListByUser lists the user's notifications, newest first.
+	ListByUser(ctx context.Context, userID string) ([]*Notification, error)
+	// MarkRead marks one of the user's notifications as read and returns
+	// ErrNotificationNotFound if the user has no such notification.
+	MarkRead(ctx context.Context, id, userID string) error
+}
@@ -0,0 +1,16 @@
+package notification
+
+import "context"
+
+// Notifier leaves a notification in a user's inbox. Called with a context
+// from a unit of work, the notification is only kept if the unit of work
+// commits.
+type Notifier interface {
+	Notify(ctx context.Context, userID, notificationType, message string, data map[string]string) error
+}
+
+type Usecase interface {
+	Notifier
+	ListNotifications(ctx context.Context, userID string) ([]*Notification, error)
+	MarkRead(ctx context.Context, id, userID string) error
+}
@@ -0,0 +1,20 @@
+package offer
+
+import "errors"
+
+var (
+	ErrOfferNotFound      = errors.New("offer not found")
+	ErrListingNotFound    = errors.New("listing not found")
+	ErrNotAvailable       = errors.New("listing is not available for offers")
+	ErrInvalidListingType = errors.New("listing type must be bundle or product")
+	ErrWrongBuyer         = errors.New("resellers make offers on bundles and consumers on products")
+	ErrOwnListing         = errors.New("sellers cannot make offers on their own listings")
+	ErrInvalidPrice       = errors.New("invalid offer price")
+	ErrInvalidAction      = errors.New("action must be accept, reject or counter")
+	ErrOfferExists        = errors.New("you already have an open or accepted offer on this listing")
+	ErrNotOpen            = errors.New("offer is no longer open")
+	ErrOfferExpired       = errors.New("offer has expired")
+	ErrNotYourTurn        = errors.New("offer is waiting on the other party")
+	ErrReserved           = errors.New("listing is reserved for another buyer")
+	ErrOfferChanged       = errors.New("offer was updated by another request")
+)
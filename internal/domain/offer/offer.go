@@ -0,0 +1,160 @@
+package offer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+// ListingType is what kind of listing an offer is made on. Resellers make
+// offers on bundles to suppliers, consumers on products to resellers.
+type ListingType string
+
+const (
+	ListingBundle  ListingType = "bundle"
+	ListingProduct ListingType = "product"
+)
+
+// Status is a step in an offer's life. An open offer waits for one party to
+// accept, reject or counter the last proposal. An accepted offer reserves
+// the listing for the buyer; it is completed if the buyer checks out in time
+// and lapses otherwise.
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusAccepted  Status = "accepted"
+	StatusRejected  Status = "rejected"
+	StatusExpired   Status = "expired"
+	StatusCompleted Status = "completed"
+	StatusLapsed    Status = "lapsed"
+)
+
+const (
+	// ResponseWindow is how long a party has to answer a proposal.
+	ResponseWindow = 48 * time.Hour
+	// CheckoutWindow is how long an accepted offer holds the listing.
+	CheckoutWindow = 24 * time.Hour
+)
+
+// Proposal is one price put forward in an offer's thread.
+type Proposal struct {
+	ByID  string    `bson:"by_id" json:"by_id"`
+	Price float64   `bson:"price" json:"price"`
+	Note  string    `bson:"note,omitempty" json:"note,omitempty"`
+	At    time.Time `bson:"at" json:"at"`
+}
+
+type Offer struct {
+	ID          string      `bson:"_id" json:"id"`
+	ListingType ListingType `bson:"listing_type" json:"listing_type"`
+	ListingID   string      `bson:"listing_id" json:"listing_id"`
+	BuyerID     string      `bson:"buyer_id" json:"buyer_id"`
+	SellerID    string      `bson:"seller_id" json:"seller_id"`
+	// Prices are in the listing's currency. ListPrice is the listing's
+	// price when the offer was made.
+	Currency  money.Currency `bson:"currency" json:"currency"`
+	ListPrice float64        `bson:"list_price" json:"list_price"`
+	// Price is the last price proposed, and the agreed one once accepted.
+	Price  float64    `bson:"price" json:"price"`
+	Thread []Proposal `bson:"thread" json:"thread"`
+	Status Status     `bson:"status" json:"status"`
+	// AwaitingID is the party who has to answer an open offer by ExpiresAt.
+	AwaitingID    string     `bson:"awaiting_id,omitempty" json:"awaiting_id,omitempty"`
+	ExpiresAt     time.Time  `bson:"expires_at" json:"expires_at"`
+	ReservedUntil *time.Time `bson:"reserved_until,omitempty" json:"reserved_until,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// Reservation holds a listing for the buyer whose offer was accepted, at the
+// agreed price, until the checkout window closes.
+type Reservation struct {
+	OfferID string      `bson:"offer_id" json:"offer_id"`
+	BuyerID string      `bson:"buyer_id" json:"buyer_id"`
+	Price   money.Money `bson:"price" json:"price"`
+	Until   time.Time   `bson:"until" json:"until"`
+}
+
+// Holds reports whether the listing is still reserved at now.
+func (r *Reservation) Holds(now time.Time) bool {
+	return r != nil && now.Before(r.Until)
+}
+
+// PriceFor returns what buyerID pays for a listing at listPrice: the agreed
+// price if it is reserved for them, and the list price if it is not
+// reserved. A listing reserved for someone else cannot be bought.
+func (r *Reservation) PriceFor(buyerID string, listPrice money.Money, now time.Time) (money.Money, error) {
+	if !r.Holds(now) {
+		return listPrice, nil
+	}
+	if r.BuyerID != buyerID {
+		return money.Money{}, ErrReserved
+	}
+	return r.Price, nil
+}
+
+// IsParty reports whether the actor may see the offer: its buyer, its
+// seller or staff.
+func (o *Offer) IsParty(actorID string, role user.Role) bool {
+	return role == user.RoleAdmin || actorID == o.BuyerID || actorID == o.SellerID
+}
+
+// Propose adds a price to the thread and hands the offer to the other party.
+func (o *Offer) Propose(byID string, price float64, note string, now time.Time) error {
+	if price <= 0 || price > o.ListPrice {
+		return fmt.Errorf("%w: price must be above zero and at most the list price of %.2f %s", ErrInvalidPrice, o.ListPrice, o.Currency)
+	}
+	o.Thread = append(o.Thread, Proposal{ByID: byID, Price: price, Note: note, At: now})
+	o.Price = price
+	o.AwaitingID = o.SellerID
+	if byID == o.SellerID {
+		o.AwaitingID = o.BuyerID
+	}
+	o.ExpiresAt = now.Add(ResponseWindow)
+	o.UpdatedAt = now
+	return nil
+}
+
+// Respondable checks that actorID may answer the offer at now.
+func (o *Offer) Respondable(actorID string, now time.Time) error {
+	if o.Status != StatusOpen {
+		return ErrNotOpen
+	}
+	if !now.Before(o.ExpiresAt) {
+		return ErrOfferExpired
+	}
+	if actorID != o.AwaitingID {
+		return ErrNotYourTurn
+	}
+	return nil
+}
+
+// Accept agrees to the last proposal and reserves the listing for the buyer.
+func (o *Offer) Accept(actorID string, now time.Time) (*Reservation, error) {
+	if err := o.Respondable(actorID, now); err != nil {
+		return nil, err
+	}
+	until := now.Add(CheckoutWindow)
+	o.Status, o.AwaitingID, o.ReservedUntil, o.UpdatedAt = StatusAccepted, "", &until, now
+	return &Reservation{OfferID: o.ID, BuyerID: o.BuyerID, Price: money.FromMajor(o.Price, o.Currency), Until: until}, nil
+}
+
+// Reject ends the negotiation.
+func (o *Offer) Reject(actorID string, now time.Time) error {
+	if err := o.Respondable(actorID, now); err != nil {
+		return err
+	}
+	o.Status, o.AwaitingID, o.UpdatedAt = StatusRejected, "", now
+	return nil
+}
+
+// Counter answers the last proposal with another price.
+func (o *Offer) Counter(actorID string, price float64, note string, now time.Time) error {
+	if err := o.Respondable(actorID, now); err != nil {
+		return err
+	}
+	return o.Propose(actorID, price, note, now)
+}
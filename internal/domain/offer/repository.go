@@ -0,0 +1,17 @@
+package offer
+
+import "context"
+
+type Repository interface {
+	CreateOffer(ctx context.Context, o *Offer) error
+	// GetOfferByID returns ErrOfferNotFound if there is no such offer.
+	GetOfferByID(ctx context.Context, id string) (*Offer, error)
+	// GetOpenOffer returns the buyer's open offer on the listing, or nil.
+	GetOpenOffer(ctx context.Context, listingID, buyerID string) (*Offer, error)
+	// ListOffersByUser lists the offers the user is buyer or seller in,
+	// most recently updated first.
+	ListOffersByUser(ctx context.Context, userID string) ([]*Offer, error)
+	// UpdateOffer stores o only if the offer is still in status from with
+	// rounds proposals in its thread, and returns ErrOfferChanged otherwise.
+	UpdateOffer(ctx context.Context, o *Offer, from Status, rounds int) error
+}
@@ -0,0 +1,49 @@
+package offer
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+// Request is what a buyer fills in to make an offer.
+type Request struct {
+	ListingType ListingType `json:"listing_type"`
+	ListingID   string      `json:"listing_id"`
+	Price       float64     `json:"price"`
+	Note        string      `json:"note"`
+}
+
+// Action is how a party answers an open offer.
+type Action string
+
+const (
+	ActionAccept  Action = "accept"
+	ActionReject  Action = "reject"
+	ActionCounter Action = "counter"
+)
+
+// Response answers an open offer. Price and Note are for counters.
+type Response struct {
+	Action Action  `json:"action"`
+	Price  float64 `json:"price"`
+	Note   string  `json:"note"`
+}
+
+type Usecase interface {
+	// MakeOffer opens an offer on an available listing and queues its
+	// expiry.
+	MakeOffer(ctx context.Context, buyerID string, role user.Role, req Request) (*Offer, error)
+	GetOffer(ctx context.Context, id, actorID string, role user.Role) (*Offer, error)
+	ListMyOffers(ctx context.Context, userID string) ([]*Offer, error)
+	// Respond accepts, rejects or counters an open offer on behalf of the
+	// party it is waiting on. Accepting reserves the listing for the buyer
+	// at the agreed price for the checkout window.
+	Respond(ctx context.Context, id, actorID string, r Response) (*Offer, error)
+	// ExpireOffer expires an open offer nobody answered in time. Other
+	// offers are left alone.
+	ExpireOffer(ctx context.Context, id string) error
+	// ReleaseOffer ends an accepted offer's checkout window, completing it
+	// if the listing was bought and releasing the listing otherwise.
+	ReleaseOffer(ctx context.Context, id string) error
+}
@@ -0,0 +1,63 @@
+package order
+
+import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+
+// IsBundleOrder reports whether the order is a B2B bundle sale rather than a
+// consumer purchase of reseller products.
+func (o *Order) IsBundleOrder() bool {
+	return o.BundleID != "" && o.ConsumerID == ""
+}
+
+// cancellableStatuses lists, per role, the statuses from which that role may
+// cancel a consumer order. Bundle orders are completed at purchase, so their
+// cancellation is further limited by the warehouse state of the bundle.
+var cancellableStatuses = map[user.Role][]OrderStatus{
+	user.RoleConsumer: {OrderStatusPending, OrderStatusProcessing},
+	user.RoleReseller: {OrderStatusPending, OrderStatusProcessing, OrderStatusCompleted},
+	user.RoleSupplier: {OrderStatusProcessing, OrderStatusCompleted},
+	user.RoleAdmin:    {OrderStatusPending, OrderStatusProcessing, OrderStatusCompleted, OrderStatusShipped},
+}
+
+// IsParty reports whether the actor takes part in the order in the given role.
+func (o *Order) IsParty(actorID string, role user.Role) bool {
+	switch role {
+	case user.RoleAdmin:
+		return true
+	case user.RoleConsumer:
+		return o.ConsumerID == actorID
+	case user.RoleReseller:
+		return o.ResellerID == actorID
+	case user.RoleSupplier:
+		return o.IsBundleOrder() && o.SupplierID == actorID
+	}
+	return false
+}
+
+// CanCancel decides whether the actor may cancel the order in its current status.
+func (o *Order) CanCancel(actorID string, role user.Role) error {
+	if o.Status == OrderStatusCanceled {
+		return ErrAlreadyCanceled
+	}
+	if !o.IsParty(actorID, role) {
+		return ErrNotOrderParty
+	}
+	for _, s := range cancellableStatuses[role] {
+		if o.Status == s {
+			return nil
+		}
+	}
+	return ErrCannotCancel
+}
+
+// CanRefund decides whether the actor may issue a refund on the order. Only
+// the seller and admins can give money back.
+func (o *Order) CanRefund(actorID string, role user.Role) error {
+	if role == user.RoleConsumer || !o.IsParty(actorID, role) {
+		return ErrNotOrderParty
+	}
+	// The reseller is the seller of a consumer order but the buyer of a bundle order.
+	if role == user.RoleReseller && o.IsBundleOrder() {
+		return ErrNotOrderParty
+	}
+	return nil
+}
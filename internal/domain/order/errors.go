@@ -0,0 +1,13 @@
+package order
+
+import "errors"
+
+var (
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrNotOrderParty       = errors.New("you are not a party to this order")
+	ErrCannotCancel        = errors.New("order cannot be canceled in its current status")
+	ErrAlreadyCanceled     = errors.New("order is already canceled")
+	ErrInvalidTransition   = errors.New("order cannot move to the requested status")
+	ErrStatusChanged       = errors.New("order status was changed by another request")
+	ErrInvalidRefundAmount = errors.New("refund amount must be positive and no more than the amount still refundable")
+)
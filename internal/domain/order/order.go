@@ -1,31 +1,83 @@
 package order
 
-import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+)
 
+// OrderStatus is a step in the order lifecycle. The legal moves between
+// statuses are defined in status.go.
 type OrderStatus string
 
 const (
-	Pending   OrderStatus = "pending"
-	Shipped   OrderStatus = "shipped"
-	Delivered OrderStatus = "delivered"
-	Failed    OrderStatus = "failed"
-
+	OrderStatusPending    OrderStatus = "pending"
 	OrderStatusProcessing OrderStatus = "processing"
+	OrderStatusShipped    OrderStatus = "shipped"
+	OrderStatusDelivered  OrderStatus = "delivered"
 	OrderStatusCompleted  OrderStatus = "completed"
 	OrderStatusCanceled   OrderStatus = "canceled"
+	OrderStatusFailed     OrderStatus = "failed"
 )
 
 type Order struct {
-	ID          string      `json:"id"`
-	ResellerID  string      `json:"reseller_id"`
-	SupplierID  string      `json:"supplier_id"`
-	BundleID    string      `json:"bundle_id"`
-	PlatformFee float64     `json:"platform_fee"`
-	ConsumerID  string      `json:"consumer_id"`
-	ProductIDs  []string    `json:"product_ids"`
-	TotalPrice  float64     `json:"total_price"`
-	Status      OrderStatus `json:"status"`
-	CreatedAt   string      `json:"created_at"`
+	ID            string             `bson:"_id" json:"id"`
+	ResellerID    string             `bson:"reseller_id" json:"reseller_id"`
+	SupplierID    string             `bson:"supplier_id" json:"supplier_id"`
+	BundleID      string             `bson:"bundle_id" json:"bundle_id"`
+	PlatformFee   money.Money        `bson:"platform_fee" json:"platform_fee"`
+	SellerEarning money.Money        `bson:"seller_earning" json:"seller_earning"`
+	ConsumerID    string             `bson:"consumer_id" json:"consumer_id"`
+	ProductIDs    []string           `bson:"product_ids" json:"product_ids"`
+	TotalPrice    money.Money        `bson:"total_price" json:"total_price"`
+	Status        OrderStatus        `bson:"status" json:"status"`
+	History       []StatusTransition `bson:"history" json:"history"`
+	CreatedAt     string             `bson:"created_at" json:"created_at"`
+	// ShippingAddress is where a consumer order goes. Bundle orders are
+	// delivered to the warehouse and have none.
+	ShippingAddress *ShippingAddress `bson:"shipping_address,omitempty" json:"shipping_address,omitempty"`
+	// Discount is what a promo code took off the order; TotalPrice is what
+	// was paid after it.
+	Discount  money.Money `bson:"discount,omitempty" json:"discount"`
+	PromoCode string      `bson:"promo_code,omitempty" json:"promo_code,omitempty"`
+	// TotalPrice, fees and Discount are in money.Settlement. Charged is
+	// TotalPrice in the currency the buyer paid in.
+	Charged *money.Money `bson:"charged,omitempty" json:"charged,omitempty"`
+	// Tax is the part of TotalPrice collected for the tax authority, broken
+	// down by TaxLines.
+	Tax      money.Money `bson:"tax,omitempty" json:"tax"`
+	TaxLines []tax.Line  `bson:"tax_lines,omitempty" json:"tax_lines,omitempty"`
+	// LineTotals is what was paid for each of ProductIDs, after discount and
+	// with tax; empty on orders placed before it was recorded.
+	LineTotals map[string]money.Money `bson:"line_totals,omitempty" json:"line_totals,omitempty"`
+	// Titles is what each of ProductIDs, or the bundle, was listed as when
+	// it was bought, so the order can still be described once the listing
+	// is gone.
+	Titles map[string]string `bson:"titles,omitempty" json:"titles,omitempty"`
+}
+
+// LineTotal returns what was paid for one of the order's products. Orders
+// without line totals are split evenly between their products.
+func (o *Order) LineTotal(productID string) money.Money {
+	if total, ok := o.LineTotals[productID]; ok {
+		return total
+	}
+	if len(o.ProductIDs) == 0 {
+		return money.Money{Currency: o.TotalPrice.Currency}
+	}
+	return o.TotalPrice.Times(1 / float64(len(o.ProductIDs)))
+}
+
+// ShippingAddress is the ship-to address copied onto an order at checkout.
+type ShippingAddress struct {
+	FullName   string `bson:"full_name" json:"full_name"`
+	Phone      string `bson:"phone" json:"phone"`
+	Line1      string `bson:"line1" json:"line1"`
+	Line2      string `bson:"line2" json:"line2"`
+	City       string `bson:"city" json:"city"`
+	Region     string `bson:"region" json:"region"`
+	PostalCode string `bson:"postal_code" json:"postal_code"`
+	Country    string `bson:"country" json:"country"`
 }
 
 type PerformanceMetrics struct {
@@ -36,6 +88,8 @@ type PerformanceMetrics struct {
 
 type DashboardMetrics struct {
 	TotalSales         float64            `json:"totalSales"`
+	Earnings           float64            `json:"earnings"`
+	Balance            float64            `json:"balance"`
 	ActiveBundles      []*bundle.Bundle   `json:"activeBundles"`
 	PerformanceMetrics PerformanceMetrics `json:"performanceMetrics"`
 	Rating             int                `json:"rating"`
@@ -43,9 +97,11 @@ type DashboardMetrics struct {
 }
 
 type ResellerMetrics struct {
-	TotalBoughtBundles int               `json:"totalBoughtBundles"`
-	TotalItemsSold     int               `json:"totalItemsSold"`
-	Rating             int               `json:"rating"`
-	BestSelling        float64           `json:"bestSelling"`
-	BoughtBundles      []*bundle.Bundle  `json:"boughtBundles"`
+	TotalBoughtBundles int              `json:"totalBoughtBundles"`
+	TotalItemsSold     int              `json:"totalItemsSold"`
+	Rating             int              `json:"rating"`
+	BestSelling        float64          `json:"bestSelling"`
+	Earnings           float64          `json:"earnings"`
+	Balance            float64          `json:"balance"`
+	BoughtBundles      []*bundle.Bundle `json:"boughtBundles"`
 }
@@ -0,0 +1,76 @@
+package order
+
+import (
+	"fmt"
+	"time"
+)
+
+// SystemActor is recorded as the actor of transitions the platform makes on
+// its own, such as simulated deliveries.
+const SystemActor = "system"
+
+// StatusTransition is one entry in an order's status history.
+type StatusTransition struct {
+	From    OrderStatus `bson:"from,omitempty" json:"from,omitempty"`
+	To      OrderStatus `bson:"to" json:"to"`
+	ActorID string      `bson:"actor_id" json:"actor_id"`
+	Reason  string      `bson:"reason,omitempty" json:"reason,omitempty"`
+	At      time.Time   `bson:"at" json:"at"`
+}
+
+// transitions lists the statuses an order may move to from each status.
+// Canceled and failed orders are final.
+var transitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:    {OrderStatusProcessing, OrderStatusShipped, OrderStatusCanceled, OrderStatusFailed},
+	OrderStatusProcessing: {OrderStatusShipped, OrderStatusCompleted, OrderStatusCanceled, OrderStatusFailed},
+	OrderStatusShipped:    {OrderStatusDelivered, OrderStatusCanceled, OrderStatusFailed},
+	OrderStatusDelivered:  {OrderStatusCompleted},
+	OrderStatusCompleted:  {OrderStatusCanceled},
+}
+
+// CanTransition reports whether an order may move from one status to another.
+func CanTransition(from, to OrderStatus) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFulfilled reports whether the buyer has received what they paid for.
+func (s OrderStatus) IsFulfilled() bool {
+	return s == OrderStatusDelivered || s == OrderStatusCompleted
+}
+
+// Place sets the status a new order starts in and opens its history.
+func (o *Order) Place(status OrderStatus, actorID string) {
+	o.Status = status
+	o.History = []StatusTransition{{To: status, ActorID: actorID, At: time.Now()}}
+}
+
+// Transition checks that the order may move to the given status and returns
+// the transition to store. The order itself is left unchanged until Apply.
+func (o *Order) Transition(to OrderStatus, actorID, reason string) (StatusTransition, error) {
+	if !CanTransition(o.Status, to) {
+		return StatusTransition{}, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, o.Status, to)
+	}
+	return StatusTransition{From: o.Status, To: to, ActorID: actorID, Reason: reason, At: time.Now()}, nil
+}
+
+// Apply records a stored transition on the in-memory order.
+func (o *Order) Apply(t StatusTransition) {
+	o.Status = t.To
+	o.History = append(o.History, t)
+}
+
+// FulfilledAt returns when the order was first delivered or completed, and
+// false if it never was.
+func (o *Order) FulfilledAt() (time.Time, bool) {
+	for _, t := range o.History {
+		if t.To.IsFulfilled() {
+			return t.At, true
+		}
+	}
+	return time.Time{}, false
+}
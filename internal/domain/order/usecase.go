@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 )
 
@@ -14,5 +16,25 @@ type Usecase interface {
 	GetOrderByID(ctx context.Context, orderID string) (*Order, error)
 	GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*Order, error)
 	GetResellerMetrics(ctx context.Context, resellerID string) (*ResellerMetrics, error)
+	// GetOrdersToFulfil lists the consumer orders a reseller has sold, newest first.
+	GetOrdersToFulfil(ctx context.Context, resellerID string) ([]*Order, error)
 	GetAdminDashboardMetrics(ctx context.Context) (*admin.Metrics, error)
+	CancelOrder(ctx context.Context, orderID, actorID string, role user.Role, reason string) (*Order, error)
+	RefundOrder(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error)
+	// RecordRefund is RefundOrder without returning the money: it books the
+	// refund as pending and queues the job that settles it, and joins the
+	// caller's unit of work. Call SettleRefund once that commits.
+	RecordRefund(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error)
+	payment.RefundSettler
+	GetEscrow(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error)
+	// DisputeEscrow freezes a bundle payment until an admin resolves it.
+	DisputeEscrow(ctx context.Context, orderID, resellerID, reason string) (*escrow.Escrow, error)
+	// ResolveEscrow settles a disputed escrow, either releasing it to the
+	// supplier or refunding the reseller.
+	ResolveEscrow(ctx context.Context, orderID, adminID string, refund bool, note string) (*escrow.Escrow, error)
+	// ConfirmArrival records that a bundle arrived at the warehouse and passed
+	// inspection, which releases its payment to the supplier.
+	ConfirmArrival(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error)
+	escrow.Releaser
+	escrow.ArrivalReleaser
 }
@@ -0,0 +1,61 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+var (
+	ErrPaymentDeclined    = errors.New("payment declined")
+	ErrGatewayUnavailable = errors.New("payment gateway unavailable")
+	ErrChargeNotFound     = errors.New("charge not found")
+	ErrCurrencyMismatch   = errors.New("refund currency does not match the charge")
+	ErrInvalidSignature   = errors.New("invalid webhook signature")
+)
+
+type ChargeStatus string
+
+const (
+	ChargeAuthorized ChargeStatus = "authorized"
+	ChargeCaptured   ChargeStatus = "captured"
+	ChargeRefunded   ChargeStatus = "refunded"
+)
+
+// Charge is money taken from the buyer in the currency they pay in.
+type Charge struct {
+	ID          string
+	Amount      money.Money
+	Refunded    money.Money
+	ReferenceID string
+	Status      ChargeStatus
+}
+
+type Refund struct {
+	ID       string
+	ChargeID string
+	Amount   money.Money
+}
+
+// WebhookEvent is a verified notification sent by the gateway, e.g. "charge.refunded".
+type WebhookEvent struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	ChargeID string `json:"charge_id"`
+}
+
+// Gateway moves money through an external payment provider.
+type Gateway interface {
+	// Authorize reserves amount on the buyer's payment method without
+	// collecting it. The charge is in amount's currency.
+	Authorize(ctx context.Context, amount money.Money, referenceID string) (*Charge, error)
+	// Capture collects a previously authorized charge.
+	Capture(ctx context.Context, chargeID string) (*Charge, error)
+	// Refund returns amount of a captured charge to the buyer; partial refunds
+	// are allowed and must be in the charge's currency. Refunding again with
+	// the same idempotencyKey returns the first refund instead of refunding twice.
+	Refund(ctx context.Context, chargeID string, amount money.Money, idempotencyKey string) (*Refund, error)
+	// VerifyWebhook checks the signature of a webhook payload and decodes it.
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
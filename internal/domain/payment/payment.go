@@ -1,5 +1,12 @@
 package payment
 
+import (
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+)
+
 type PaymentType string
 
 const (
@@ -7,15 +14,57 @@ const (
 	B2C PaymentType = "b2c"
 )
 
+const (
+	StatusPaid     = "paid"
+	StatusRefunded = "refunded"
+	// StatusRefundPending marks a refund that is on the books but whose money
+	// the gateway has not returned yet; it becomes refunded once it has.
+	StatusRefundPending = "refund_pending"
+	// StatusHeld marks a payment collected into escrow; it becomes paid
+	// once released to the seller.
+	StatusHeld = "held"
+)
+
 type Payment struct {
 	ID            string
 	FromUserID    string
 	ToUserID      string
-	Amount        float64
-	PlatformFee   float64
-	SellerEarning float64
+	Amount        money.Money
+	Discount      money.Money // Taken off by PromoCode; Amount is after it
+	PromoCode     string
+	CreditAmount  money.Money // Part of Amount paid from store credit; the rest went through ChargeID
+	PlatformFee   money.Money
+	SellerEarning money.Money
 	Status        string
-	ReferenceID   string      // This is either BundleID or ProductID
+	ReferenceID   string      // BundleID for B2B payments, OrderID for B2C payments
+	OrderID       string      // Order this payment settles
+	ChargeID      string      // Gateway charge the money was collected with
+	RefundOf      string      // For refunds: ID of the payment being reversed
 	Type          PaymentType // "b2b" or "b2c"
-	CreatedAt     string
+	FeeRule       string      // Fee rule that set PlatformFee; several are comma separated
+	FeeVersion    int         // Fee policy version FeeRule belongs to
+	PayoutID      string      // Payout batch that settled this payment with the seller; empty until then
+	CreatedAt     time.Time   // Stored in UTC
+	// Amount, fees and earnings settle in money.Settlement. Original is
+	// Amount in the currency the buyer paid in, at ExchangeRate units of
+	// money.Settlement per unit of Original.Currency. Both are empty on
+	// payments made before they were recorded.
+	Original     money.Money
+	ExchangeRate float64
+	// Tax is the part of Amount collected for the tax authority, broken down
+	// by TaxLines. It is neither platform fee nor seller earning.
+	Tax      money.Money
+	TaxLines []tax.Line
+	// Refunded is how much of a sale has been refunded so far, pending
+	// refunds included. It stays empty on refunds themselves.
+	Refunded money.Money
+}
+
+// InOriginal converts a settlement amount of this payment, such as a part
+// being refunded, into the currency the buyer paid in at the same rate.
+func (p *Payment) InOriginal(amount money.Money) money.Money {
+	if p.ExchangeRate <= 0 || p.Original.Currency == "" {
+		return amount
+	}
+	return money.FromMajor(amount.Major()/p.ExchangeRate, p.Original.Currency)
 }
@@ -1,10 +1,41 @@
 package payment
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+var (
+	ErrAlreadySettled  = errors.New("payment has already been settled by another payout")
+	ErrPaymentNotFound = errors.New("payment not found")
+	ErrPaymentChanged  = errors.New("payment was refunded by another request")
+)
 
 type Repository interface {
 	RecordPayment(ctx context.Context, p *Payment) error
+	UpdatePaymentStatus(ctx context.Context, id, status string) error
 	GetPaymentsByUser(ctx context.Context, userID string) ([]*Payment, error)
 	GetPaymentsByType(ctx context.Context, userID string, pType PaymentType) ([]*Payment, error)
+	GetPaymentsByOrder(ctx context.Context, orderID string) ([]*Payment, error)
+	// GetPaymentByID returns nil if there is no such payment.
+	GetPaymentByID(ctx context.Context, id string) (*Payment, error)
+	// ReserveRefund adds amount to what has been refunded of a payment. It
+	// fails with ErrPaymentChanged unless the payment's refunded total is
+	// still refunded and the new total stays within its amount.
+	ReserveRefund(ctx context.Context, id string, refunded, amount money.Money) error
 	GetAllPlatformFees(ctx context.Context) (float64, float64, error)
+	// ListUnsettledPayments returns the payments and refunds no payout batch
+	// has settled yet.
+	ListUnsettledPayments(ctx context.Context) ([]*Payment, error)
+	// AssignPayout marks the payments as settled by a payout batch. It fails
+	// with ErrAlreadySettled if another batch got to any of them first.
+	AssignPayout(ctx context.Context, paymentIDs []string, payoutID string) error
+	// ReleasePayout makes the payments of a failed payout batch unsettled again.
+	ReleasePayout(ctx context.Context, payoutID string) error
+	// ListPaymentsBetween returns the payments and refunds made from from up
+	// to, but not including, to.
+	ListPaymentsBetween(ctx context.Context, from, to time.Time) ([]*Payment, error)
 }
@@ -0,0 +1,9 @@
+package payment
+
+import "context"
+
+// RefundSettler returns the money of a pending refund through the gateway
+// once the refund has been recorded.
+type RefundSettler interface {
+	SettleRefund(ctx context.Context, refundID string) error
+}
@@ -0,0 +1,12 @@
+package payout
+
+import "errors"
+
+var (
+	ErrInvalidMethod  = errors.New("invalid payout method")
+	ErrNoMethod       = errors.New("no payout method registered")
+	ErrPayoutNotFound = errors.New("payout not found")
+	// ErrPayoutRejected is returned by a provider that will never accept the
+	// payout as sent, e.g. because the account does not exist.
+	ErrPayoutRejected = errors.New("payout rejected by provider")
+)
@@ -0,0 +1,68 @@
+package payout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status is a step in a payout batch's lifecycle. Batches start pending and
+// end paid or failed.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusPaid    Status = "paid"
+	StatusFailed  Status = "failed"
+)
+
+// MethodType is how a seller wants to receive their money.
+type MethodType string
+
+const (
+	MethodBankTransfer MethodType = "bank_transfer"
+	MethodMobileMoney  MethodType = "mobile_money"
+)
+
+// Method is where a seller's payouts are sent. Each seller has at most one.
+type Method struct {
+	UserID        string     `bson:"_id" json:"user_id"`
+	Type          MethodType `bson:"type" json:"type"`
+	AccountName   string     `bson:"account_name" json:"account_name"`
+	AccountNumber string     `bson:"account_number" json:"account_number"`
+	// Institution is the bank or mobile money operator holding the account.
+	Institution string    `bson:"institution" json:"institution"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+func (m *Method) Validate() error {
+	if m.Type != MethodBankTransfer && m.Type != MethodMobileMoney {
+		return fmt.Errorf("%w: type must be %s or %s", ErrInvalidMethod, MethodBankTransfer, MethodMobileMoney)
+	}
+	required := []struct{ name, value string }{
+		{"account_name", m.AccountName},
+		{"account_number", m.AccountNumber},
+		{"institution", m.Institution},
+	}
+	for _, f := range required {
+		if strings.TrimSpace(f.value) == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidMethod, f.name)
+		}
+	}
+	return nil
+}
+
+// Payout is one batch of a seller's settled earnings sent through the payout
+// provider. PaymentIDs lists the sales and refunds it settles.
+type Payout struct {
+	ID            string     `bson:"_id" json:"id"`
+	SellerID      string     `bson:"seller_id" json:"seller_id"`
+	Amount        float64    `bson:"amount" json:"amount"`
+	Status        Status     `bson:"status" json:"status"`
+	PaymentIDs    []string   `bson:"payment_ids" json:"payment_ids"`
+	Method        Method     `bson:"method" json:"method"`
+	ProviderRef   string     `bson:"provider_ref,omitempty" json:"provider_ref,omitempty"`
+	FailureReason string     `bson:"failure_reason,omitempty" json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	SettledAt     *time.Time `bson:"settled_at,omitempty" json:"settled_at,omitempty"`
+}
@@ -0,0 +1,12 @@
+package payout
+
+import "context"
+
+// Provider sends money to sellers' accounts.
+type Provider interface {
+	// Send pays out p to p.Method and returns the provider's reference for
+	// the transfer. Sending the same payout again must not pay it twice.
+	// Errors other than ErrPayoutRejected are treated as temporary and the
+	// payout is tried again later.
+	Send(ctx context.Context, p *Payout) (string, error)
+}
@@ -0,0 +1,13 @@
+package payout
+
+import "context"
+
+type Repository interface {
+	SaveMethod(ctx context.Context, m *Method) error
+	GetMethod(ctx context.Context, userID string) (*Method, error)
+	CreatePayout(ctx context.Context, p *Payout) error
+	UpdatePayout(ctx context.Context, p *Payout) error
+	GetPayoutByID(ctx context.Context, id string) (*Payout, error)
+	// ListPayoutsBySeller returns the seller's payouts, newest first.
+	ListPayoutsBySeller(ctx context.Context, sellerID string) ([]*Payout, error)
+}
@@ -0,0 +1,15 @@
+package payout
+
+import "context"
+
+type Usecase interface {
+	SetPayoutMethod(ctx context.Context, userID string, m *Method) (*Method, error)
+	GetPayoutMethod(ctx context.Context, userID string) (*Method, error)
+	ListPayouts(ctx context.Context, sellerID string) ([]*Payout, error)
+	GetPayout(ctx context.Context, sellerID, id string) (*Payout, error)
+	// RunSettlement groups every seller's settled earnings into pending
+	// payouts and queues them to be sent.
+	RunSettlement(ctx context.Context) ([]*Payout, error)
+	// SendPayout pays a pending payout through the provider.
+	SendPayout(ctx context.Context, id string) error
+}
@@ -0,0 +1,9 @@
+package product
+
+import "errors"
+
+var ErrInvalidQuery = errors.New("invalid product search")
+
+// ErrNotAvailable is returned when a product is no longer listed for sale,
+// for example because another consumer bought it first.
+var ErrNotAvailable = errors.New("product not available")
@@ -1,6 +1,8 @@
 package product
 
 import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -14,11 +16,16 @@ type Product struct {
 	Size        string             `json:"size"`
 	Type        string             `json:"type"`
 	Grade       string             `json:"grade"`
-	Price       float64            `json:"price"`
+	Price       money.Money        `json:"price"` // in the currency the product was listed in
 	Status      string             `json:"status"`
 	ImageURL    string             `json:"image_url"`
 	CreatedAt   string             `json:"created_at"`
 	Rating      float64            `bson:"rating" json:"rating"`
+	// Reservation holds the product for the consumer whose offer was accepted.
+	Reservation *offer.Reservation `bson:"reservation,omitempty" json:"reservation,omitempty"`
+	// BuyerID is the consumer who bought the product once it is sold. It is
+	// kept from other users.
+	BuyerID string `bson:"buyer_id,omitempty" json:"-"`
 }
 
 func (p *Product) GenerateID() string {
@@ -1,13 +1,30 @@
 package product
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+)
 
 type Repository interface {
 	AddProduct(ctx context.Context, p *Product) error
 	GetProductByID(ctx context.Context, id string) (*Product, error)
 	ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*Product, error)
 	ListAvailableProducts(ctx context.Context, page, limit int) ([]*Product, error)
+	SearchProducts(ctx context.Context, q *Query) (*SearchResult, error)
 	DeleteProduct(ctx context.Context, id string) error
 	UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error
+	// MarkAsSold marks an available product sold to buyerID, or fails with
+	// ErrNotAvailable if it is no longer available.
+	MarkAsSold(ctx context.Context, id, buyerID string) error
+	// Relist puts a product back up for sale, clearing its buyer and any
+	// reservation.
+	Relist(ctx context.Context, id string) error
+	// Reserve holds an available product for r's buyer, or fails with
+	// offer.ErrReserved if another reservation still holds it at now.
+	Reserve(ctx context.Context, id string, r *offer.Reservation, now time.Time) error
+	// Unreserve releases the product if offerID still holds it.
+	Unreserve(ctx context.Context, id, offerID string) error
 	GetProductsByBundleID(ctx context.Context, bundleID string) ([]*Product, error)
 }
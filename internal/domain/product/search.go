@@ -0,0 +1,123 @@
+package product
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// SortOrder is how catalog search results are ordered.
+type SortOrder string
+
+const (
+	// SortRelevance ranks by how well a product matches the search text. It
+	// needs text; without it results are ordered newest first.
+	SortRelevance SortOrder = "relevance"
+	SortPriceAsc  SortOrder = "price_asc"
+	SortPriceDesc SortOrder = "price_desc"
+	SortNewest    SortOrder = "newest"
+)
+
+// Statuses a catalog search may ask for. Anything else is not for browsing.
+const (
+	StatusAvailable = "available"
+	StatusSold      = "sold"
+)
+
+const (
+	// DefaultPageSize is used for searches that do not ask for a page size.
+	DefaultPageSize = 20
+	// MaxPageSize bounds how many products one page may hold.
+	MaxPageSize = 100
+)
+
+// Query is a consumer's catalog search. Zero values leave a filter off. Price
+// bounds are in Currency; they and price ordering compare prices converted
+// to the settlement currency at Rates, so products listed in any currency
+// are considered.
+type Query struct {
+	// Text is matched against title, description, size, type and grade.
+	Text     string
+	Size     string
+	Type     string
+	Grade    string
+	MinPrice *float64
+	MaxPrice *float64
+	Currency money.Currency
+	Rates    money.Rates
+	Status   string
+	Sort     SortOrder
+	Page     int
+	Limit    int
+}
+
+// FacetCount is how many matching products share one value of a field.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Facets count the matching products per size, type and grade. Each count
+// applies every filter except the one on its own field, so a client can show
+// what picking another value would give.
+type Facets struct {
+	Sizes  []FacetCount `json:"sizes"`
+	Types  []FacetCount `json:"types"`
+	Grades []FacetCount `json:"grades"`
+}
+
+// SearchResult is one page of a catalog search.
+type SearchResult struct {
+	Products []*Product `json:"products"`
+	Total    int        `json:"total"`
+	Page     int        `json:"page"`
+	Limit    int        `json:"limit"`
+	Facets   Facets     `json:"facets"`
+}
+
+// Priced reports whether the search compares prices.
+func (q *Query) Priced() bool {
+	return q.MinPrice != nil || q.MaxPrice != nil || q.Sort == SortPriceAsc || q.Sort == SortPriceDesc
+}
+
+// Normalize fills in defaults and checks the query. Only available products
+// are searched unless sold ones are asked for.
+func (q *Query) Normalize() error {
+	q.Text = strings.TrimSpace(q.Text)
+	q.Currency = money.OrSettlement(q.Currency)
+	if q.Status == "" {
+		q.Status = StatusAvailable
+	}
+	if q.Status != StatusAvailable && q.Status != StatusSold {
+		return fmt.Errorf("%w: status must be available or sold", ErrInvalidQuery)
+	}
+	if q.Sort == "" {
+		q.Sort = SortRelevance
+	}
+	switch q.Sort {
+	case SortRelevance:
+		if q.Text == "" {
+			q.Sort = SortNewest
+		}
+	case SortPriceAsc, SortPriceDesc, SortNewest:
+	default:
+		return fmt.Errorf("%w: sort must be relevance, price_asc, price_desc or newest", ErrInvalidQuery)
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil && *q.MinPrice > *q.MaxPrice {
+		return fmt.Errorf("%w: min_price is above max_price", ErrInvalidQuery)
+	}
+	if q.Page < 0 {
+		return fmt.Errorf("%w: page must be 1 or more", ErrInvalidQuery)
+	}
+	if q.Page == 0 {
+		q.Page = 1
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxPageSize)
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ type Usecase interface {
 	GetProductByID(ctx context.Context, id string) (*Product, error)
 	ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*Product, error)
 	ListAvailableProducts(ctx context.Context, page, limit int) ([]*Product, error)
+	SearchProducts(ctx context.Context, q Query) (*SearchResult, error)
 	DeleteProduct(ctx context.Context, id string) error
 	UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error
 }
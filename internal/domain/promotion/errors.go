@@ -0,0 +1,14 @@
+package promotion
+
+import "errors"
+
+var (
+	ErrInvalidPromotion  = errors.New("invalid promotion")
+	ErrPromotionExists   = errors.New("a promotion with this code already exists")
+	ErrPromotionNotFound = errors.New("promo code not found")
+	ErrPromotionInactive = errors.New("promo code is not active")
+	ErrNotApplicable     = errors.New("promo code does not apply to any item being bought")
+	ErrBasketTooSmall    = errors.New("basket total is below the promo code minimum")
+	ErrUsageLimitReached = errors.New("promo code has been used the maximum number of times")
+	ErrUserLimitReached  = errors.New("you have already used this promo code the maximum number of times")
+)
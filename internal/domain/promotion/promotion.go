@@ -0,0 +1,153 @@
+package promotion
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// Type is how a promotion takes money off.
+type Type string
+
+const (
+	TypePercentage Type = "percentage"
+	TypeFixed      Type = "fixed"
+)
+
+// Promotion is a code consumers enter at checkout for a discount. Zero
+// limits, an empty window edge and empty scopes mean no restriction.
+type Promotion struct {
+	ID    string  `bson:"_id" json:"id"`
+	Code  string  `bson:"code" json:"code"`
+	Type  Type    `bson:"type" json:"type"`
+	Value float64 `bson:"value" json:"value"` // fraction off for percentage codes, e.g. 0.1 for 10%; amount off for fixed codes
+	// StartsAt and EndsAt bound when the code can be used.
+	StartsAt *time.Time `bson:"starts_at,omitempty" json:"starts_at,omitempty"`
+	EndsAt   *time.Time `bson:"ends_at,omitempty" json:"ends_at,omitempty"`
+	// MinBasketTotal is the least the discounted items must add up to.
+	MinBasketTotal float64 `bson:"min_basket_total" json:"min_basket_total"`
+	MaxUses        int     `bson:"max_uses" json:"max_uses"`
+	MaxUsesPerUser int     `bson:"max_uses_per_user" json:"max_uses_per_user"`
+	// ResellerID and ClothingType limit the discount to matching items.
+	ResellerID   string    `bson:"reseller_id,omitempty" json:"reseller_id,omitempty"`
+	ClothingType string    `bson:"clothing_type,omitempty" json:"clothing_type,omitempty"`
+	Active       bool      `bson:"active" json:"active"`
+	UsedCount    int       `bson:"used_count" json:"used_count"`
+	CreatedBy    string    `bson:"created_by" json:"created_by"`
+	CreatedAt    time.Time `bson:"created_at" json:"created_at"`
+}
+
+// NormalizeCode makes codes case-insensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the code, its value and that its window and limits make sense.
+func (p *Promotion) Validate() error {
+	p.Code = NormalizeCode(p.Code)
+	if p.Code == "" || len(p.Code) > 32 || strings.ContainsAny(p.Code, " \t") {
+		return fmt.Errorf("%w: code must be 1 to 32 characters without spaces", ErrInvalidPromotion)
+	}
+	switch p.Type {
+	case TypePercentage:
+		if p.Value <= 0 || p.Value > 1 {
+			return fmt.Errorf("%w: percentage value must be above 0 and at most 1", ErrInvalidPromotion)
+		}
+	case TypeFixed:
+		if p.Value <= 0 {
+			return fmt.Errorf("%w: fixed value must be positive", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: type must be percentage or fixed", ErrInvalidPromotion)
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPromotion)
+	}
+	if p.MinBasketTotal < 0 || p.MaxUses < 0 || p.MaxUsesPerUser < 0 {
+		return fmt.Errorf("%w: limits cannot be negative", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+// Line is one item of a basket a promotion is applied to.
+type Line struct {
+	ListingID    string
+	SellerID     string
+	ClothingType string
+	Amount       float64
+}
+
+// Discount is what a promotion took off a basket.
+type Discount struct {
+	PromotionID string
+	Code        string
+	Total       float64
+	// ByListing is the part of Total taken off each discounted item.
+	ByListing map[string]float64
+}
+
+// For returns the discount on one item.
+func (d *Discount) For(listingID string) float64 {
+	if d == nil {
+		return 0
+	}
+	return d.ByListing[listingID]
+}
+
+// covers reports whether the promotion's scope includes the line.
+func (p *Promotion) covers(l Line) bool {
+	return (p.ResellerID == "" || p.ResellerID == l.SellerID) &&
+		(p.ClothingType == "" || strings.EqualFold(p.ClothingType, l.ClothingType))
+}
+
+// Apply works out the discount on a basket at now without recording a use.
+// A fixed discount is spread over the items it covers in proportion to
+// their price and never exceeds what they cost. The total and every item's
+// share are whole cents; rounding the shares leaves the last item with
+// whatever is left of the total.
+func (p *Promotion) Apply(lines []Line, now time.Time) (*Discount, error) {
+	if !p.Active || (p.StartsAt != nil && now.Before(*p.StartsAt)) || (p.EndsAt != nil && !now.Before(*p.EndsAt)) {
+		return nil, ErrPromotionInactive
+	}
+
+	var covered []Line
+	var eligible float64
+	for _, l := range lines {
+		if p.covers(l) {
+			covered = append(covered, l)
+			eligible += l.Amount
+		}
+	}
+	if len(covered) == 0 || eligible <= 0 {
+		return nil, ErrNotApplicable
+	}
+	if eligible < p.MinBasketTotal {
+		return nil, fmt.Errorf("%w: the discounted items must add up to at least %.2f", ErrBasketTooSmall, p.MinBasketTotal)
+	}
+
+	offs := make([]float64, len(covered))
+	var exact float64
+	for i, l := range covered {
+		offs[i] = l.Amount * p.Value
+		if p.Type == TypeFixed {
+			offs[i] = math.Min(p.Value, eligible) * l.Amount / eligible
+		}
+		exact += offs[i]
+	}
+
+	total := money.InSettlement(exact)
+	left := total
+	d := &Discount{PromotionID: p.ID, Code: p.Code, Total: total.Major(), ByListing: make(map[string]float64, len(covered))}
+	for i, l := range covered {
+		off := money.InSettlement(offs[i])
+		if i == len(covered)-1 {
+			off = left
+		}
+		d.ByListing[l.ListingID] = off.Major()
+		left = left.Sub(off)
+	}
+	return d, nil
+}
@@ -0,0 +1,18 @@
+package promotion
+
+import "context"
+
+type Repository interface {
+	// CreatePromotion returns ErrPromotionExists if the code is taken.
+	CreatePromotion(ctx context.Context, p *Promotion) error
+	GetPromotionByCode(ctx context.Context, code string) (*Promotion, error)
+	// ListPromotions returns every promotion, newest first.
+	ListPromotions(ctx context.Context) ([]*Promotion, error)
+	SetActive(ctx context.Context, code string, active bool) error
+	// Redeem records one use of p by the user. It returns ErrUsageLimitReached
+	// or ErrUserLimitReached, and records nothing, if that would exceed
+	// p.MaxUses or p.MaxUsesPerUser.
+	Redeem(ctx context.Context, p *Promotion, userID string) error
+	// Unredeem gives back a use recorded by Redeem.
+	Unredeem(ctx context.Context, promotionID, userID string) error
+}
@@ -0,0 +1,18 @@
+package promotion
+
+import "context"
+
+// Discounter applies promo codes at checkout.
+type Discounter interface {
+	// Redeem applies the code to the basket and records a use by the user.
+	Redeem(ctx context.Context, code, userID string, lines []Line) (*Discount, error)
+	// Release gives back the use recorded for a checkout that did not go through.
+	Release(ctx context.Context, d *Discount, userID string) error
+}
+
+type Usecase interface {
+	Discounter
+	CreatePromotion(ctx context.Context, adminID string, p *Promotion) (*Promotion, error)
+	ListPromotions(ctx context.Context) ([]*Promotion, error)
+	SetActive(ctx context.Context, code string, active bool) (*Promotion, error)
+}
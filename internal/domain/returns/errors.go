@@ -0,0 +1,20 @@
+package returns
+
+import "errors"
+
+var (
+	ErrReturnNotFound     = errors.New("return not found")
+	ErrInvalidReason      = errors.New("reason must be wrong_size, not_as_described, damaged or changed_mind")
+	ErrInvalidPhoto       = errors.New("invalid photo")
+	ErrNotInOrder         = errors.New("product is not part of the order")
+	ErrNotReturnable      = errors.New("order cannot be returned until it is delivered")
+	ErrWindowClosed       = errors.New("return window for this order has closed")
+	ErrReturnsNotAccepted = errors.New("seller does not accept returns")
+	ErrReturnExists       = errors.New("product already has a return")
+	ErrInvalidTransition  = errors.New("return cannot move to the requested status")
+	ErrResponseOverdue    = errors.New("return was not answered in time and can only be approved")
+	ErrReasonRequired     = errors.New("a reason is required to reject a return")
+	ErrInvalidCondition   = errors.New("condition must be resellable or damaged")
+	ErrReturnChanged      = errors.New("return was updated by another request")
+	ErrInvalidPolicy      = errors.New("invalid return policy")
+)
@@ -0,0 +1,40 @@
+package returns
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultWindowDays applies to shops that have not set a return policy.
+	DefaultWindowDays = 14
+	// MaxWindowDays is the longest return window a shop may offer.
+	MaxWindowDays = 90
+)
+
+// Policy is a reseller's return policy. Consumers can request a return for
+// WindowDays after their order is delivered; a window of zero means the shop
+// does not take returns.
+type Policy struct {
+	ResellerID string    `bson:"_id" json:"reseller_id"`
+	WindowDays int       `bson:"window_days" json:"window_days"`
+	UpdatedAt  time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// DefaultPolicy is the policy of a shop that has not set its own.
+func DefaultPolicy(resellerID string) *Policy {
+	return &Policy{ResellerID: resellerID, WindowDays: DefaultWindowDays}
+}
+
+func (p *Policy) Validate() error {
+	if p.WindowDays < 0 || p.WindowDays > MaxWindowDays {
+		return fmt.Errorf("%w: window must be between 0 and %d days", ErrInvalidPolicy, MaxWindowDays)
+	}
+	return nil
+}
+
+// Closes returns when the return window ends for an order delivered at
+// deliveredAt.
+func (p *Policy) Closes(deliveredAt time.Time) time.Time {
+	return deliveredAt.AddDate(0, 0, p.WindowDays)
+}
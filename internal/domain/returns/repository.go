@@ -0,0 +1,24 @@
+package returns
+
+import "context"
+
+type Repository interface {
+	// CreateReturn fails with ErrReturnExists if the order line already has
+	// a return that was not rejected.
+	CreateReturn(ctx context.Context, r *Return) error
+	GetReturnByID(ctx context.Context, id string) (*Return, error)
+	// GetReturnByProduct returns the order line's return unless it was
+	// rejected. A line is returned at most once but may be requested again
+	// after a rejection.
+	GetReturnByProduct(ctx context.Context, orderID, productID string) (*Return, error)
+	// ListReturnsByUser lists the returns the user is consumer or reseller
+	// in, newest first.
+	ListReturnsByUser(ctx context.Context, userID string) ([]*Return, error)
+	// UpdateReturn stores r only if the return is still in status from and
+	// returns ErrReturnChanged otherwise.
+	UpdateReturn(ctx context.Context, r *Return, from Status) error
+	// GetPolicy returns the reseller's return policy, or nil if they have
+	// not set one.
+	GetPolicy(ctx context.Context, resellerID string) (*Policy, error)
+	SavePolicy(ctx context.Context, p *Policy) error
+}
@@ -0,0 +1,171 @@
+package returns
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+// Status is a step in a return's lifecycle. A consumer requests a return,
+// the reseller approves or rejects it, the consumer ships the item back and
+// the reseller confirms receipt, which refunds the item.
+type Status string
+
+const (
+	StatusRequested   Status = "requested"
+	StatusApproved    Status = "approved"
+	StatusRejected    Status = "rejected"
+	StatusShippedBack Status = "shipped_back"
+	StatusReceived    Status = "received"
+)
+
+// transitions lists the statuses a return may move to from each status.
+// Rejected and received returns are final. A reseller may confirm receipt of
+// an approved return the consumer did not mark as shipped.
+var transitions = map[Status][]Status{
+	StatusRequested:   {StatusApproved, StatusRejected},
+	StatusApproved:    {StatusShippedBack, StatusReceived},
+	StatusShippedBack: {StatusReceived},
+}
+
+// CanTransition reports whether a return may move from one status to another.
+func CanTransition(from, to Status) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsClosed reports whether nothing more will happen to the return.
+func (s Status) IsClosed() bool {
+	return s == StatusRejected || s == StatusReceived
+}
+
+// Reason is why the consumer is sending the item back.
+type Reason string
+
+const (
+	ReasonWrongSize      Reason = "wrong_size"
+	ReasonNotAsDescribed Reason = "not_as_described"
+	ReasonDamaged        Reason = "damaged"
+	ReasonChangedMind    Reason = "changed_mind"
+)
+
+// IsValid reports whether r is a known reason.
+func (r Reason) IsValid() bool {
+	switch r {
+	case ReasonWrongSize, ReasonNotAsDescribed, ReasonDamaged, ReasonChangedMind:
+		return true
+	}
+	return false
+}
+
+// Condition is the state the reseller found the returned item in.
+type Condition string
+
+const (
+	// ConditionResellable puts the product back on sale.
+	ConditionResellable Condition = "resellable"
+	// ConditionDamaged takes the product off sale for good.
+	ConditionDamaged Condition = "damaged"
+)
+
+// IsValid reports whether c is a known condition.
+func (c Condition) IsValid() bool {
+	return c == ConditionResellable || c == ConditionDamaged
+}
+
+// ProductStatus is the status the returned product is given.
+func (c Condition) ProductStatus() string {
+	if c == ConditionDamaged {
+		return "damaged"
+	}
+	return "available"
+}
+
+// ResponseWindow is how long a reseller has to approve or reject a return.
+// Returns left unanswered are approved when it ends.
+const ResponseWindow = 72 * time.Hour
+
+// maxPhotos bounds the photos attached to a return request.
+const maxPhotos = 10
+
+// Return is a consumer's request to send back one product of a delivered
+// order for a refund.
+type Return struct {
+	ID         string  `bson:"_id" json:"id"`
+	OrderID    string  `bson:"order_id" json:"order_id"`
+	ProductID  string  `bson:"product_id" json:"product_id"`
+	ConsumerID string  `bson:"consumer_id" json:"consumer_id"`
+	ResellerID string  `bson:"reseller_id" json:"reseller_id"`
+	Reason     Reason  `bson:"reason" json:"reason"`
+	Note       string  `bson:"note,omitempty" json:"note,omitempty"`
+	Photos     []Photo `bson:"photos,omitempty" json:"photos,omitempty"`
+	Status     Status  `bson:"status" json:"status"`
+	// Amount is what the consumer paid for the product and gets back once
+	// the reseller has it.
+	Amount float64 `bson:"amount" json:"amount"`
+	// RespondBy is when the return is approved if the reseller has not
+	// answered.
+	RespondBy      time.Time    `bson:"respond_by" json:"respond_by"`
+	TrackingNumber string       `bson:"tracking_number,omitempty" json:"tracking_number,omitempty"`
+	Condition      Condition    `bson:"condition,omitempty" json:"condition,omitempty"`
+	RefundID       string       `bson:"refund_id,omitempty" json:"refund_id,omitempty"`
+	History        []Transition `bson:"history" json:"history"`
+	CreatedAt      time.Time    `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time    `bson:"updated_at" json:"updated_at"`
+}
+
+// Photo links to a picture of the item hosted elsewhere.
+type Photo struct {
+	URL string `bson:"url" json:"url"`
+}
+
+// Transition is one entry in a return's status history.
+type Transition struct {
+	From    Status    `bson:"from,omitempty" json:"from,omitempty"`
+	To      Status    `bson:"to" json:"to"`
+	ActorID string    `bson:"actor_id" json:"actor_id"`
+	Note    string    `bson:"note,omitempty" json:"note,omitempty"`
+	At      time.Time `bson:"at" json:"at"`
+}
+
+// ValidatePhotos checks that there are not too many photos and that each is
+// a web link.
+func ValidatePhotos(photos []Photo) error {
+	if len(photos) > maxPhotos {
+		return fmt.Errorf("%w: at most %d photos per return", ErrInvalidPhoto, maxPhotos)
+	}
+	for _, p := range photos {
+		u, err := url.Parse(p.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %q is not a web link", ErrInvalidPhoto, p.URL)
+		}
+	}
+	return nil
+}
+
+// IsParty reports whether the actor may see the return: its consumer, its
+// reseller or staff.
+func (r *Return) IsParty(actorID string, role user.Role) bool {
+	if role == user.RoleAdmin {
+		return true
+	}
+	return actorID == r.ConsumerID || actorID == r.ResellerID
+}
+
+// Move checks that the return may move to the given status and records the
+// move on it.
+func (r *Return) Move(to Status, actorID, note string, at time.Time) error {
+	if !CanTransition(r.Status, to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, r.Status, to)
+	}
+	r.History = append(r.History, Transition{From: r.Status, To: to, ActorID: actorID, Note: note, At: at})
+	r.Status = to
+	r.UpdatedAt = at
+	return nil
+}
@@ -0,0 +1,49 @@
+package returns
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+// Orders is the part of the order usecase returns act through: reading the
+// order and refunding the returned item.
+type Orders interface {
+	GetOrderByID(ctx context.Context, orderID string) (*order.Order, error)
+	RecordRefund(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error)
+	payment.RefundSettler
+}
+
+// Request is what a consumer fills in to return an item.
+type Request struct {
+	OrderID   string  `json:"order_id"`
+	ProductID string  `json:"product_id"`
+	Reason    Reason  `json:"reason"`
+	Note      string  `json:"note"`
+	Photos    []Photo `json:"photos"`
+}
+
+type Usecase interface {
+	// RequestReturn opens a return for one product of the consumer's
+	// delivered order, within the reseller's return window.
+	RequestReturn(ctx context.Context, consumerID string, req Request) (*Return, error)
+	GetReturn(ctx context.Context, id, actorID string, role user.Role) (*Return, error)
+	ListMyReturns(ctx context.Context, userID string) ([]*Return, error)
+	// Decide approves or rejects a requested return. Rejecting needs a
+	// reason and is only possible within the response window.
+	Decide(ctx context.Context, id, resellerID string, approve bool, note string) (*Return, error)
+	// MarkShipped records that the consumer has sent the item back.
+	MarkShipped(ctx context.Context, id, consumerID, trackingNumber string) (*Return, error)
+	// ConfirmReceipt closes the return once the reseller has the item,
+	// refunding it and putting the product back on sale or marking it
+	// damaged.
+	ConfirmReceipt(ctx context.Context, id, resellerID string, condition Condition) (*Return, error)
+	// ApproveOverdue approves a return the reseller did not answer within
+	// the response window. Other returns are left alone.
+	ApproveOverdue(ctx context.Context, id string) error
+	// GetPolicy returns the reseller's return policy, or the default one.
+	GetPolicy(ctx context.Context, resellerID string) (*Policy, error)
+	SetPolicy(ctx context.Context, resellerID string, windowDays int) (*Policy, error)
+}
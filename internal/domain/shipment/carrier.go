@@ -0,0 +1,15 @@
+package shipment
+
+import (
+	"context"
+	"time"
+)
+
+// Carrier is a delivery company whose parcels can be tracked.
+type Carrier interface {
+	Name() string
+	EstimateDelivery(shippedAt time.Time) time.Time
+	// Track returns every event the carrier has recorded for the parcel so
+	// far, oldest first.
+	Track(ctx context.Context, trackingNumber string, shippedAt time.Time) ([]TrackingEvent, error)
+}
@@ -0,0 +1,10 @@
+package shipment
+
+import "errors"
+
+var (
+	ErrCarrierRequired        = errors.New("carrier is required")
+	ErrTrackingNumberRequired = errors.New("tracking number is required")
+	ErrShipmentNotFound       = errors.New("order has not been shipped")
+	ErrNotConsumerOrder       = errors.New("only consumer orders are shipped by resellers")
+)
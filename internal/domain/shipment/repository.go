@@ -0,0 +1,9 @@
+package shipment
+
+import "context"
+
+type Repository interface {
+	CreateShipment(ctx context.Context, s *Shipment) error
+	GetShipmentByOrder(ctx context.Context, orderID string) (*Shipment, error)
+	UpdateTracking(ctx context.Context, id string, status Status, events []TrackingEvent) error
+}
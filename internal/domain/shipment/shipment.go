@@ -0,0 +1,34 @@
+package shipment
+
+import "time"
+
+type Status string
+
+const (
+	StatusInTransit      Status = "in_transit"
+	StatusOutForDelivery Status = "out_for_delivery"
+	StatusDelivered      Status = "delivered"
+)
+
+// TrackingEvent is one step of a parcel's journey as reported by the carrier.
+type TrackingEvent struct {
+	Status      Status    `bson:"status" json:"status"`
+	Description string    `bson:"description" json:"description"`
+	Location    string    `bson:"location,omitempty" json:"location,omitempty"`
+	At          time.Time `bson:"at" json:"at"`
+}
+
+// Shipment tracks the parcel a reseller sent for a consumer order. Only
+// parcels sent with a carrier that can be tracked have an EstimatedDelivery.
+type Shipment struct {
+	ID                string          `bson:"_id" json:"id"`
+	OrderID           string          `bson:"order_id" json:"order_id"`
+	ResellerID        string          `bson:"reseller_id" json:"reseller_id"`
+	ConsumerID        string          `bson:"consumer_id" json:"consumer_id"`
+	Carrier           string          `bson:"carrier" json:"carrier"`
+	TrackingNumber    string          `bson:"tracking_number" json:"tracking_number"`
+	Status            Status          `bson:"status" json:"status"`
+	Events            []TrackingEvent `bson:"events" json:"events"`
+	ShippedAt         time.Time       `bson:"shipped_at" json:"shipped_at"`
+	EstimatedDelivery *time.Time      `bson:"estimated_delivery,omitempty" json:"estimated_delivery,omitempty"`
+}
@@ -0,0 +1,16 @@
+package shipment
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+type Usecase interface {
+	ShipOrder(ctx context.Context, orderID, resellerID, carrier, trackingNumber string) (*Shipment, error)
+	GetTracking(ctx context.Context, orderID, actorID string, role user.Role) (*Shipment, error)
+	ConfirmDelivery(ctx context.Context, orderID, actorID string, role user.Role) (*Shipment, error)
+	// SyncTracking pulls new events from the carrier and delivers the order
+	// once the carrier reports it delivered.
+	SyncTracking(ctx context.Context, orderID string) error
+}
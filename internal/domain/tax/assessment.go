@@ -0,0 +1,95 @@
+package tax
+
+import "context"
+
+// Sale is what tax is assessed on. Amount is the list price after any
+// discount; whether it includes tax depends on the policy's Mode.
+type Sale struct {
+	Type               string // SaleB2B or SaleB2C
+	SellerID           string
+	BuyerID            string
+	SellerJurisdiction string // looked up from SellerID if empty
+	BuyerJurisdiction  string // the ship-to country; looked up from BuyerID if empty
+	Amount             float64
+}
+
+// Line is the tax one rule charged on part of a sale. Orders, payments and
+// invoices carry their tax as lines so reports can split it by rule and
+// jurisdiction.
+type Line struct {
+	Rule               string  `bson:"rule" json:"rule"`
+	SaleType           string  `bson:"sale_type" json:"sale_type"`
+	SellerJurisdiction string  `bson:"seller_jurisdiction,omitempty" json:"seller_jurisdiction,omitempty"`
+	BuyerJurisdiction  string  `bson:"buyer_jurisdiction,omitempty" json:"buyer_jurisdiction,omitempty"`
+	Rate               float64 `bson:"rate" json:"rate"`
+	Inclusive          bool    `bson:"inclusive" json:"inclusive"` // the list price already included the tax
+	Taxable            float64 `bson:"taxable" json:"taxable"`
+	Amount             float64 `bson:"amount" json:"amount"`
+	PolicyVersion      int     `bson:"policy_version" json:"policy_version"`
+}
+
+// sameRule reports whether two lines can be added together.
+func (l Line) sameRule(o Line) bool {
+	return l.Rule == o.Rule && l.SaleType == o.SaleType && l.Rate == o.Rate && l.Inclusive == o.Inclusive &&
+		l.SellerJurisdiction == o.SellerJurisdiction && l.BuyerJurisdiction == o.BuyerJurisdiction &&
+		l.PolicyVersion == o.PolicyVersion
+}
+
+// Assessment is the tax on a sale. Net is what the seller sold for before
+// tax and Gross what the buyer pays; the platform fee is charged on Net.
+type Assessment struct {
+	Net   float64
+	Tax   float64
+	Gross float64
+	Lines []Line
+}
+
+// Assessor taxes sales with the current tax policy.
+type Assessor interface {
+	Assess(ctx context.Context, s Sale) (Assessment, error)
+}
+
+// AddLines merges more into lines, adding up lines of the same rule.
+func AddLines(lines []Line, more ...Line) []Line {
+	for _, m := range more {
+		merged := false
+		for i := range lines {
+			if lines[i].sameRule(m) {
+				lines[i].Taxable += m.Taxable
+				lines[i].Amount += m.Amount
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			lines = append(lines, m)
+		}
+	}
+	return lines
+}
+
+// ScaleLines returns a copy of lines with their amounts multiplied by share,
+// e.g. a negative share of a refunded payment.
+func ScaleLines(lines []Line, share float64) []Line {
+	if len(lines) == 0 {
+		return nil
+	}
+	scaled := make([]Line, len(lines))
+	for i, l := range lines {
+		l.Taxable *= share
+		l.Amount *= share
+		scaled[i] = l
+	}
+	return scaled
+}
+
+// ExclusiveTotal is the tax in lines that was added on top of list prices.
+func ExclusiveTotal(lines []Line) float64 {
+	var t float64
+	for _, l := range lines {
+		if !l.Inclusive {
+			t += l.Amount
+		}
+	}
+	return t
+}
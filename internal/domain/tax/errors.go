@@ -0,0 +1,9 @@
+package tax
+
+import "errors"
+
+var (
+	ErrInvalidPolicy  = errors.New("invalid tax policy")
+	ErrPolicyConflict = errors.New("tax policy was changed by someone else; reload and try again")
+	ErrInvalidPeriod  = errors.New("invalid report period")
+)
@@ -0,0 +1,170 @@
+package tax
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// Sale types a rule can be limited to. They are the payment types of the
+// sales taxed: suppliers selling bundles to resellers, and resellers
+// selling products to consumers.
+const (
+	SaleB2B = "b2b"
+	SaleB2C = "b2c"
+)
+
+// Mode says whether list prices already include tax.
+type Mode string
+
+const (
+	// ModeExclusive adds tax on top of the list price.
+	ModeExclusive Mode = "exclusive"
+	// ModeInclusive takes tax out of the list price, so buyers pay the
+	// price as listed.
+	ModeInclusive Mode = "inclusive"
+)
+
+// Rule taxes sales matching all of its non-empty criteria at Rate.
+// Jurisdictions are ISO country codes such as "ET".
+type Rule struct {
+	Name               string  `bson:"name" json:"name"`
+	SaleType           string  `bson:"sale_type,omitempty" json:"sale_type,omitempty"`
+	SellerJurisdiction string  `bson:"seller_jurisdiction,omitempty" json:"seller_jurisdiction,omitempty"`
+	BuyerJurisdiction  string  `bson:"buyer_jurisdiction,omitempty" json:"buyer_jurisdiction,omitempty"`
+	Rate               float64 `bson:"rate" json:"rate"` // fraction of the taxable amount, e.g. 0.15 for 15%
+}
+
+func (r Rule) matches(s Sale) bool {
+	return (r.SaleType == "" || r.SaleType == s.Type) &&
+		(r.SellerJurisdiction == "" || strings.EqualFold(r.SellerJurisdiction, s.SellerJurisdiction)) &&
+		(r.BuyerJurisdiction == "" || strings.EqualFold(r.BuyerJurisdiction, s.BuyerJurisdiction))
+}
+
+// specificity counts the criteria a rule sets; the most specific matching
+// rule wins.
+func (r Rule) specificity() int {
+	n := 0
+	for _, set := range []bool{r.SaleType != "", r.SellerJurisdiction != "", r.BuyerJurisdiction != ""} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// Policy is one version of the tax table. Like the fee policy, every change
+// is stored as a new version so that past payments can be explained.
+type Policy struct {
+	Version   int       `bson:"_id" json:"version"`
+	Mode      Mode      `bson:"mode" json:"mode"`
+	Rules     []Rule    `bson:"rules" json:"rules"`
+	Note      string    `bson:"note" json:"note"`
+	UpdatedBy string    `bson:"updated_by" json:"updated_by"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// DefaultPolicy taxes nothing; it applies until an admin saves a policy.
+func DefaultPolicy() *Policy {
+	return &Policy{Mode: ModeExclusive, Rules: []Rule{}}
+}
+
+// Validate checks the mode and that every rule is uniquely named and has a
+// rate below 100%. A rule without criteria taxes every sale.
+func (p *Policy) Validate() error {
+	if p.Mode != ModeExclusive && p.Mode != ModeInclusive {
+		return fmt.Errorf("%w: mode must be %q or %q", ErrInvalidPolicy, ModeExclusive, ModeInclusive)
+	}
+
+	names := make(map[string]bool)
+	for _, r := range p.Rules {
+		if r.Name == "" || names[r.Name] {
+			return fmt.Errorf("%w: rule names must be unique", ErrInvalidPolicy)
+		}
+		names[r.Name] = true
+		if r.SaleType != "" && r.SaleType != SaleB2B && r.SaleType != SaleB2C {
+			return fmt.Errorf("%w: rule %q has unknown sale type %q", ErrInvalidPolicy, r.Name, r.SaleType)
+		}
+		if r.Rate < 0 || r.Rate >= 1 {
+			return fmt.Errorf("%w: rule %q rate must be at least 0 and below 1", ErrInvalidPolicy, r.Name)
+		}
+	}
+	return nil
+}
+
+// Normalize upper-cases the rules' jurisdiction codes.
+func (p *Policy) Normalize() {
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		r.SellerJurisdiction = strings.ToUpper(strings.TrimSpace(r.SellerJurisdiction))
+		r.BuyerJurisdiction = strings.ToUpper(strings.TrimSpace(r.BuyerJurisdiction))
+	}
+}
+
+// NeedsSeller and NeedsBuyer report whether any rule depends on the
+// seller's or the buyer's jurisdiction.
+func (p *Policy) NeedsSeller() bool {
+	for _, r := range p.Rules {
+		if r.SellerJurisdiction != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) NeedsBuyer() bool {
+	for _, r := range p.Rules {
+		if r.BuyerJurisdiction != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Assess taxes a sale under the most specific matching rule. Earlier rules
+// win ties. A sale no rule matches is not taxed. The tax is rounded once, to
+// the settlement currency's minor unit, and the line's taxable amount and
+// the sale's net and gross are worked out from it, so they always add up.
+func (p *Policy) Assess(s Sale) Assessment {
+	amount := money.InSettlement(s.Amount)
+	a := Assessment{Net: amount.Major(), Gross: amount.Major()}
+
+	var rule *Rule
+	best := -1
+	for i, r := range p.Rules {
+		if r.matches(s) && r.specificity() > best {
+			best = r.specificity()
+			rule = &p.Rules[i]
+		}
+	}
+	if rule == nil || rule.Rate == 0 {
+		return a
+	}
+
+	l := Line{
+		Rule:               rule.Name,
+		SaleType:           s.Type,
+		SellerJurisdiction: s.SellerJurisdiction,
+		BuyerJurisdiction:  s.BuyerJurisdiction,
+		Rate:               rule.Rate,
+		Inclusive:          p.Mode == ModeInclusive,
+		PolicyVersion:      p.Version,
+	}
+	var tax, taxable money.Money
+	if l.Inclusive {
+		tax = money.InSettlement(amount.Major() - amount.Major()/(1+rule.Rate))
+		taxable = amount.Sub(tax)
+		a.Net = taxable.Major()
+	} else {
+		tax = amount.Times(rule.Rate)
+		taxable = amount
+		a.Gross = amount.Add(tax).Major()
+	}
+	l.Taxable = taxable.Major()
+	l.Amount = tax.Major()
+	a.Tax = l.Amount
+	a.Lines = []Line{l}
+	return a
+}
@@ -0,0 +1,94 @@
+package tax
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period is how a tax report groups payments in time.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodMonth Period = "month"
+	PeriodYear  Period = "year"
+)
+
+// ParsePeriod reads a report period, a month by default.
+func ParsePeriod(s string) (Period, error) {
+	switch p := Period(s); p {
+	case "":
+		return PeriodMonth, nil
+	case PeriodDay, PeriodMonth, PeriodYear:
+		return p, nil
+	default:
+		return "", fmt.Errorf("%w: %q; use day, month or year", ErrInvalidPeriod, s)
+	}
+}
+
+// Label names the period t falls in, in UTC, e.g. "2025-06" for a month.
+func (p Period) Label(t time.Time) string {
+	t = t.UTC()
+	switch p {
+	case PeriodDay:
+		return t.Format("2006-01-02")
+	case PeriodYear:
+		return t.Format("2006")
+	default:
+		return t.Format("2006-01")
+	}
+}
+
+// ReportRow is the tax one rule charged in one jurisdiction pair over a
+// period. Refunds are netted off.
+type ReportRow struct {
+	Period             string  `json:"period"`
+	Rule               string  `json:"rule"`
+	SaleType           string  `json:"sale_type"`
+	SellerJurisdiction string  `json:"seller_jurisdiction,omitempty"`
+	BuyerJurisdiction  string  `json:"buyer_jurisdiction,omitempty"`
+	Rate               float64 `json:"rate"`
+	Taxable            float64 `json:"taxable"`
+	Tax                float64 `json:"tax"`
+}
+
+// Report is the tax collected on payments made from From up to To.
+type Report struct {
+	From         time.Time   `json:"from"`
+	To           time.Time   `json:"to"`
+	Period       Period      `json:"period"`
+	Rows         []ReportRow `json:"rows"`
+	TotalTaxable float64     `json:"total_taxable"`
+	TotalTax     float64     `json:"total_tax"`
+}
+
+// Add books a payment's tax lines under the period paidAt falls in.
+func (r *Report) Add(paidAt time.Time, lines []Line) {
+	label := r.Period.Label(paidAt)
+	for _, l := range lines {
+		row := r.row(label, l)
+		row.Taxable += l.Taxable
+		row.Tax += l.Amount
+		r.TotalTaxable += l.Taxable
+		r.TotalTax += l.Amount
+	}
+}
+
+func (r *Report) row(label string, l Line) *ReportRow {
+	for i := range r.Rows {
+		row := &r.Rows[i]
+		if row.Period == label && row.Rule == l.Rule && row.SaleType == l.SaleType && row.Rate == l.Rate &&
+			row.SellerJurisdiction == l.SellerJurisdiction && row.BuyerJurisdiction == l.BuyerJurisdiction {
+			return row
+		}
+	}
+	r.Rows = append(r.Rows, ReportRow{
+		Period:             label,
+		Rule:               l.Rule,
+		SaleType:           l.SaleType,
+		SellerJurisdiction: l.SellerJurisdiction,
+		BuyerJurisdiction:  l.BuyerJurisdiction,
+		Rate:               l.Rate,
+	})
+	return &r.Rows[len(r.Rows)-1]
+}
@@ -0,0 +1,13 @@
+package tax
+
+import "context"
+
+type Repository interface {
+	// GetCurrentPolicy returns the latest policy, or nil if none was saved.
+	GetCurrentPolicy(ctx context.Context) (*Policy, error)
+	// SavePolicy stores p as a new version. It returns ErrPolicyConflict if
+	// that version already exists.
+	SavePolicy(ctx context.Context, p *Policy) error
+	// ListPolicies returns every saved version, newest first.
+	ListPolicies(ctx context.Context) ([]*Policy, error)
+}
@@ -0,0 +1,16 @@
+package tax
+
+import (
+	"context"
+	"time"
+)
+
+type Usecase interface {
+	Assessor
+	GetPolicy(ctx context.Context) (*Policy, error)
+	// UpdatePolicy saves p as the next version of the policy.
+	UpdatePolicy(ctx context.Context, adminID string, p *Policy) (*Policy, error)
+	GetPolicyHistory(ctx context.Context) ([]*Policy, error)
+	// GetReport sums the tax on payments made from from up to to by period.
+	GetReport(ctx context.Context, from, to time.Time, period Period) (*Report, error)
+}
@@ -4,4 +4,7 @@ import "context"
 
 type Usecase interface {
 	UpdateSupplierTrustScoreOnNewRating(ctx context.Context, supplierID string, declaredRating float64, productRating float64) error
+	// RecordLostDispute lowers a supplier's trust score after staff decide a
+	// dispute against them.
+	RecordLostDispute(ctx context.Context, supplierID string) error
 }
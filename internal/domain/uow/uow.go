@@ -0,0 +1,10 @@
+package uow
+
+import "context"
+
+// UnitOfWork groups repository writes so they commit or abort as a whole.
+// Repositories join the unit of work through the context passed to fn, so
+// every call made inside fn must use that context.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
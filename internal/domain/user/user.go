@@ -24,4 +24,5 @@ type User struct {
 	TrustTotalError float64   `bson:"trust_total_error"`
 	IsDeleted       bool      `bson:"is_deleted"`
 	IsBlacklisted   bool      `bson:"is_blacklisted"`
+	Country         string    `bson:"country"` // ISO code of the user's tax jurisdiction
 }
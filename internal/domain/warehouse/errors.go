@@ -0,0 +1,8 @@
+package warehouse
+
+import "errors"
+
+var (
+	ErrItemNotFound   = errors.New("warehouse item not found")
+	ErrAlreadyArrived = errors.New("warehouse item has already arrived")
+)
@@ -1,13 +1,20 @@
 package warehouse
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Repository interface {
 	AddItem(ctx context.Context, item *WarehouseItem) error
+	GetItemByID(ctx context.Context, itemID string) (*WarehouseItem, error)
 	GetItemsByReseller(ctx context.Context, resellerID string) ([]*WarehouseItem, error)
 	GetItemsByBundle(ctx context.Context, bundleID string) ([]*WarehouseItem, error)
 	MarkItemAsListed(ctx context.Context, itemID string) error
 	MarkItemAsSkipped(ctx context.Context, itemID string) error
+	// MarkItemAsArrived records when an item was unpacked and returns
+	// ErrAlreadyArrived if that was recorded before.
+	MarkItemAsArrived(ctx context.Context, itemID string, at time.Time) error
 	DeleteItem(ctx context.Context, itemID string) error
 	HasResellerReceivedBundle(ctx context.Context, resellerID string, bundleID string) (bool, error)
 	CountByStatus(ctx context.Context, status string) (int, error)
@@ -2,8 +2,13 @@ package warehouse
 
 import (
 	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 )
 
 type Usecase interface {
 	GetWarehouseItems(ctx context.Context, resellerID string) ([]*WarehouseItem, error)
+	// MarkItemArrived records that the item's reseller or an admin unpacked
+	// it at the warehouse, which releases the payment held for its bundle.
+	MarkItemArrived(ctx context.Context, itemID, actorID string, role user.Role) (*WarehouseItem, error)
 }
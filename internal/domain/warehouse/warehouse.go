@@ -1,5 +1,7 @@
 package warehouse
 
+import "time"
+
 type WarehouseItem struct {
 	ID         string `bson:"_id"`
 	ResellerID string `bson:"reseller_id"`
@@ -7,4 +9,7 @@ type WarehouseItem struct {
 	ProductID  string `bson:"product_id"`
 	Status     string `bson:"status"` // listed, skipped, pending
 	CreatedAt  string `bson:"created_at"`
+	// ArrivedAt is when the bundle was unpacked at the warehouse. Listing is
+	// simulated and happens independently of it.
+	ArrivedAt *time.Time `bson:"arrived_at,omitempty"`
 }
@@ -0,0 +1,62 @@
+package carrier
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+)
+
+// LocalCarrierName is the carrier name resellers use for the local courier.
+const LocalCarrierName = "local"
+
+// localTimeline is the route every local parcel takes, as offsets from the
+// time it was shipped.
+var localTimeline = []struct {
+	after       time.Duration
+	status      shipment.Status
+	description string
+	location    string
+}{
+	{0, shipment.StatusInTransit, "Picked up by courier", "Seller"},
+	{time.Minute, shipment.StatusInTransit, "Arrived at sorting hub", "Sorting hub"},
+	{2 * time.Minute, shipment.StatusOutForDelivery, "Out for delivery", "Local depot"},
+	{3 * time.Minute, shipment.StatusDelivered, "Delivered", "Recipient"},
+}
+
+// LocalCarrier simulates a same-day courier. Its tracking events appear on a
+// fixed timeline so that orders move through shipping without a real carrier.
+type LocalCarrier struct {
+	clock job.Clock
+}
+
+func NewLocalCarrier(clock job.Clock) *LocalCarrier {
+	return &LocalCarrier{clock: clock}
+}
+
+func (c *LocalCarrier) Name() string {
+	return LocalCarrierName
+}
+
+func (c *LocalCarrier) EstimateDelivery(shippedAt time.Time) time.Time {
+	return shippedAt.Add(localTimeline[len(localTimeline)-1].after)
+}
+
+func (c *LocalCarrier) Track(ctx context.Context, trackingNumber string, shippedAt time.Time) ([]shipment.TrackingEvent, error) {
+	now := c.clock.Now()
+	var events []shipment.TrackingEvent
+	for _, step := range localTimeline {
+		at := shippedAt.Add(step.after)
+		if at.After(now) {
+			break
+		}
+		events = append(events, shipment.TrackingEvent{
+			Status:      step.status,
+			Description: step.description,
+			Location:    step.location,
+			At:          at,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,175 @@
+package gateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+// FakeGateway is an in-process payment.Gateway. Charge and refund IDs are
+// sequential, so results are deterministic, and the exported fields let
+// callers force declines, outages and slow responses.
+type FakeGateway struct {
+	// Decline makes Authorize fail with payment.ErrPaymentDeclined.
+	Decline bool
+	// Fail, when set, is returned by every call, e.g. payment.ErrGatewayUnavailable.
+	Fail error
+	// Delay is how long each call takes. A call whose context ends first
+	// returns the context's error, which is how timeouts are simulated.
+	Delay time.Duration
+
+	mu      sync.Mutex
+	secret  []byte
+	seq     int
+	charges map[string]*payment.Charge
+	refunds map[string]*payment.Refund // by idempotency key
+}
+
+func NewFakeGateway(webhookSecret string) *FakeGateway {
+	return &FakeGateway{
+		secret:  []byte(webhookSecret),
+		charges: make(map[string]*payment.Charge),
+		refunds: make(map[string]*payment.Refund),
+	}
+}
+
+func (g *FakeGateway) Authorize(ctx context.Context, amount money.Money, referenceID string) (*payment.Charge, error) {
+	if err := g.wait(ctx); err != nil {
+		return nil, err
+	}
+	if g.Decline {
+		return nil, payment.ErrPaymentDeclined
+	}
+	if amount.Amount <= 0 {
+		return nil, errors.New("charge amount must be positive")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c := &payment.Charge{
+		ID:          g.nextID("ch_fake"),
+		Amount:      amount,
+		Refunded:    money.Money{Currency: amount.Currency},
+		ReferenceID: referenceID,
+		Status:      payment.ChargeAuthorized,
+	}
+	g.charges[c.ID] = c
+	copied := *c
+	return &copied, nil
+}
+
+func (g *FakeGateway) Capture(ctx context.Context, chargeID string) (*payment.Charge, error) {
+	if err := g.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c, ok := g.charges[chargeID]
+	if !ok {
+		return nil, payment.ErrChargeNotFound
+	}
+	if c.Status != payment.ChargeAuthorized {
+		return nil, fmt.Errorf("charge %s is %s and cannot be captured", chargeID, c.Status)
+	}
+	c.Status = payment.ChargeCaptured
+	copied := *c
+	return &copied, nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, chargeID string, amount money.Money, idempotencyKey string) (*payment.Refund, error) {
+	if err := g.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if r, ok := g.refunds[idempotencyKey]; ok {
+		copied := *r
+		return &copied, nil
+	}
+	c, ok := g.charges[chargeID]
+	if !ok {
+		return nil, payment.ErrChargeNotFound
+	}
+	if c.Status == payment.ChargeAuthorized {
+		return nil, fmt.Errorf("charge %s has not been captured", chargeID)
+	}
+	if amount.Currency != c.Amount.Currency {
+		return nil, payment.ErrCurrencyMismatch
+	}
+	if amount.Amount <= 0 || amount.Amount > c.Amount.Amount-c.Refunded.Amount {
+		return nil, fmt.Errorf("refund amount %.2f exceeds refundable balance", amount.Major())
+	}
+	c.Refunded = c.Refunded.Add(amount)
+	if c.Refunded.Amount >= c.Amount.Amount {
+		c.Status = payment.ChargeRefunded
+	}
+	r := &payment.Refund{
+		ID:       g.nextID("re_fake"),
+		ChargeID: chargeID,
+		Amount:   amount,
+	}
+	if idempotencyKey != "" {
+		g.refunds[idempotencyKey] = r
+	}
+	copied := *r
+	return &copied, nil
+}
+
+func (g *FakeGateway) VerifyWebhook(payload []byte, signature string) (*payment.WebhookEvent, error) {
+	if !hmac.Equal([]byte(g.Sign(payload)), []byte(signature)) {
+		return nil, payment.ErrInvalidSignature
+	}
+	var event payment.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	return &event, nil
+}
+
+// GetCharge returns a snapshot of a charge so callers can inspect its state.
+func (g *FakeGateway) GetCharge(chargeID string) (*payment.Charge, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c, ok := g.charges[chargeID]
+	if !ok {
+		return nil, false
+	}
+	copied := *c
+	return &copied, true
+}
+
+// Sign returns the signature VerifyWebhook expects for payload: a hex encoded
+// HMAC-SHA256 keyed with the webhook secret.
+func (g *FakeGateway) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (g *FakeGateway) wait(ctx context.Context) error {
+	if g.Delay > 0 {
+		select {
+		case <-time.After(g.Delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return g.Fail
+}
+
+// nextID must be called with g.mu held.
+func (g *FakeGateway) nextID(prefix string) string {
+	g.seq++
+	return fmt.Sprintf("%s_%06d", prefix, g.seq)
+}
@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payout"
+)
+
+// FakePayoutProvider is an in-process payout.Provider. Transfer references are
+// sequential and sending a payout again returns its first reference, like a
+// real provider keyed on the payout ID.
+type FakePayoutProvider struct {
+	// Reject makes Send fail with payout.ErrPayoutRejected.
+	Reject bool
+	// Fail, when set, is returned by every call to simulate an outage.
+	Fail error
+
+	mu   sync.Mutex
+	seq  int
+	sent map[string]string
+}
+
+func NewFakePayoutProvider() *FakePayoutProvider {
+	return &FakePayoutProvider{sent: make(map[string]string)}
+}
+
+func (f *FakePayoutProvider) Send(ctx context.Context, p *payout.Payout) (string, error) {
+	if f.Fail != nil {
+		return "", f.Fail
+	}
+	if f.Reject {
+		return "", payout.ErrPayoutRejected
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if ref, ok := f.sent[p.ID]; ok {
+		return ref, nil
+	}
+	f.seq++
+	ref := fmt.Sprintf("po_fake_%06d", f.seq)
+	f.sent[p.ID] = ref
+	return ref, nil
+}
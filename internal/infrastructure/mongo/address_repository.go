@@ -0,0 +1,93 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/address"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoAddressRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoAddressRepository(db *mongo.Database) address.Repository {
+	return &mongoAddressRepository{
+		collection: db.Collection("addresses"),
+	}
+}
+
+func (r *mongoAddressRepository) CreateAddress(ctx context.Context, a *address.Address) error {
+	if a.ID == "" {
+		a.ID = primitive.NewObjectID().Hex()
+	}
+	_, err := r.collection.InsertOne(ctx, a)
+	return err
+}
+
+func (r *mongoAddressRepository) GetAddressByID(ctx context.Context, id string) (*address.Address, error) {
+	var a address.Address
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&a)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *mongoAddressRepository) ListAddressesByUser(ctx context.Context, userID string) ([]*address.Address, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var addresses []*address.Address
+	if err := cursor.All(ctx, &addresses); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (r *mongoAddressRepository) UpdateAddress(ctx context.Context, a *address.Address) error {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": a.ID}, a)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return address.ErrAddressNotFound
+	}
+	return nil
+}
+
+func (r *mongoAddressRepository) DeleteAddress(ctx context.Context, id string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *mongoAddressRepository) SetDefaultAddress(ctx context.Context, userID, id string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "_id": bson.M{"$ne": id}},
+		bson.M{"$set": bson.M{"is_default": false}},
+	)
+	if err != nil {
+		return err
+	}
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "user_id": userID},
+		bson.M{"$set": bson.M{"is_default": true}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return address.ErrAddressNotFound
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auction"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoAuctionRepository struct {
+	collection *mongo.Collection
+	bids       *mongo.Collection
+}
+
+func NewMongoAuctionRepository(db *mongo.Database) auction.Repository {
+	return &mongoAuctionRepository{
+		collection: db.Collection("auctions"),
+		bids:       db.Collection("auction_bids"),
+	}
+}
+
+func (r *mongoAuctionRepository) CreateAuction(ctx context.Context, a *auction.Auction) error {
+	_, err := r.collection.InsertOne(ctx, a)
+	return err
+}
+
+func (r *mongoAuctionRepository) GetAuctionByID(ctx context.Context, id string) (*auction.Auction, error) {
+	var a auction.Auction
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&a)
+	if err == mongo.ErrNoDocuments {
+		return nil, auction.ErrAuctionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *mongoAuctionRepository) ListAuctions(ctx context.Context, status auction.Status) ([]*auction.Auction, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "ends_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*auction.Auction
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoAuctionRepository) UpdateAuction(ctx context.Context, a *auction.Auction, from auction.Status, bids int) error {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": a.ID, "status": from, "bid_count": bids}, a)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return auction.ErrAuctionChanged
+	}
+	return nil
+}
+
+func (r *mongoAuctionRepository) AddBid(ctx context.Context, b *auction.Bid) error {
+	_, err := r.bids.InsertOne(ctx, b)
+	return err
+}
+
+func (r *mongoAuctionRepository) ListBids(ctx context.Context, auctionID string) ([]*auction.Bid, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "amount.amount", Value: -1}, {Key: "placed_at", Value: 1}})
+	cursor, err := r.bids.Find(ctx, bson.M{"auction_id": auctionID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*auction.Bid
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
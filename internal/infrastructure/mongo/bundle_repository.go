@@ -3,10 +3,13 @@ package mongo
 import (
 	"context"
 	"errors" // Added
+	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type BundleRepository struct {
@@ -25,15 +28,15 @@ func (r *BundleRepository) CreateBundle(ctx context.Context, b *bundle.Bundle) e
 }
 
 func (r *BundleRepository) GetBundleByID(ctx context.Context, id string) (*bundle.Bundle, error) {
-	var bundle bundle.Bundle
-	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&bundle)
+	var b bundle.Bundle
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&b)
 	if err == mongo.ErrNoDocuments {
-		return nil, errors.New("bundle not found") // Updated: Return a specific error message
+		return nil, bundle.ErrBundleNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &bundle, nil
+	return &b, nil
 }
 
 func (r *BundleRepository) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
@@ -59,29 +62,144 @@ func (r *BundleRepository) ListBundles(ctx context.Context, supplierID string) (
 	return bundles, nil
 }
 
-func (r *BundleRepository) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	var bundles []*bundle.Bundle
-	cursor, err := r.collection.Find(ctx, bson.M{"status": "available"})
-	if err != nil {
-		return nil, err
+// ListAvailableBundles pages through the bundles q matches. Searches that
+// compare prices first price every bundle in minor units of the settlement
+// currency, so bundles listed in different currencies compare fairly.
+func (r *BundleRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query, after *bundle.Cursor, limit int) ([]*bundle.Bundle, error) {
+	field := bundleSortFields[q.Sort]
+	dir, op := 1, "$gt"
+	if q.Desc {
+		dir, op = -1, "$lt"
 	}
-	defer cursor.Close(ctx)
 
-	for cursor.Next(ctx) {
-		var b bundle.Bundle
-		if err := cursor.Decode(&b); err != nil {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: availableBundleFilter(q)}}}
+	if q.Priced() {
+		r, err := settlementRange(q.Rates, q.Currency, q.MinPrice, q.MaxPrice)
+		if err != nil {
 			return nil, err
 		}
-		bundles = append(bundles, &b)
+		pipeline = append(pipeline,
+			bson.D{{Key: "$addFields", Value: bson.M{settlementPriceField: settlementPrice(q.Rates)}}},
+			bson.D{{Key: "$match", Value: bson.M{settlementPriceField: r}}},
+		)
+	}
+	if after != nil {
+		v := cursorValue(after)
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": []bson.M{
+			{field: bson.M{op: v}},
+			{field: v, "_id": bson.M{op: after.ID}},
+		}}}})
 	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: field, Value: dir}, {Key: "_id", Value: dir}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+		bson.D{{Key: "$unset", Value: settlementPriceField}},
+	)
 
-	if err := cursor.Err(); err != nil {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(ctx)
 
+	var bundles []*bundle.Bundle
+	if err := cursor.All(ctx, &bundles); err != nil {
+		return nil, err
+	}
 	return bundles, nil
 }
 
+// bundleSortFields maps each search order to the field it sorts on.
+var bundleSortFields = map[bundle.SortField]string{
+	bundle.SortDateListed:    "datelisted",
+	bundle.SortPrice:         settlementPriceField,
+	bundle.SortSupplierTrust: "supplier_trust",
+}
+
+func availableBundleFilter(q *bundle.Query) bson.M {
+	filter := bson.M{"status": "available"}
+	if q.Grade != "" {
+		filter["grade"] = q.Grade
+	}
+	if q.SortingLevel != "" {
+		filter["sortinglevel"] = q.SortingLevel
+	}
+	if q.Type != "" {
+		filter["type"] = q.Type
+	}
+	if r := between(q.MinRating, q.MaxRating); r != nil {
+		filter["declared_rating"] = r
+	}
+	if q.MinSupplierTrust != nil {
+		filter["supplier_trust"] = bson.M{"$gte": *q.MinSupplierTrust}
+	}
+	if q.Text != "" {
+		filter["$text"] = bson.M{"$search": q.Text}
+	}
+	return filter
+}
+
+// between builds an inclusive range condition, or returns nil when neither
+// bound is set.
+func between(lo, hi *int) bson.M {
+	if lo == nil && hi == nil {
+		return nil
+	}
+	r := bson.M{}
+	if lo != nil {
+		r["$gte"] = *lo
+	}
+	if hi != nil {
+		r["$lte"] = *hi
+	}
+	return r
+}
+
+func cursorValue(c *bundle.Cursor) interface{} {
+	switch c.Sort {
+	case bundle.SortPrice:
+		return c.Price
+	case bundle.SortSupplierTrust:
+		return c.Trust
+	default:
+		return c.ListedAt
+	}
+}
+
+// EnsureIndexes creates the indexes bundle searches rely on: one per stored
+// sort field behind the status filter, and a text index over title and
+// description. Prices are normalized per search, so they have none.
+func (r *BundleRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "datelisted", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "supplier_trust", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "supplierid", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+			Options: options.Index().SetName("bundle_text").SetWeights(bson.D{{Key: "title", Value: 3}, {Key: "description", Value: 1}}),
+		},
+	})
+	return err
+}
+
+// BackfillSupplierTrust copies the supplier's trust score onto bundles
+// listed before it was kept on them, or 0 for a supplier who is gone, so
+// that trust filters and the trust sort see every bundle. Bundles that
+// already have a score are left alone, so it is safe to run on every start.
+func (r *BundleRepository) BackfillSupplierTrust(ctx context.Context) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"supplier_trust": bson.M{"$exists": false}}}},
+		{{Key: "$lookup", Value: bson.M{"from": "users", "localField": "supplierid", "foreignField": "_id", "as": "supplier"}}},
+		{{Key: "$project", Value: bson.M{"supplier_trust": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$supplier.trust_score", 0}}, 0}}}}},
+		{{Key: "$merge", Value: bson.M{"into": r.collection.Name(), "on": "_id", "whenMatched": "merge", "whenNotMatched": "discard"}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
 func (r *BundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
 	var bundles []*bundle.Bundle
 	cursor, err := r.collection.Find(ctx, bson.M{"resellerid": resellerID, "status": "purchased"})
@@ -111,14 +229,71 @@ func (r *BundleRepository) UpdateBundleStatus(ctx context.Context, id string, st
 }
 
 func (r *BundleRepository) MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error {
-	_, err := r.collection.UpdateOne(
+	// Only an available bundle can be bought, so concurrent purchases cannot both succeed.
+	result, err := r.collection.UpdateOne(
 		ctx,
-		bson.M{"_id": bundleID},
+		bson.M{"_id": bundleID, "status": "available"},
 		bson.M{"$set": bson.M{"status": "purchased", "resellerid": resellerID}},
 	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return bundle.ErrNotAvailable
+	}
+	return nil
+}
+
+func (r *BundleRepository) SetListingMode(ctx context.Context, bundleID string, mode bundle.ListingMode, now time.Time) error {
+	// Matching on the old mode keeps two requests from both switching the bundle.
+	filter := bson.M{"_id": bundleID, "status": "available", "listing_mode": bson.M{"$ne": mode}}
+	if mode == bundle.Auctioned {
+		// A bundle held for an accepted offer stays on sale to its buyer.
+		filter["$or"] = unreserved(now)
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"listing_mode": mode}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return bundle.ErrNotAvailable
+	}
+	return nil
+}
+
+func (r *BundleRepository) Reserve(ctx context.Context, bundleID string, res *offer.Reservation, now time.Time) error {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": bundleID, "status": "available", "$or": unreserved(now)},
+		bson.M{"$set": bson.M{"reservation": res}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return offer.ErrReserved
+	}
+	return nil
+}
+
+func (r *BundleRepository) Unreserve(ctx context.Context, bundleID, offerID string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": bundleID, "reservation.offer_id": offerID},
+		bson.M{"$unset": bson.M{"reservation": ""}},
+	)
 	return err
 }
 
+// unreserved matches listings no reservation holds at now, so two offers
+// accepted at once cannot both reserve the same listing.
+func unreserved(now time.Time) bson.A {
+	return bson.A{
+		bson.M{"reservation": nil},
+		bson.M{"reservation.until": bson.M{"$lt": now}},
+	}
+}
+
 func (r *BundleRepository) DeleteBundle(ctx context.Context, bundleID string) error {
 	// Update the bundle's status to "deactivated"
 	result, err := r.collection.UpdateOne(
@@ -167,3 +342,8 @@ func (r *BundleRepository) CountBundles(ctx context.Context) (int, error) {
 	count, err := r.collection.CountDocuments(ctx, bson.M{})
 	return int(count), err
 }
+
+func (r *BundleRepository) UpdateSupplierTrust(ctx context.Context, supplierID string, score int) error {
+	_, err := r.collection.UpdateMany(ctx, bson.M{"supplierid": supplierID}, bson.M{"$set": bson.M{"supplier_trust": score}})
+	return err
+}
@@ -0,0 +1,133 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoCreditRepository struct {
+	wallets   *mongo.Collection
+	entries   *mongo.Collection
+	giftCards *mongo.Collection
+}
+
+func NewMongoCreditRepository(db *mongo.Database) credit.Repository {
+	return &mongoCreditRepository{
+		wallets:   db.Collection("credit_wallets"),
+		entries:   db.Collection("credit_entries"),
+		giftCards: db.Collection("gift_cards"),
+	}
+}
+
+func (r *mongoCreditRepository) GetWallet(ctx context.Context, userID string) (*credit.Wallet, error) {
+	var w credit.Wallet
+	err := r.wallets.FindOne(ctx, bson.M{"_id": userID}).Decode(&w)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// PostEntry moves the balance with a single conditional update, so
+// concurrent spends cannot take it below zero. Callers run it inside a unit
+// of work to keep the balance and its history together.
+func (r *mongoCreditRepository) PostEntry(ctx context.Context, e *credit.Entry) error {
+	update := bson.M{
+		"$inc":         bson.M{"balance.amount": e.Amount.Amount},
+		"$set":         bson.M{"updated_at": e.CreatedAt},
+		"$setOnInsert": bson.M{"balance.currency": e.Amount.Currency},
+	}
+	if e.Amount.Amount < 0 {
+		res, err := r.wallets.UpdateOne(ctx, bson.M{"_id": e.UserID, "balance.amount": bson.M{"$gte": -e.Amount.Amount}}, update)
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			return credit.ErrInsufficientCredit
+		}
+	} else {
+		if _, err := r.wallets.UpdateOne(ctx, bson.M{"_id": e.UserID}, update, options.Update().SetUpsert(true)); err != nil {
+			return err
+		}
+	}
+	_, err := r.entries.InsertOne(ctx, e)
+	return err
+}
+
+func (r *mongoCreditRepository) ListEntries(ctx context.Context, userID string) ([]*credit.Entry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.entries.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*credit.Entry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *mongoCreditRepository) CreateGiftCard(ctx context.Context, g *credit.GiftCard) error {
+	_, err := r.giftCards.InsertOne(ctx, g)
+	return err
+}
+
+func (r *mongoCreditRepository) ListGiftCards(ctx context.Context) ([]*credit.GiftCard, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.giftCards.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var cards []*credit.GiftCard
+	if err := cursor.All(ctx, &cards); err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+func (r *mongoCreditRepository) ClaimGiftCard(ctx context.Context, code, userID string, now time.Time) (*credit.GiftCard, error) {
+	filter := bson.M{
+		"_id":    code,
+		"status": credit.GiftCardActive,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$exists": false}},
+			bson.M{"expires_at": bson.M{"$gt": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"status": credit.GiftCardRedeemed, "redeemed_by": userID, "redeemed_at": now}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var g credit.GiftCard
+	err := r.giftCards.FindOneAndUpdate(ctx, filter, update, opts).Decode(&g)
+	if err == nil {
+		return &g, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	// Work out why the card could not be claimed.
+	err = r.giftCards.FindOne(ctx, bson.M{"_id": code}).Decode(&g)
+	if err == mongo.ErrNoDocuments {
+		return nil, credit.ErrGiftCardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if g.Status == credit.GiftCardRedeemed {
+		return nil, credit.ErrGiftCardRedeemed
+	}
+	return nil, credit.ErrGiftCardExpired
+}
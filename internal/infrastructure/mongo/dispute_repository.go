@@ -0,0 +1,100 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDisputeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoDisputeRepository(db *mongo.Database) dispute.Repository {
+	return &mongoDisputeRepository{
+		collection: db.Collection("disputes"),
+	}
+}
+
+func (r *mongoDisputeRepository) CreateDispute(ctx context.Context, d *dispute.Dispute) error {
+	_, err := r.collection.InsertOne(ctx, d)
+	return err
+}
+
+func (r *mongoDisputeRepository) GetDisputeByID(ctx context.Context, id string) (*dispute.Dispute, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *mongoDisputeRepository) GetOpenDisputeByOrder(ctx context.Context, orderID string) (*dispute.Dispute, error) {
+	return r.findOne(ctx, bson.M{
+		"order_id": orderID,
+		"status":   bson.M{"$nin": []dispute.Status{dispute.StatusResolvedBuyer, dispute.StatusResolvedSeller}},
+	})
+}
+
+func (r *mongoDisputeRepository) ListDisputesByUser(ctx context.Context, userID string) ([]*dispute.Dispute, error) {
+	return r.find(ctx, bson.M{"$or": []bson.M{{"buyer_id": userID}, {"seller_id": userID}}})
+}
+
+func (r *mongoDisputeRepository) ListDisputes(ctx context.Context, status dispute.Status) ([]*dispute.Dispute, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *mongoDisputeRepository) AddMessage(ctx context.Context, id string, m dispute.Message, status dispute.Status) error {
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$push": bson.M{"messages": m},
+		"$set":  bson.M{"status": status, "updated_at": m.CreatedAt},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return dispute.ErrDisputeNotFound
+	}
+	return nil
+}
+
+func (r *mongoDisputeRepository) UpdateDispute(ctx context.Context, d *dispute.Dispute, from dispute.Status) error {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": d.ID, "status": from}, d)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return dispute.ErrDisputeChanged
+	}
+	return nil
+}
+
+func (r *mongoDisputeRepository) findOne(ctx context.Context, filter bson.M) (*dispute.Dispute, error) {
+	var d dispute.Dispute
+	err := r.collection.FindOne(ctx, filter).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *mongoDisputeRepository) find(ctx context.Context, filter bson.M) ([]*dispute.Dispute, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var disputes []*dispute.Dispute
+	if err := cursor.All(ctx, &disputes); err != nil {
+		return nil, err
+	}
+	return disputes, nil
+}
@@ -0,0 +1,59 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoEscrowRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoEscrowRepository(db *mongo.Database) escrow.Repository {
+	return &mongoEscrowRepository{
+		collection: db.Collection("escrows"),
+	}
+}
+
+func (r *mongoEscrowRepository) CreateEscrow(ctx context.Context, e *escrow.Escrow) error {
+	_, err := r.collection.InsertOne(ctx, e)
+	return err
+}
+
+func (r *mongoEscrowRepository) GetEscrowByOrder(ctx context.Context, orderID string) (*escrow.Escrow, error) {
+	var e escrow.Escrow
+	err := r.collection.FindOne(ctx, bson.M{"_id": orderID}).Decode(&e)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *mongoEscrowRepository) GetEscrowByWarehouseItem(ctx context.Context, itemID string) (*escrow.Escrow, error) {
+	var e escrow.Escrow
+	err := r.collection.FindOne(ctx, bson.M{"warehouse_item_id": itemID}).Decode(&e)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *mongoEscrowRepository) UpdateEscrow(ctx context.Context, e *escrow.Escrow, from escrow.Status) error {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": e.OrderID, "status": from}, e)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return escrow.ErrEscrowChanged
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoFeeRepository keeps one document per policy version, keyed by the
+// version number, so a concurrent update of the same version fails.
+type mongoFeeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoFeeRepository(db *mongo.Database) fee.Repository {
+	return &mongoFeeRepository{
+		collection: db.Collection("fee_policies"),
+	}
+}
+
+func (r *mongoFeeRepository) GetCurrentPolicy(ctx context.Context) (*fee.Policy, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})
+	var p fee.Policy
+	err := r.collection.FindOne(ctx, bson.M{}, opts).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *mongoFeeRepository) SavePolicy(ctx context.Context, p *fee.Policy) error {
+	_, err := r.collection.InsertOne(ctx, p)
+	if mongo.IsDuplicateKeyError(err) {
+		return fee.ErrPolicyConflict
+	}
+	return err
+}
+
+func (r *mongoFeeRepository) ListPolicies(ctx context.Context) ([]*fee.Policy, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	policies := []*fee.Policy{}
+	if err := cursor.All(ctx, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
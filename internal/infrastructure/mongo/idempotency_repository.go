@@ -0,0 +1,54 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/idempotency"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoIdempotencyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoIdempotencyRepository(db *mongo.Database) idempotency.Repository {
+	return &mongoIdempotencyRepository{
+		collection: db.Collection("idempotency_keys"),
+	}
+}
+
+// Reserve replaces an expired record or inserts a new one. A live record does
+// not match the filter, so the upsert collides with its _id instead.
+func (r *mongoIdempotencyRepository) Reserve(ctx context.Context, rec *idempotency.Record, now time.Time) error {
+	filter := bson.M{"_id": rec.ID, "expires_at": bson.M{"$lte": now}}
+	_, err := r.collection.ReplaceOne(ctx, filter, rec, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return idempotency.ErrKeyInUse
+	}
+	return err
+}
+
+func (r *mongoIdempotencyRepository) GetRecord(ctx context.Context, userID, key string) (*idempotency.Record, error) {
+	var rec idempotency.Record
+	err := r.collection.FindOne(ctx, bson.M{"_id": idempotency.RecordID(userID, key)}).Decode(&rec)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *mongoIdempotencyRepository) Complete(ctx context.Context, rec *idempotency.Record) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": rec.ID}, rec)
+	return err
+}
+
+func (r *mongoIdempotencyRepository) Release(ctx context.Context, userID, key string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": idempotency.RecordID(userID, key)})
+	return err
+}
@@ -0,0 +1,57 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// invoiceCounterID names the invoice sequence in the counters collection.
+const invoiceCounterID = "invoice"
+
+type mongoInvoiceRepository struct {
+	invoices *mongo.Collection
+	counters *mongo.Collection
+}
+
+func NewMongoInvoiceRepository(db *mongo.Database) invoice.Repository {
+	return &mongoInvoiceRepository{
+		invoices: db.Collection("invoices"),
+		counters: db.Collection("counters"),
+	}
+}
+
+func (r *mongoInvoiceRepository) GetInvoiceByOrder(ctx context.Context, orderID string) (*invoice.Invoice, error) {
+	var inv invoice.Invoice
+	err := r.invoices.FindOne(ctx, bson.M{"_id": orderID}).Decode(&inv)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (r *mongoInvoiceRepository) NextNumber(ctx context.Context) (int64, error) {
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := r.counters.FindOneAndUpdate(ctx, bson.M{"_id": invoiceCounterID}, bson.M{"$inc": bson.M{"seq": 1}}, opts).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Seq, nil
+}
+
+func (r *mongoInvoiceRepository) CreateInvoice(ctx context.Context, inv *invoice.Invoice) error {
+	_, err := r.invoices.InsertOne(ctx, inv)
+	if mongo.IsDuplicateKeyError(err) {
+		return invoice.ErrInvoiceExists
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoJobRepository(db *mongo.Database) job.Repository {
+	return &mongoJobRepository{
+		collection: db.Collection("jobs"),
+	}
+}
+
+// EnsureJobIndexes creates the indexes ClaimDue relies on: queued jobs by
+// run time and running jobs by lease expiry.
+func EnsureJobIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("jobs").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "locked_until", Value: 1}}},
+	})
+	return err
+}
+
+func (r *mongoJobRepository) Enqueue(ctx context.Context, j *job.Job) error {
+	if j.ID == "" {
+		j.ID = primitive.NewObjectID().Hex()
+	}
+	_, err := r.collection.InsertOne(ctx, j)
+	if mongo.IsDuplicateKeyError(err) {
+		return job.ErrDuplicateJob
+	}
+	return err
+}
+
+func (r *mongoJobRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*job.Job, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": job.StatusQueued, "run_at": bson.M{"$lte": now}},
+		bson.M{"status": job.StatusRunning, "locked_until": bson.M{"$lte": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": job.StatusRunning, "locked_until": now.Add(lease), "lease": primitive.NewObjectID().Hex(), "updated_at": now},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var j job.Job
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&j)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func (r *mongoJobRepository) Complete(ctx context.Context, id, lease string, now time.Time) error {
+	update := bson.M{"$set": bson.M{"status": job.StatusSucceeded, "updated_at": now, "last_error": ""}}
+	return r.update(ctx, id, lease, update)
+}
+
+func (r *mongoJobRepository) Retry(ctx context.Context, id, lease string, now, runAt time.Time, lastError string) error {
+	update := bson.M{"$set": bson.M{"status": job.StatusQueued, "run_at": runAt, "updated_at": now, "last_error": lastError}}
+	return r.update(ctx, id, lease, update)
+}
+
+func (r *mongoJobRepository) Fail(ctx context.Context, id, lease string, now time.Time, lastError string) error {
+	update := bson.M{"$set": bson.M{"status": job.StatusFailed, "updated_at": now, "last_error": lastError}}
+	return r.update(ctx, id, lease, update)
+}
+
+func (r *mongoJobRepository) Requeue(ctx context.Context, id string, now time.Time) error {
+	filter := bson.M{"_id": id, "status": job.StatusFailed}
+	update := bson.M{"$set": bson.M{"status": job.StatusQueued, "run_at": now, "updated_at": now, "attempts": 0}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		if _, err := r.GetJobByID(ctx, id); err != nil {
+			return err
+		}
+		return job.ErrNotRetryable
+	}
+	return nil
+}
+
+func (r *mongoJobRepository) GetJobByID(ctx context.Context, id string) (*job.Job, error) {
+	var j job.Job
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&j)
+	if err == mongo.ErrNoDocuments {
+		return nil, job.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func (r *mongoJobRepository) ListJobs(ctx context.Context, status job.Status, page, limit int) ([]*job.Job, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "run_at", Value: -1}}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*job.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// update applies the outcome of a claim, but only while the job is still
+// running under that claim's lease.
+func (r *mongoJobRepository) update(ctx context.Context, id, lease string, update bson.M) error {
+	filter := bson.M{"_id": id, "status": job.StatusRunning, "lease": lease}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		if _, err := r.GetJobByID(ctx, id); err != nil {
+			return err
+		}
+		return job.ErrLeaseLost
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoLedgerRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoLedgerRepository(db *mongo.Database) ledger.Repository {
+	return &mongoLedgerRepository{
+		collection: db.Collection("ledger_entries"),
+	}
+}
+
+func (r *mongoLedgerRepository) PostEntries(ctx context.Context, entries []ledger.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(entries))
+	for i, e := range entries {
+		docs[i] = e
+	}
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *mongoLedgerRepository) AccountTotals(ctx context.Context, account string) (map[ledger.Kind]ledger.Totals, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "lines.account", Value: account}}}},
+		{{Key: "$unwind", Value: "$lines"}},
+		{{Key: "$match", Value: bson.D{{Key: "lines.account", Value: account}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$kind"},
+			{Key: "debit", Value: bson.D{{Key: "$sum", Value: "$lines.debit"}}},
+			{Key: "credit", Value: bson.D{{Key: "$sum", Value: "$lines.credit"}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Kind          ledger.Kind `bson:"_id"`
+		ledger.Totals `bson:",inline"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	totals := make(map[ledger.Kind]ledger.Totals, len(rows))
+	for _, row := range rows {
+		totals[row.Kind] = row.Totals
+	}
+	return totals, nil
+}
@@ -0,0 +1,126 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// moneyFields lists, per collection, the amounts that used to be stored as
+// plain numbers. Listings and cart items kept their currency alongside in a
+// "currency" field; auctions still do. Orders and payments were always in
+// money.Settlement.
+var moneyFields = []struct {
+	collection   string
+	fields       []string
+	listed       bool // whether the amounts are in the document's "currency"
+	keepCurrency bool // whether "currency" stays once the amounts carry it
+}{
+	{collection: "bundles", fields: []string{"price"}, listed: true},
+	{collection: "products", fields: []string{"price"}, listed: true},
+	{collection: "cartitems", fields: []string{"price"}, listed: true},
+	{collection: "orders", fields: []string{"total_price", "platform_fee", "seller_earning", "discount", "tax"}},
+	{collection: "payments", fields: []string{"amount", "discount", "creditamount", "platformfee", "sellerearning", "tax"}},
+	{collection: "escrows", fields: []string{"amount"}},
+	{collection: "credit_wallets", fields: []string{"balance"}},
+	{collection: "credit_entries", fields: []string{"amount"}},
+	{collection: "gift_cards", fields: []string{"amount"}},
+	{collection: "auctions", fields: []string{"starting_price", "reserve_price", "bid_increment", "high_bid"}, listed: true, keepCurrency: true},
+}
+
+// MigrateMoney rewrites amounts stored as plain numbers into money.Money
+// documents and drops the "currency" fields they were kept with. Documents
+// already migrated are left alone, so it is safe to run on every start.
+func MigrateMoney(ctx context.Context, db *mongo.Database) error {
+	for _, m := range moneyFields {
+		currency := bson.M{"$literal": money.Settlement}
+		if m.listed {
+			currency = bson.M{"$ifNull": bson.A{"$currency", money.Settlement}}
+		}
+
+		set := bson.M{}
+		stale := bson.A{}
+		for _, f := range m.fields {
+			set[f] = moneyFrom("$"+f, currency)
+			stale = append(stale, bson.M{f: bson.M{"$type": "number"}})
+		}
+		if m.listed && !m.keepCurrency {
+			set["reservation"] = bson.M{"$cond": bson.A{
+				bson.M{"$isNumber": "$reservation.price"},
+				bson.M{"$mergeObjects": bson.A{"$reservation", bson.M{"price": moneyFrom("$reservation.price", currency)}}},
+				"$reservation",
+			}}
+			stale = append(stale, bson.M{"reservation.price": bson.M{"$type": "number"}})
+		}
+		if m.collection == "orders" {
+			set["line_totals"] = bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$type": "$line_totals"}, "object"}},
+				bson.M{"$arrayToObject": bson.M{"$map": bson.M{
+					"input": bson.M{"$objectToArray": "$line_totals"},
+					"in":    bson.M{"k": "$$this.k", "v": moneyFrom("$$this.v", currency)},
+				}}},
+				"$line_totals",
+			}}
+		}
+
+		pipeline := mongo.Pipeline{{{Key: "$set", Value: set}}}
+		if !m.keepCurrency {
+			pipeline = append(pipeline, bson.D{{Key: "$unset", Value: "currency"}})
+		}
+		if _, err := db.Collection(m.collection).UpdateMany(ctx, bson.M{"$or": stale}, pipeline); err != nil {
+			return err
+		}
+	}
+	return migrateBids(ctx, db)
+}
+
+// migrateBids converts bid amounts, which are in the currency of the auction
+// they were placed on.
+func migrateBids(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("auctions").Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"currency": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var a struct {
+			ID       string         `bson:"_id"`
+			Currency money.Currency `bson:"currency"`
+		}
+		if err := cursor.Decode(&a); err != nil {
+			return err
+		}
+		currency := bson.M{"$literal": money.OrSettlement(a.Currency)}
+		pipeline := mongo.Pipeline{{{Key: "$set", Value: bson.M{"amount": moneyFrom("$amount", currency)}}}}
+		filter := bson.M{"auction_id": a.ID, "amount": bson.M{"$type": "number"}}
+		if _, err := db.Collection("auction_bids").UpdateMany(ctx, filter, pipeline); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// moneyFrom converts the number at path, in major units of currency, into a
+// money.Money document. Anything else at path is kept as it is.
+func moneyFrom(path string, currency bson.M) bson.M {
+	branches := bson.A{}
+	for _, c := range []money.Currency{money.ETB, money.USD} {
+		branches = append(branches, bson.M{
+			"case": bson.M{"$eq": bson.A{currency, c}},
+			"then": money.FromMajor(1, c).Amount,
+		})
+	}
+	units := bson.M{"$switch": bson.M{"branches": branches, "default": money.FromMajor(1, money.Settlement).Amount}}
+
+	return bson.M{"$cond": bson.A{
+		bson.M{"$isNumber": path},
+		bson.M{
+			"amount":   bson.M{"$toLong": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{path, units}}, 0}}},
+			"currency": currency,
+		},
+		path,
+	}}
+}
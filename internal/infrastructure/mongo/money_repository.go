@@ -0,0 +1,93 @@
+package mongo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoMoneyRepository keeps one exchange rate per currency, keyed by its code.
+type mongoMoneyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoMoneyRepository(db *mongo.Database) money.Repository {
+	return &mongoMoneyRepository{
+		collection: db.Collection("exchange_rates"),
+	}
+}
+
+func (r *mongoMoneyRepository) ListRates(ctx context.Context) ([]*money.Rate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rates := []*money.Rate{}
+	if err := cursor.All(ctx, &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (r *mongoMoneyRepository) SaveRate(ctx context.Context, rate *money.Rate) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": rate.Currency}, rate, options.Replace().SetUpsert(true))
+	return err
+}
+
+// settlementPriceField holds a listing's price in minor units of the
+// settlement currency while a search compares prices. It is never stored.
+const settlementPriceField = "settlement_price"
+
+// settlementPrice converts a listing's price to minor units of the
+// settlement currency at rates. Prices in a currency without a rate come out
+// null.
+func settlementPrice(rates money.Rates) bson.M {
+	currencies := []money.Currency{money.Settlement}
+	for c := range rates {
+		if c != money.Settlement {
+			currencies = append(currencies, c)
+		}
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+
+	branches := bson.A{}
+	for _, c := range currencies {
+		f, err := rates.MinorFactor(c)
+		if err != nil {
+			continue
+		}
+		branches = append(branches, bson.M{
+			"case": bson.M{"$eq": bson.A{"$price.currency", c}},
+			"then": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{"$price.amount", f}}, 0}},
+		})
+	}
+	return bson.M{"$switch": bson.M{"branches": branches, "default": nil}}
+}
+
+// settlementRange is the condition on settlementPriceField for price bounds
+// given in c. It always leaves out listings that could not be priced.
+func settlementRange(rates money.Rates, c money.Currency, lo, hi *float64) (bson.M, error) {
+	r := bson.M{"$ne": nil}
+	if lo != nil {
+		v, err := rates.SettlementMinor(money.FromMajor(*lo, c))
+		if err != nil {
+			return nil, err
+		}
+		r["$gte"] = v
+	}
+	if hi != nil {
+		v, err := rates.SettlementMinor(money.FromMajor(*hi, c))
+		if err != nil {
+			return nil, err
+		}
+		r["$lte"] = v
+	}
+	return r, nil
+}
@@ -0,0 +1,49 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/notification"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoNotificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoNotificationRepository(db *mongo.Database) notification.Repository {
+	return &mongoNotificationRepository{collection: db.Collection("notifications")}
+}
+
+func (r *mongoNotificationRepository) CreateNotification(ctx context.Context, n *notification.Notification) error {
+	_, err := r.collection.InsertOne(ctx, n)
+	return err
+}
+
+func (r *mongoNotificationRepository) ListByUser(ctx context.Context, userID string) ([]*notification.Notification, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*notification.Notification
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoNotificationRepository) MarkRead(ctx context.Context, id, userID string) error {
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "user_id": userID}, bson.M{"$set": bson.M{"read": true}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return notification.ErrNotificationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoOfferRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoOfferRepository(db *mongo.Database) offer.Repository {
+	return &mongoOfferRepository{collection: db.Collection("offers")}
+}
+
+func (r *mongoOfferRepository) CreateOffer(ctx context.Context, o *offer.Offer) error {
+	_, err := r.collection.InsertOne(ctx, o)
+	return err
+}
+
+func (r *mongoOfferRepository) GetOfferByID(ctx context.Context, id string) (*offer.Offer, error) {
+	var o offer.Offer
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&o)
+	if err == mongo.ErrNoDocuments {
+		return nil, offer.ErrOfferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *mongoOfferRepository) GetOpenOffer(ctx context.Context, listingID, buyerID string) (*offer.Offer, error) {
+	var o offer.Offer
+	err := r.collection.FindOne(ctx, bson.M{"listing_id": listingID, "buyer_id": buyerID, "status": offer.StatusOpen}).Decode(&o)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *mongoOfferRepository) ListOffersByUser(ctx context.Context, userID string) ([]*offer.Offer, error) {
+	filter := bson.M{"$or": bson.A{bson.M{"buyer_id": userID}, bson.M{"seller_id": userID}}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*offer.Offer
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoOfferRepository) UpdateOffer(ctx context.Context, o *offer.Offer, from offer.Status, rounds int) error {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": o.ID, "status": from, "thread": bson.M{"$size": rounds}}, o)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return offer.ErrOfferChanged
+	}
+	return nil
+}
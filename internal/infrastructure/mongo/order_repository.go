@@ -57,12 +57,22 @@ func (r *mongoOrderRepository) GetOrderByID(ctx context.Context, orderID string)
     return &o, nil
 }
 
-func (r *mongoOrderRepository) UpdateOrderStatus(ctx context.Context, orderID string, status order.OrderStatus) error {
-    filter := bson.M{"_id": orderID} // Fixed: Corrected filter key from "order_id" to "_id"
-    update := bson.M{"$set": bson.M{"status": status}}
+func (r *mongoOrderRepository) TransitionStatus(ctx context.Context, orderID string, t order.StatusTransition) error {
+    // Matching on the previous status keeps two concurrent transitions from both applying.
+    filter := bson.M{"_id": orderID, "status": t.From}
+    update := bson.M{
+        "$set":  bson.M{"status": t.To},
+        "$push": bson.M{"history": t},
+    }
 
-    _, err := r.collection.UpdateOne(ctx, filter, update)
-    return err
+    result, err := r.collection.UpdateOne(ctx, filter, update)
+    if err != nil {
+        return err
+    }
+    if result.MatchedCount == 0 {
+        return order.ErrStatusChanged
+    }
+    return nil
 }
 
 func (r *mongoOrderRepository) DeleteOrder(ctx context.Context, orderID string) error {
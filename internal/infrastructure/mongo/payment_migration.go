@@ -0,0 +1,20 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigratePaymentTimes rewrites payment times stored as RFC 3339 text into
+// dates, so that payments can be queried by time. Dates are kept in UTC.
+// Payments already migrated are left alone, so it is safe to run on every
+// start.
+func MigratePaymentTimes(ctx context.Context, db *mongo.Database) error {
+	pipeline := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"createdat": bson.M{"$dateFromString": bson.M{"dateString": "$createdat"}},
+	}}}}
+	_, err := db.Collection("payments").UpdateMany(ctx, bson.M{"createdat": bson.M{"$type": "string"}}, pipeline)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -24,13 +25,19 @@ func (repo *mongoPaymentRepository) RecordPayment(ctx context.Context, p *paymen
 	if p.ID == "" {
 		p.ID = primitive.NewObjectID().Hex()
 	}
-	if p.CreatedAt == "" {
-		p.CreatedAt = time.Now().Format(time.RFC3339)
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now()
 	}
+	p.CreatedAt = p.CreatedAt.UTC()
 	_, err := repo.collection.InsertOne(ctx, p)
 	return err
 }
 
+func (repo *mongoPaymentRepository) UpdatePaymentStatus(ctx context.Context, id, status string) error {
+	_, err := repo.collection.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"status": status}})
+	return err
+}
+
 func (repo *mongoPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string) ([]*payment.Payment, error) {
 	filter := bson.M{"fromuserid": userID}
 	cursor, err := repo.collection.Find(ctx, filter)
@@ -63,18 +70,63 @@ func (repo *mongoPaymentRepository) GetPaymentsByType(ctx context.Context, userI
 	}
 	return payments, nil
 }
+func (repo *mongoPaymentRepository) GetPaymentsByOrder(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	cursor, err := repo.collection.Find(ctx, bson.M{"orderid": orderID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var payments []*payment.Payment
+	if err = cursor.All(ctx, &payments); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (repo *mongoPaymentRepository) GetPaymentByID(ctx context.Context, id string) (*payment.Payment, error) {
+	var p payment.Payment
+	err := repo.collection.FindOne(ctx, bson.M{"id": id}).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ReserveRefund also accepts payments stored before the refunded total was
+// kept; refunded, summed from their refunds by the caller, stands in for it.
+func (repo *mongoPaymentRepository) ReserveRefund(ctx context.Context, id string, refunded, amount money.Money) error {
+	filter := bson.M{
+		"id":            id,
+		"$or":           bson.A{bson.M{"refunded": bson.M{"$exists": false}}, bson.M{"refunded.amount": refunded.Amount}},
+		"amount.amount": bson.M{"$gte": refunded.Amount + amount.Amount},
+	}
+	res, err := repo.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"refunded": refunded.Add(amount)}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return payment.ErrPaymentChanged
+	}
+	return nil
+}
+
 func (repo *mongoPaymentRepository) GetAllPlatformFees(ctx context.Context) (float64, float64, error) {
 	pipeline := mongo.Pipeline{
 		bson.D{
 			{Key: "$match", Value: bson.D{
-				{Key: "status", Value: "paid"},
+				// Refunds are stored as negative entries, so including them nets them off.
+				{Key: "status", Value: bson.D{{Key: "$in", Value: bson.A{payment.StatusPaid, payment.StatusRefunded}}}},
 			}},
 		},
 		bson.D{
 			{Key: "$group", Value: bson.D{
 				{Key: "_id", Value: nil},
-				{Key: "totalSales", Value: bson.D{{Key: "$sum", Value: "$amount"}}},
-				{Key: "platformFees", Value: bson.D{{Key: "$sum", Value: "$platformfee"}}},
+				{Key: "totalSales", Value: bson.D{{Key: "$sum", Value: "$amount.amount"}}},
+				{Key: "platformFees", Value: bson.D{{Key: "$sum", Value: "$platformfee.amount"}}},
 			}},
 		},
 	}
@@ -86,13 +138,71 @@ func (repo *mongoPaymentRepository) GetAllPlatformFees(ctx context.Context) (flo
 	defer cursor.Close(ctx)
 
 	var result []struct {
-		TotalSales   float64 `bson:"totalSales"`
-		PlatformFees float64 `bson:"platformFees"`
+		TotalSales   int64 `bson:"totalSales"`
+		PlatformFees int64 `bson:"platformFees"`
 	}
 
 	if err := cursor.All(ctx, &result); err != nil || len(result) == 0 {
 		return 0, 0, err
 	}
 
-	return result[0].TotalSales, result[0].PlatformFees, nil
+	// Payment amounts are in minor units of the settlement currency.
+	sales := money.Money{Amount: result[0].TotalSales, Currency: money.Settlement}
+	fees := money.Money{Amount: result[0].PlatformFees, Currency: money.Settlement}
+	return sales.Major(), fees.Major(), nil
+}
+
+func (repo *mongoPaymentRepository) ListUnsettledPayments(ctx context.Context) ([]*payment.Payment, error) {
+	filter := bson.M{
+		"status":   bson.M{"$in": bson.A{payment.StatusPaid, payment.StatusRefunded}},
+		"payoutid": bson.M{"$in": bson.A{nil, ""}},
+	}
+	cursor, err := repo.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var payments []*payment.Payment
+	if err = cursor.All(ctx, &payments); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (repo *mongoPaymentRepository) AssignPayout(ctx context.Context, paymentIDs []string, payoutID string) error {
+	res, err := repo.collection.UpdateMany(ctx,
+		bson.M{"id": bson.M{"$in": paymentIDs}, "payoutid": bson.M{"$in": bson.A{nil, ""}}},
+		bson.M{"$set": bson.M{"payoutid": payoutID}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount != int64(len(paymentIDs)) {
+		return payment.ErrAlreadySettled
+	}
+	return nil
+}
+
+func (repo *mongoPaymentRepository) ReleasePayout(ctx context.Context, payoutID string) error {
+	_, err := repo.collection.UpdateMany(ctx,
+		bson.M{"payoutid": payoutID},
+		bson.M{"$set": bson.M{"payoutid": ""}},
+	)
+	return err
+}
+
+func (repo *mongoPaymentRepository) ListPaymentsBetween(ctx context.Context, from, to time.Time) ([]*payment.Payment, error) {
+	filter := bson.M{"createdat": bson.M{"$gte": from.UTC(), "$lt": to.UTC()}}
+	cursor, err := repo.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var payments []*payment.Payment
+	if err = cursor.All(ctx, &payments); err != nil {
+		return nil, err
+	}
+	return payments, nil
 }
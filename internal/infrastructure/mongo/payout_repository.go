@@ -0,0 +1,82 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payout"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoPayoutRepository struct {
+	methods *mongo.Collection
+	payouts *mongo.Collection
+}
+
+func NewMongoPayoutRepository(db *mongo.Database) payout.Repository {
+	return &mongoPayoutRepository{
+		methods: db.Collection("payout_methods"),
+		payouts: db.Collection("payouts"),
+	}
+}
+
+func (r *mongoPayoutRepository) SaveMethod(ctx context.Context, m *payout.Method) error {
+	_, err := r.methods.ReplaceOne(ctx, bson.M{"_id": m.UserID}, m, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *mongoPayoutRepository) GetMethod(ctx context.Context, userID string) (*payout.Method, error) {
+	var m payout.Method
+	err := r.methods.FindOne(ctx, bson.M{"_id": userID}).Decode(&m)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *mongoPayoutRepository) CreatePayout(ctx context.Context, p *payout.Payout) error {
+	_, err := r.payouts.InsertOne(ctx, p)
+	return err
+}
+
+func (r *mongoPayoutRepository) UpdatePayout(ctx context.Context, p *payout.Payout) error {
+	res, err := r.payouts.ReplaceOne(ctx, bson.M{"_id": p.ID}, p)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return payout.ErrPayoutNotFound
+	}
+	return nil
+}
+
+func (r *mongoPayoutRepository) GetPayoutByID(ctx context.Context, id string) (*payout.Payout, error) {
+	var p payout.Payout
+	err := r.payouts.FindOne(ctx, bson.M{"_id": id}).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *mongoPayoutRepository) ListPayoutsBySeller(ctx context.Context, sellerID string) ([]*payout.Payout, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.payouts.Find(ctx, bson.M{"seller_id": sellerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var payouts []*payout.Payout
+	if err := cursor.All(ctx, &payouts); err != nil {
+		return nil, err
+	}
+	return payouts, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -73,7 +74,7 @@ func (r *mongoProductRepository) ListAvailableProducts(ctx context.Context, page
 	skip := (page - 1) * limit
 	opts := options.Find().SetSkip(int64(skip)).SetLimit(int64(limit))
 
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	cursor, err := r.collection.Find(ctx, bson.M{"status": product.StatusAvailable}, opts)
 	if err != nil {
 		return nil, fmt.Errorf("database query failed: %w", err)
 	}
@@ -94,6 +95,146 @@ func (r *mongoProductRepository) ListAvailableProducts(ctx context.Context, page
 	return products, nil
 }
 
+// SearchProducts runs the search and its facet counts in one aggregation.
+// Filters on size, type and grade are applied per facet so each facet counts
+// over every filter but its own.
+func (r *mongoProductRepository) SearchProducts(ctx context.Context, q *product.Query) (*product.SearchResult, error) {
+	match := bson.M{"status": q.Status}
+	if q.Text != "" {
+		match["$text"] = bson.M{"$search": q.Text}
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	if q.Priced() {
+		// Prices listed in different currencies are compared in the
+		// settlement currency.
+		r, err := settlementRange(q.Rates, q.Currency, q.MinPrice, q.MaxPrice)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline,
+			bson.D{{Key: "$addFields", Value: bson.M{settlementPriceField: settlementPrice(q.Rates)}}},
+			bson.D{{Key: "$match", Value: bson.M{settlementPriceField: r}}},
+		)
+	}
+
+	chosen := map[string]string{"size": q.Size, "type": q.Type, "grade": q.Grade}
+	// except matches the chosen facet values of every field but one.
+	except := func(field string) bson.M {
+		m := bson.M{}
+		for f, v := range chosen {
+			if f != field && v != "" {
+				m[f] = v
+			}
+		}
+		return m
+	}
+	facet := func(field string) bson.A {
+		m := except(field)
+		m[field] = bson.M{"$nin": bson.A{nil, ""}}
+		return bson.A{
+			bson.M{"$match": m},
+			bson.M{"$group": bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		}
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"products": bson.A{
+			bson.M{"$match": except("")},
+			bson.M{"$sort": productSort(q.Sort)},
+			bson.M{"$skip": (q.Page - 1) * q.Limit},
+			bson.M{"$limit": q.Limit},
+			bson.M{"$unset": settlementPriceField},
+		},
+		"total":  bson.A{bson.M{"$match": except("")}, bson.M{"$count": "n"}},
+		"sizes":  facet("size"),
+		"types":  facet("type"),
+		"grades": facet("grade"),
+	}}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var out []struct {
+		Products []*product.Product `bson:"products"`
+		Total    []struct {
+			N int `bson:"n"`
+		} `bson:"total"`
+		Sizes  []facetBucket `bson:"sizes"`
+		Types  []facetBucket `bson:"types"`
+		Grades []facetBucket `bson:"grades"`
+	}
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode products: %w", err)
+	}
+
+	res := &product.SearchResult{Products: []*product.Product{}, Page: q.Page, Limit: q.Limit}
+	if len(out) == 0 {
+		return res, nil
+	}
+	if out[0].Products != nil {
+		res.Products = out[0].Products
+	}
+	if len(out[0].Total) > 0 {
+		res.Total = out[0].Total[0].N
+	}
+	res.Facets = product.Facets{
+		Sizes:  facetCounts(out[0].Sizes),
+		Types:  facetCounts(out[0].Types),
+		Grades: facetCounts(out[0].Grades),
+	}
+	return res, nil
+}
+
+type facetBucket struct {
+	Value string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+func facetCounts(buckets []facetBucket) []product.FacetCount {
+	counts := make([]product.FacetCount, len(buckets))
+	for i, b := range buckets {
+		counts[i] = product.FacetCount{Value: b.Value, Count: b.Count}
+	}
+	return counts
+}
+
+func productSort(order product.SortOrder) bson.D {
+	switch order {
+	case product.SortRelevance:
+		return bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}}
+	case product.SortPriceAsc:
+		return bson.D{{Key: settlementPriceField, Value: 1}, {Key: "_id", Value: 1}}
+	case product.SortPriceDesc:
+		return bson.D{{Key: settlementPriceField, Value: -1}, {Key: "_id", Value: -1}}
+	default:
+		return bson.D{{Key: "createdat", Value: -1}, {Key: "_id", Value: -1}}
+	}
+}
+
+// EnsureProductIndexes creates the indexes catalog searches rely on: the
+// status filter with the newest-first order behind it, and a text index over
+// the searchable fields weighted towards the title. Prices are normalized
+// per search, so they have none.
+func EnsureProductIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("products").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdat", Value: -1}}},
+		{
+			Keys: bson.D{
+				{Key: "title", Value: "text"}, {Key: "description", Value: "text"},
+				{Key: "size", Value: "text"}, {Key: "type", Value: "text"}, {Key: "grade", Value: "text"},
+			},
+			Options: options.Index().SetName("product_text").SetWeights(bson.D{
+				{Key: "title", Value: 5}, {Key: "type", Value: 3}, {Key: "size", Value: 2}, {Key: "grade", Value: 2}, {Key: "description", Value: 1},
+			}),
+		},
+	})
+	return err
+}
+
 func (r *mongoProductRepository) DeleteProduct(ctx context.Context, id string) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
@@ -103,6 +244,59 @@ func (r *mongoProductRepository) UpdateProduct(ctx context.Context, id string, u
 	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": updates})
 	return err
 }
+
+func (r *mongoProductRepository) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	// Only an available product can be sold, so concurrent checkouts cannot both succeed.
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": product.StatusAvailable},
+		bson.M{"$set": bson.M{"status": product.StatusSold, "buyer_id": buyerID}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return product.ErrNotAvailable
+	}
+	return nil
+}
+
+func (r *mongoProductRepository) Relist(ctx context.Context, id string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set":   bson.M{"status": product.StatusAvailable},
+			"$unset": bson.M{"buyer_id": "", "reservation": ""},
+		},
+	)
+	return err
+}
+
+func (r *mongoProductRepository) Reserve(ctx context.Context, id string, res *offer.Reservation, now time.Time) error {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": product.StatusAvailable, "$or": unreserved(now)},
+		bson.M{"$set": bson.M{"reservation": res}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return offer.ErrReserved
+	}
+	return nil
+}
+
+func (r *mongoProductRepository) Unreserve(ctx context.Context, id, offerID string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "reservation.offer_id": offerID},
+		bson.M{"$unset": bson.M{"reservation": ""}},
+	)
+	return err
+}
+
 func (r *mongoProductRepository) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
 	var products []*product.Product
 
@@ -0,0 +1,136 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/promotion"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoPromotionRepository struct {
+	promotions *mongo.Collection
+	usages     *mongo.Collection
+}
+
+func NewMongoPromotionRepository(db *mongo.Database) promotion.Repository {
+	return &mongoPromotionRepository{
+		promotions: db.Collection("promotions"),
+		usages:     db.Collection("promotion_usages"),
+	}
+}
+
+// EnsurePromotionIndexes makes promo codes unique, so two promotions created
+// at once cannot share a code.
+func EnsurePromotionIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("promotions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func (r *mongoPromotionRepository) CreatePromotion(ctx context.Context, p *promotion.Promotion) error {
+	existing, err := r.GetPromotionByCode(ctx, p.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return promotion.ErrPromotionExists
+	}
+	_, err = r.promotions.InsertOne(ctx, p)
+	if mongo.IsDuplicateKeyError(err) {
+		return promotion.ErrPromotionExists
+	}
+	return err
+}
+
+func (r *mongoPromotionRepository) GetPromotionByCode(ctx context.Context, code string) (*promotion.Promotion, error) {
+	var p promotion.Promotion
+	err := r.promotions.FindOne(ctx, bson.M{"code": code}).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *mongoPromotionRepository) ListPromotions(ctx context.Context) ([]*promotion.Promotion, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.promotions.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var promotions []*promotion.Promotion
+	if err := cursor.All(ctx, &promotions); err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (r *mongoPromotionRepository) SetActive(ctx context.Context, code string, active bool) error {
+	res, err := r.promotions.UpdateOne(ctx, bson.M{"code": code}, bson.M{"$set": bson.M{"active": active}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return promotion.ErrPromotionNotFound
+	}
+	return nil
+}
+
+// Redeem counts the use against the overall limit first and then against
+// the user's own, taking the first back if the second is exhausted. Each
+// step is a single conditional update, so concurrent checkouts cannot
+// overshoot either limit.
+func (r *mongoPromotionRepository) Redeem(ctx context.Context, p *promotion.Promotion, userID string) error {
+	filter := bson.M{"_id": p.ID}
+	if p.MaxUses > 0 {
+		filter["used_count"] = bson.M{"$lt": p.MaxUses}
+	}
+	res, err := r.promotions.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"used_count": 1}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return promotion.ErrUsageLimitReached
+	}
+
+	usageFilter := bson.M{"_id": usageID(p.ID, userID)}
+	if p.MaxUsesPerUser > 0 {
+		usageFilter["count"] = bson.M{"$lt": p.MaxUsesPerUser}
+	}
+	_, err = r.usages.UpdateOne(ctx, usageFilter, bson.M{
+		"$inc":         bson.M{"count": 1},
+		"$setOnInsert": bson.M{"promotion_id": p.ID, "user_id": userID},
+	}, options.Update().SetUpsert(true))
+	if err == nil {
+		return nil
+	}
+	// A used-up user's document does not match, so the upsert collides with it.
+	if _, undoErr := r.promotions.UpdateOne(ctx, bson.M{"_id": p.ID}, bson.M{"$inc": bson.M{"used_count": -1}}); undoErr != nil {
+		return undoErr
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return promotion.ErrUserLimitReached
+	}
+	return err
+}
+
+func (r *mongoPromotionRepository) Unredeem(ctx context.Context, promotionID, userID string) error {
+	_, err := r.usages.UpdateOne(ctx, bson.M{"_id": usageID(promotionID, userID), "count": bson.M{"$gt": 0}}, bson.M{"$inc": bson.M{"count": -1}})
+	if err != nil {
+		return err
+	}
+	_, err = r.promotions.UpdateOne(ctx, bson.M{"_id": promotionID, "used_count": bson.M{"$gt": 0}}, bson.M{"$inc": bson.M{"used_count": -1}})
+	return err
+}
+
+func usageID(promotionID, userID string) string {
+	return promotionID + ":" + userID
+}
@@ -0,0 +1,109 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/returns"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoReturnsRepository struct {
+	collection *mongo.Collection
+	policies   *mongo.Collection
+}
+
+func NewMongoReturnsRepository(db *mongo.Database) returns.Repository {
+	return &mongoReturnsRepository{
+		collection: db.Collection("returns"),
+		policies:   db.Collection("return_policies"),
+	}
+}
+
+// EnsureReturnsIndexes allows one return per order line that has not been
+// rejected, so two requests for the same item cannot both be created.
+func EnsureReturnsIndexes(ctx context.Context, db *mongo.Database) error {
+	open := bson.A{returns.StatusRequested, returns.StatusApproved, returns.StatusShippedBack, returns.StatusReceived}
+	_, err := db.Collection("returns").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "product_id", Value: 1}},
+		Options: options.Index().SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": bson.M{"$in": open}}),
+	})
+	return err
+}
+
+func (r *mongoReturnsRepository) CreateReturn(ctx context.Context, ret *returns.Return) error {
+	_, err := r.collection.InsertOne(ctx, ret)
+	if mongo.IsDuplicateKeyError(err) {
+		return returns.ErrReturnExists
+	}
+	return err
+}
+
+func (r *mongoReturnsRepository) GetReturnByID(ctx context.Context, id string) (*returns.Return, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *mongoReturnsRepository) GetReturnByProduct(ctx context.Context, orderID, productID string) (*returns.Return, error) {
+	return r.findOne(ctx, bson.M{
+		"order_id":   orderID,
+		"product_id": productID,
+		"status":     bson.M{"$ne": returns.StatusRejected},
+	})
+}
+
+func (r *mongoReturnsRepository) ListReturnsByUser(ctx context.Context, userID string) ([]*returns.Return, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"$or": []bson.M{{"consumer_id": userID}, {"reseller_id": userID}}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*returns.Return
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoReturnsRepository) UpdateReturn(ctx context.Context, ret *returns.Return, from returns.Status) error {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": ret.ID, "status": from}, ret)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return returns.ErrReturnChanged
+	}
+	return nil
+}
+
+func (r *mongoReturnsRepository) GetPolicy(ctx context.Context, resellerID string) (*returns.Policy, error) {
+	var p returns.Policy
+	err := r.policies.FindOne(ctx, bson.M{"_id": resellerID}).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *mongoReturnsRepository) SavePolicy(ctx context.Context, p *returns.Policy) error {
+	_, err := r.policies.ReplaceOne(ctx, bson.M{"_id": p.ResellerID}, p, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *mongoReturnsRepository) findOne(ctx context.Context, filter bson.M) (*returns.Return, error) {
+	var ret returns.Return
+	err := r.collection.FindOne(ctx, filter).Decode(&ret)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
@@ -0,0 +1,46 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoShipmentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoShipmentRepository(db *mongo.Database) shipment.Repository {
+	return &mongoShipmentRepository{
+		collection: db.Collection("shipments"),
+	}
+}
+
+func (r *mongoShipmentRepository) CreateShipment(ctx context.Context, s *shipment.Shipment) error {
+	if s.ID == "" {
+		s.ID = primitive.NewObjectID().Hex()
+	}
+	_, err := r.collection.InsertOne(ctx, s)
+	return err
+}
+
+func (r *mongoShipmentRepository) GetShipmentByOrder(ctx context.Context, orderID string) (*shipment.Shipment, error) {
+	var s shipment.Shipment
+	err := r.collection.FindOne(ctx, bson.M{"order_id": orderID}).Decode(&s)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *mongoShipmentRepository) UpdateTracking(ctx context.Context, id string, status shipment.Status, events []shipment.TrackingEvent) error {
+	update := bson.M{"$set": bson.M{"status": status, "events": events}}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
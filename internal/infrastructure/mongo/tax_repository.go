@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoTaxRepository keeps one document per policy version, keyed by the
+// version number, so a concurrent update of the same version fails.
+type mongoTaxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoTaxRepository(db *mongo.Database) tax.Repository {
+	return &mongoTaxRepository{
+		collection: db.Collection("tax_policies"),
+	}
+}
+
+func (r *mongoTaxRepository) GetCurrentPolicy(ctx context.Context) (*tax.Policy, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})
+	var p tax.Policy
+	err := r.collection.FindOne(ctx, bson.M{}, opts).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *mongoTaxRepository) SavePolicy(ctx context.Context, p *tax.Policy) error {
+	_, err := r.collection.InsertOne(ctx, p)
+	if mongo.IsDuplicateKeyError(err) {
+		return tax.ErrPolicyConflict
+	}
+	return err
+}
+
+func (r *mongoTaxRepository) ListPolicies(ctx context.Context) ([]*tax.Policy, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	policies := []*tax.Policy{}
+	if err := cursor.All(ctx, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
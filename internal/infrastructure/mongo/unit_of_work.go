@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoUnitOfWork struct {
+	client *mongo.Client
+}
+
+// NewMongoUnitOfWork returns a unit of work backed by a multi-document
+// transaction. Transactions need MongoDB to run as a replica set.
+func NewMongoUnitOfWork(db *mongo.Database) uow.UnitOfWork {
+	return &mongoUnitOfWork{client: db.Client()}
+}
+
+// Do joins the transaction ctx is already in, if any, so that units of work
+// can be nested.
+func (u *mongoUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	session, err := u.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// WithTransaction retries fn on transient errors and aborts on any error fn returns.
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
@@ -3,7 +3,6 @@ package mongo
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
@@ -87,10 +86,7 @@ func (r *mongoUserRepository) ListUsersByRole(ctx context.Context, role user.Rol
 	return users, nil
 }
 func (r *mongoUserRepository) UpdateTrustData(ctx context.Context, user *user.User) error {
-	fmt.Println("💾 Updating trust data for:", user.ID)
-	fmt.Printf("🧠 ID type: %T\n", user.ID)
-
-	// ❌ DO NOT convert to ObjectID — use string ID directly
+	// User IDs are stored as strings, not ObjectIDs.
 	filter := bson.M{"_id": user.ID}
 	update := bson.M{
 		"$set": bson.M{
@@ -101,23 +97,8 @@ func (r *mongoUserRepository) UpdateTrustData(ctx context.Context, user *user.Us
 		},
 	}
 
-	fmt.Println("➡️ Filter:", filter)
-	fmt.Println("➡️ New Score:", user.TrustScore)
-	fmt.Println("➡️ Total Error:", user.TrustTotalError)
-	fmt.Println("➡️ Rated Count:", user.TrustRatedCount)
-
-	res, err := r.collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		fmt.Println("❌ Mongo update error:", err)
-		return err
-	}
-
-	fmt.Println("🔄 ModifiedCount:", res.ModifiedCount)
-	if res.ModifiedCount == 0 {
-		fmt.Println("⚠️ Warning: No document was modified. Check if the ID matches an existing user.")
-	}
-
-	return nil
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
 }
 func (r *mongoUserRepository) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
 	var users []*user.User
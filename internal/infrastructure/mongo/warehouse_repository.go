@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"go.mongodb.org/mongo-driver/bson"
@@ -23,6 +24,18 @@ func (r *mongoRepository) AddItem(ctx context.Context, item *warehouse.Warehouse
 	return err
 }
 
+func (r *mongoRepository) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
+	var item warehouse.WarehouseItem
+	err := r.collection.FindOne(ctx, bson.M{"_id": itemID}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, warehouse.ErrItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
 func (r *mongoRepository) GetItemsByReseller(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{"reseller_id": resellerID})
 	if err != nil {
@@ -59,6 +72,17 @@ func (r *mongoRepository) MarkItemAsSkipped(ctx context.Context, itemID string)
 	return err
 }
 
+func (r *mongoRepository) MarkItemAsArrived(ctx context.Context, itemID string, at time.Time) error {
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": itemID, "arrived_at": nil}, bson.M{"$set": bson.M{"arrived_at": at}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return warehouse.ErrAlreadyArrived
+	}
+	return nil
+}
+
 func (r *mongoRepository) DeleteItem(ctx context.Context, itemID string) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": itemID})
 	return err
@@ -67,7 +91,10 @@ func (r *mongoRepository) HasResellerReceivedBundle(ctx context.Context, reselle
 	filter := bson.M{
 		"reseller_id": resellerID,
 		"bundle_id":   bundleID,
-		"status":      bson.M{"$in": []string{"arrived", "listed"}},
+		"$or": bson.A{
+			bson.M{"status": bson.M{"$in": []string{"arrived", "listed"}}},
+			bson.M{"arrived_at": bson.M{"$ne": nil}},
+		},
 	}
 	count, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
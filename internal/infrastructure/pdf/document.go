@@ -0,0 +1,89 @@
+// Package pdf writes simple text-only PDF documents without a third-party
+// library.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page in points, with text set in 10pt Courier on 14pt lines.
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 50
+	leading      = 14
+	linesPerPage = (pageHeight - 2*margin) / leading
+)
+
+// document lays lines of text out top to bottom, starting a new page when
+// one fills up, and returns the PDF file.
+func document(lines []string) []byte {
+	var pages [][]string
+	for len(lines) > linesPerPage {
+		pages = append(pages, lines[:linesPerPage])
+		lines = lines[linesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	// Objects 1-3 are the catalog, the page tree and the font; each page
+	// then takes two more, itself and its content stream.
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	for i, page := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 5+2*i))
+		stream := content(page)
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// content is the page's text drawing operators.
+func content(lines []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "BT\n/F1 10 Tf\n%d TL\n%d %d Td\n", leading, margin, pageHeight-margin)
+	for _, l := range lines {
+		fmt.Fprintf(&sb, "(%s) Tj T*\n", escape(l))
+	}
+	sb.WriteString("ET")
+	return sb.String()
+}
+
+// escape makes s safe inside a PDF string literal. Characters outside
+// printable ASCII are replaced, as the standard fonts cannot show them.
+func escape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r < ' ' || r > '~':
+			sb.WriteByte('?')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,92 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// lineWidth is how many Courier characters fit across the page margins.
+const lineWidth = 80
+
+type invoiceRenderer struct{}
+
+// NewInvoiceRenderer lays invoices out as plain single-font PDFs.
+func NewInvoiceRenderer() invoice.Renderer {
+	return invoiceRenderer{}
+}
+
+func (invoiceRenderer) RenderPDF(inv *invoice.Invoice) ([]byte, error) {
+	var lines []string
+	add := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+	rule := strings.Repeat("-", lineWidth)
+
+	add("AFRO VINTAGE")
+	add("%s %s", strings.ToUpper(string(inv.Kind)), inv.Number)
+	add("")
+	add("Order:      %s", inv.OrderID)
+	add("Order date: %s", inv.OrderDate)
+	add("Issued:     %s", inv.IssuedAt.Format("2006-01-02"))
+	add("")
+	add("%-40s%s", "Seller", "Buyer")
+	add("%-40s%s", clip(inv.Seller.Name, 38), clip(inv.Buyer.Name, 40))
+	add("%-40s%s", clip(inv.Seller.Email, 38), clip(inv.Buyer.Email, 40))
+	if a := inv.ShipTo; a != nil {
+		add("")
+		add("Ship to:")
+		for _, l := range []string{a.FullName, a.Line1, a.Line2, strings.TrimSpace(a.City + " " + a.Region + " " + a.PostalCode), a.Country} {
+			if l != "" {
+				add("  %s", clip(l, lineWidth-2))
+			}
+		}
+	}
+	add("")
+	add("%-46s%6s%14s%14s", "Item", "Qty", "Unit price", "Amount")
+	add(rule)
+	for _, item := range inv.Items {
+		add("%-46s%6d%14.2f%14.2f", clip(item.Description, 44), item.Quantity, item.UnitPrice, item.Amount)
+	}
+	add(rule)
+	total := func(label string, amount float64) {
+		add("%66s%14.2f", label, amount)
+	}
+	total("Subtotal", inv.Subtotal)
+	if inv.Discount > 0 {
+		label := "Discount included"
+		if inv.PromoCode != "" {
+			label += " (" + inv.PromoCode + ")"
+		}
+		total(label, inv.Discount)
+	}
+	for _, l := range inv.TaxLines {
+		label := fmt.Sprintf("Tax %s (%g%%)", l.Rule, l.Rate*100)
+		if l.Inclusive {
+			label = "Incl. " + label
+		}
+		total(clip(label, 64), l.Amount)
+	}
+	total("Total", inv.Total)
+	if c := inv.Charged; c != nil && c.Currency != money.Settlement {
+		total(clip("Charged ("+string(c.Currency)+")", 64), c.Major())
+	}
+	if inv.PaidWithCredit > 0 {
+		total("Paid with store credit", inv.PaidWithCredit)
+	}
+	add("")
+	total("Platform fee", inv.PlatformFee)
+	total("Seller earning", inv.SellerEarning)
+
+	return document(lines), nil
+}
+
+// clip shortens s to at most n characters.
+func clip(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/address"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type AddressController struct {
+	addressUC address.Usecase
+}
+
+func NewAddressController(addressUC address.Usecase) *AddressController {
+	return &AddressController{addressUC: addressUC}
+}
+
+type addressRequest struct {
+	Label      string `json:"label"`
+	FullName   string `json:"full_name"`
+	Phone      string `json:"phone"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+func (r addressRequest) toAddress() *address.Address {
+	return &address.Address{
+		Label:      r.Label,
+		FullName:   r.FullName,
+		Phone:      r.Phone,
+		Line1:      r.Line1,
+		Line2:      r.Line2,
+		City:       r.City,
+		Region:     r.Region,
+		PostalCode: r.PostalCode,
+		Country:    r.Country,
+		IsDefault:  r.IsDefault,
+	}
+}
+
+// GET /addresses
+func (c *AddressController) ListAddresses(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	addresses, err := c.addressUC.ListAddresses(ctx, userID)
+	if err != nil {
+		ctx.JSON(addressErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if addresses == nil {
+		addresses = []*address.Address{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Addresses retrieved successfully",
+		Data:    addresses,
+	})
+}
+
+// POST /addresses
+func (c *AddressController) AddAddress(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req addressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	a, err := c.addressUC.AddAddress(ctx, userID, req.toAddress())
+	if err != nil {
+		ctx.JSON(addressErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Address added successfully",
+		Data:    a,
+	})
+}
+
+// PUT /addresses/:id
+func (c *AddressController) UpdateAddress(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req addressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	a, err := c.addressUC.UpdateAddress(ctx, userID, ctx.Param("id"), req.toAddress())
+	if err != nil {
+		ctx.JSON(addressErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Address updated successfully",
+		Data:    a,
+	})
+}
+
+// DELETE /addresses/:id
+func (c *AddressController) DeleteAddress(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := c.addressUC.DeleteAddress(ctx, userID, ctx.Param("id")); err != nil {
+		ctx.JSON(addressErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Address deleted successfully",
+	})
+}
+
+// POST /addresses/:id/default
+func (c *AddressController) SetDefaultAddress(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	a, err := c.addressUC.SetDefaultAddress(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(addressErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Default address updated",
+		Data:    a,
+	})
+}
+
+func addressErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, address.ErrAddressNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, address.ErrInvalidAddress):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
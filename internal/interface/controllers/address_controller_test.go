@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/address"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockAddressUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAddressUsecase) AddAddress(ctx context.Context, userID string, a *address.Address) (*address.Address, error) {
+	args := m.Called(ctx, userID, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+func (m *MockAddressUsecase) ListAddresses(ctx context.Context, userID string) ([]*address.Address, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*address.Address), args.Error(1)
+}
+
+func (m *MockAddressUsecase) UpdateAddress(ctx context.Context, userID, id string, a *address.Address) (*address.Address, error) {
+	args := m.Called(ctx, userID, id, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+func (m *MockAddressUsecase) DeleteAddress(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAddressUsecase) SetDefaultAddress(ctx context.Context, userID, id string) (*address.Address, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+func (m *MockAddressUsecase) ResolveShippingAddress(ctx context.Context, userID, id string) (*address.Address, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+type AddressControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockAddressUsecase
+	controller *AddressController
+}
+
+func (suite *AddressControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockAddressUsecase)
+	suite.controller = NewAddressController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestAddressControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AddressControllerTestSuite))
+}
+
+func (suite *AddressControllerTestSuite) TestAddAddress_Success() {
+	// Setup
+	saved := &address.Address{ID: "addr1", UserID: "consumer1", Line1: "Bole Road", City: "Addis Ababa", IsDefault: true}
+	suite.usecase.On("AddAddress", mock.Anything, "consumer1", mock.MatchedBy(func(a *address.Address) bool {
+		return a.Line1 == "Bole Road" && a.City == "Addis Ababa"
+	})).Return(saved, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/addresses", strings.NewReader(`{"full_name":"Abebe Kebede","phone":"+251911000000","line1":"Bole Road","city":"Addis Ababa","country":"ET"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "consumer1")
+
+	// Execute
+	suite.controller.AddAddress(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "addr1")
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *AddressControllerTestSuite) TestAddAddress_MissingField() {
+	// Setup
+	suite.usecase.On("AddAddress", mock.Anything, "consumer1", mock.Anything).Return(nil, &address.ValidationError{Field: "city"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/addresses", strings.NewReader(`{"full_name":"Abebe Kebede"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "consumer1")
+
+	// Execute
+	suite.controller.AddAddress(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "city is required")
+}
+
+func (suite *AddressControllerTestSuite) TestListAddresses_Empty() {
+	// Setup
+	suite.usecase.On("ListAddresses", mock.Anything, "consumer1").Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/addresses", nil)
+	c.Set("userID", "consumer1")
+
+	// Execute
+	suite.controller.ListAddresses(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"data":[]`)
+}
+
+func (suite *AddressControllerTestSuite) TestSetDefaultAddress_NotFound() {
+	// Setup
+	suite.usecase.On("SetDefaultAddress", mock.Anything, "consumer1", "addr9").Return(nil, address.ErrAddressNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "addr9"}}
+	c.Request = httptest.NewRequest("POST", "/addresses/addr9/default", nil)
+	c.Set("userID", "consumer1")
+
+	// Execute
+	suite.controller.SetDefaultAddress(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
@@ -75,6 +76,77 @@ func (m *AdminMockOrderUsecase) GetOrderByID(ctx context.Context, orderID string
 	return nil, args.Error(1)
 }
 
+func (m *AdminMockOrderUsecase) CancelOrder(ctx context.Context, orderID, actorID string, role user.Role, reason string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, actorID, role, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *AdminMockOrderUsecase) RefundOrder(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, actorID, role, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *AdminMockOrderUsecase) RecordRefund(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, actorID, role, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *AdminMockOrderUsecase) SettleRefund(ctx context.Context, refundID string) error {
+	args := m.Called(ctx, refundID)
+	return args.Error(0)
+}
+
+func (m *AdminMockOrderUsecase) GetEscrow(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *AdminMockOrderUsecase) DisputeEscrow(ctx context.Context, orderID, resellerID, reason string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, resellerID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *AdminMockOrderUsecase) ResolveEscrow(ctx context.Context, orderID, adminID string, refund bool, note string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, adminID, refund, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *AdminMockOrderUsecase) ConfirmArrival(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *AdminMockOrderUsecase) ReleaseEscrow(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *AdminMockOrderUsecase) ReleaseOnArrival(ctx context.Context, warehouseItemID string) error {
+	args := m.Called(ctx, warehouseItemID)
+	return args.Error(0)
+}
+
 func (m *AdminMockOrderUsecase) GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*order.Order, error) {
 	args := m.Called(ctx, supplierID)
 	return nil, args.Error(1)
@@ -87,6 +159,14 @@ func (m *AdminMockOrderUsecase) GetAdminDashboardMetrics(ctx context.Context) (*
 	}
 	return args.Get(0).(*admin.Metrics), args.Error(1)
 }
+func (m *AdminMockOrderUsecase) GetOrdersToFulfil(ctx context.Context, resellerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
 func (m *AdminMockOrderUsecase) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
 	args := m.Called(ctx, resellerID)
 	if args.Get(0) == nil {
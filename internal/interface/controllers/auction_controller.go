@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auction"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type AuctionController struct {
+	auctionUC auction.Usecase
+}
+
+func NewAuctionController(auctionUC auction.Usecase) *AuctionController {
+	return &AuctionController{auctionUC: auctionUC}
+}
+
+// POST /auctions puts one of the supplier's bundles up for auction. Prices
+// are in whole units of the bundle's listing currency.
+func (c *AuctionController) CreateAuction(ctx *gin.Context) {
+	type Request struct {
+		BundleID         string    `json:"bundle_id"`
+		StartingPrice    float64   `json:"starting_price"`
+		ReservePrice     float64   `json:"reserve_price"`
+		BidIncrement     float64   `json:"bid_increment"`
+		Currency         string    `json:"currency"`
+		EndsAt           time.Time `json:"ends_at"`
+		ExtensionMinutes int       `json:"extension_minutes"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	currency, err := parseListingCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	l := auction.Listing{
+		BundleID:         req.BundleID,
+		StartingPrice:    money.FromMajor(req.StartingPrice, currency),
+		ReservePrice:     money.FromMajor(req.ReservePrice, currency),
+		BidIncrement:     money.FromMajor(req.BidIncrement, currency),
+		EndsAt:           req.EndsAt,
+		ExtensionMinutes: req.ExtensionMinutes,
+	}
+
+	a, err := c.auctionUC.CreateAuction(ctx, ctx.GetString("userID"), l)
+	if err != nil {
+		ctx.JSON(auctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Auction created successfully",
+		Data:    a,
+	})
+}
+
+// GET /auctions?status= lists auctions, ending soonest first.
+func (c *AuctionController) ListAuctions(ctx *gin.Context) {
+	list, err := c.auctionUC.ListAuctions(ctx, auction.Status(ctx.Query("status")))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch auctions"})
+		return
+	}
+	if list == nil {
+		list = []*auction.Auction{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Auctions retrieved successfully",
+		Data:    list,
+	})
+}
+
+// GET /auctions/:id
+func (c *AuctionController) GetAuction(ctx *gin.Context) {
+	a, err := c.auctionUC.GetAuction(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(auctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Auction retrieved successfully",
+		Data:    a,
+	})
+}
+
+// POST /auctions/:id/bids bids on an auction in its currency.
+func (c *AuctionController) PlaceBid(ctx *gin.Context) {
+	type Request struct {
+		Amount float64 `json:"amount"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Amount <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	a, err := c.auctionUC.PlaceBid(ctx, ctx.Param("id"), ctx.GetString("userID"), req.Amount)
+	if err != nil {
+		ctx.JSON(auctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Bid placed successfully",
+		Data:    a,
+	})
+}
+
+// GET /auctions/:id/bids lists an auction's bid history, highest first.
+func (c *AuctionController) ListBids(ctx *gin.Context) {
+	bids, err := c.auctionUC.ListBids(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(auctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if bids == nil {
+		bids = []*auction.Bid{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Bids retrieved successfully",
+		Data:    bids,
+	})
+}
+
+func auctionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, auction.ErrInvalidAuction), errors.Is(err, auction.ErrBidTooLow):
+		return http.StatusBadRequest
+	case errors.Is(err, auction.ErrNotSupplier), errors.Is(err, auction.ErrOwnAuction):
+		return http.StatusForbidden
+	case errors.Is(err, auction.ErrAuctionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, auction.ErrAlreadyAuctioned), errors.Is(err, auction.ErrAuctionEnded), errors.Is(err, auction.ErrAuctionChanged),
+		errors.Is(err, bundle.ErrNotAvailable):
+		return http.StatusConflict
+	default:
+		return orderErrorStatus(err)
+	}
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auction"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockAuctionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAuctionUsecase) CreateAuction(ctx context.Context, supplierID string, l auction.Listing) (*auction.Auction, error) {
+	args := m.Called(ctx, supplierID, l)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auction.Auction), args.Error(1)
+}
+
+func (m *MockAuctionUsecase) GetAuction(ctx context.Context, id string) (*auction.Auction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auction.Auction), args.Error(1)
+}
+
+func (m *MockAuctionUsecase) ListAuctions(ctx context.Context, status auction.Status) ([]*auction.Auction, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auction.Auction), args.Error(1)
+}
+
+func (m *MockAuctionUsecase) PlaceBid(ctx context.Context, id, resellerID string, amount float64) (*auction.Auction, error) {
+	args := m.Called(ctx, id, resellerID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auction.Auction), args.Error(1)
+}
+
+func (m *MockAuctionUsecase) ListBids(ctx context.Context, id string) ([]*auction.Bid, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auction.Bid), args.Error(1)
+}
+
+func (m *MockAuctionUsecase) CloseAuction(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type AuctionControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockAuctionUsecase
+	controller *AuctionController
+}
+
+func (suite *AuctionControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockAuctionUsecase)
+	suite.controller = NewAuctionController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestAuctionControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuctionControllerTestSuite))
+}
+
+func (suite *AuctionControllerTestSuite) TestCreateAuction_BundleSold() {
+	// Setup
+	suite.usecase.On("CreateAuction", mock.Anything, "supplier1", mock.MatchedBy(func(l auction.Listing) bool {
+		return l.BundleID == "bundle1" && l.StartingPrice == money.InSettlement(100) && l.ExtensionMinutes == 5
+	})).Return(nil, bundle.ErrNotAvailable)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/auctions", strings.NewReader(`{"bundle_id":"bundle1","starting_price":100,"bid_increment":10,"ends_at":"2030-01-01T00:00:00Z","extension_minutes":5}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "supplier1")
+
+	// Execute
+	suite.controller.CreateAuction(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *AuctionControllerTestSuite) TestPlaceBid_Success() {
+	// Setup
+	suite.usecase.On("PlaceBid", mock.Anything, "auc1", "reseller1", 150.0).
+		Return(&auction.Auction{ID: "auc1", HighBid: money.InSettlement(150), HighBidderID: "reseller1", ReservePrice: money.InSettlement(140), ReserveMet: true}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "auc1"}}
+	c.Request = httptest.NewRequest("POST", "/auctions/auc1/bids", strings.NewReader(`{"amount":150}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.PlaceBid(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.NotContains(suite.T(), w.Body.String(), "reserve_price")
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *AuctionControllerTestSuite) TestPlaceBid_TooLow() {
+	// Setup
+	suite.usecase.On("PlaceBid", mock.Anything, "auc1", "reseller1", 90.0).Return(nil, auction.ErrBidTooLow)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "auc1"}}
+	c.Request = httptest.NewRequest("POST", "/auctions/auc1/bids", strings.NewReader(`{"amount":90}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.PlaceBid(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *AuctionControllerTestSuite) TestListBids_UnknownAuction() {
+	// Setup
+	suite.usecase.On("ListBids", mock.Anything, "missing").Return(nil, auction.ErrAuctionNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "missing"}}
+	c.Request = httptest.NewRequest("GET", "/auctions/missing/bids", nil)
+
+	// Execute
+	suite.controller.ListBids(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
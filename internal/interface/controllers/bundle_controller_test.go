@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
@@ -52,9 +53,12 @@ func (m *MockBundleUsecase) GetBundlePublicByID(ctx context.Context, bundleID st
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockBundleUsecase) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+func (m *MockBundleUsecase) ListAvailableBundles(ctx context.Context, q bundle.Query) (*bundle.Page, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Page), args.Error(1)
 }
 
 func (m *MockBundleUsecase) DecreaseRemainingItemCount(ctx context.Context, bundleID string) error {
@@ -75,7 +79,7 @@ type BundleControllerTestSuite struct {
 func (suite *BundleControllerTestSuite) SetupTest() {
 	suite.mockBundleUC = new(MockBundleUsecase)
 	suite.mockUserUC = new(MockUserUsecase)
-	suite.controller = NewBundleController(suite.mockBundleUC, suite.mockUserUC)
+	suite.controller = NewBundleController(suite.mockBundleUC, suite.mockUserUC, fixedRates{})
 	gin.SetMode(gin.TestMode)
 	suite.router = gin.Default()
 	suite.supplierID = "supplier123"
@@ -175,7 +179,7 @@ func (suite *BundleControllerTestSuite) TestListBundles_Success() {
 			ID:           "bundle1",
 			Title:        "Test Bundle 1",
 			Grade:        "A",
-			Price:        100.0,
+			Price:        money.InSettlement(100.0),
 			SortingLevel: "basic",
 			Status:       "available",
 		},
@@ -230,12 +234,17 @@ func (suite *BundleControllerTestSuite) TestUpdateBundle_Success() {
 		ID:           bundleID,
 		Title:        "Updated Title",
 		Grade:        "A",
-		Price:        150.0,
+		Price:        money.InSettlement(150.0),
 		SortingLevel: "basic",
 		Status:       "available",
 	}
 
-	suite.mockBundleUC.On("UpdateBundle", mock.Anything, suite.supplierID, bundleID, updates).Return(nil)
+	// The price is stored in the bundle's listing currency.
+	stored := map[string]interface{}{
+		"title": "Updated Title",
+		"price": money.InSettlement(150.0),
+	}
+	suite.mockBundleUC.On("UpdateBundle", mock.Anything, suite.supplierID, bundleID, stored).Return(nil)
 	suite.mockBundleUC.On("GetBundleByID", mock.Anything, suite.supplierID, bundleID).Return(updatedBundle, nil)
 
 	// Execute
@@ -262,7 +271,7 @@ func (suite *BundleControllerTestSuite) TestGetBundle_Success() {
 		ID:           bundleID,
 		Title:        "Test Bundle",
 		Grade:        "A",
-		Price:        100.0,
+		Price:        money.InSettlement(100.0),
 		SortingLevel: "basic",
 		Status:       "available",
 	}
@@ -291,13 +300,13 @@ func (suite *BundleControllerTestSuite) TestListAvailableBundles_Success() {
 			ID:           "bundle1",
 			Title:        "Test Bundle 1",
 			Grade:        "A",
-			Price:        100.0,
+			Price:        money.InSettlement(100.0),
 			SortingLevel: "basic",
 			Status:       "available",
 		},
 	}
 
-	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything).Return(bundles, nil)
+	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything, mock.Anything).Return(&bundle.Page{Bundles: bundles}, nil)
 
 	// Execute
 	w := httptest.NewRecorder()
@@ -310,6 +319,52 @@ func (suite *BundleControllerTestSuite) TestListAvailableBundles_Success() {
 	suite.mockBundleUC.AssertExpectations(suite.T())
 }
 
+func (suite *BundleControllerTestSuite) TestListAvailableBundles_ParsesFilters() {
+	// Setup
+	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything, mock.MatchedBy(func(q bundle.Query) bool {
+		return q.Grade == "A" && q.SortingLevel == bundle.Sorted && *q.MinPrice == 50 && q.MaxPrice == nil &&
+			*q.MinSupplierTrust == 70 && q.Text == "denim" && q.Sort == bundle.SortPrice && q.Desc &&
+			q.Limit == 10 && q.After == "abc"
+	})).Return(&bundle.Page{NextCursor: "def"}, nil)
+
+	// Execute
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/bundles/available?grade=A&sorting_level=sorted&min_price=50&min_trust=70&q=denim&sort=price&order=desc&limit=10&cursor=abc", nil)
+	suite.router.GET("/bundles/available", suite.controller.ListAvailableBundles)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"bundles":[],"next_cursor":"def"}`, w.Body.String())
+	suite.mockBundleUC.AssertExpectations(suite.T())
+}
+
+func (suite *BundleControllerTestSuite) TestListAvailableBundles_BadNumber() {
+	// Execute
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/bundles/available?max_price=cheap", nil)
+	suite.router.GET("/bundles/available", suite.controller.ListAvailableBundles)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockBundleUC.AssertNotCalled(suite.T(), "ListAvailableBundles", mock.Anything, mock.Anything)
+}
+
+func (suite *BundleControllerTestSuite) TestListAvailableBundles_InvalidCursor() {
+	// Setup
+	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything, mock.Anything).Return(nil, bundle.ErrInvalidCursor)
+
+	// Execute
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/bundles/available?cursor=stale", nil)
+	suite.router.GET("/bundles/available", suite.controller.ListAvailableBundles)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
 func TestBundleControllerSuite(t *testing.T) {
 	suite.Run(t, new(BundleControllerTestSuite))
 }
@@ -1,10 +1,14 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
@@ -15,12 +19,14 @@ import (
 type BundleController struct {
 	bundleUsecase bundle.Usecase
 	userUsecase   user.Usecase
+	rates         money.Converter
 }
 
-func NewBundleController(bundleUsecase bundle.Usecase, userUsecase user.Usecase) *BundleController {
+func NewBundleController(bundleUsecase bundle.Usecase, userUsecase user.Usecase, rates money.Converter) *BundleController {
 	return &BundleController{
 		bundleUsecase: bundleUsecase,
 		userUsecase:   userUsecase,
+		rates:         rates,
 	}
 }
 
@@ -68,6 +74,14 @@ func (c *BundleController) CreateBundle(ctx *gin.Context) {
 		})
 		return
 	}
+	currency, err := parseListingCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
 
 	b := &bundle.Bundle{
 		ID:                 "bundle_" + primitive.NewObjectID().Hex(),
@@ -80,11 +94,13 @@ func (c *BundleController) CreateBundle(ctx *gin.Context) {
 		SortingLevel:       bundle.SortingLevel(req.Type),
 		EstimatedBreakdown: req.EstimatedBreakdown,
 		Type:               req.ClothingTypes[0],
-		Price:              req.Price,
+		Price:              money.FromMajor(req.Price, currency),
 		Status:             "available",
 		CreatedAt:          time.Now().Format(time.RFC3339),
 		DeclaredRating:     req.DeclaredRating, // ✅ included here
 		RemainingItemCount: req.NumberOfItems,
+		DateListed:         time.Now(),
+		SupplierTrust:      user.TrustScore,
 	}
 
 	if err := c.bundleUsecase.CreateBundle(ctx, supplierIDStr, b); err != nil {
@@ -95,14 +111,7 @@ func (c *BundleController) CreateBundle(ctx *gin.Context) {
 		return
 	}
 
-	resp := models.BundleResponse{
-		ID:     b.ID,
-		Title:  b.Title,
-		Grade:  b.Grade,
-		Price:  b.Price,
-		Type:   string(b.SortingLevel),
-		Status: b.Status,
-	}
+	resp := newBundleResponse(b)
 
 	ctx.JSON(http.StatusOK, common.APIResponse{
 		Success: true,
@@ -150,14 +159,7 @@ func (c *BundleController) ListBundles(ctx *gin.Context) {
 
 	var resp []models.BundleResponse
 	for _, b := range bundles {
-		resp = append(resp, models.BundleResponse{
-			ID:     b.ID,
-			Title:  b.Title,
-			Grade:  b.Grade,
-			Price:  b.Price,
-			Type:   string(b.SortingLevel),
-			Status: b.Status,
-		})
+		resp = append(resp, newBundleResponse(b))
 	}
 
 	ctx.JSON(http.StatusOK, common.APIResponse{
@@ -269,9 +271,24 @@ func (c *BundleController) UpdateBundle(ctx *gin.Context) {
 		})
 		return
 	}
+	current, err := c.bundleUsecase.GetBundleByID(ctx, supplierIDStr, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err := normalizePrice(updatedData, current.Price.Currency); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
 
 	// Call the use case to update the bundle
-	err := c.bundleUsecase.UpdateBundle(ctx, supplierIDStr, id, updatedData)
+	err = c.bundleUsecase.UpdateBundle(ctx, supplierIDStr, id, updatedData)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, common.APIResponse{
 			Success: false,
@@ -291,14 +308,7 @@ func (c *BundleController) UpdateBundle(ctx *gin.Context) {
 	}
 
 	// Map to response DTO
-	resp := models.BundleResponse{
-		ID:     updatedBundle.ID,
-		Title:  updatedBundle.Title,
-		Grade:  updatedBundle.Grade,
-		Price:  updatedBundle.Price,
-		Type:   string(updatedBundle.SortingLevel),
-		Status: updatedBundle.Status,
-	}
+	resp := newBundleResponse(updatedBundle)
 
 	ctx.JSON(http.StatusOK, common.APIResponse{
 		Success: true,
@@ -358,14 +368,7 @@ func (c *BundleController) GetBundle(ctx *gin.Context) { // Added
 	}
 
 	// Map to response DTO
-	resp := models.BundleResponse{
-		ID:     b.ID,
-		Title:  b.Title,
-		Grade:  b.Grade,
-		Price:  b.Price,
-		Type:   string(b.SortingLevel),
-		Status: b.Status,
-	}
+	resp := newBundleResponse(b)
 
 	ctx.JSON(http.StatusOK, common.APIResponse{
 		Success: true,
@@ -374,13 +377,153 @@ func (c *BundleController) GetBundle(ctx *gin.Context) { // Added
 	})
 }
 
+// GET /bundles/available?grade=&sorting_level=&type=&min_price=&max_price=
+// &min_rating=&max_rating=&min_trust=&q=&sort=&order=&limit=&cursor=
+// searches the bundles on sale. Prices are shown, filtered and sorted in
+// ?currency=, the settlement currency by default.
 func (c *BundleController) ListAvailableBundles(ctx *gin.Context) {
-	bundles, err := c.bundleUsecase.ListAvailableBundles(ctx)
+	q, err := bundleQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	display, err := newPriceDisplay(ctx, c.rates)
+	if err != nil {
+		ctx.JSON(moneyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	q.Currency = display.to
+	if q.Priced() {
+		// Bundles listed in any currency are compared at the current rates.
+		if q.Rates, err = c.rates.Rates(ctx); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	page, err := c.bundleUsecase.ListAvailableBundles(ctx, q)
+	if errors.Is(err, bundle.ErrInvalidQuery) || errors.Is(err, bundle.ErrInvalidCursor) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(http.StatusOK, bundles)
+	resp := models.BundlePageResponse{Bundles: []models.BundleListingResponse{}, NextCursor: page.NextCursor}
+	for _, b := range page.Bundles {
+		price, err := display.show(b.Price)
+		if err != nil {
+			ctx.JSON(moneyErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		resp.Bundles = append(resp.Bundles, models.BundleListingResponse{
+			ID:                 b.ID,
+			SupplierID:         b.SupplierID,
+			Title:              b.Title,
+			Description:        b.Description,
+			SampleImage:        b.SampleImage,
+			Quantity:           b.Quantity,
+			Grade:              b.Grade,
+			SortingLevel:       string(b.SortingLevel),
+			Type:               b.Type,
+			Price:              price.Major(),
+			Currency:           string(price.Currency),
+			Status:             b.Status,
+			ListingMode:        string(b.ListingMode),
+			DeclaredRating:     b.DeclaredRating,
+			RemainingItemCount: b.RemainingItemCount,
+			SupplierTrust:      b.SupplierTrust,
+			DateListed:         b.DateListed,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// newBundleResponse is how a supplier sees one of their bundles, priced as
+// listed.
+func newBundleResponse(b *bundle.Bundle) models.BundleResponse {
+	return models.BundleResponse{
+		ID:       b.ID,
+		Title:    b.Title,
+		Grade:    b.Grade,
+		Price:    b.Price.Major(),
+		Currency: string(b.Price.Currency),
+		Type:     string(b.SortingLevel),
+		Status:   b.Status,
+	}
+}
+
+// bundleQuery reads a bundle search from the query string.
+func bundleQuery(ctx *gin.Context) (bundle.Query, error) {
+	q := bundle.Query{
+		Grade:        ctx.Query("grade"),
+		SortingLevel: bundle.SortingLevel(ctx.Query("sorting_level")),
+		Type:         ctx.Query("type"),
+		Text:         ctx.Query("q"),
+		Sort:         bundle.SortField(ctx.Query("sort")),
+		After:        ctx.Query("cursor"),
+	}
+	switch ctx.Query("order") {
+	case "", "asc":
+	case "desc":
+		q.Desc = true
+	default:
+		return q, fmt.Errorf("%w: order must be asc or desc", bundle.ErrInvalidQuery)
+	}
+	if q.Sort == "" && ctx.Query("order") != "" {
+		// An order on its own applies to the default sort.
+		q.Sort = bundle.SortDateListed
+	}
+
+	var err error
+	if q.MinPrice, err = floatParam(ctx, "min_price"); err != nil {
+		return q, err
+	}
+	if q.MaxPrice, err = floatParam(ctx, "max_price"); err != nil {
+		return q, err
+	}
+	if q.MinRating, err = intParam(ctx, "min_rating"); err != nil {
+		return q, err
+	}
+	if q.MaxRating, err = intParam(ctx, "max_rating"); err != nil {
+		return q, err
+	}
+	if q.MinSupplierTrust, err = intParam(ctx, "min_trust"); err != nil {
+		return q, err
+	}
+	if limit, err := intParam(ctx, "limit"); err != nil {
+		return q, err
+	} else if limit != nil {
+		q.Limit = *limit
+	}
+	return q, nil
+}
+
+// floatParam reads an optional numeric query parameter.
+func floatParam(ctx *gin.Context, name string) (*float64, error) {
+	s := ctx.Query(name)
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", name)
+	}
+	return &v, nil
+}
+
+// intParam reads an optional whole-number query parameter.
+func intParam(ctx *gin.Context, name string) (*int, error) {
+	s := ctx.Query(name)
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a whole number", name)
+	}
+	return &v, nil
 }
 
 func (c *BundleController) GetBundleDetail(ctx *gin.Context) {
@@ -442,12 +585,29 @@ func (c *BundleController) GetBundleDetail(ctx *gin.Context) {
 		return
 	}
 
+	display, err := newPriceDisplay(ctx, c.rates)
+	if err != nil {
+		ctx.JSON(moneyErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	price, err := display.show(bundle.Price)
+	if err != nil {
+		ctx.JSON(moneyErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
 	// Calculate supplier rating from trust score
 	supplierRating := float64(supplier.TrustScore) / 100.0
 
 	// Construct response
 	response := models.BundleDetailResponse{}
-	
+
 	// Fill bundle details
 	response.Bundle.ID = bundle.ID
 	response.Bundle.Title = bundle.Title
@@ -458,7 +618,8 @@ func (c *BundleController) GetBundleDetail(ctx *gin.Context) {
 	response.Bundle.SortingLevel = string(bundle.SortingLevel)
 	response.Bundle.EstimatedBreakdown = bundle.EstimatedBreakdown
 	response.Bundle.Type = bundle.Type
-	response.Bundle.Price = bundle.Price
+	response.Bundle.Price = price.Major()
+	response.Bundle.Currency = string(price.Currency)
 	response.Bundle.Status = bundle.Status
 	response.Bundle.DeclaredRating = bundle.DeclaredRating
 	response.Bundle.RemainingItemCount = bundle.RemainingItemCount
@@ -65,7 +65,8 @@ func (ctr *CartItemController) GetCartItems(c *gin.Context) {
 			ID:        item.ID,
 			ListingID: item.ListingID,
 			Title:     item.Title,
-			Price:     item.Price,
+			Price:     item.Price.Major(),
+			Currency:  string(item.Price.Currency),
 			ImageURL:  item.ImageURL,
 			Grade:     item.Grade,
 			CreatedAt: item.CreatedAt.Format(time.RFC3339),
@@ -105,7 +106,16 @@ func (ctr *CartItemController) CheckoutCart(c *gin.Context) {
 		return
 	}
 
-	resp, err := ctr.usecase.CheckoutCart(c.Request.Context(), userID)
+	// The body is optional; without one the order ships to the default address.
+	var req models.CheckoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+			return
+		}
+	}
+
+	resp, err := ctr.usecase.CheckoutCart(c.Request.Context(), userID, req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -132,7 +142,15 @@ func (ctr *CartItemController) CheckoutSingleItem(c *gin.Context) {
 		return
 	}
 
-	resp, err := ctr.usecase.CheckoutSingleItem(c.Request.Context(), userID, listingID)
+	var req models.CheckoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+			return
+		}
+	}
+
+	resp, err := ctr.usecase.CheckoutSingleItem(c.Request.Context(), userID, listingID, req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
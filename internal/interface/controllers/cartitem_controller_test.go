@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/cartitem"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -38,8 +39,8 @@ func (m *MockCartItemUsecase) RemoveCartItem(ctx context.Context, userID, listin
 }
 
 // Change signature to return *models.CheckoutResponse instead of interface{}
-func (m *MockCartItemUsecase) CheckoutCart(ctx context.Context, userID string) (*models.CheckoutResponse, error) {
-	args := m.Called(ctx, userID)
+func (m *MockCartItemUsecase) CheckoutCart(ctx context.Context, userID string, req models.CheckoutRequest) (*models.CheckoutResponse, error) {
+	args := m.Called(ctx, userID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -47,8 +48,8 @@ func (m *MockCartItemUsecase) CheckoutCart(ctx context.Context, userID string) (
 }
 
 // Change signature to return *models.CheckoutResponse instead of interface{}
-func (m *MockCartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listingID string) (*models.CheckoutResponse, error) {
-	args := m.Called(ctx, userID, listingID)
+func (m *MockCartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listingID string, req models.CheckoutRequest) (*models.CheckoutResponse, error) {
+	args := m.Called(ctx, userID, listingID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -142,7 +143,7 @@ func (suite *CartItemControllerTestSuite) TestGetCartItems_Success() {
 			ID:        "item1",
 			ListingID: "listing1",
 			Title:     "Test Item 1",
-			Price:     100.0,
+			Price:     money.InSettlement(100.0),
 			ImageURL:  "image1.jpg",
 			Grade:     "A",
 			CreatedAt: now,
@@ -229,7 +230,7 @@ func (suite *CartItemControllerTestSuite) TestCheckoutCart_Success() {
 			},
 		},
 	}
-	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID).Return(dummyResp, nil)
+	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID, models.CheckoutRequest{}).Return(dummyResp, nil)
 
 	// Execute
 	w := httptest.NewRecorder()
@@ -252,9 +253,27 @@ func (suite *CartItemControllerTestSuite) TestCheckoutCart_Success() {
 	suite.mockUC.AssertExpectations(suite.T())
 }
 
+func (suite *CartItemControllerTestSuite) TestCheckoutCart_WithOptions() {
+	// Setup
+	dummyResp := &models.CheckoutResponse{TotalAmount: 100.0}
+	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID, models.CheckoutRequest{AddressID: "addr1", PromoCode: "SUMMER10", UseCredit: true}).Return(dummyResp, nil)
+
+	// Execute
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(models.CheckoutRequest{AddressID: "addr1", PromoCode: "SUMMER10", UseCredit: true})
+	req, _ := http.NewRequest("POST", "/api/checkout", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	suite.router.POST("/api/checkout", suite.controller.CheckoutCart)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
 func (suite *CartItemControllerTestSuite) TestCheckoutCart_ValidationError() {
 	// Setup
-	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID).Return(nil, errors.New("some items are unavailable"))
+	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID, models.CheckoutRequest{}).Return(nil, errors.New("some items are unavailable"))
 
 	// Execute
 	w := httptest.NewRecorder()
@@ -300,7 +319,7 @@ func (suite *CartItemControllerTestSuite) TestCheckoutSingleItem_Success() {
 		},
 	}
 	listingID := "listing123"
-	suite.mockUC.On("CheckoutSingleItem", mock.Anything, suite.userID, listingID).Return(dummyResp, nil)
+	suite.mockUC.On("CheckoutSingleItem", mock.Anything, suite.userID, listingID, models.CheckoutRequest{}).Return(dummyResp, nil)
 
 	// Execute
 	w := httptest.NewRecorder()
@@ -5,19 +5,22 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
 	"github.com/gin-gonic/gin"
 )
 
 type ConsumerController struct {
-	orderRepo order.Repository
+	orderRepo    order.Repository
+	productRepo  product.Repository
+	shipmentRepo shipment.Repository
 }
 
-func NewConsumerController(orderRepo order.Repository) *ConsumerController {
-	return &ConsumerController{orderRepo: orderRepo}
+func NewConsumerController(orderRepo order.Repository, productRepo product.Repository, shipmentRepo shipment.Repository) *ConsumerController {
+	return &ConsumerController{orderRepo: orderRepo, productRepo: productRepo, shipmentRepo: shipmentRepo}
 }
 
 func (c *ConsumerController) GetOrderHistory(ctx *gin.Context) {
@@ -43,16 +46,6 @@ func (c *ConsumerController) GetOrderHistory(ctx *gin.Context) {
 		return
 	}
 
-	// Simulate delivery status
-	for i := range orders {
-		createdAt, _ := time.Parse(time.RFC3339, orders[i].CreatedAt)
-		if time.Since(createdAt) > 10*time.Minute && orders[i].Status == order.Pending {
-			orders[i].Status = order.Failed
-		} else if time.Since(createdAt) > 3*time.Minute && orders[i].Status == order.Pending {
-			orders[i].Status = order.Delivered
-		}
-	}
-
 	// Filter by status if provided
 	if status != "" {
 		filteredOrders := []*order.Order{}
@@ -83,20 +76,32 @@ func (c *ConsumerController) GetOrderHistory(ctx *gin.Context) {
 	// Map to response format
 	var response []map[string]interface{}
 	for _, o := range paginatedOrders {
-		itemTitle := ""
+		itemTitle, imageURL := "", ""
 		if len(o.ProductIDs) > 0 {
-			itemTitle = o.ProductIDs[0] // Use the first product ID if available
+			itemTitle = o.ProductIDs[0] // Fall back to the product ID if the product is gone
+			if p, err := c.productRepo.GetProductByID(ctx, o.ProductIDs[0]); err == nil && p != nil {
+				itemTitle, imageURL = p.Title, p.ImageURL
+			}
 		}
 
-		response = append(response, map[string]interface{}{
+		entry := map[string]interface{}{
 			"orderId":               o.ID,
 			"itemTitle":             itemTitle,
 			"price":                 o.TotalPrice,
-			"imageUrl":              "https://example.com/image.jpg", // Placeholder
+			"imageUrl":              imageURL,
 			"status":                o.Status,
+			"statusHistory":         o.History,
 			"purchaseDate":          o.CreatedAt,
-			"estimatedDeliveryTime": "3 minutes",
-		})
+			"estimatedDeliveryTime": nil,
+		}
+		// Orders only get a delivery estimate and tracking once the reseller ships them.
+		if s, err := c.shipmentRepo.GetShipmentByOrder(ctx, o.ID); err == nil && s != nil {
+			entry["estimatedDeliveryTime"] = s.EstimatedDelivery
+			entry["carrier"] = s.Carrier
+			entry["trackingNumber"] = s.TrackingNumber
+			entry["tracking"] = s.Events
+		}
+		response = append(response, entry)
 	}
 
 	if len(response) == 0 {
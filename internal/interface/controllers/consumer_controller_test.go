@@ -10,7 +10,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -42,8 +46,8 @@ func (m *MockOrderRepository) GetOrderByID(ctx context.Context, orderID string)
 	return args.Get(0).(*order.Order), args.Error(1)
 }
 
-func (m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, orderID string, status order.OrderStatus) error {
-	args := m.Called(ctx, orderID, status)
+func (m *MockOrderRepository) TransitionStatus(ctx context.Context, orderID string, t order.StatusTransition) error {
+	args := m.Called(ctx, orderID, t)
 	return args.Error(0)
 }
 
@@ -68,17 +72,125 @@ func (m *MockOrderRepository) GetOrdersByReseller(ctx context.Context, resellerI
 	return args.Get(0).([]*order.Order), args.Error(1)
 }
 
+type ConsumerMockProductRepository struct {
+	mock.Mock
+}
+
+func (m *ConsumerMockProductRepository) AddProduct(ctx context.Context, p *product.Product) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *ConsumerMockProductRepository) GetProductByID(ctx context.Context, id string) (*product.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *ConsumerMockProductRepository) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
+	args := m.Called(ctx, resellerID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *ConsumerMockProductRepository) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *ConsumerMockProductRepository) SearchProducts(ctx context.Context, q *product.Query) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
+func (m *ConsumerMockProductRepository) DeleteProduct(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ConsumerMockProductRepository) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *ConsumerMockProductRepository) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *ConsumerMockProductRepository) Relist(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ConsumerMockProductRepository) Reserve(ctx context.Context, id string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, id, r, now)
+	return args.Error(0)
+}
+
+func (m *ConsumerMockProductRepository) Unreserve(ctx context.Context, id, offerID string) error {
+	args := m.Called(ctx, id, offerID)
+	return args.Error(0)
+}
+
+func (m *ConsumerMockProductRepository) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
+	args := m.Called(ctx, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+type MockShipmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockShipmentRepository) CreateShipment(ctx context.Context, s *shipment.Shipment) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockShipmentRepository) GetShipmentByOrder(ctx context.Context, orderID string) (*shipment.Shipment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipment.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepository) UpdateTracking(ctx context.Context, id string, status shipment.Status, events []shipment.TrackingEvent) error {
+	args := m.Called(ctx, id, status, events)
+	return args.Error(0)
+}
+
 type ConsumerControllerTestSuite struct {
 	suite.Suite
-	mockRepo    *MockOrderRepository
-	controller  *ConsumerController
-	testContext *gin.Context
-	recorder    *httptest.ResponseRecorder
+	mockRepo         *MockOrderRepository
+	mockProductRepo  *ConsumerMockProductRepository
+	mockShipmentRepo *MockShipmentRepository
+	controller       *ConsumerController
+	testContext      *gin.Context
+	recorder         *httptest.ResponseRecorder
 }
 
 func (suite *ConsumerControllerTestSuite) SetupTest() {
 	suite.mockRepo = new(MockOrderRepository)
-	suite.controller = NewConsumerController(suite.mockRepo)
+	suite.mockProductRepo = new(ConsumerMockProductRepository)
+	suite.mockShipmentRepo = new(MockShipmentRepository)
+	suite.controller = NewConsumerController(suite.mockRepo, suite.mockProductRepo, suite.mockShipmentRepo)
+	// Orders in most tests have no product details and have not shipped yet.
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	suite.mockShipmentRepo.On("GetShipmentByOrder", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 
 	// Set up a Gin context and response recorder for testing
 	gin.SetMode(gin.TestMode)
@@ -92,19 +204,41 @@ func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_Success() {
 			ID:         "order1",
 			ConsumerID: "consumer1",
 			ProductIDs: []string{"product1"},
-			TotalPrice: 100.0,
-			Status:     order.Pending,
+			TotalPrice: money.InSettlement(100.0),
+			Status:     order.OrderStatusShipped,
 			CreatedAt:  time.Now().Add(-5 * time.Minute).Format(time.RFC3339),
 		},
 	}
+	eta := time.Now().Add(time.Hour)
 
+	// Replace the default stubs with the shipped order's product and tracking.
+	suite.mockProductRepo = new(ConsumerMockProductRepository)
+	suite.mockShipmentRepo = new(MockShipmentRepository)
+	suite.controller = NewConsumerController(suite.mockRepo, suite.mockProductRepo, suite.mockShipmentRepo)
 	suite.mockRepo.On("GetOrdersByConsumer", mock.Anything, "consumer1").Return(orders, nil)
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, "product1").
+		Return(&product.Product{ID: "product1", Title: "Denim Jacket", ImageURL: "https://cdn.example.com/jacket.jpg"}, nil)
+	suite.mockShipmentRepo.On("GetShipmentByOrder", mock.Anything, "order1").Return(&shipment.Shipment{
+		OrderID:           "order1",
+		Carrier:           "local",
+		TrackingNumber:    "TRK123",
+		EstimatedDelivery: &eta,
+		Events:            []shipment.TrackingEvent{{Status: shipment.StatusInTransit, Description: "Picked up by courier"}},
+	}, nil)
 
 	suite.testContext.Set("userID", "consumer1")
 	suite.controller.GetOrderHistory(suite.testContext)
 
 	assert.Equal(suite.T(), http.StatusOK, suite.recorder.Code)
+	body := suite.recorder.Body.String()
+	assert.Contains(suite.T(), body, "Denim Jacket")
+	assert.Contains(suite.T(), body, "https://cdn.example.com/jacket.jpg")
+	assert.Contains(suite.T(), body, "TRK123")
+	assert.Contains(suite.T(), body, "Picked up by courier")
+	assert.NotContains(suite.T(), body, "3 minutes")
 	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockProductRepo.AssertExpectations(suite.T())
+	suite.mockShipmentRepo.AssertExpectations(suite.T())
 }
 
 func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_NoOrders() {
@@ -129,25 +263,36 @@ func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_ErrorFetchingOrder
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
-func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_DeliveryFailure() {
-	orders := []*order.Order{
-		{
-			ID:         "order1",
-			ConsumerID: "consumer1",
-			ProductIDs: []string{"product1"},
-			TotalPrice: 100.0,
-			Status:     order.Pending,
-			CreatedAt:  time.Now().Add(-11 * time.Minute).Format(time.RFC3339), // Simulate long delay
-		},
+func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_ReportsStoredStatus() {
+	failed := &order.Order{
+		ID:         "order1",
+		ConsumerID: "consumer1",
+		ProductIDs: []string{"product1"},
+		TotalPrice: money.InSettlement(100.0),
+		CreatedAt:  time.Now().Add(-11 * time.Minute).Format(time.RFC3339),
+	}
+	failed.Place(order.OrderStatusPending, "consumer1")
+	t, _ := failed.Transition(order.OrderStatusFailed, order.SystemActor, "carrier lost the parcel")
+	failed.Apply(t)
+
+	// An old pending order is no longer reported as failed or delivered based on its age.
+	pending := &order.Order{
+		ID:         "order2",
+		ConsumerID: "consumer1",
+		Status:     order.OrderStatusPending,
+		CreatedAt:  time.Now().Add(-11 * time.Minute).Format(time.RFC3339),
 	}
 
-	suite.mockRepo.On("GetOrdersByConsumer", mock.Anything, "consumer1").Return(orders, nil)
+	suite.mockRepo.On("GetOrdersByConsumer", mock.Anything, "consumer1").Return([]*order.Order{failed, pending}, nil)
 
 	suite.testContext.Set("userID", "consumer1")
+	suite.testContext.Request = httptest.NewRequest(http.MethodGet, "/orders/history?status=failed", nil)
 	suite.controller.GetOrderHistory(suite.testContext)
 
 	assert.Equal(suite.T(), http.StatusOK, suite.recorder.Code)
-	assert.Contains(suite.T(), suite.recorder.Body.String(), "failed") // Check for failure state
+	assert.Contains(suite.T(), suite.recorder.Body.String(), "order1")
+	assert.Contains(suite.T(), suite.recorder.Body.String(), "carrier lost the parcel")
+	assert.NotContains(suite.T(), suite.recorder.Body.String(), "order2")
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
@@ -158,8 +303,8 @@ func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_Pagination() {
 			ID:         fmt.Sprintf("order%d", i),
 			ConsumerID: "consumer1",
 			ProductIDs: []string{fmt.Sprintf("product%d", i)},
-			TotalPrice: float64(i * 10),
-			Status:     order.Pending,
+			TotalPrice: money.InSettlement(float64(i * 10)),
+			Status:     order.OrderStatusPending,
 			CreatedAt:  time.Now().Add(-time.Duration(i) * time.Minute).Format(time.RFC3339),
 		})
 	}
@@ -180,15 +325,15 @@ func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_Pagination() {
 
 func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_FilterByStatus() {
 	orders := []*order.Order{
-		{ID: "order1", ConsumerID: "consumer1", Status: order.Pending}, // Status matches filter
-		{ID: "order2", ConsumerID: "consumer1", Status: order.Failed},  // Different status
-		{ID: "order3", ConsumerID: "consumer1", Status: order.Pending}, // Status matches filter
+		{ID: "order1", ConsumerID: "consumer1", Status: order.OrderStatusPending}, // Status matches filter
+		{ID: "order2", ConsumerID: "consumer1", Status: order.OrderStatusFailed},  // Different status
+		{ID: "order3", ConsumerID: "consumer1", Status: order.OrderStatusPending}, // Status matches filter
 	}
 
 	// Simulate delivery logic only for orders that should match the filter
 	for i := range orders {
-		if orders[i].ID == "order1" && orders[i].Status == order.Pending {
-			orders[i].Status = order.Delivered // Simulate status transition for order1 only
+		if orders[i].ID == "order1" && orders[i].Status == order.OrderStatusPending {
+			orders[i].Status = order.OrderStatusDelivered // Simulate status transition for order1 only
 		}
 	}
 
@@ -231,8 +376,8 @@ func (suite *ConsumerControllerTestSuite) TestGetOrderHistory_DefaultQueryParame
 			ID:         fmt.Sprintf("order%d", i),
 			ConsumerID: "consumer1",
 			ProductIDs: []string{fmt.Sprintf("product%d", i)},
-			TotalPrice: float64(i * 10),
-			Status:     order.Pending,
+			TotalPrice: money.InSettlement(float64(i * 10)),
+			Status:     order.OrderStatusPending,
 			CreatedAt:  time.Now().Add(-time.Duration(i) * time.Minute).Format(time.RFC3339),
 		})
 	}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type CreditController struct {
+	creditUC credit.Usecase
+}
+
+func NewCreditController(creditUC credit.Usecase) *CreditController {
+	return &CreditController{creditUC: creditUC}
+}
+
+// GET /credit shows the consumer's store credit balance and history.
+func (c *CreditController) GetMyCredit(ctx *gin.Context) {
+	c.respondHistory(ctx, ctx.GetString("userID"))
+}
+
+// POST /credit/gift-cards/redeem adds a gift card to the consumer's credit.
+func (c *CreditController) RedeemGiftCard(ctx *gin.Context) {
+	type Request struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload; code is required"})
+		return
+	}
+
+	entry, err := c.creditUC.RedeemGiftCard(ctx, ctx.GetString("userID"), req.Code)
+	if err != nil {
+		ctx.JSON(creditErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Gift card redeemed successfully",
+		Data:    entry,
+	})
+}
+
+// GET /admin/credit/:userId shows a consumer's full credit history.
+func (c *CreditController) GetUserCredit(ctx *gin.Context) {
+	c.respondHistory(ctx, ctx.Param("userId"))
+}
+
+// POST /admin/credit/:userId grants a consumer store credit.
+func (c *CreditController) IssueCredit(ctx *gin.Context) {
+	type Request struct {
+		Amount float64 `json:"amount"`
+		Reason string  `json:"reason"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	entry, err := c.creditUC.IssueCredit(ctx, ctx.GetString("userID"), ctx.Param("userId"), req.Amount, req.Reason)
+	if err != nil {
+		ctx.JSON(creditErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Store credit issued successfully",
+		Data:    entry,
+	})
+}
+
+// POST /admin/gift-cards issues a gift card with a new code.
+func (c *CreditController) IssueGiftCard(ctx *gin.Context) {
+	type Request struct {
+		Amount    float64    `json:"amount"`
+		Note      string     `json:"note"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	g, err := c.creditUC.IssueGiftCard(ctx, ctx.GetString("userID"), req.Amount, req.Note, req.ExpiresAt)
+	if err != nil {
+		ctx.JSON(creditErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Gift card issued successfully",
+		Data:    g,
+	})
+}
+
+// GET /admin/gift-cards
+func (c *CreditController) ListGiftCards(ctx *gin.Context) {
+	cards, err := c.creditUC.ListGiftCards(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch gift cards"})
+		return
+	}
+	if cards == nil {
+		cards = []*credit.GiftCard{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Gift cards retrieved successfully",
+		Data:    cards,
+	})
+}
+
+func (c *CreditController) respondHistory(ctx *gin.Context, userID string) {
+	h, err := c.creditUC.GetHistory(ctx, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch store credit"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Store credit retrieved successfully",
+		Data:    h,
+	})
+}
+
+func creditErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, credit.ErrInvalidAmount), errors.Is(err, credit.ErrReasonRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, credit.ErrConsumerNotFound), errors.Is(err, credit.ErrGiftCardNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, credit.ErrGiftCardRedeemed), errors.Is(err, credit.ErrGiftCardExpired):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
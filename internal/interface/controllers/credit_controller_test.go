@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockCreditUsecase struct {
+	mock.Mock
+}
+
+func (m *MockCreditUsecase) Spend(ctx context.Context, userID string, amount float64, orderIDs []string) (float64, error) {
+	args := m.Called(ctx, userID, amount, orderIDs)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCreditUsecase) Restore(ctx context.Context, userID string, amount float64, t credit.EntryType, orderIDs []string) error {
+	args := m.Called(ctx, userID, amount, t, orderIDs)
+	return args.Error(0)
+}
+
+func (m *MockCreditUsecase) GetHistory(ctx context.Context, userID string) (*credit.History, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credit.History), args.Error(1)
+}
+
+func (m *MockCreditUsecase) IssueCredit(ctx context.Context, adminID, userID string, amount float64, reason string) (*credit.Entry, error) {
+	args := m.Called(ctx, adminID, userID, amount, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credit.Entry), args.Error(1)
+}
+
+func (m *MockCreditUsecase) IssueGiftCard(ctx context.Context, adminID string, amount float64, note string, expiresAt *time.Time) (*credit.GiftCard, error) {
+	args := m.Called(ctx, adminID, amount, note, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credit.GiftCard), args.Error(1)
+}
+
+func (m *MockCreditUsecase) ListGiftCards(ctx context.Context) ([]*credit.GiftCard, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*credit.GiftCard), args.Error(1)
+}
+
+func (m *MockCreditUsecase) RedeemGiftCard(ctx context.Context, userID, code string) (*credit.Entry, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credit.Entry), args.Error(1)
+}
+
+type CreditControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockCreditUsecase
+	controller *CreditController
+}
+
+func (suite *CreditControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockCreditUsecase)
+	suite.controller = NewCreditController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestCreditControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(CreditControllerTestSuite))
+}
+
+func (suite *CreditControllerTestSuite) TestIssueCredit_Success() {
+	// Setup
+	suite.usecase.On("IssueCredit", mock.Anything, "admin1", "consumer1", 15.0, "late delivery").
+		Return(&credit.Entry{ID: "e1", Type: credit.EntryIssued, Amount: money.InSettlement(15)}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "userId", Value: "consumer1"}}
+	c.Request = httptest.NewRequest("POST", "/admin/credit/consumer1", strings.NewReader(`{"amount":15,"reason":"late delivery"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.IssueCredit(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *CreditControllerTestSuite) TestIssueCredit_MissingReason() {
+	// Setup
+	suite.usecase.On("IssueCredit", mock.Anything, "admin1", "consumer1", 15.0, "").Return(nil, credit.ErrReasonRequired)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "userId", Value: "consumer1"}}
+	c.Request = httptest.NewRequest("POST", "/admin/credit/consumer1", strings.NewReader(`{"amount":15}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.IssueCredit(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *CreditControllerTestSuite) TestRedeemGiftCard_AlreadyRedeemed() {
+	// Setup
+	suite.usecase.On("RedeemGiftCard", mock.Anything, "consumer1", "ABCD-EFGH").Return(nil, credit.ErrGiftCardRedeemed)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/credit/gift-cards/redeem", strings.NewReader(`{"code":"ABCD-EFGH"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "consumer1")
+
+	// Execute
+	suite.controller.RedeemGiftCard(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *CreditControllerTestSuite) TestGetUserCredit() {
+	// Setup
+	suite.usecase.On("GetHistory", mock.Anything, "consumer1").
+		Return(&credit.History{UserID: "consumer1", Balance: money.InSettlement(15), Entries: []*credit.Entry{{ID: "e1", Amount: money.InSettlement(15), Reason: "late delivery"}}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "userId", Value: "consumer1"}}
+	c.Request = httptest.NewRequest("GET", "/admin/credit/consumer1", nil)
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.GetUserCredit(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"reason":"late delivery"`)
+}
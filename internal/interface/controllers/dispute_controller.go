@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type DisputeController struct {
+	disputeUC dispute.Usecase
+}
+
+func NewDisputeController(disputeUC dispute.Usecase) *DisputeController {
+	return &DisputeController{disputeUC: disputeUC}
+}
+
+// messageRequest is the body of a dispute message.
+type messageRequest struct {
+	Message     string               `json:"message"`
+	Attachments []dispute.Attachment `json:"attachments"`
+}
+
+func (r messageRequest) toMessage() dispute.Message {
+	return dispute.Message{Body: r.Message, Attachments: r.Attachments}
+}
+
+// POST /disputes opens a dispute against one of the caller's orders.
+func (c *DisputeController) OpenDispute(ctx *gin.Context) {
+	type Request struct {
+		OrderID string         `json:"order_id"`
+		Reason  dispute.Reason `json:"reason"`
+		messageRequest
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.OrderID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	d, err := c.disputeUC.OpenDispute(ctx, req.OrderID, ctx.GetString("userID"), req.Reason, req.toMessage())
+	if err != nil {
+		ctx.JSON(disputeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Dispute opened successfully",
+		Data:    d,
+	})
+}
+
+// GET /disputes lists the disputes the caller is buyer or seller in.
+func (c *DisputeController) ListMyDisputes(ctx *gin.Context) {
+	disputes, err := c.disputeUC.ListMyDisputes(ctx, ctx.GetString("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch disputes"})
+		return
+	}
+	if disputes == nil {
+		disputes = []*dispute.Dispute{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Disputes retrieved successfully",
+		Data:    disputes,
+	})
+}
+
+// GET /disputes/:id
+func (c *DisputeController) GetDispute(ctx *gin.Context) {
+	d, err := c.disputeUC.GetDispute(ctx, ctx.Param("id"), ctx.GetString("userID"), user.Role(ctx.GetString("role")))
+	if err != nil {
+		ctx.JSON(disputeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Dispute retrieved successfully",
+		Data:    d,
+	})
+}
+
+// POST /disputes/:id/messages adds a statement or evidence to a dispute.
+func (c *DisputeController) AddMessage(ctx *gin.Context) {
+	var req messageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	d, err := c.disputeUC.AddMessage(ctx, ctx.Param("id"), ctx.GetString("userID"), user.Role(ctx.GetString("role")), req.toMessage())
+	if err != nil {
+		ctx.JSON(disputeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Message added successfully",
+		Data:    d,
+	})
+}
+
+// GET /admin/disputes?status=
+func (c *DisputeController) ListDisputes(ctx *gin.Context) {
+	disputes, err := c.disputeUC.ListDisputes(ctx, dispute.Status(ctx.Query("status")))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch disputes"})
+		return
+	}
+	if disputes == nil {
+		disputes = []*dispute.Dispute{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Disputes retrieved successfully",
+		Data:    disputes,
+	})
+}
+
+// PUT /admin/disputes/:id/status asks the seller for their side or takes the
+// dispute under review.
+func (c *DisputeController) UpdateStatus(ctx *gin.Context) {
+	type Request struct {
+		Status dispute.Status `json:"status"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	d, err := c.disputeUC.UpdateStatus(ctx, ctx.Param("id"), ctx.GetString("userID"), req.Status)
+	if err != nil {
+		ctx.JSON(disputeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Dispute status updated successfully",
+		Data:    d,
+	})
+}
+
+// POST /admin/disputes/:id/resolve decides a dispute. Deciding for the buyer
+// refunds amount, or everything still refundable when it is left out.
+func (c *DisputeController) ResolveDispute(ctx *gin.Context) {
+	type Request struct {
+		Outcome string  `json:"outcome"` // buyer or seller
+		Amount  float64 `json:"amount"`
+		Note    string  `json:"note"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if req.Outcome != "buyer" && req.Outcome != "seller" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "outcome must be buyer or seller"})
+		return
+	}
+	if req.Amount < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": order.ErrInvalidRefundAmount.Error()})
+		return
+	}
+
+	d, err := c.disputeUC.Resolve(ctx, ctx.Param("id"), ctx.GetString("userID"), req.Outcome == "buyer", req.Amount, req.Note)
+	if err != nil {
+		ctx.JSON(disputeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Dispute resolved successfully",
+		Data:    d,
+	})
+}
+
+// disputeErrorStatus maps dispute failures, and the order and escrow failures
+// behind a resolution, to client errors.
+func disputeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dispute.ErrInvalidReason), errors.Is(err, dispute.ErrEmptyMessage), errors.Is(err, dispute.ErrInvalidAttachment):
+		return http.StatusBadRequest
+	case errors.Is(err, dispute.ErrDisputeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dispute.ErrDisputeExists), errors.Is(err, dispute.ErrCannotDispute), errors.Is(err, dispute.ErrInvalidTransition),
+		errors.Is(err, dispute.ErrDisputeResolved), errors.Is(err, dispute.ErrDisputeChanged):
+		return http.StatusConflict
+	default:
+		return orderErrorStatus(err)
+	}
+}
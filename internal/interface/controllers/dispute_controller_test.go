@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockDisputeUsecase struct {
+	mock.Mock
+}
+
+func (m *MockDisputeUsecase) OpenDispute(ctx context.Context, orderID, buyerID string, reason dispute.Reason, first dispute.Message) (*dispute.Dispute, error) {
+	args := m.Called(ctx, orderID, buyerID, reason, first)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) GetDispute(ctx context.Context, id, actorID string, role user.Role) (*dispute.Dispute, error) {
+	args := m.Called(ctx, id, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) ListMyDisputes(ctx context.Context, userID string) ([]*dispute.Dispute, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) AddMessage(ctx context.Context, id, actorID string, role user.Role, msg dispute.Message) (*dispute.Dispute, error) {
+	args := m.Called(ctx, id, actorID, role, msg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) ListDisputes(ctx context.Context, status dispute.Status) ([]*dispute.Dispute, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) UpdateStatus(ctx context.Context, id, adminID string, status dispute.Status) (*dispute.Dispute, error) {
+	args := m.Called(ctx, id, adminID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) Resolve(ctx context.Context, id, adminID string, forBuyer bool, amount float64, note string) (*dispute.Dispute, error) {
+	args := m.Called(ctx, id, adminID, forBuyer, amount, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+type DisputeControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockDisputeUsecase
+	controller *DisputeController
+}
+
+func (suite *DisputeControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockDisputeUsecase)
+	suite.controller = NewDisputeController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestDisputeControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(DisputeControllerTestSuite))
+}
+
+func (suite *DisputeControllerTestSuite) TestOpenDispute_Success() {
+	// Setup
+	suite.usecase.On("OpenDispute", mock.Anything, "order1", "consumer1", dispute.ReasonNotReceived, mock.MatchedBy(func(m dispute.Message) bool {
+		return m.Body == "Never arrived" && len(m.Attachments) == 1
+	})).Return(&dispute.Dispute{ID: "d1", Status: dispute.StatusOpen}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/disputes", strings.NewReader(`{"order_id":"order1","reason":"not_received","message":"Never arrived","attachments":[{"name":"tracking.png","url":"https://img.example.com/t.png"}]}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "consumer1")
+
+	// Execute
+	suite.controller.OpenDispute(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *DisputeControllerTestSuite) TestOpenDispute_NotOrderParty() {
+	// Setup
+	suite.usecase.On("OpenDispute", mock.Anything, "order1", "consumer2", dispute.ReasonNotReceived, mock.Anything).Return(nil, order.ErrNotOrderParty)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/disputes", strings.NewReader(`{"order_id":"order1","reason":"not_received","message":"Never arrived"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "consumer2")
+
+	// Execute
+	suite.controller.OpenDispute(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *DisputeControllerTestSuite) TestAddMessage_Resolved() {
+	// Setup
+	suite.usecase.On("AddMessage", mock.Anything, "d1", "reseller1", user.RoleReseller, mock.Anything).Return(nil, dispute.ErrDisputeResolved)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "d1"}}
+	c.Request = httptest.NewRequest("POST", "/disputes/d1/messages", strings.NewReader(`{"message":"One more thing"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+	c.Set("role", "reseller")
+
+	// Execute
+	suite.controller.AddMessage(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *DisputeControllerTestSuite) TestListDisputes_ByStatus() {
+	// Setup
+	suite.usecase.On("ListDisputes", mock.Anything, dispute.StatusUnderReview).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/admin/disputes?status=under_review", nil)
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.ListDisputes(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"data":[]`)
+}
+
+func (suite *DisputeControllerTestSuite) TestResolveDispute_ForBuyer() {
+	// Setup
+	suite.usecase.On("Resolve", mock.Anything, "d1", "admin1", true, 25.0, "item damaged").
+		Return(&dispute.Dispute{ID: "d1", Status: dispute.StatusResolvedBuyer}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "d1"}}
+	c.Request = httptest.NewRequest("POST", "/admin/disputes/d1/resolve", strings.NewReader(`{"outcome":"buyer","amount":25,"note":"item damaged"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.ResolveDispute(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *DisputeControllerTestSuite) TestResolveDispute_InvalidOutcome() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "d1"}}
+	c.Request = httptest.NewRequest("POST", "/admin/disputes/d1/resolve", strings.NewReader(`{"outcome":"split"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.ResolveDispute(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "Resolve", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
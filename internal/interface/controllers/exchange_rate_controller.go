@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeRateController struct {
+	moneyUC money.Usecase
+}
+
+func NewExchangeRateController(moneyUC money.Usecase) *ExchangeRateController {
+	return &ExchangeRateController{moneyUC: moneyUC}
+}
+
+// GET /exchange-rates lists what each currency is worth in the settlement currency.
+func (c *ExchangeRateController) ListRates(ctx *gin.Context) {
+	rates, err := c.moneyUC.ListRates(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch exchange rates"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Exchange rates retrieved successfully",
+		Data:    rates,
+	})
+}
+
+// PUT /admin/exchange-rates/:currency sets a currency's rate.
+func (c *ExchangeRateController) SetRate(ctx *gin.Context) {
+	type Request struct {
+		Rate float64 `json:"rate" binding:"required"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload; rate is required"})
+		return
+	}
+	cur, err := money.ParseCurrency(ctx.Param("currency"))
+	if err != nil {
+		ctx.JSON(moneyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := c.moneyUC.SetRate(ctx, ctx.GetString("userID"), cur, req.Rate)
+	if err != nil {
+		ctx.JSON(moneyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Exchange rate updated",
+		Data:    rate,
+	})
+}
+
+func moneyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, money.ErrUnsupportedCurrency), errors.Is(err, money.ErrInvalidRate), errors.Is(err, money.ErrSettlementRate):
+		return http.StatusBadRequest
+	case errors.Is(err, money.ErrNoRate):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// priceDisplay shows list prices in the currency a listing request asks for
+// with ?currency=, or as listed if it asks for none.
+type priceDisplay struct {
+	to    money.Currency
+	rates money.Rates
+}
+
+func newPriceDisplay(ctx *gin.Context, rates money.Converter) (*priceDisplay, error) {
+	d := &priceDisplay{}
+	q := ctx.Query("currency")
+	if q == "" {
+		return d, nil
+	}
+	to, err := money.ParseCurrency(q)
+	if err != nil {
+		return nil, err
+	}
+	table, err := rates.Rates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.to, d.rates = to, table
+	return d, nil
+}
+
+func (d *priceDisplay) show(price money.Money) (money.Money, error) {
+	if d.to == "" {
+		return price, nil
+	}
+	return d.rates.Convert(price, d.to)
+}
+
+// parseListingCurrency reads the currency a new listing is priced in,
+// defaulting to the settlement currency.
+func parseListingCurrency(s string) (money.Currency, error) {
+	if s == "" {
+		return money.Settlement, nil
+	}
+	return money.ParseCurrency(s)
+}
+
+// normalizePrice turns the price and currency of a listing's partial update
+// into the stored price. A price without a currency stays in current, the
+// currency the listing is priced in; a currency needs a price to go with it.
+func normalizePrice(updates map[string]interface{}, current money.Currency) error {
+	v, hasPrice := updates["price"]
+	c, hasCurrency := updates["currency"]
+	delete(updates, "currency")
+	if !hasPrice {
+		if hasCurrency {
+			return errors.New("currency can only be changed together with price")
+		}
+		return nil
+	}
+	price, ok := v.(float64)
+	if !ok {
+		return errors.New("price must be a number")
+	}
+	if hasCurrency {
+		s, _ := c.(string)
+		parsed, err := money.ParseCurrency(s)
+		if err != nil {
+			return err
+		}
+		current = parsed
+	}
+	updates["price"] = money.FromMajor(price, current)
+	return nil
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockMoneyUsecase struct {
+	mock.Mock
+}
+
+func (m *MockMoneyUsecase) Rates(ctx context.Context) (money.Rates, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(money.Rates), args.Error(1)
+}
+
+func (m *MockMoneyUsecase) ListRates(ctx context.Context) ([]*money.Rate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*money.Rate), args.Error(1)
+}
+
+func (m *MockMoneyUsecase) SetRate(ctx context.Context, adminID string, c money.Currency, rate float64) (*money.Rate, error) {
+	args := m.Called(ctx, adminID, c, rate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*money.Rate), args.Error(1)
+}
+
+// fixedRates is an exchange-rate table that never changes.
+type fixedRates money.Rates
+
+func (r fixedRates) Rates(ctx context.Context) (money.Rates, error) {
+	return money.Rates(r), nil
+}
+
+type ExchangeRateControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockMoneyUsecase
+	controller *ExchangeRateController
+}
+
+func (suite *ExchangeRateControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockMoneyUsecase)
+	suite.controller = NewExchangeRateController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestExchangeRateControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateControllerTestSuite))
+}
+
+func (suite *ExchangeRateControllerTestSuite) TestSetRate_Success() {
+	// Setup
+	suite.usecase.On("SetRate", mock.Anything, "admin1", money.USD, 57.5).
+		Return(&money.Rate{Currency: money.USD, Rate: 57.5, UpdatedBy: "admin1"}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "currency", Value: "usd"}}
+	c.Request = httptest.NewRequest("PUT", "/admin/exchange-rates/usd", strings.NewReader(`{"rate":57.5}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.SetRate(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ExchangeRateControllerTestSuite) TestSetRate_Rejected() {
+	tests := []struct {
+		name           string
+		currency       string
+		err            error
+		expectedStatus int
+	}{
+		{"unsupported currency", "EUR", nil, http.StatusBadRequest},
+		{"settlement currency", "ETB", money.ErrSettlementRate, http.StatusBadRequest},
+		{"non-positive rate", "USD", money.ErrInvalidRate, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.SetupTest()
+			suite.usecase.On("SetRate", mock.Anything, "admin1", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{gin.Param{Key: "currency", Value: tt.currency}}
+			c.Request = httptest.NewRequest("PUT", "/admin/exchange-rates/"+tt.currency, strings.NewReader(`{"rate":-1}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", "admin1")
+
+			suite.controller.SetRate(c)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type FeeController struct {
+	feeUC fee.Usecase
+}
+
+func NewFeeController(feeUC fee.Usecase) *FeeController {
+	return &FeeController{feeUC: feeUC}
+}
+
+// GET /admin/fees
+func (c *FeeController) GetPolicy(ctx *gin.Context) {
+	p, err := c.feeUC.GetPolicy(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch fee policy"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Fee policy retrieved successfully",
+		Data:    p,
+	})
+}
+
+// PUT /admin/fees replaces the whole policy with a new version.
+func (c *FeeController) UpdatePolicy(ctx *gin.Context) {
+	type Request struct {
+		B2B       fee.Rate       `json:"b2b"`
+		B2C       fee.Rate       `json:"b2c"`
+		Overrides []fee.Override `json:"overrides"`
+		Note      string         `json:"note" binding:"required"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload; a note explaining the change is required"})
+		return
+	}
+
+	p, err := c.feeUC.UpdatePolicy(ctx, ctx.GetString("userID"), &fee.Policy{
+		B2B:       req.B2B,
+		B2C:       req.B2C,
+		Overrides: req.Overrides,
+		Note:      req.Note,
+	})
+	if err != nil {
+		ctx.JSON(feeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Fee policy updated",
+		Data:    p,
+	})
+}
+
+// GET /admin/fees/history
+func (c *FeeController) GetPolicyHistory(ctx *gin.Context) {
+	policies, err := c.feeUC.GetPolicyHistory(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch fee policy history"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Fee policy history retrieved successfully",
+		Data:    policies,
+	})
+}
+
+func feeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, fee.ErrInvalidPolicy):
+		return http.StatusBadRequest
+	case errors.Is(err, fee.ErrPolicyConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
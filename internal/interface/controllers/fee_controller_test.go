@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockFeeUsecase struct {
+	mock.Mock
+}
+
+func (m *MockFeeUsecase) Quote(ctx context.Context, s fee.Sale) (fee.Quote, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).(fee.Quote), args.Error(1)
+}
+
+func (m *MockFeeUsecase) GetPolicy(ctx context.Context) (*fee.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Policy), args.Error(1)
+}
+
+func (m *MockFeeUsecase) UpdatePolicy(ctx context.Context, adminID string, p *fee.Policy) (*fee.Policy, error) {
+	args := m.Called(ctx, adminID, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Policy), args.Error(1)
+}
+
+func (m *MockFeeUsecase) GetPolicyHistory(ctx context.Context) ([]*fee.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fee.Policy), args.Error(1)
+}
+
+type FeeControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockFeeUsecase
+	controller *FeeController
+}
+
+func (suite *FeeControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockFeeUsecase)
+	suite.controller = NewFeeController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestFeeControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(FeeControllerTestSuite))
+}
+
+func (suite *FeeControllerTestSuite) TestUpdatePolicy_Success() {
+	// Setup
+	saved := &fee.Policy{Version: 2, B2B: fee.Rate{Rate: 0.03}, B2C: fee.Rate{Rate: 0.02}, Note: "raise b2b"}
+	suite.usecase.On("UpdatePolicy", mock.Anything, "admin1", mock.MatchedBy(func(p *fee.Policy) bool {
+		return p.B2B.Rate == 0.03 && len(p.Overrides) == 1 && p.Overrides[0].Category == "jacket" && p.Overrides[0].Rate.Rate == 0.05
+	})).Return(saved, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/admin/fees", strings.NewReader(`{"b2b":{"rate":0.03},"b2c":{"rate":0.02},"overrides":[{"name":"jackets","category":"jacket","rate":0.05}],"note":"raise b2b"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.UpdatePolicy(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"version":2`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *FeeControllerTestSuite) TestUpdatePolicy_NoteRequired() {
+	// Setup
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/admin/fees", strings.NewReader(`{"b2b":{"rate":0.03}}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.UpdatePolicy(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "UpdatePolicy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *FeeControllerTestSuite) TestUpdatePolicy_Invalid() {
+	// Setup
+	suite.usecase.On("UpdatePolicy", mock.Anything, "admin1", mock.Anything).
+		Return(nil, fmt.Errorf("%w: b2c rate must be at least 0 and below 1", fee.ErrInvalidPolicy))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/admin/fees", strings.NewReader(`{"b2c":{"rate":2},"note":"oops"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.UpdatePolicy(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *FeeControllerTestSuite) TestGetPolicyHistory() {
+	// Setup
+	history := []*fee.Policy{{Version: 2, Note: "raise b2b"}, {Version: 1, Note: "initial"}}
+	suite.usecase.On("GetPolicyHistory", mock.Anything).Return(history, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/admin/fees/history", nil)
+
+	// Execute
+	suite.controller.GetPolicyHistory(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "raise b2b")
+	suite.usecase.AssertExpectations(suite.T())
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type InvoiceController struct {
+	invoiceUC invoice.Usecase
+}
+
+func NewInvoiceController(invoiceUC invoice.Usecase) *InvoiceController {
+	return &InvoiceController{invoiceUC: invoiceUC}
+}
+
+// GET /orders/:id/invoice returns the order's invoice or receipt as JSON, or
+// as a PDF download with ?format=pdf.
+func (c *InvoiceController) GetInvoice(ctx *gin.Context) {
+	orderID, actorID, role := ctx.Param("id"), ctx.GetString("userID"), user.Role(ctx.GetString("role"))
+
+	switch ctx.DefaultQuery("format", "json") {
+	case "json":
+		inv, err := c.invoiceUC.GetInvoice(ctx, orderID, actorID, role)
+		if err != nil {
+			ctx.JSON(invoiceErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusOK, common.APIResponse{
+			Success: true,
+			Message: "Invoice retrieved successfully",
+			Data:    inv,
+		})
+	case "pdf":
+		inv, pdf, err := c.invoiceUC.GetInvoicePDF(ctx, orderID, actorID, role)
+		if err != nil {
+			ctx.JSON(invoiceErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Header("Content-Disposition", `attachment; filename="`+inv.Filename("pdf")+`"`)
+		ctx.Data(http.StatusOK, "application/pdf", pdf)
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or pdf"})
+	}
+}
+
+func invoiceErrorStatus(err error) int {
+	if errors.Is(err, invoice.ErrInvoiceNotFound) {
+		return http.StatusNotFound
+	}
+	return orderErrorStatus(err)
+}
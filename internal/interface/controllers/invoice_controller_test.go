@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockInvoiceUsecase struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceUsecase) GetInvoice(ctx context.Context, orderID, actorID string, role user.Role) (*invoice.Invoice, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invoice.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceUsecase) GetInvoicePDF(ctx context.Context, orderID, actorID string, role user.Role) (*invoice.Invoice, []byte, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*invoice.Invoice), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *MockInvoiceUsecase) IssueInvoice(ctx context.Context, o *order.Order, paid *payment.Payment) (*invoice.Invoice, error) {
+	args := m.Called(ctx, o, paid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invoice.Invoice), args.Error(1)
+}
+
+type InvoiceControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockInvoiceUsecase
+	controller *InvoiceController
+}
+
+func (suite *InvoiceControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockInvoiceUsecase)
+	suite.controller = NewInvoiceController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestInvoiceControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(InvoiceControllerTestSuite))
+}
+
+func (suite *InvoiceControllerTestSuite) getInvoice(query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order1"}}
+	c.Request = httptest.NewRequest("GET", "/orders/order1/invoice"+query, nil)
+	c.Set("userID", "consumer1")
+	c.Set("role", "consumer")
+	suite.controller.GetInvoice(c)
+	return w
+}
+
+func (suite *InvoiceControllerTestSuite) TestGetInvoice_JSON() {
+	// Setup
+	suite.usecase.On("GetInvoice", mock.Anything, "order1", "consumer1", user.RoleConsumer).
+		Return(&invoice.Invoice{ID: "order1", Number: "INV-000001", Kind: invoice.KindReceipt}, nil)
+
+	// Execute
+	w := suite.getInvoice("")
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"number":"INV-000001"`)
+}
+
+func (suite *InvoiceControllerTestSuite) TestGetInvoice_PDF() {
+	// Setup
+	suite.usecase.On("GetInvoicePDF", mock.Anything, "order1", "consumer1", user.RoleConsumer).
+		Return(&invoice.Invoice{ID: "order1", Number: "INV-000001", Kind: invoice.KindReceipt}, []byte("%PDF-1.4"), nil)
+
+	// Execute
+	w := suite.getInvoice("?format=pdf")
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "application/pdf", w.Header().Get("Content-Type"))
+	assert.Contains(suite.T(), w.Header().Get("Content-Disposition"), "INV-000001")
+	assert.Equal(suite.T(), "%PDF-1.4", w.Body.String())
+}
+
+func (suite *InvoiceControllerTestSuite) TestGetInvoice_UnknownFormat() {
+	// Execute
+	w := suite.getInvoice("?format=xml")
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "GetInvoice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InvoiceControllerTestSuite) TestGetInvoice_Errors() {
+	tests := []struct {
+		err        error
+		wantStatus int
+	}{
+		{order.ErrOrderNotFound, http.StatusNotFound},
+		{order.ErrNotOrderParty, http.StatusForbidden},
+		{invoice.ErrInvoiceNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		suite.SetupTest()
+		suite.usecase.On("GetInvoice", mock.Anything, "order1", "consumer1", user.RoleConsumer).Return(nil, tt.err)
+
+		w := suite.getInvoice("?format=json")
+
+		assert.Equal(suite.T(), tt.wantStatus, w.Code, tt.err.Error())
+	}
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type JobController struct {
+	jobUC job.Usecase
+}
+
+func NewJobController(jobUC job.Usecase) *JobController {
+	return &JobController{jobUC: jobUC}
+}
+
+// GET /admin/jobs?status=&page=&limit=
+func (c *JobController) ListJobs(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	jobs, err := c.jobUC.ListJobs(ctx, job.Status(ctx.Query("status")), page, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jobs"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Jobs retrieved successfully",
+		Data:    jobs,
+	})
+}
+
+// GET /admin/jobs/:id
+func (c *JobController) GetJob(ctx *gin.Context) {
+	j, err := c.jobUC.GetJob(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(jobErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Job retrieved successfully",
+		Data:    j,
+	})
+}
+
+// POST /admin/jobs/:id/retry
+func (c *JobController) RetryJob(ctx *gin.Context) {
+	j, err := c.jobUC.RetryJob(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(jobErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Job queued for retry",
+		Data:    j,
+	})
+}
+
+func jobErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, job.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, job.ErrNotRetryable):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockJobUsecase struct {
+	mock.Mock
+}
+
+func (m *MockJobUsecase) ListJobs(ctx context.Context, status job.Status, page, limit int) ([]*job.Job, error) {
+	args := m.Called(ctx, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*job.Job), args.Error(1)
+}
+
+func (m *MockJobUsecase) GetJob(ctx context.Context, id string) (*job.Job, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*job.Job), args.Error(1)
+}
+
+func (m *MockJobUsecase) RetryJob(ctx context.Context, id string) (*job.Job, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*job.Job), args.Error(1)
+}
+
+type JobControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockJobUsecase
+	controller *JobController
+}
+
+func (suite *JobControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockJobUsecase)
+	suite.controller = NewJobController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestJobControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(JobControllerTestSuite))
+}
+
+func (suite *JobControllerTestSuite) TestListJobs_FilterByStatus() {
+	// Setup
+	jobs := []*job.Job{{ID: "job1", Type: job.TypeTrackShipment, Status: job.StatusFailed}}
+	suite.usecase.On("ListJobs", mock.Anything, job.StatusFailed, 2, 5).Return(jobs, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/admin/jobs?status=failed&page=2&limit=5", nil)
+
+	// Execute
+	suite.controller.ListJobs(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "job1")
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *JobControllerTestSuite) TestGetJob_NotFound() {
+	// Setup
+	suite.usecase.On("GetJob", mock.Anything, "missing").Return(nil, job.ErrJobNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "missing"}}
+	c.Request = httptest.NewRequest("GET", "/admin/jobs/missing", nil)
+
+	// Execute
+	suite.controller.GetJob(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *JobControllerTestSuite) TestRetryJob_NotRetryable() {
+	// Setup
+	suite.usecase.On("RetryJob", mock.Anything, "job1").Return(nil, job.ErrNotRetryable)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "job1"}}
+	c.Request = httptest.NewRequest("POST", "/admin/jobs/job1/retry", nil)
+
+	// Execute
+	suite.controller.RetryJob(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
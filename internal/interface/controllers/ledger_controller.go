@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type LedgerController struct {
+	ledgerUC ledger.Usecase
+}
+
+func NewLedgerController(ledgerUC ledger.Usecase) *LedgerController {
+	return &LedgerController{ledgerUC: ledgerUC}
+}
+
+// GET /ledger/balance returns the caller's own balance.
+func (c *LedgerController) GetMyBalance(ctx *gin.Context) {
+	c.respondWithBalance(ctx, ctx.GetString("userID"))
+}
+
+// GET /admin/ledger/balances/:userId
+func (c *LedgerController) GetUserBalance(ctx *gin.Context) {
+	c.respondWithBalance(ctx, ctx.Param("userId"))
+}
+
+func (c *LedgerController) respondWithBalance(ctx *gin.Context, userID string) {
+	if userID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "user id is required"})
+		return
+	}
+	bal, err := c.ledgerUC.GetBalance(ctx, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch balance"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Balance retrieved successfully",
+		Data:    bal,
+	})
+}
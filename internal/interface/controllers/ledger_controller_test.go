@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockLedgerUsecase struct {
+	mock.Mock
+}
+
+func (m *MockLedgerUsecase) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockLedgerUsecase) RecordCreditIssued(ctx context.Context, userID string, amount float64, memo string) error {
+	args := m.Called(ctx, userID, amount, memo)
+	return args.Error(0)
+}
+
+func (m *MockLedgerUsecase) RecordGiftCardRedeemed(ctx context.Context, userID, code string, amount float64) error {
+	args := m.Called(ctx, userID, code, amount)
+	return args.Error(0)
+}
+
+func (m *MockLedgerUsecase) RecordPayout(ctx context.Context, sellerID, payoutID string, amount float64) error {
+	args := m.Called(ctx, sellerID, payoutID, amount)
+	return args.Error(0)
+}
+
+func (m *MockLedgerUsecase) RecordRelease(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockLedgerUsecase) GetBalance(ctx context.Context, userID string) (*ledger.Balance, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledger.Balance), args.Error(1)
+}
+
+type LedgerControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockLedgerUsecase
+	controller *LedgerController
+}
+
+func (suite *LedgerControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockLedgerUsecase)
+	suite.controller = NewLedgerController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestLedgerControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(LedgerControllerTestSuite))
+}
+
+func (suite *LedgerControllerTestSuite) TestGetMyBalance_Success() {
+	// Setup
+	bal := &ledger.Balance{UserID: "reseller1", Sales: 100, Fees: 2, Earnings: 98, Available: 98}
+	suite.usecase.On("GetBalance", mock.Anything, "reseller1").Return(bal, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/ledger/balance", nil)
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.GetMyBalance(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"available":98`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *LedgerControllerTestSuite) TestGetUserBalance_Error() {
+	// Setup
+	suite.usecase.On("GetBalance", mock.Anything, "supplier1").Return(nil, errors.New("database error"))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "userId", Value: "supplier1"}}
+	c.Request = httptest.NewRequest("GET", "/admin/ledger/balances/supplier1", nil)
+
+	// Execute
+	suite.controller.GetUserBalance(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/notification"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationController struct {
+	notificationUC notification.Usecase
+}
+
+func NewNotificationController(notificationUC notification.Usecase) *NotificationController {
+	return &NotificationController{notificationUC: notificationUC}
+}
+
+// GET /notifications lists the caller's notifications, newest first.
+func (c *NotificationController) ListNotifications(ctx *gin.Context) {
+	list, err := c.notificationUC.ListNotifications(ctx, ctx.GetString("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications"})
+		return
+	}
+	if list == nil {
+		list = []*notification.Notification{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Notifications retrieved successfully",
+		Data:    list,
+	})
+}
+
+// PATCH /notifications/:id/read
+func (c *NotificationController) MarkRead(ctx *gin.Context) {
+	err := c.notificationUC.MarkRead(ctx, ctx.Param("id"), ctx.GetString("userID"))
+	if errors.Is(err, notification.ErrNotificationNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Notification marked as read",
+	})
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type OfferController struct {
+	offerUC offer.Usecase
+}
+
+func NewOfferController(offerUC offer.Usecase) *OfferController {
+	return &OfferController{offerUC: offerUC}
+}
+
+// POST /offers proposes a price for a bundle or product.
+func (c *OfferController) MakeOffer(ctx *gin.Context) {
+	var req offer.Request
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.ListingID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	o, err := c.offerUC.MakeOffer(ctx, ctx.GetString("userID"), user.Role(ctx.GetString("role")), req)
+	if err != nil {
+		ctx.JSON(offerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Offer made successfully",
+		Data:    o,
+	})
+}
+
+// GET /offers lists the offers the caller has made or received.
+func (c *OfferController) ListMyOffers(ctx *gin.Context) {
+	list, err := c.offerUC.ListMyOffers(ctx, ctx.GetString("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch offers"})
+		return
+	}
+	if list == nil {
+		list = []*offer.Offer{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Offers retrieved successfully",
+		Data:    list,
+	})
+}
+
+// GET /offers/:id returns the offer with its full thread.
+func (c *OfferController) GetOffer(ctx *gin.Context) {
+	o, err := c.offerUC.GetOffer(ctx, ctx.Param("id"), ctx.GetString("userID"), user.Role(ctx.GetString("role")))
+	if err != nil {
+		ctx.JSON(offerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Offer retrieved successfully",
+		Data:    o,
+	})
+}
+
+// POST /offers/:id/respond accepts, rejects or counters the last proposal.
+func (c *OfferController) Respond(ctx *gin.Context) {
+	var r offer.Response
+	if err := ctx.ShouldBindJSON(&r); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if r.Action != offer.ActionAccept && r.Action != offer.ActionReject && r.Action != offer.ActionCounter {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": offer.ErrInvalidAction.Error()})
+		return
+	}
+
+	o, err := c.offerUC.Respond(ctx, ctx.Param("id"), ctx.GetString("userID"), r)
+	if err != nil {
+		ctx.JSON(offerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Offer updated successfully",
+		Data:    o,
+	})
+}
+
+func offerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, offer.ErrInvalidListingType), errors.Is(err, offer.ErrInvalidPrice), errors.Is(err, offer.ErrInvalidAction):
+		return http.StatusBadRequest
+	case errors.Is(err, offer.ErrWrongBuyer), errors.Is(err, offer.ErrOwnListing), errors.Is(err, offer.ErrNotYourTurn):
+		return http.StatusForbidden
+	case errors.Is(err, offer.ErrOfferNotFound), errors.Is(err, offer.ErrListingNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, offer.ErrNotAvailable), errors.Is(err, offer.ErrOfferExists), errors.Is(err, offer.ErrNotOpen),
+		errors.Is(err, offer.ErrOfferExpired), errors.Is(err, offer.ErrReserved), errors.Is(err, offer.ErrOfferChanged),
+		errors.Is(err, bundle.ErrAuctioned):
+		return http.StatusConflict
+	default:
+		return orderErrorStatus(err)
+	}
+}
@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockOfferUsecase struct {
+	mock.Mock
+}
+
+func (m *MockOfferUsecase) MakeOffer(ctx context.Context, buyerID string, role user.Role, req offer.Request) (*offer.Offer, error) {
+	args := m.Called(ctx, buyerID, role, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*offer.Offer), args.Error(1)
+}
+
+func (m *MockOfferUsecase) GetOffer(ctx context.Context, id, actorID string, role user.Role) (*offer.Offer, error) {
+	args := m.Called(ctx, id, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*offer.Offer), args.Error(1)
+}
+
+func (m *MockOfferUsecase) ListMyOffers(ctx context.Context, userID string) ([]*offer.Offer, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*offer.Offer), args.Error(1)
+}
+
+func (m *MockOfferUsecase) Respond(ctx context.Context, id, actorID string, r offer.Response) (*offer.Offer, error) {
+	args := m.Called(ctx, id, actorID, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*offer.Offer), args.Error(1)
+}
+
+func (m *MockOfferUsecase) ExpireOffer(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOfferUsecase) ReleaseOffer(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type OfferControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockOfferUsecase
+	controller *OfferController
+}
+
+func (suite *OfferControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockOfferUsecase)
+	suite.controller = NewOfferController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestOfferControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(OfferControllerTestSuite))
+}
+
+func (suite *OfferControllerTestSuite) TestMakeOffer_Success() {
+	// Setup
+	suite.usecase.On("MakeOffer", mock.Anything, "reseller1", user.RoleReseller, offer.Request{
+		ListingType: offer.ListingBundle, ListingID: "bundle1", Price: 150, Note: "150?",
+	}).Return(&offer.Offer{ID: "offer1", Status: offer.StatusOpen}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/offers", strings.NewReader(`{"listing_type":"bundle","listing_id":"bundle1","price":150,"note":"150?"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+	c.Set("role", "reseller")
+
+	// Execute
+	suite.controller.MakeOffer(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *OfferControllerTestSuite) TestMakeOffer_Reserved() {
+	// Setup
+	suite.usecase.On("MakeOffer", mock.Anything, "reseller1", user.RoleReseller, mock.Anything).Return(nil, offer.ErrReserved)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/offers", strings.NewReader(`{"listing_type":"bundle","listing_id":"bundle1","price":150}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+	c.Set("role", "reseller")
+
+	// Execute
+	suite.controller.MakeOffer(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *OfferControllerTestSuite) TestRespond_Counter() {
+	// Setup
+	suite.usecase.On("Respond", mock.Anything, "offer1", "supplier1", offer.Response{Action: offer.ActionCounter, Price: 180}).
+		Return(&offer.Offer{ID: "offer1", Status: offer.StatusOpen, Price: 180}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "offer1"}}
+	c.Request = httptest.NewRequest("POST", "/offers/offer1/respond", strings.NewReader(`{"action":"counter","price":180}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "supplier1")
+
+	// Execute
+	suite.controller.Respond(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *OfferControllerTestSuite) TestRespond_InvalidAction() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "offer1"}}
+	c.Request = httptest.NewRequest("POST", "/offers/offer1/respond", strings.NewReader(`{"action":"haggle"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "supplier1")
+
+	// Execute
+	suite.controller.Respond(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "Respond", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OfferControllerTestSuite) TestRespond_NotYourTurn() {
+	// Setup
+	suite.usecase.On("Respond", mock.Anything, "offer1", "reseller1", mock.Anything).Return(nil, offer.ErrNotYourTurn)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "offer1"}}
+	c.Request = httptest.NewRequest("POST", "/offers/offer1/respond", strings.NewReader(`{"action":"accept"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.Respond(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
@@ -1,9 +1,15 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
 	"github.com/gin-gonic/gin"
 )
@@ -40,7 +46,7 @@ func (c *OrderController) PurchaseBundle(ctx *gin.Context) {
 
 	order, payment, warehouseItem, err := c.orderUseCase.PurchaseBundle(ctx, req.BundleID, resellerIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{
@@ -63,4 +69,155 @@ func (c *OrderController) GetOrderByID(ctx *gin.Context) {
 		return
 	}
 	ctx.JSON(http.StatusOK, order)
-}
\ No newline at end of file
+}
+
+// GetOrdersToFulfil lists the reseller's consumer orders with their ship-to addresses.
+func (c *OrderController) GetOrdersToFulfil(ctx *gin.Context) {
+	resellerID := ctx.GetString("userID")
+	if resellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	orders, err := c.orderUseCase.GetOrdersToFulfil(ctx, resellerID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Orders retrieved successfully",
+		Data:    orders,
+	})
+}
+
+func (c *OrderController) CancelOrder(ctx *gin.Context) {
+	type Request struct {
+		Reason string `json:"reason"`
+	}
+
+	var req Request
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+			return
+		}
+	}
+
+	actorID := ctx.GetString("userID")
+	if actorID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	o, err := c.orderUseCase.CancelOrder(ctx, ctx.Param("id"), actorID, user.Role(ctx.GetString("role")), req.Reason)
+	if err != nil {
+		ctx.JSON(orderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Order canceled successfully",
+		Data:    o,
+	})
+}
+
+func (c *OrderController) RefundOrder(ctx *gin.Context) {
+	type Request struct {
+		Amount float64 `json:"amount"`
+	}
+
+	var req Request
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+			return
+		}
+	}
+
+	actorID := ctx.GetString("userID")
+	if actorID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	refund, err := c.orderUseCase.RefundOrder(ctx, ctx.Param("id"), actorID, user.Role(ctx.GetString("role")), req.Amount)
+	if err != nil {
+		ctx.JSON(orderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Order refunded successfully",
+		Data:    refund,
+	})
+}
+
+func (c *OrderController) GetEscrow(ctx *gin.Context) {
+	actorID := ctx.GetString("userID")
+	if actorID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	e, err := c.orderUseCase.GetEscrow(ctx, ctx.Param("id"), actorID, user.Role(ctx.GetString("role")))
+	if err != nil {
+		ctx.JSON(orderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Escrow fetched successfully",
+		Data:    e,
+	})
+}
+
+func (c *OrderController) ConfirmArrival(ctx *gin.Context) {
+	actorID := ctx.GetString("userID")
+	if actorID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	e, err := c.orderUseCase.ConfirmArrival(ctx, ctx.Param("id"), actorID, user.Role(ctx.GetString("role")))
+	if err != nil {
+		ctx.JSON(orderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Arrival confirmed; the payment was released to the supplier",
+		Data:    e,
+	})
+}
+
+// orderErrorStatus maps cancellation, refund and escrow failures to client errors.
+func orderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, order.ErrOrderNotFound), errors.Is(err, escrow.ErrEscrowNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, order.ErrNotOrderParty):
+		return http.StatusForbidden
+	case errors.Is(err, order.ErrCannotCancel), errors.Is(err, order.ErrAlreadyCanceled):
+		return http.StatusConflict
+	case errors.Is(err, escrow.ErrNotHeld), errors.Is(err, escrow.ErrNotDisputed), errors.Is(err, escrow.ErrFundsHeld), errors.Is(err, escrow.ErrEscrowChanged),
+		errors.Is(err, payment.ErrPaymentChanged):
+		return http.StatusConflict
+	case errors.Is(err, order.ErrInvalidRefundAmount), errors.Is(err, escrow.ErrDisputeReasonRequired):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// purchaseErrorStatus maps purchase failures the buyer can act on to client errors.
+func purchaseErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, payment.ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, bundle.ErrNotAvailable), errors.Is(err, bundle.ErrAuctioned), errors.Is(err, offer.ErrReserved):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -46,6 +50,77 @@ func (m *MockOrderUseCase) GetOrderByID(ctx context.Context, orderID string) (*o
 	return args.Get(0).(*order.Order), args.Error(1)
 }
 
+func (m *MockOrderUseCase) CancelOrder(ctx context.Context, orderID, actorID string, role user.Role, reason string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, actorID, role, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RefundOrder(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, actorID, role, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RecordRefund(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, actorID, role, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUseCase) SettleRefund(ctx context.Context, refundID string) error {
+	args := m.Called(ctx, refundID)
+	return args.Error(0)
+}
+
+func (m *MockOrderUseCase) GetEscrow(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrderUseCase) DisputeEscrow(ctx context.Context, orderID, resellerID, reason string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, resellerID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ResolveEscrow(ctx context.Context, orderID, adminID string, refund bool, note string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, adminID, refund, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ConfirmArrival(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ReleaseEscrow(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderUseCase) ReleaseOnArrival(ctx context.Context, warehouseItemID string) error {
+	args := m.Called(ctx, warehouseItemID)
+	return args.Error(0)
+}
+
 func (m *MockOrderUseCase) GetDashboardMetrics(ctx context.Context, supplierID string) (*order.DashboardMetrics, error) {
 	args := m.Called(ctx, supplierID)
 	if args.Get(0) == nil {
@@ -54,6 +129,14 @@ func (m *MockOrderUseCase) GetDashboardMetrics(ctx context.Context, supplierID s
 	return args.Get(0).(*order.DashboardMetrics), args.Error(1)
 }
 
+func (m *MockOrderUseCase) GetOrdersToFulfil(ctx context.Context, resellerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
 func (m *MockOrderUseCase) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
 	args := m.Called(ctx, resellerID)
 	if args.Get(0) == nil {
@@ -168,6 +251,27 @@ func (suite *OrderControllerTestSuite) TestPurchaseBundle_UseCaseError() {
 	suite.orderUseCase.AssertExpectations(suite.T())
 }
 
+func (suite *OrderControllerTestSuite) TestPurchaseBundle_PaymentDeclined() {
+	// Setup
+	suite.orderUseCase.On("PurchaseBundle", mock.Anything, "bundle123", "reseller123").
+		Return(nil, nil, nil, fmt.Errorf("payment failed: %w", payment.ErrPaymentDeclined))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+
+	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123"})
+	c.Request = httptest.NewRequest("POST", "/purchase", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.PurchaseBundle(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusPaymentRequired, w.Code)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
 func (suite *OrderControllerTestSuite) TestGetOrderByID_Success() {
 	// Setup
 	expectedOrder := &order.Order{ID: "order123"}
@@ -199,6 +303,32 @@ func (suite *OrderControllerTestSuite) TestGetOrderByID_MissingID() {
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
 
+func (suite *OrderControllerTestSuite) TestGetOrdersToFulfil_ShowsShipTo() {
+	// Setup
+	orders := []*order.Order{{
+		ID:              "order123",
+		ResellerID:      "reseller123",
+		ConsumerID:      "consumer123",
+		Status:          order.OrderStatusPending,
+		ShippingAddress: &order.ShippingAddress{FullName: "Abebe Kebede", Line1: "Bole Road", City: "Addis Ababa", Country: "ET"},
+	}}
+	suite.orderUseCase.On("GetOrdersToFulfil", mock.Anything, "reseller123").Return(orders, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/orders/fulfilment", nil)
+	c.Set("userID", "reseller123")
+
+	// Execute
+	suite.controller.GetOrdersToFulfil(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"shipping_address"`)
+	assert.Contains(suite.T(), w.Body.String(), "Bole Road")
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
 func (suite *OrderControllerTestSuite) TestGetOrderByID_UseCaseError() {
 	// Setup
 	suite.orderUseCase.On("GetOrderByID", mock.Anything, "order123").
@@ -216,3 +346,94 @@ func (suite *OrderControllerTestSuite) TestGetOrderByID_UseCaseError() {
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
 	suite.orderUseCase.AssertExpectations(suite.T())
 }
+
+func (suite *OrderControllerTestSuite) TestCancelOrder_Success() {
+	// Setup
+	canceled := &order.Order{ID: "order123", Status: order.OrderStatusCanceled}
+	suite.orderUseCase.On("CancelOrder", mock.Anything, "order123", "consumer123", user.RoleConsumer, "changed my mind").
+		Return(canceled, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "consumer123")
+	c.Set("role", "consumer")
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order123"}}
+
+	body, _ := json.Marshal(gin.H{"reason": "changed my mind"})
+	c.Request = httptest.NewRequest("POST", "/orders/order123/cancel", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.CancelOrder(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *OrderControllerTestSuite) TestCancelOrder_NotAllowed() {
+	// Setup
+	suite.orderUseCase.On("CancelOrder", mock.Anything, "order123", "consumer123", user.RoleConsumer, "").
+		Return(nil, order.ErrCannotCancel)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "consumer123")
+	c.Set("role", "consumer")
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order123"}}
+	c.Request = httptest.NewRequest("POST", "/orders/order123/cancel", nil)
+
+	// Execute
+	suite.controller.CancelOrder(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *OrderControllerTestSuite) TestRefundOrder_Success() {
+	// Setup
+	refund := &payment.Payment{Amount: money.InSettlement(-25.0), Status: payment.StatusRefunded}
+	suite.orderUseCase.On("RefundOrder", mock.Anything, "order123", "reseller123", user.RoleReseller, 25.0).
+		Return(refund, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+	c.Set("role", "reseller")
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order123"}}
+
+	body, _ := json.Marshal(gin.H{"amount": 25.0})
+	c.Request = httptest.NewRequest("POST", "/orders/order123/refund", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.RefundOrder(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *OrderControllerTestSuite) TestRefundOrder_InvalidAmount() {
+	// Setup
+	suite.orderUseCase.On("RefundOrder", mock.Anything, "order123", "reseller123", user.RoleReseller, 500.0).
+		Return(nil, order.ErrInvalidRefundAmount)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+	c.Set("role", "reseller")
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order123"}}
+
+	body, _ := json.Marshal(gin.H{"amount": 500.0})
+	c.Request = httptest.NewRequest("POST", "/orders/order123/refund", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.RefundOrder(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
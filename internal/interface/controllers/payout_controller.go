@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payout"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type PayoutController struct {
+	payoutUC payout.Usecase
+}
+
+func NewPayoutController(payoutUC payout.Usecase) *PayoutController {
+	return &PayoutController{payoutUC: payoutUC}
+}
+
+// PUT /payouts/method registers or replaces the caller's payout method.
+func (c *PayoutController) SetPayoutMethod(ctx *gin.Context) {
+	type Request struct {
+		Type          payout.MethodType `json:"type"`
+		AccountName   string            `json:"account_name"`
+		AccountNumber string            `json:"account_number"`
+		Institution   string            `json:"institution"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	m, err := c.payoutUC.SetPayoutMethod(ctx, ctx.GetString("userID"), &payout.Method{
+		Type:          req.Type,
+		AccountName:   req.AccountName,
+		AccountNumber: req.AccountNumber,
+		Institution:   req.Institution,
+	})
+	if err != nil {
+		ctx.JSON(payoutErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Payout method saved",
+		Data:    m,
+	})
+}
+
+// GET /payouts/method
+func (c *PayoutController) GetPayoutMethod(ctx *gin.Context) {
+	m, err := c.payoutUC.GetPayoutMethod(ctx, ctx.GetString("userID"))
+	if err != nil {
+		ctx.JSON(payoutErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Payout method retrieved successfully",
+		Data:    m,
+	})
+}
+
+// GET /payouts lists the caller's payouts, newest first.
+func (c *PayoutController) ListPayouts(ctx *gin.Context) {
+	payouts, err := c.payoutUC.ListPayouts(ctx, ctx.GetString("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch payouts"})
+		return
+	}
+	if payouts == nil {
+		payouts = []*payout.Payout{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Payouts retrieved successfully",
+		Data:    payouts,
+	})
+}
+
+// GET /payouts/:id
+func (c *PayoutController) GetPayout(ctx *gin.Context) {
+	p, err := c.payoutUC.GetPayout(ctx, ctx.GetString("userID"), ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(payoutErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Payout retrieved successfully",
+		Data:    p,
+	})
+}
+
+func payoutErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, payout.ErrInvalidMethod):
+		return http.StatusBadRequest
+	case errors.Is(err, payout.ErrNoMethod), errors.Is(err, payout.ErrPayoutNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
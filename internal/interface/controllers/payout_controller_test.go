@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payout"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockPayoutUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPayoutUsecase) SetPayoutMethod(ctx context.Context, userID string, method *payout.Method) (*payout.Method, error) {
+	args := m.Called(ctx, userID, method)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payout.Method), args.Error(1)
+}
+
+func (m *MockPayoutUsecase) GetPayoutMethod(ctx context.Context, userID string) (*payout.Method, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payout.Method), args.Error(1)
+}
+
+func (m *MockPayoutUsecase) ListPayouts(ctx context.Context, sellerID string) ([]*payout.Payout, error) {
+	args := m.Called(ctx, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payout.Payout), args.Error(1)
+}
+
+func (m *MockPayoutUsecase) GetPayout(ctx context.Context, sellerID, id string) (*payout.Payout, error) {
+	args := m.Called(ctx, sellerID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payout.Payout), args.Error(1)
+}
+
+func (m *MockPayoutUsecase) RunSettlement(ctx context.Context) ([]*payout.Payout, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payout.Payout), args.Error(1)
+}
+
+func (m *MockPayoutUsecase) SendPayout(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type PayoutControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockPayoutUsecase
+	controller *PayoutController
+}
+
+func (suite *PayoutControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockPayoutUsecase)
+	suite.controller = NewPayoutController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestPayoutControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(PayoutControllerTestSuite))
+}
+
+func (suite *PayoutControllerTestSuite) TestSetPayoutMethod_Success() {
+	// Setup
+	suite.usecase.On("SetPayoutMethod", mock.Anything, "supplier1", mock.MatchedBy(func(m *payout.Method) bool {
+		return m.Type == payout.MethodBankTransfer && m.AccountNumber == "1000123456"
+	})).Return(&payout.Method{UserID: "supplier1", Type: payout.MethodBankTransfer, AccountNumber: "1000123456"}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/payouts/method", strings.NewReader(`{"type":"bank_transfer","account_name":"Abebe Kebede","account_number":"1000123456","institution":"CBE"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "supplier1")
+
+	// Execute
+	suite.controller.SetPayoutMethod(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *PayoutControllerTestSuite) TestSetPayoutMethod_Invalid() {
+	// Setup
+	suite.usecase.On("SetPayoutMethod", mock.Anything, "supplier1", mock.Anything).Return(nil, payout.ErrInvalidMethod)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/payouts/method", strings.NewReader(`{"type":"cheque"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "supplier1")
+
+	// Execute
+	suite.controller.SetPayoutMethod(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *PayoutControllerTestSuite) TestListPayouts_Empty() {
+	// Setup
+	suite.usecase.On("ListPayouts", mock.Anything, "reseller1").Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/payouts", nil)
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.ListPayouts(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"data":[]`)
+}
+
+func (suite *PayoutControllerTestSuite) TestGetPayout_NotFound() {
+	// Setup
+	suite.usecase.On("GetPayout", mock.Anything, "reseller1", "po1").Return(nil, payout.ErrPayoutNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "po1"}}
+	c.Request = httptest.NewRequest("GET", "/payouts/po1", nil)
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.GetPayout(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
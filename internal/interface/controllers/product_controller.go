@@ -2,14 +2,17 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/trust"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -19,6 +22,7 @@ type ProductController struct {
 	TrustUsecase  trust.Usecase
 	BundleUsecase bundle.Usecase
 	WarehouseRepo warehouse.Repository
+	Rates         money.Converter
 }
 
 func NewProductController(
@@ -26,21 +30,39 @@ func NewProductController(
 	trustUC trust.Usecase,
 	bundleUC bundle.Usecase,
 	warehouseRepo warehouse.Repository, // ✅ new param
+	rates money.Converter,
 ) *ProductController {
 	return &ProductController{
 		Usecase:       prodUC,
 		TrustUsecase:  trustUC,
 		BundleUsecase: bundleUC,
 		WarehouseRepo: warehouseRepo, // ✅ assign it
+		Rates:         rates,
 	}
 }
 
 func (h *ProductController) Create(c *gin.Context) {
-	var p product.Product
-	if err := c.ShouldBindJSON(&p); err != nil {
+	var req models.CreateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
 		return
 	}
+	currency, err := parseListingCurrency(req.Currency)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	p := product.Product{
+		BundleID:    req.BundleID,
+		Title:       req.Title,
+		Description: req.Description,
+		Size:        req.Size,
+		Type:        req.Type,
+		Grade:       req.Grade,
+		Price:       money.FromMajor(req.Price, currency),
+		ImageURL:    req.ImageURL,
+		Rating:      req.Rating,
+	}
 
 	userID, exists := c.Get("userID")
 	if !exists {
@@ -122,7 +144,11 @@ func (h *ProductController) GetByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "product not found"})
 		return
 	}
-	c.JSON(http.StatusOK, prod)
+	resp, ok := h.showPrices(c, prod)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, resp[0])
 }
 
 func (h *ProductController) ListAvailable(c *gin.Context) {
@@ -136,11 +162,77 @@ func (h *ProductController) ListAvailable(c *gin.Context) {
 	}
 
 	if len(products) == 0 {
-		c.JSON(http.StatusOK, gin.H{"message": "no products available", "products": []product.Product{}})
+		c.JSON(http.StatusOK, gin.H{"message": "no products available", "products": []models.ProductListingResponse{}})
+		return
+	}
+	resp, ok := h.showPrices(c, products...)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	c.JSON(http.StatusOK, resp)
+}
+
+// Search handles GET /products/search?q=&size=&type=&grade=&min_price=
+// &max_price=&status=&sort=&page=&limit=. Prices are shown, filtered and
+// sorted in ?currency=, the settlement currency by default.
+func (h *ProductController) Search(c *gin.Context) {
+	q := product.Query{
+		Text:   c.Query("q"),
+		Size:   c.Query("size"),
+		Type:   c.Query("type"),
+		Grade:  c.Query("grade"),
+		Status: c.Query("status"),
+		Sort:   product.SortOrder(c.Query("sort")),
+	}
+	var err error
+	if q.MinPrice, err = floatParam(c, "min_price"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if q.MaxPrice, err = floatParam(c, "max_price"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for name, dst := range map[string]*int{"page": &q.Page, "limit": &q.Limit} {
+		v, err := intParam(c, name)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if v != nil {
+			*dst = *v
+		}
+	}
+	display, err := newPriceDisplay(c, h.Rates)
+	if err != nil {
+		c.JSON(moneyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	q.Currency = display.to
+	if q.Priced() {
+		// Products listed in any currency are compared at the current rates.
+		if q.Rates, err = h.Rates.Rates(c.Request.Context()); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search products", "details": err.Error()})
+			return
+		}
+	}
+
+	res, err := h.Usecase.SearchProducts(c.Request.Context(), q)
+	if errors.Is(err, product.ErrInvalidQuery) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search products", "details": err.Error()})
+		return
+	}
+	resp := models.ProductSearchResponse{Total: res.Total, Page: res.Page, Limit: res.Limit, Facets: res.Facets}
+	if resp.Products, err = productResponses(display, res.Products); err != nil {
+		c.JSON(moneyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 func (h *ProductController) ListByReseller(c *gin.Context) {
@@ -155,11 +247,15 @@ func (h *ProductController) ListByReseller(c *gin.Context) {
 	}
 
 	if len(products) == 0 {
-		c.JSON(http.StatusOK, gin.H{"message": "no products found for this reseller", "products": []product.Product{}})
+		c.JSON(http.StatusOK, gin.H{"message": "no products found for this reseller", "products": []models.ProductListingResponse{}})
+		return
+	}
+	resp, ok := h.showPrices(c, products...)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	c.JSON(http.StatusOK, resp)
 }
 
 func (h *ProductController) Update(c *gin.Context) {
@@ -169,6 +265,19 @@ func (h *ProductController) Update(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid update payload"})
 		return
 	}
+	_, hasPrice := updates["price"]
+	_, hasCurrency := updates["currency"]
+	if hasPrice || hasCurrency {
+		current, err := h.Usecase.GetProductByID(c.Request.Context(), id)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "product not found"})
+			return
+		}
+		if err := normalizePrice(updates, current.Price.Currency); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
 	if err := h.Usecase.UpdateProduct(c.Request.Context(), id, updates); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update product"})
 		return
@@ -184,3 +293,49 @@ func (h *ProductController) Delete(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "product deleted"})
 }
+
+// showPrices builds the responses for products with their prices in the
+// currency asked for with ?currency=. It answers the request itself and
+// reports false if it cannot.
+func (h *ProductController) showPrices(c *gin.Context, products ...*product.Product) ([]models.ProductListingResponse, bool) {
+	display, err := newPriceDisplay(c, h.Rates)
+	if err != nil {
+		c.JSON(moneyErrorStatus(err), gin.H{"error": err.Error()})
+		return nil, false
+	}
+	resp, err := productResponses(display, products)
+	if err != nil {
+		c.JSON(moneyErrorStatus(err), gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return resp, true
+}
+
+func productResponses(display *priceDisplay, products []*product.Product) ([]models.ProductListingResponse, error) {
+	resp := make([]models.ProductListingResponse, 0, len(products))
+	for _, p := range products {
+		price, err := display.show(p.Price)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, models.ProductListingResponse{
+			ID:          p.ID,
+			ResellerID:  p.ResellerID.Hex(),
+			SupplierID:  p.SupplierID,
+			BundleID:    p.BundleID,
+			Title:       p.Title,
+			Description: p.Description,
+			Size:        p.Size,
+			Type:        p.Type,
+			Grade:       p.Grade,
+			Price:       price.Major(),
+			Currency:    string(price.Currency),
+			Status:      p.Status,
+			ImageURL:    p.ImageURL,
+			CreatedAt:   p.CreatedAt,
+			Rating:      p.Rating,
+			Reservation: p.Reservation,
+		})
+	}
+	return resp, nil
+}
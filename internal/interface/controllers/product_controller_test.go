@@ -11,8 +11,10 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -59,6 +61,14 @@ func (m *MockProductUseCase) ListAvailableProducts(ctx context.Context, page, li
 	return args.Get(0).([]*product.Product), args.Error(1)
 }
 
+func (m *MockProductUseCase) SearchProducts(ctx context.Context, q product.Query) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
 func (m *MockProductUseCase) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
 	args := m.Called(ctx, resellerID, page, limit)
 	if args.Get(0) == nil {
@@ -86,6 +96,11 @@ func (m *MockTrustUseCase) UpdateSupplierTrustScoreOnNewRating(ctx context.Conte
 	return args.Error(0)
 }
 
+func (m *MockTrustUseCase) RecordLostDispute(ctx context.Context, supplierID string) error {
+	args := m.Called(ctx, supplierID)
+	return args.Error(0)
+}
+
 type MockBundleUseCase struct {
 	mock.Mock
 }
@@ -116,12 +131,12 @@ func (m *MockBundleUseCase) GetBundlePublicByID(ctx context.Context, id string)
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockBundleUseCase) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
+func (m *MockBundleUseCase) ListAvailableBundles(ctx context.Context, q bundle.Query) (*bundle.Page, error) {
+	args := m.Called(ctx, q)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*bundle.Page), args.Error(1)
 }
 
 func (m *MockBundleUseCase) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
@@ -186,6 +201,19 @@ func (m *MockWarehouseRepo) MarkItemAsSkipped(ctx context.Context, itemID string
 	args := m.Called(ctx, itemID)
 	return args.Error(0)
 }
+
+func (m *MockWarehouseRepo) MarkItemAsArrived(ctx context.Context, itemID string, at time.Time) error {
+	args := m.Called(ctx, itemID, at)
+	return args.Error(0)
+}
+
+func (m *MockWarehouseRepo) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
 func (m *MockWarehouseRepo) CountByStatus(ctx context.Context, status string) (int, error) {
 	args := m.Called(ctx, status)
 	return args.Int(0), args.Error(1)
@@ -201,6 +229,7 @@ func (suite *ProductControllerTestSuite) SetupTest() {
 		suite.trustUseCase,
 		suite.bundleUseCase,
 		suite.warehouseRepo,
+		fixedRates{money.USD: 50},
 	)
 	gin.SetMode(gin.TestMode)
 	suite.router = gin.Default()
@@ -242,7 +271,7 @@ func (suite *ProductControllerTestSuite) TestCreate_Success() {
 	c, _ := gin.CreateTestContext(w)
 	c.Set("userID", userID.Hex())
 
-	body, _ := json.Marshal(product)
+	body, _ := json.Marshal(models.CreateProductRequest{BundleID: product.BundleID, Rating: product.Rating})
 	req := httptest.NewRequest("POST", "/products", bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 	c.Request = req
@@ -286,7 +315,7 @@ func (suite *ProductControllerTestSuite) TestCreate_Unauthorized() {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
-	body, _ := json.Marshal(product)
+	body, _ := json.Marshal(models.CreateProductRequest{BundleID: product.BundleID})
 	c.Request = httptest.NewRequest("POST", "/products", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
@@ -358,6 +387,111 @@ func (suite *ProductControllerTestSuite) TestListAvailable_Success() {
 	suite.productUseCase.AssertExpectations(suite.T())
 }
 
+func (suite *ProductControllerTestSuite) TestListAvailable_InDisplayCurrency() {
+	// Setup
+	expectedProducts := []*product.Product{
+		{ID: "product1", Price: money.InSettlement(500)},
+		{ID: "product2", Price: money.FromMajor(20, money.USD)},
+	}
+	suite.productUseCase.On("ListAvailableProducts", mock.Anything, 1, 10).
+		Return(expectedProducts, nil)
+
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products?currency=usd", nil)
+
+	// Execute
+	suite.controller.ListAvailable(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var got []models.ProductListingResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(suite.T(), 10.0, got[0].Price)
+	assert.Equal(suite.T(), "USD", got[0].Currency)
+	assert.Equal(suite.T(), 20.0, got[1].Price)
+}
+
+func (suite *ProductControllerTestSuite) TestListAvailable_UnsupportedCurrency() {
+	// Setup
+	suite.productUseCase.On("ListAvailableProducts", mock.Anything, 1, 10).
+		Return([]*product.Product{{ID: "product1", Price: money.InSettlement(500)}}, nil)
+
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products?currency=EUR", nil)
+
+	// Execute
+	suite.controller.ListAvailable(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ProductControllerTestSuite) TestSearch_InDisplayCurrency() {
+	// Setup
+	suite.productUseCase.On("SearchProducts", mock.Anything, mock.MatchedBy(func(q product.Query) bool {
+		return q.Text == "denim jacket" && q.Size == "M" && *q.MaxPrice == 30 && q.MinPrice == nil &&
+			q.Sort == product.SortPriceAsc && q.Currency == money.USD && q.Page == 2 && q.Limit == 5
+	})).Return(&product.SearchResult{
+		Products: []*product.Product{{ID: "product1", Price: money.FromMajor(20, money.USD)}},
+		Total:    6,
+		Page:     2,
+		Limit:    5,
+		Facets:   product.Facets{Sizes: []product.FacetCount{{Value: "M", Count: 4}, {Value: "L", Count: 2}}},
+	}, nil)
+
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products/search?q=denim+jacket&size=M&max_price=30&sort=price_asc&currency=USD&page=2&limit=5", nil)
+
+	// Execute
+	suite.controller.Search(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var got models.ProductSearchResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(suite.T(), 6, got.Total)
+	assert.Equal(suite.T(), 20.0, got.Products[0].Price)
+	assert.Equal(suite.T(), []product.FacetCount{{Value: "M", Count: 4}, {Value: "L", Count: 2}}, got.Facets.Sizes)
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestSearch_InvalidQuery() {
+	// Setup
+	suite.productUseCase.On("SearchProducts", mock.Anything, mock.Anything).
+		Return(nil, product.ErrInvalidQuery)
+
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products/search?status=reserved", nil)
+
+	// Execute
+	suite.controller.Search(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ProductControllerTestSuite) TestSearch_BadPrice() {
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products/search?min_price=free", nil)
+
+	// Execute
+	suite.controller.Search(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "SearchProducts", mock.Anything, mock.Anything)
+}
+
 func (suite *ProductControllerTestSuite) TestListByReseller_Success() {
 	// Setup
 	expectedProducts := []*product.Product{
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/promotion"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionController struct {
+	promotionUC promotion.Usecase
+}
+
+func NewPromotionController(promotionUC promotion.Usecase) *PromotionController {
+	return &PromotionController{promotionUC: promotionUC}
+}
+
+// POST /admin/promotions creates a promo code. It is active straight away.
+func (c *PromotionController) CreatePromotion(ctx *gin.Context) {
+	type Request struct {
+		Code           string         `json:"code"`
+		Type           promotion.Type `json:"type"`
+		Value          float64        `json:"value"`
+		StartsAt       *time.Time     `json:"starts_at"`
+		EndsAt         *time.Time     `json:"ends_at"`
+		MinBasketTotal float64        `json:"min_basket_total"`
+		MaxUses        int            `json:"max_uses"`
+		MaxUsesPerUser int            `json:"max_uses_per_user"`
+		ResellerID     string         `json:"reseller_id"`
+		ClothingType   string         `json:"clothing_type"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	p, err := c.promotionUC.CreatePromotion(ctx, ctx.GetString("userID"), &promotion.Promotion{
+		Code:           req.Code,
+		Type:           req.Type,
+		Value:          req.Value,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		MinBasketTotal: req.MinBasketTotal,
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+		ResellerID:     req.ResellerID,
+		ClothingType:   req.ClothingType,
+	})
+	if err != nil {
+		ctx.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Promotion created successfully",
+		Data:    p,
+	})
+}
+
+// GET /admin/promotions
+func (c *PromotionController) ListPromotions(ctx *gin.Context) {
+	promotions, err := c.promotionUC.ListPromotions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch promotions"})
+		return
+	}
+	if promotions == nil {
+		promotions = []*promotion.Promotion{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Promotions retrieved successfully",
+		Data:    promotions,
+	})
+}
+
+// PUT /admin/promotions/:code/active pauses or resumes a promo code.
+func (c *PromotionController) SetActive(ctx *gin.Context) {
+	type Request struct {
+		Active *bool `json:"active" binding:"required"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload; active is required"})
+		return
+	}
+
+	p, err := c.promotionUC.SetActive(ctx, ctx.Param("code"), *req.Active)
+	if err != nil {
+		ctx.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Promotion updated successfully",
+		Data:    p,
+	})
+}
+
+func promotionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, promotion.ErrInvalidPromotion):
+		return http.StatusBadRequest
+	case errors.Is(err, promotion.ErrPromotionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, promotion.ErrPromotionExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
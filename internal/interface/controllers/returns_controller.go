@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/returns"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type ReturnsController struct {
+	returnsUC returns.Usecase
+}
+
+func NewReturnsController(returnsUC returns.Usecase) *ReturnsController {
+	return &ReturnsController{returnsUC: returnsUC}
+}
+
+// POST /returns asks to send back one product of a delivered order.
+func (c *ReturnsController) RequestReturn(ctx *gin.Context) {
+	var req returns.Request
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.OrderID == "" || req.ProductID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	r, err := c.returnsUC.RequestReturn(ctx, ctx.GetString("userID"), req)
+	if err != nil {
+		ctx.JSON(returnsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Return requested successfully",
+		Data:    r,
+	})
+}
+
+// GET /returns lists the returns the caller has requested or has to handle.
+func (c *ReturnsController) ListMyReturns(ctx *gin.Context) {
+	list, err := c.returnsUC.ListMyReturns(ctx, ctx.GetString("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch returns"})
+		return
+	}
+	if list == nil {
+		list = []*returns.Return{}
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Returns retrieved successfully",
+		Data:    list,
+	})
+}
+
+// GET /returns/:id
+func (c *ReturnsController) GetReturn(ctx *gin.Context) {
+	r, err := c.returnsUC.GetReturn(ctx, ctx.Param("id"), ctx.GetString("userID"), user.Role(ctx.GetString("role")))
+	if err != nil {
+		ctx.JSON(returnsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Return retrieved successfully",
+		Data:    r,
+	})
+}
+
+// POST /returns/:id/decision approves or rejects a return. Rejecting needs a
+// note explaining why.
+func (c *ReturnsController) DecideReturn(ctx *gin.Context) {
+	type Request struct {
+		Outcome string `json:"outcome"` // approve or reject
+		Note    string `json:"note"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if req.Outcome != "approve" && req.Outcome != "reject" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "outcome must be approve or reject"})
+		return
+	}
+
+	r, err := c.returnsUC.Decide(ctx, ctx.Param("id"), ctx.GetString("userID"), req.Outcome == "approve", req.Note)
+	if err != nil {
+		ctx.JSON(returnsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Return updated successfully",
+		Data:    r,
+	})
+}
+
+// POST /returns/:id/ship records that the consumer has sent the item back.
+func (c *ReturnsController) MarkShipped(ctx *gin.Context) {
+	type Request struct {
+		TrackingNumber string `json:"tracking_number"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	r, err := c.returnsUC.MarkShipped(ctx, ctx.Param("id"), ctx.GetString("userID"), req.TrackingNumber)
+	if err != nil {
+		ctx.JSON(returnsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Return marked as shipped",
+		Data:    r,
+	})
+}
+
+// POST /returns/:id/receive confirms the item is back and refunds it.
+func (c *ReturnsController) ConfirmReceipt(ctx *gin.Context) {
+	type Request struct {
+		Condition returns.Condition `json:"condition"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	r, err := c.returnsUC.ConfirmReceipt(ctx, ctx.Param("id"), ctx.GetString("userID"), req.Condition)
+	if err != nil {
+		ctx.JSON(returnsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Return received and refunded",
+		Data:    r,
+	})
+}
+
+// GET /return-policies/:resellerId
+func (c *ReturnsController) GetPolicy(ctx *gin.Context) {
+	p, err := c.returnsUC.GetPolicy(ctx, ctx.Param("resellerId"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch return policy"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Return policy retrieved successfully",
+		Data:    p,
+	})
+}
+
+// PUT /return-policies sets the calling reseller's return window.
+func (c *ReturnsController) SetPolicy(ctx *gin.Context) {
+	type Request struct {
+		WindowDays *int `json:"window_days"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.WindowDays == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	p, err := c.returnsUC.SetPolicy(ctx, ctx.GetString("userID"), *req.WindowDays)
+	if err != nil {
+		ctx.JSON(returnsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Return policy updated successfully",
+		Data:    p,
+	})
+}
+
+// returnsErrorStatus maps return failures, and the order failures behind a
+// refund, to client errors.
+func returnsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, returns.ErrInvalidReason), errors.Is(err, returns.ErrInvalidPhoto), errors.Is(err, returns.ErrNotInOrder),
+		errors.Is(err, returns.ErrReasonRequired), errors.Is(err, returns.ErrInvalidCondition), errors.Is(err, returns.ErrInvalidPolicy):
+		return http.StatusBadRequest
+	case errors.Is(err, returns.ErrReturnNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, returns.ErrNotReturnable), errors.Is(err, returns.ErrWindowClosed), errors.Is(err, returns.ErrReturnsNotAccepted),
+		errors.Is(err, returns.ErrReturnExists), errors.Is(err, returns.ErrInvalidTransition), errors.Is(err, returns.ErrResponseOverdue),
+		errors.Is(err, returns.ErrReturnChanged):
+		return http.StatusConflict
+	default:
+		return orderErrorStatus(err)
+	}
+}
@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/returns"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockReturnsUsecase struct {
+	mock.Mock
+}
+
+func (m *MockReturnsUsecase) RequestReturn(ctx context.Context, consumerID string, req returns.Request) (*returns.Return, error) {
+	args := m.Called(ctx, consumerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsUsecase) GetReturn(ctx context.Context, id, actorID string, role user.Role) (*returns.Return, error) {
+	args := m.Called(ctx, id, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsUsecase) ListMyReturns(ctx context.Context, userID string) ([]*returns.Return, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsUsecase) Decide(ctx context.Context, id, resellerID string, approve bool, note string) (*returns.Return, error) {
+	args := m.Called(ctx, id, resellerID, approve, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsUsecase) MarkShipped(ctx context.Context, id, consumerID, trackingNumber string) (*returns.Return, error) {
+	args := m.Called(ctx, id, consumerID, trackingNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsUsecase) ConfirmReceipt(ctx context.Context, id, resellerID string, condition returns.Condition) (*returns.Return, error) {
+	args := m.Called(ctx, id, resellerID, condition)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsUsecase) ApproveOverdue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockReturnsUsecase) GetPolicy(ctx context.Context, resellerID string) (*returns.Policy, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Policy), args.Error(1)
+}
+
+func (m *MockReturnsUsecase) SetPolicy(ctx context.Context, resellerID string, windowDays int) (*returns.Policy, error) {
+	args := m.Called(ctx, resellerID, windowDays)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Policy), args.Error(1)
+}
+
+type ReturnsControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockReturnsUsecase
+	controller *ReturnsController
+}
+
+func (suite *ReturnsControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockReturnsUsecase)
+	suite.controller = NewReturnsController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestReturnsControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReturnsControllerTestSuite))
+}
+
+func (suite *ReturnsControllerTestSuite) TestRequestReturn_Success() {
+	// Setup
+	suite.usecase.On("RequestReturn", mock.Anything, "consumer1", mock.MatchedBy(func(r returns.Request) bool {
+		return r.OrderID == "order1" && r.ProductID == "p1" && r.Reason == returns.ReasonWrongSize && len(r.Photos) == 1
+	})).Return(&returns.Return{ID: "ret1", Status: returns.StatusRequested}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/returns", strings.NewReader(`{"order_id":"order1","product_id":"p1","reason":"wrong_size","photos":[{"url":"https://img.example.com/a.jpg"}]}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "consumer1")
+
+	// Execute
+	suite.controller.RequestReturn(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ReturnsControllerTestSuite) TestRequestReturn_WindowClosed() {
+	// Setup
+	suite.usecase.On("RequestReturn", mock.Anything, "consumer1", mock.Anything).Return(nil, returns.ErrWindowClosed)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/returns", strings.NewReader(`{"order_id":"order1","product_id":"p1","reason":"wrong_size"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "consumer1")
+
+	// Execute
+	suite.controller.RequestReturn(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *ReturnsControllerTestSuite) TestDecideReturn_InvalidOutcome() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "ret1"}}
+	c.Request = httptest.NewRequest("POST", "/returns/ret1/decision", strings.NewReader(`{"outcome":"maybe"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.DecideReturn(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "Decide", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ReturnsControllerTestSuite) TestConfirmReceipt_Damaged() {
+	// Setup
+	suite.usecase.On("ConfirmReceipt", mock.Anything, "ret1", "reseller1", returns.ConditionDamaged).
+		Return(&returns.Return{ID: "ret1", Status: returns.StatusReceived, Condition: returns.ConditionDamaged}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "ret1"}}
+	c.Request = httptest.NewRequest("POST", "/returns/ret1/receive", strings.NewReader(`{"condition":"damaged"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.ConfirmReceipt(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ReturnsControllerTestSuite) TestSetPolicy_MissingWindow() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/return-policies", strings.NewReader(`{}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.SetPolicy(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "SetPolicy", mock.Anything, mock.Anything, mock.Anything)
+}
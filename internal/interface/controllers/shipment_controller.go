@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type ShipmentController struct {
+	shipmentUC shipment.Usecase
+}
+
+func NewShipmentController(shipmentUC shipment.Usecase) *ShipmentController {
+	return &ShipmentController{shipmentUC: shipmentUC}
+}
+
+// POST /orders/:id/ship
+func (c *ShipmentController) ShipOrder(ctx *gin.Context) {
+	type Request struct {
+		Carrier        string `json:"carrier" binding:"required"`
+		TrackingNumber string `json:"tracking_number" binding:"required"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload; carrier and tracking_number are required"})
+		return
+	}
+
+	resellerID := ctx.GetString("userID")
+	if resellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	s, err := c.shipmentUC.ShipOrder(ctx, ctx.Param("id"), resellerID, req.Carrier, req.TrackingNumber)
+	if err != nil {
+		ctx.JSON(shipmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Order shipped successfully",
+		Data:    s,
+	})
+}
+
+// GET /orders/:id/tracking
+func (c *ShipmentController) GetTracking(ctx *gin.Context) {
+	actorID := ctx.GetString("userID")
+	if actorID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	s, err := c.shipmentUC.GetTracking(ctx, ctx.Param("id"), actorID, user.Role(ctx.GetString("role")))
+	if err != nil {
+		ctx.JSON(shipmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Tracking retrieved successfully",
+		Data:    s,
+	})
+}
+
+// POST /orders/:id/confirm-delivery
+func (c *ShipmentController) ConfirmDelivery(ctx *gin.Context) {
+	actorID := ctx.GetString("userID")
+	if actorID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	s, err := c.shipmentUC.ConfirmDelivery(ctx, ctx.Param("id"), actorID, user.Role(ctx.GetString("role")))
+	if err != nil {
+		ctx.JSON(shipmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Delivery confirmed",
+		Data:    s,
+	})
+}
+
+func shipmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, order.ErrOrderNotFound), errors.Is(err, shipment.ErrShipmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, order.ErrNotOrderParty):
+		return http.StatusForbidden
+	case errors.Is(err, order.ErrInvalidTransition), errors.Is(err, order.ErrStatusChanged):
+		return http.StatusConflict
+	case errors.Is(err, shipment.ErrCarrierRequired), errors.Is(err, shipment.ErrTrackingNumberRequired), errors.Is(err, shipment.ErrNotConsumerOrder):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
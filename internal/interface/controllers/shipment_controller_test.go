@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockShipmentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockShipmentUsecase) ShipOrder(ctx context.Context, orderID, resellerID, carrier, trackingNumber string) (*shipment.Shipment, error) {
+	args := m.Called(ctx, orderID, resellerID, carrier, trackingNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipment.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentUsecase) GetTracking(ctx context.Context, orderID, actorID string, role user.Role) (*shipment.Shipment, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipment.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentUsecase) ConfirmDelivery(ctx context.Context, orderID, actorID string, role user.Role) (*shipment.Shipment, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipment.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentUsecase) SyncTracking(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+type ShipmentControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockShipmentUsecase
+	controller *ShipmentController
+}
+
+func (suite *ShipmentControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockShipmentUsecase)
+	suite.controller = NewShipmentController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestShipmentControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ShipmentControllerTestSuite))
+}
+
+func (suite *ShipmentControllerTestSuite) TestShipOrder_Success() {
+	// Setup
+	s := &shipment.Shipment{ID: "ship1", OrderID: "order1", Carrier: "local", TrackingNumber: "TRK123", Status: shipment.StatusInTransit}
+	suite.usecase.On("ShipOrder", mock.Anything, "order1", "reseller1", "local", "TRK123").Return(s, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order1"}}
+	c.Request = httptest.NewRequest("POST", "/orders/order1/ship", strings.NewReader(`{"carrier":"local","tracking_number":"TRK123"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.ShipOrder(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "TRK123")
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ShipmentControllerTestSuite) TestShipOrder_MissingTrackingNumber() {
+	// Setup
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order1"}}
+	c.Request = httptest.NewRequest("POST", "/orders/order1/ship", strings.NewReader(`{"carrier":"local"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.ShipOrder(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "ShipOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShipmentControllerTestSuite) TestShipOrder_AlreadyShipped() {
+	// Setup
+	suite.usecase.On("ShipOrder", mock.Anything, "order1", "reseller1", "local", "TRK123").Return(nil, order.ErrInvalidTransition)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order1"}}
+	c.Request = httptest.NewRequest("POST", "/orders/order1/ship", strings.NewReader(`{"carrier":"local","tracking_number":"TRK123"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "reseller1")
+
+	// Execute
+	suite.controller.ShipOrder(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ShipmentControllerTestSuite) TestGetTracking_NotShipped() {
+	// Setup
+	suite.usecase.On("GetTracking", mock.Anything, "order1", "consumer1", user.RoleConsumer).Return(nil, shipment.ErrShipmentNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order1"}}
+	c.Request = httptest.NewRequest("GET", "/orders/order1/tracking", nil)
+	c.Set("userID", "consumer1")
+	c.Set("role", string(user.RoleConsumer))
+
+	// Execute
+	suite.controller.GetTracking(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ShipmentControllerTestSuite) TestConfirmDelivery_NotParty() {
+	// Setup
+	suite.usecase.On("ConfirmDelivery", mock.Anything, "order1", "consumer2", user.RoleConsumer).Return(nil, order.ErrNotOrderParty)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "order1"}}
+	c.Request = httptest.NewRequest("POST", "/orders/order1/confirm-delivery", nil)
+	c.Set("userID", "consumer2")
+	c.Set("role", string(user.RoleConsumer))
+
+	// Execute
+	suite.controller.ConfirmDelivery(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
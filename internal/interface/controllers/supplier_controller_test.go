@@ -8,8 +8,10 @@ import (
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -37,6 +39,14 @@ func (m *MockOrderUsecase) GetDashboardMetrics(ctx context.Context, supplierID s
 	return args.Get(0).(*order.DashboardMetrics), args.Error(1)
 }
 
+func (m *MockOrderUsecase) GetOrdersToFulfil(ctx context.Context, resellerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
 func (m *MockOrderUsecase) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
 	args := m.Called(ctx, resellerID)
 	if args.Get(0) == nil {
@@ -53,6 +63,77 @@ func (m *MockOrderUsecase) GetOrderByID(ctx context.Context, orderID string) (*o
 	return args.Get(0).(*order.Order), args.Error(1)
 }
 
+func (m *MockOrderUsecase) CancelOrder(ctx context.Context, orderID, actorID string, role user.Role, reason string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, actorID, role, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUsecase) RefundOrder(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, actorID, role, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUsecase) RecordRefund(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, actorID, role, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUsecase) SettleRefund(ctx context.Context, refundID string) error {
+	args := m.Called(ctx, refundID)
+	return args.Error(0)
+}
+
+func (m *MockOrderUsecase) GetEscrow(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrderUsecase) DisputeEscrow(ctx context.Context, orderID, resellerID, reason string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, resellerID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrderUsecase) ResolveEscrow(ctx context.Context, orderID, adminID string, refund bool, note string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, adminID, refund, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrderUsecase) ConfirmArrival(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrderUsecase) ReleaseEscrow(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderUsecase) ReleaseOnArrival(ctx context.Context, warehouseItemID string) error {
+	args := m.Called(ctx, warehouseItemID)
+	return args.Error(0)
+}
+
 func (m *MockOrderUsecase) GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*order.Order, error) {
 	args := m.Called(ctx, supplierID)
 	if args.Get(0) == nil {
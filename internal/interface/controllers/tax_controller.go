@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+// reportDateLayout is how tax report bounds are given, e.g. 2025-06-30.
+const reportDateLayout = "2006-01-02"
+
+type TaxController struct {
+	taxUC tax.Usecase
+}
+
+func NewTaxController(taxUC tax.Usecase) *TaxController {
+	return &TaxController{taxUC: taxUC}
+}
+
+// GET /admin/tax
+func (c *TaxController) GetPolicy(ctx *gin.Context) {
+	p, err := c.taxUC.GetPolicy(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tax policy"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Tax policy retrieved successfully",
+		Data:    p,
+	})
+}
+
+// PUT /admin/tax replaces the whole policy with a new version.
+func (c *TaxController) UpdatePolicy(ctx *gin.Context) {
+	type Request struct {
+		Mode  tax.Mode   `json:"mode"`
+		Rules []tax.Rule `json:"rules"`
+		Note  string     `json:"note" binding:"required"`
+	}
+
+	var req Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload; a note explaining the change is required"})
+		return
+	}
+
+	p, err := c.taxUC.UpdatePolicy(ctx, ctx.GetString("userID"), &tax.Policy{
+		Mode:  req.Mode,
+		Rules: req.Rules,
+		Note:  req.Note,
+	})
+	if err != nil {
+		ctx.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Tax policy updated",
+		Data:    p,
+	})
+}
+
+// GET /admin/tax/history
+func (c *TaxController) GetPolicyHistory(ctx *gin.Context) {
+	policies, err := c.taxUC.GetPolicyHistory(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tax policy history"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Tax policy history retrieved successfully",
+		Data:    policies,
+	})
+}
+
+// GET /admin/tax/report?from=2025-01-01&to=2025-06-30&period=month sums the
+// tax collected between the two dates, both included.
+func (c *TaxController) GetReport(ctx *gin.Context) {
+	from, err := time.Parse(reportDateLayout, ctx.Query("from"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "from must be a date such as 2025-01-01"})
+		return
+	}
+	to, err := time.Parse(reportDateLayout, ctx.Query("to"))
+	if err != nil || to.Before(from) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "to must be a date no earlier than from"})
+		return
+	}
+	period, err := tax.ParsePeriod(ctx.Query("period"))
+	if err != nil {
+		ctx.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.taxUC.GetReport(ctx, from, to.AddDate(0, 0, 1), period)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build tax report"})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Tax report generated",
+		Data:    report,
+	})
+}
+
+func taxErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tax.ErrInvalidPolicy), errors.Is(err, tax.ErrInvalidPeriod):
+		return http.StatusBadRequest
+	case errors.Is(err, tax.ErrPolicyConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockTaxUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTaxUsecase) Assess(ctx context.Context, s tax.Sale) (tax.Assessment, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).(tax.Assessment), args.Error(1)
+}
+
+func (m *MockTaxUsecase) GetPolicy(ctx context.Context) (*tax.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tax.Policy), args.Error(1)
+}
+
+func (m *MockTaxUsecase) UpdatePolicy(ctx context.Context, adminID string, p *tax.Policy) (*tax.Policy, error) {
+	args := m.Called(ctx, adminID, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tax.Policy), args.Error(1)
+}
+
+func (m *MockTaxUsecase) GetPolicyHistory(ctx context.Context) ([]*tax.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*tax.Policy), args.Error(1)
+}
+
+func (m *MockTaxUsecase) GetReport(ctx context.Context, from, to time.Time, period tax.Period) (*tax.Report, error) {
+	args := m.Called(ctx, from, to, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tax.Report), args.Error(1)
+}
+
+type TaxControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockTaxUsecase
+	controller *TaxController
+}
+
+func (suite *TaxControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockTaxUsecase)
+	suite.controller = NewTaxController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestTaxControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(TaxControllerTestSuite))
+}
+
+func (suite *TaxControllerTestSuite) TestUpdatePolicy_Success() {
+	// Setup
+	saved := &tax.Policy{Version: 1, Mode: tax.ModeInclusive, Note: "VAT"}
+	suite.usecase.On("UpdatePolicy", mock.Anything, "admin1", mock.MatchedBy(func(p *tax.Policy) bool {
+		return p.Mode == tax.ModeInclusive && len(p.Rules) == 1 && p.Rules[0].SellerJurisdiction == "ET" && p.Rules[0].Rate == 0.15
+	})).Return(saved, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/admin/tax", strings.NewReader(`{"mode":"inclusive","rules":[{"name":"et_vat","seller_jurisdiction":"ET","rate":0.15}],"note":"VAT"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", "admin1")
+
+	// Execute
+	suite.controller.UpdatePolicy(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"version":1`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *TaxControllerTestSuite) TestGetReport_Success() {
+	// Setup
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	suite.usecase.On("GetReport", mock.Anything, from, to, tax.PeriodMonth).
+		Return(&tax.Report{From: from, To: to, Period: tax.PeriodMonth, Rows: []tax.ReportRow{}, TotalTax: 42.5}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/admin/tax/report?from=2025-01-01&to=2025-06-30", nil)
+
+	// Execute
+	suite.controller.GetReport(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"total_tax":42.5`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *TaxControllerTestSuite) TestGetReport_BadQuery() {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing from", "to=2025-06-30"},
+		{"to before from", "from=2025-06-30&to=2025-01-01"},
+		{"unknown period", "from=2025-01-01&to=2025-06-30&period=week"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/admin/tax/report?"+tt.query, nil)
+
+			suite.controller.GetReport(c)
+
+			assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+		})
+	}
+	suite.usecase.AssertNotCalled(suite.T(), "GetReport", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
 	"github.com/gin-gonic/gin"
@@ -42,3 +44,34 @@ func (c *WarehouseController) GetWarehouseItems(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, items)
 }
+
+func (c *WarehouseController) MarkItemArrived(ctx *gin.Context) {
+	actorID := ctx.GetString("userID")
+	if actorID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	item, err := c.warehouseUsecase.MarkItemArrived(ctx, ctx.Param("id"), actorID, user.Role(ctx.GetString("role")))
+	if err != nil {
+		ctx.JSON(warehouseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Arrival recorded; any payment held for the bundle was released to the supplier",
+		Data:    item,
+	})
+}
+
+// warehouseErrorStatus maps warehouse and escrow failures to client errors.
+func warehouseErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, warehouse.ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, warehouse.ErrAlreadyArrived):
+		return http.StatusConflict
+	default:
+		return orderErrorStatus(err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +26,14 @@ func (m *MockWarehouseUsecase) GetWarehouseItems(ctx context.Context, resellerID
 	return args.Get(0).([]*warehouse.WarehouseItem), args.Error(1)
 }
 
+func (m *MockWarehouseUsecase) MarkItemArrived(ctx context.Context, itemID, actorID string, role user.Role) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, itemID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
 type WarehouseControllerTestSuite struct {
 	suite.Suite
 	usecase    *MockWarehouseUsecase
@@ -147,3 +156,20 @@ func (suite *WarehouseControllerTestSuite) TestGetWarehouseItems_UseCaseError()
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
 	suite.usecase.AssertExpectations(suite.T())
 }
+
+func (suite *WarehouseControllerTestSuite) TestMarkItemArrived_AlreadyArrived() {
+	suite.usecase.On("MarkItemArrived", mock.Anything, "item1", "reseller123", user.RoleReseller).
+		Return(nil, warehouse.ErrAlreadyArrived)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+	c.Set("role", "reseller")
+	c.Params = gin.Params{{Key: "id", Value: "item1"}}
+	c.Request = httptest.NewRequest("POST", "/warehouse/item1/arrived", nil)
+
+	suite.controller.MarkItemArrived(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
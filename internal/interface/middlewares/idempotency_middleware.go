@@ -0,0 +1,138 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/idempotency"
+	"github.com/gin-gonic/gin"
+)
+
+// Idempotency makes a request carrying an Idempotency-Key header run at most
+// once per user and key. Repeating it replays the stored response; reusing
+// the key for a different request is rejected. Requests without the header
+// are passed through. It must run after AuthMiddleware.
+func Idempotency(repo idempotency.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotency.Header)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > idempotency.MaxKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": idempotency.ErrInvalidKey.Error()})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := c.GetString("userID")
+		now := time.Now()
+		rec := &idempotency.Record{
+			ID:          idempotency.RecordID(userID, key),
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash(c.Request, body),
+			Status:      idempotency.StatusInProgress,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(idempotency.Retention),
+		}
+
+		err = repo.Reserve(c, rec, now)
+		if errors.Is(err, idempotency.ErrKeyInUse) {
+			replay(c, repo, rec)
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to record idempotency key"})
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		finished := false
+		defer func() {
+			// Only a request that panicked or failed with a server error may be
+			// retried. Anything else may already have charged the buyer.
+			if finished && recorder.Status() < http.StatusInternalServerError {
+				return
+			}
+			if err := repo.Release(c, userID, key); err != nil {
+				log.Println("Failed to release idempotency key:", err)
+			}
+		}()
+
+		c.Next()
+		finished = true
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		rec.Status = idempotency.StatusCompleted
+		rec.ResponseStatus = recorder.Status()
+		rec.ContentType = recorder.Header().Get("Content-Type")
+		rec.ResponseBody = recorder.body.Bytes()
+		if err := repo.Complete(c, rec); err != nil {
+			// The key stays in progress until it expires, so a retry is
+			// refused rather than run a second time.
+			log.Println("Failed to store idempotent response:", err)
+		}
+	}
+}
+
+// replay answers a repeated request from the record of the first one.
+func replay(c *gin.Context, repo idempotency.Repository, rec *idempotency.Record) {
+	stored, err := repo.GetRecord(c, rec.UserID, rec.Key)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up idempotency key"})
+		return
+	}
+	switch {
+	case stored == nil:
+		// Released by a failed attempt between our reserve and lookup.
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": idempotency.ErrRequestInFlight.Error()})
+	case stored.RequestHash != rec.RequestHash:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": idempotency.ErrKeyReused.Error()})
+	case stored.Status == idempotency.StatusInProgress:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": idempotency.ErrRequestInFlight.Error()})
+	default:
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(stored.ResponseStatus, stored.ContentType, stored.ResponseBody)
+		c.Abort()
+	}
+}
+
+// requestHash identifies a request by its method, path and body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body as it is written.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
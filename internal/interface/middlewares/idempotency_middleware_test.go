@@ -0,0 +1,208 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/idempotency"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyRepo struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepo) Reserve(ctx context.Context, r *idempotency.Record, now time.Time) error {
+	args := m.Called(ctx, r, now)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepo) GetRecord(ctx context.Context, userID, key string) (*idempotency.Record, error) {
+	args := m.Called(ctx, userID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*idempotency.Record), args.Error(1)
+}
+
+func (m *MockIdempotencyRepo) Complete(ctx context.Context, r *idempotency.Record) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepo) Release(ctx context.Context, userID, key string) error {
+	args := m.Called(ctx, userID, key)
+	return args.Error(0)
+}
+
+// idempotentRouter serves POST /orders behind the middleware and counts how
+// many times the handler ran.
+func idempotentRouter(repo idempotency.Repository, status int, calls *int) *gin.Engine {
+	r := setupRouter()
+	r.POST("/orders", func(c *gin.Context) {
+		c.Set("userID", "reseller1")
+	}, Idempotency(repo), func(c *gin.Context) {
+		*calls++
+		c.JSON(status, gin.H{"order_id": "order1"})
+	})
+	return r
+}
+
+func postOrder(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(body))
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_NoKey(t *testing.T) {
+	repo := new(MockIdempotencyRepo)
+	calls := 0
+	r := idempotentRouter(repo, http.StatusCreated, &calls)
+
+	w := postOrder(r, "", `{"bundle_id":"b1"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 1, calls)
+	repo.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_FirstRequestStoresResponse(t *testing.T) {
+	repo := new(MockIdempotencyRepo)
+	repo.On("Reserve", mock.Anything, mock.MatchedBy(func(rec *idempotency.Record) bool {
+		return rec.ID == "reseller1:k1" && rec.Status == idempotency.StatusInProgress
+	}), mock.Anything).Return(nil)
+	repo.On("Complete", mock.Anything, mock.MatchedBy(func(rec *idempotency.Record) bool {
+		return rec.Status == idempotency.StatusCompleted && rec.ResponseStatus == http.StatusCreated &&
+			string(rec.ResponseBody) == `{"order_id":"order1"}`
+	})).Return(nil)
+	calls := 0
+	r := idempotentRouter(repo, http.StatusCreated, &calls)
+
+	w := postOrder(r, "k1", `{"bundle_id":"b1"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 1, calls)
+	repo.AssertExpectations(t)
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	repo := new(MockIdempotencyRepo)
+	repo.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	repo.On("Release", mock.Anything, "reseller1", "k1").Return(nil)
+	calls := 0
+	r := idempotentRouter(repo, http.StatusInternalServerError, &calls)
+
+	w := postOrder(r, "k1", `{"bundle_id":"b1"}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_PanicReleasesKey(t *testing.T) {
+	repo := new(MockIdempotencyRepo)
+	repo.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	repo.On("Release", mock.Anything, "reseller1", "k1").Return(nil)
+	r := setupRouter()
+	r.Use(gin.Recovery())
+	r.POST("/orders", func(c *gin.Context) {
+		c.Set("userID", "reseller1")
+	}, Idempotency(repo), func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := postOrder(r, "k1", `{"bundle_id":"b1"}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_CompleteFailsKeepsKey(t *testing.T) {
+	repo := new(MockIdempotencyRepo)
+	repo.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	repo.On("Complete", mock.Anything, mock.Anything).Return(errors.New("db down"))
+	calls := 0
+	r := idempotentRouter(repo, http.StatusCreated, &calls)
+
+	w := postOrder(r, "k1", `{"bundle_id":"b1"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	// The purchase went through, so a retry must not be able to run it again.
+	repo.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_RepeatedRequest(t *testing.T) {
+	body := `{"bundle_id":"b1"}`
+	first := httptest.NewRequest("POST", "/orders", nil)
+	hash := requestHash(first, []byte(body))
+
+	tests := []struct {
+		name           string
+		stored         *idempotency.Record
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "replays stored response",
+			stored:         &idempotency.Record{RequestHash: hash, Status: idempotency.StatusCompleted, ResponseStatus: http.StatusCreated, ContentType: "application/json", ResponseBody: []byte(`{"order_id":"order1"}`)},
+			body:           body,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"order_id":"order1"}`,
+		},
+		{
+			name:           "different body",
+			stored:         &idempotency.Record{RequestHash: hash, Status: idempotency.StatusCompleted},
+			body:           `{"bundle_id":"b2"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"Idempotency-Key was already used with a different request"}`,
+		},
+		{
+			name:           "first request still running",
+			stored:         &idempotency.Record{RequestHash: hash, Status: idempotency.StatusInProgress},
+			body:           body,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"a request with this Idempotency-Key is still being processed"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockIdempotencyRepo)
+			repo.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(idempotency.ErrKeyInUse)
+			repo.On("GetRecord", mock.Anything, "reseller1", "k1").Return(tt.stored, nil)
+			calls := 0
+			r := idempotentRouter(repo, http.StatusCreated, &calls)
+
+			w := postOrder(r, "k1", tt.body)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, 0, calls)
+		})
+	}
+}
+
+func TestIdempotency_ReserveFails(t *testing.T) {
+	repo := new(MockIdempotencyRepo)
+	repo.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db down"))
+	calls := 0
+	r := idempotentRouter(repo, http.StatusCreated, &calls)
+
+	w := postOrder(r, "k1", `{"bundle_id":"b1"}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, 0, calls)
+}
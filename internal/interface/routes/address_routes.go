@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAddressRoutes(r *gin.Engine, ctrl *controllers.AddressController, jwtSvc auth.JWTService) {
+	addressGroup := r.Group("/addresses")
+	addressGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("consumer"))
+
+	addressGroup.GET("", ctrl.ListAddresses)
+	addressGroup.POST("", ctrl.AddAddress)
+	addressGroup.PUT("/:id", ctrl.UpdateAddress)
+	addressGroup.DELETE("/:id", ctrl.DeleteAddress)
+	addressGroup.POST("/:id/default", ctrl.SetDefaultAddress)
+}
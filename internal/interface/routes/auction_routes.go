@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAuctionRoutes(r *gin.Engine, ctrl *controllers.AuctionController, jwtSvc auth.JWTService) {
+	auctionGroup := r.Group("/auctions")
+	auctionGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	auctionGroup.POST("", middlewares.AuthorizeRoles("supplier"), ctrl.CreateAuction)
+	auctionGroup.GET("", ctrl.ListAuctions)
+	auctionGroup.GET("/:id", ctrl.GetAuction)
+	auctionGroup.POST("/:id/bids", middlewares.AuthorizeRoles("reseller"), ctrl.PlaceBid)
+	auctionGroup.GET("/:id/bids", ctrl.ListBids)
+}
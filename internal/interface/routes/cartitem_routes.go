@@ -2,12 +2,13 @@ package routes
 
 import (
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/idempotency"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterCartItemRoutes(r *gin.Engine, ctrl *controllers.CartItemController, jwtSvc auth.JWTService) {
+func RegisterCartItemRoutes(r *gin.Engine, ctrl *controllers.CartItemController, jwtSvc auth.JWTService, idemRepo idempotency.Repository) {
 	// Cart group for cart item related routes.
 	cartGroup := r.Group("/api/cart")
 	cartGroup.Use(middlewares.AuthMiddleware(jwtSvc))
@@ -22,8 +23,9 @@ func RegisterCartItemRoutes(r *gin.Engine, ctrl *controllers.CartItemController,
 	cartGroup.DELETE("/items/:listingID", middlewares.AuthorizeRoles("consumer"), ctrl.RemoveCartItem)
 
 	// Checkout route. Although related to the cart, it is defined separately.
+	// An Idempotency-Key header stops a repeated checkout from charging twice.
 	checkoutGroup := r.Group("/api/checkout")
-	checkoutGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+	checkoutGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.Idempotency(idemRepo))
 	checkoutGroup.POST("", middlewares.AuthorizeRoles("consumer"), ctrl.CheckoutCart)
 	checkoutGroup.POST("/:listingId", middlewares.AuthorizeRoles("consumer"), ctrl.CheckoutSingleItem)
 }
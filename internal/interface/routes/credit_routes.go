@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterCreditRoutes(r *gin.Engine, ctrl *controllers.CreditController, jwtSvc auth.JWTService) {
+	creditGroup := r.Group("/credit")
+	creditGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("consumer"))
+	creditGroup.GET("", ctrl.GetMyCredit)
+	creditGroup.POST("/gift-cards/redeem", ctrl.RedeemGiftCard)
+
+	adminGroup := r.Group("/admin")
+	adminGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+	adminGroup.GET("/credit/:userId", ctrl.GetUserCredit)
+	adminGroup.POST("/credit/:userId", ctrl.IssueCredit)
+	adminGroup.POST("/gift-cards", ctrl.IssueGiftCard)
+	adminGroup.GET("/gift-cards", ctrl.ListGiftCards)
+}
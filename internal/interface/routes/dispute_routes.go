@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterDisputeRoutes(r *gin.Engine, ctrl *controllers.DisputeController, jwtSvc auth.JWTService) {
+	disputeGroup := r.Group("/disputes")
+	disputeGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	disputeGroup.POST("", middlewares.AuthorizeRoles("consumer", "reseller"), ctrl.OpenDispute)
+	disputeGroup.GET("", middlewares.AuthorizeRoles("consumer", "reseller", "supplier"), ctrl.ListMyDisputes)
+	disputeGroup.GET("/:id", middlewares.AuthorizeRoles("consumer", "reseller", "supplier", "admin"), ctrl.GetDispute)
+	disputeGroup.POST("/:id/messages", middlewares.AuthorizeRoles("consumer", "reseller", "supplier", "admin"), ctrl.AddMessage)
+
+	adminGroup := r.Group("/admin/disputes")
+	adminGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+
+	// GET /admin/disputes?status=
+	adminGroup.GET("", ctrl.ListDisputes)
+	adminGroup.PUT("/:id/status", ctrl.UpdateStatus)
+	adminGroup.POST("/:id/resolve", ctrl.ResolveDispute)
+}
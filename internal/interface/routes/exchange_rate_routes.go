@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterExchangeRateRoutes(r *gin.Engine, ctrl *controllers.ExchangeRateController, jwtSvc auth.JWTService) {
+	rateGroup := r.Group("/exchange-rates")
+	rateGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+	rateGroup.GET("", ctrl.ListRates)
+
+	adminGroup := r.Group("/admin/exchange-rates")
+	adminGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+	adminGroup.PUT("/:currency", ctrl.SetRate)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterFeeRoutes(r *gin.Engine, ctrl *controllers.FeeController, jwtSvc auth.JWTService) {
+	feeGroup := r.Group("/admin/fees")
+	feeGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+
+	feeGroup.GET("", ctrl.GetPolicy)
+	feeGroup.PUT("", ctrl.UpdatePolicy)
+	feeGroup.GET("/history", ctrl.GetPolicyHistory)
+}
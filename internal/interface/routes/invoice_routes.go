@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterInvoiceRoutes(r *gin.Engine, ctrl *controllers.InvoiceController, jwtSvc auth.JWTService) {
+	invoiceGroup := r.Group("/orders")
+	invoiceGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	invoiceGroup.GET("/:id/invoice", middlewares.AuthorizeRoles("consumer", "reseller", "supplier", "admin"), ctrl.GetInvoice)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterJobRoutes(r *gin.Engine, ctrl *controllers.JobController, jwtSvc auth.JWTService) {
+	jobGroup := r.Group("/admin/jobs")
+	jobGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+
+	jobGroup.GET("", ctrl.ListJobs)
+	jobGroup.GET("/:id", ctrl.GetJob)
+	jobGroup.POST("/:id/retry", ctrl.RetryJob)
+}
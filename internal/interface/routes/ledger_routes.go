@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterLedgerRoutes(r *gin.Engine, ctrl *controllers.LedgerController, jwtSvc auth.JWTService) {
+	ledgerGroup := r.Group("/ledger")
+	ledgerGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("supplier", "reseller"))
+	ledgerGroup.GET("/balance", ctrl.GetMyBalance)
+
+	adminGroup := r.Group("/admin/ledger")
+	adminGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+	adminGroup.GET("/balances/:userId", ctrl.GetUserBalance)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterNotificationRoutes(r *gin.Engine, ctrl *controllers.NotificationController, jwtSvc auth.JWTService) {
+	notificationGroup := r.Group("/notifications")
+	notificationGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	notificationGroup.GET("", ctrl.ListNotifications)
+	notificationGroup.PATCH("/:id/read", ctrl.MarkRead)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterOfferRoutes(r *gin.Engine, ctrl *controllers.OfferController, jwtSvc auth.JWTService) {
+	offerGroup := r.Group("/offers")
+	offerGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	offerGroup.POST("", middlewares.AuthorizeRoles("consumer", "reseller"), ctrl.MakeOffer)
+	offerGroup.GET("", middlewares.AuthorizeRoles("consumer", "reseller", "supplier"), ctrl.ListMyOffers)
+	offerGroup.GET("/:id", ctrl.GetOffer)
+	offerGroup.POST("/:id/respond", middlewares.AuthorizeRoles("consumer", "reseller", "supplier"), ctrl.Respond)
+}
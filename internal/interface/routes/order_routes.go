@@ -2,16 +2,27 @@ package routes
 
 import (
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/idempotency"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterOrderRoutes(r *gin.Engine, order_ctrl *controllers.OrderController, consumer_ctrl *controllers.ConsumerController, jwtSvc auth.JWTService) {
+func RegisterOrderRoutes(r *gin.Engine, order_ctrl *controllers.OrderController, consumer_ctrl *controllers.ConsumerController, jwtSvc auth.JWTService, idemRepo idempotency.Repository) {
 	consumerGroup := r.Group("/orders")
 	consumerGroup.Use(middlewares.AuthMiddleware(jwtSvc))
 
-	consumerGroup.POST("", middlewares.AuthorizeRoles("reseller"), order_ctrl.PurchaseBundle)
+	consumerGroup.POST("", middlewares.AuthorizeRoles("reseller"), middlewares.Idempotency(idemRepo), order_ctrl.PurchaseBundle)
 	consumerGroup.POST("/:id", middlewares.AuthorizeRoles("reseller", "consumer"), order_ctrl.GetOrderByID)
+	consumerGroup.POST("/:id/cancel", middlewares.AuthorizeRoles("consumer", "reseller", "supplier", "admin"), order_ctrl.CancelOrder)
+	consumerGroup.POST("/:id/refund", middlewares.AuthorizeRoles("reseller", "supplier", "admin"), order_ctrl.RefundOrder)
+	consumerGroup.GET("/:id/escrow", middlewares.AuthorizeRoles("reseller", "supplier", "admin"), order_ctrl.GetEscrow)
+	// Escrow disputes are opened and resolved through /disputes.
 	consumerGroup.GET("/history", middlewares.AuthorizeRoles("reseller", "consumer"), consumer_ctrl.GetOrderHistory)
+	consumerGroup.GET("/fulfilment", middlewares.AuthorizeRoles("reseller"), order_ctrl.GetOrdersToFulfil)
+
+	// Arrival is normally recorded by marking the warehouse item arrived.
+	resellerGroup := r.Group("/orders")
+	resellerGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("reseller", "admin"))
+	resellerGroup.POST("/:id/escrow/arrived", order_ctrl.ConfirmArrival)
 }
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPayoutRoutes(r *gin.Engine, ctrl *controllers.PayoutController, jwtSvc auth.JWTService) {
+	payoutGroup := r.Group("/payouts")
+	payoutGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("supplier", "reseller"))
+
+	payoutGroup.GET("/method", ctrl.GetPayoutMethod)
+	payoutGroup.PUT("/method", ctrl.SetPayoutMethod)
+	payoutGroup.GET("", ctrl.ListPayouts)
+	payoutGroup.GET("/:id", ctrl.GetPayout)
+}
@@ -12,6 +12,7 @@ func RegisterProductRoutes(r *gin.Engine, ctrl *controllers.ProductController, j
 	productGroup.Use(middlewares.AuthMiddleware(jwtSvc))
 
 	productGroup.POST("", middlewares.AuthorizeRoles("reseller", "admin"), ctrl.Create)
+	productGroup.GET("/search", middlewares.AuthorizeRoles("consumer", "reseller", "admin"), ctrl.Search)
 	productGroup.GET("/:id", middlewares.AuthorizeRoles("consumer", "reseller", "admin"), ctrl.GetByID)
 	productGroup.GET("", middlewares.AuthorizeRoles("consumer", "reseller", "admin"), ctrl.ListAvailable)
 	productGroup.GET("/reseller/:id", middlewares.AuthorizeRoles("admin", "reseller"), ctrl.ListByReseller)
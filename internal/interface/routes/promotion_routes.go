@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPromotionRoutes(r *gin.Engine, ctrl *controllers.PromotionController, jwtSvc auth.JWTService) {
+	promotionGroup := r.Group("/admin/promotions")
+	promotionGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+
+	promotionGroup.POST("", ctrl.CreatePromotion)
+	promotionGroup.GET("", ctrl.ListPromotions)
+	promotionGroup.PUT("/:code/active", ctrl.SetActive)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterReturnsRoutes(r *gin.Engine, ctrl *controllers.ReturnsController, jwtSvc auth.JWTService) {
+	returnsGroup := r.Group("/returns")
+	returnsGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	returnsGroup.POST("", middlewares.AuthorizeRoles("consumer"), ctrl.RequestReturn)
+	returnsGroup.GET("", middlewares.AuthorizeRoles("consumer", "reseller"), ctrl.ListMyReturns)
+	returnsGroup.GET("/:id", middlewares.AuthorizeRoles("consumer", "reseller", "admin"), ctrl.GetReturn)
+	returnsGroup.POST("/:id/decision", middlewares.AuthorizeRoles("reseller"), ctrl.DecideReturn)
+	returnsGroup.POST("/:id/ship", middlewares.AuthorizeRoles("consumer"), ctrl.MarkShipped)
+	returnsGroup.POST("/:id/receive", middlewares.AuthorizeRoles("reseller"), ctrl.ConfirmReceipt)
+
+	policyGroup := r.Group("/return-policies")
+	policyGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	policyGroup.GET("/:resellerId", ctrl.GetPolicy)
+	policyGroup.PUT("", middlewares.AuthorizeRoles("reseller"), ctrl.SetPolicy)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterShipmentRoutes(r *gin.Engine, ctrl *controllers.ShipmentController, jwtSvc auth.JWTService) {
+	shipmentGroup := r.Group("/orders")
+	shipmentGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	shipmentGroup.POST("/:id/ship", middlewares.AuthorizeRoles("reseller"), ctrl.ShipOrder)
+	shipmentGroup.GET("/:id/tracking", middlewares.AuthorizeRoles("consumer", "reseller", "admin"), ctrl.GetTracking)
+	shipmentGroup.POST("/:id/confirm-delivery", middlewares.AuthorizeRoles("consumer", "admin"), ctrl.ConfirmDelivery)
+}
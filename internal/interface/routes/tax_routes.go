@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTaxRoutes(r *gin.Engine, ctrl *controllers.TaxController, jwtSvc auth.JWTService) {
+	taxGroup := r.Group("/admin/tax")
+	taxGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+
+	taxGroup.GET("", ctrl.GetPolicy)
+	taxGroup.PUT("", ctrl.UpdatePolicy)
+	taxGroup.GET("/history", ctrl.GetPolicyHistory)
+	taxGroup.GET("/report", ctrl.GetReport)
+}
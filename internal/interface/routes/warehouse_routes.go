@@ -12,4 +12,5 @@ func RegisterWarehouseRoutes(r *gin.Engine, warehouse_ctrl *controllers.Warehous
 	warehouseGroup.Use(middlewares.AuthMiddleware(jwtSvc))
 
 	warehouseGroup.GET("", middlewares.AuthorizeRoles("reseller"), warehouse_ctrl.GetWarehouseItems)
+	warehouseGroup.POST("/:id/arrived", middlewares.AuthorizeRoles("reseller", "admin"), warehouse_ctrl.MarkItemArrived)
 }
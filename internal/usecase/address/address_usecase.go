@@ -0,0 +1,147 @@
+package addressusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/address"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/google/uuid"
+)
+
+type addressUsecase struct {
+	repo       address.Repository
+	unitOfWork uow.UnitOfWork
+}
+
+func NewAddressUsecase(repo address.Repository, unitOfWork uow.UnitOfWork) address.Usecase {
+	return &addressUsecase{repo: repo, unitOfWork: unitOfWork}
+}
+
+// AddAddress saves a new address. A user's first address always becomes
+// their default.
+func (u *addressUsecase) AddAddress(ctx context.Context, userID string, a *address.Address) (*address.Address, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	existing, err := u.repo.ListAddressesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.ID = uuid.NewString()
+	a.UserID = userID
+	a.CreatedAt = time.Now()
+	makeDefault := a.IsDefault || len(existing) == 0
+	a.IsDefault = false
+
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.CreateAddress(ctx, a); err != nil {
+			return err
+		}
+		if makeDefault {
+			return u.repo.SetDefaultAddress(ctx, userID, a.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.IsDefault = makeDefault
+	return a, nil
+}
+
+func (u *addressUsecase) ListAddresses(ctx context.Context, userID string) ([]*address.Address, error) {
+	return u.repo.ListAddressesByUser(ctx, userID)
+}
+
+// UpdateAddress replaces the fields of an address. Which address is the
+// default is changed with SetDefaultAddress only.
+func (u *addressUsecase) UpdateAddress(ctx context.Context, userID, id string, a *address.Address) (*address.Address, error) {
+	current, err := u.getOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	a.ID = current.ID
+	a.UserID = current.UserID
+	a.IsDefault = current.IsDefault
+	a.CreatedAt = current.CreatedAt
+	if err := u.repo.UpdateAddress(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// DeleteAddress removes an address. If it was the default, the user's
+// oldest remaining address takes its place.
+func (u *addressUsecase) DeleteAddress(ctx context.Context, userID, id string) error {
+	current, err := u.getOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.DeleteAddress(ctx, id); err != nil {
+			return err
+		}
+		if !current.IsDefault {
+			return nil
+		}
+		remaining, err := u.repo.ListAddressesByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		return u.repo.SetDefaultAddress(ctx, userID, remaining[0].ID)
+	})
+}
+
+func (u *addressUsecase) SetDefaultAddress(ctx context.Context, userID, id string) (*address.Address, error) {
+	a, err := u.getOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return u.repo.SetDefaultAddress(ctx, userID, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.IsDefault = true
+	return a, nil
+}
+
+func (u *addressUsecase) ResolveShippingAddress(ctx context.Context, userID, id string) (*address.Address, error) {
+	if id != "" {
+		return u.getOwned(ctx, userID, id)
+	}
+
+	addresses, err := u.repo.ListAddressesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range addresses {
+		if a.IsDefault {
+			return a, nil
+		}
+	}
+	return nil, address.ErrAddressRequired
+}
+
+// getOwned loads an address, treating other users' addresses as missing.
+func (u *addressUsecase) getOwned(ctx context.Context, userID, id string) (*address.Address, error) {
+	a, err := u.repo.GetAddressByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil || a.UserID != userID {
+		return nil, address.ErrAddressNotFound
+	}
+	return a, nil
+}
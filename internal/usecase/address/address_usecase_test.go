@@ -0,0 +1,190 @@
+package addressusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAddressRepo struct {
+	mock.Mock
+}
+
+func (m *MockAddressRepo) CreateAddress(ctx context.Context, a *address.Address) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockAddressRepo) GetAddressByID(ctx context.Context, id string) (*address.Address, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+func (m *MockAddressRepo) ListAddressesByUser(ctx context.Context, userID string) ([]*address.Address, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*address.Address), args.Error(1)
+}
+
+func (m *MockAddressRepo) UpdateAddress(ctx context.Context, a *address.Address) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockAddressRepo) DeleteAddress(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAddressRepo) SetDefaultAddress(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+func validAddress() *address.Address {
+	return &address.Address{
+		Label:    "Home",
+		FullName: "Abebe Kebede",
+		Phone:    "+251911000000",
+		Line1:    "Bole Road",
+		City:     "Addis Ababa",
+		Country:  "ET",
+	}
+}
+
+func TestAddAddress_FirstBecomesDefault(t *testing.T) {
+	repo := new(MockAddressRepo)
+	uc := NewAddressUsecase(repo, &passthroughUnitOfWork{})
+	repo.On("ListAddressesByUser", mock.Anything, "consumer1").Return([]*address.Address{}, nil)
+	repo.On("CreateAddress", mock.Anything, mock.MatchedBy(func(a *address.Address) bool {
+		return a.UserID == "consumer1" && a.ID != ""
+	})).Return(nil)
+	repo.On("SetDefaultAddress", mock.Anything, "consumer1", mock.Anything).Return(nil)
+
+	a, err := uc.AddAddress(context.Background(), "consumer1", validAddress())
+
+	assert.NoError(t, err)
+	assert.True(t, a.IsDefault)
+	repo.AssertExpectations(t)
+}
+
+func TestAddAddress_KeepsExistingDefault(t *testing.T) {
+	repo := new(MockAddressRepo)
+	uc := NewAddressUsecase(repo, &passthroughUnitOfWork{})
+	repo.On("ListAddressesByUser", mock.Anything, "consumer1").Return([]*address.Address{{ID: "addr1", UserID: "consumer1", IsDefault: true}}, nil)
+	repo.On("CreateAddress", mock.Anything, mock.Anything).Return(nil)
+
+	a, err := uc.AddAddress(context.Background(), "consumer1", validAddress())
+
+	assert.NoError(t, err)
+	assert.False(t, a.IsDefault)
+	repo.AssertNotCalled(t, "SetDefaultAddress", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddAddress_MissingField(t *testing.T) {
+	repo := new(MockAddressRepo)
+	uc := NewAddressUsecase(repo, &passthroughUnitOfWork{})
+	a := validAddress()
+	a.City = ""
+
+	_, err := uc.AddAddress(context.Background(), "consumer1", a)
+
+	assert.ErrorIs(t, err, address.ErrInvalidAddress)
+	assert.EqualError(t, err, "city is required")
+	repo.AssertNotCalled(t, "CreateAddress", mock.Anything, mock.Anything)
+}
+
+func TestUpdateAddress_OtherUsersAddress(t *testing.T) {
+	repo := new(MockAddressRepo)
+	uc := NewAddressUsecase(repo, &passthroughUnitOfWork{})
+	repo.On("GetAddressByID", mock.Anything, "addr1").Return(&address.Address{ID: "addr1", UserID: "consumer2"}, nil)
+
+	_, err := uc.UpdateAddress(context.Background(), "consumer1", "addr1", validAddress())
+
+	assert.ErrorIs(t, err, address.ErrAddressNotFound)
+	repo.AssertNotCalled(t, "UpdateAddress", mock.Anything, mock.Anything)
+}
+
+func TestUpdateAddress_KeepsDefaultFlag(t *testing.T) {
+	repo := new(MockAddressRepo)
+	uc := NewAddressUsecase(repo, &passthroughUnitOfWork{})
+	repo.On("GetAddressByID", mock.Anything, "addr1").Return(&address.Address{ID: "addr1", UserID: "consumer1", IsDefault: true}, nil)
+	repo.On("UpdateAddress", mock.Anything, mock.MatchedBy(func(a *address.Address) bool {
+		return a.ID == "addr1" && a.IsDefault && a.Line1 == "Bole Road"
+	})).Return(nil)
+
+	_, err := uc.UpdateAddress(context.Background(), "consumer1", "addr1", validAddress())
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDeleteAddress_PromotesNextDefault(t *testing.T) {
+	repo := new(MockAddressRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	uc := NewAddressUsecase(repo, unitOfWork)
+	repo.On("GetAddressByID", mock.Anything, "addr1").Return(&address.Address{ID: "addr1", UserID: "consumer1", IsDefault: true}, nil)
+	repo.On("DeleteAddress", mock.Anything, "addr1").Return(nil)
+	repo.On("ListAddressesByUser", mock.Anything, "consumer1").Return([]*address.Address{{ID: "addr2", UserID: "consumer1"}}, nil)
+	repo.On("SetDefaultAddress", mock.Anything, "consumer1", "addr2").Return(nil)
+
+	err := uc.DeleteAddress(context.Background(), "consumer1", "addr1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, unitOfWork.calls)
+	repo.AssertExpectations(t)
+}
+
+func TestResolveShippingAddress(t *testing.T) {
+	home := &address.Address{ID: "addr1", UserID: "consumer1"}
+	office := &address.Address{ID: "addr2", UserID: "consumer1", IsDefault: true}
+
+	tests := []struct {
+		name      string
+		addresses []*address.Address
+		id        string
+		want      *address.Address
+		wantErr   error
+	}{
+		{"explicit address", []*address.Address{home, office}, "addr1", home, nil},
+		{"default address", []*address.Address{home, office}, "", office, nil},
+		{"no default", []*address.Address{home}, "", nil, address.ErrAddressRequired},
+		{"unknown address", nil, "addr9", nil, address.ErrAddressNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAddressRepo)
+			uc := NewAddressUsecase(repo, &passthroughUnitOfWork{})
+			repo.On("ListAddressesByUser", mock.Anything, "consumer1").Return(tt.addresses, nil).Maybe()
+			for _, a := range tt.addresses {
+				repo.On("GetAddressByID", mock.Anything, a.ID).Return(a, nil).Maybe()
+			}
+			repo.On("GetAddressByID", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+			got, err := uc.ResolveShippingAddress(context.Background(), "consumer1", tt.id)
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package auctionusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auction"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/notification"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/google/uuid"
+)
+
+type auctionUsecase struct {
+	repo       auction.Repository
+	bundleRepo bundle.Repository
+	purchaser  auction.Purchaser
+	notifier   notification.Notifier
+	unitOfWork uow.UnitOfWork
+	scheduler  job.Scheduler
+	clock      job.Clock
+}
+
+func NewAuctionUsecase(repo auction.Repository, bundleRepo bundle.Repository, purchaser auction.Purchaser, notifier notification.Notifier, unitOfWork uow.UnitOfWork, scheduler job.Scheduler, clock job.Clock) auction.Usecase {
+	return &auctionUsecase{
+		repo:       repo,
+		bundleRepo: bundleRepo,
+		purchaser:  purchaser,
+		notifier:   notifier,
+		unitOfWork: unitOfWork,
+		scheduler:  scheduler,
+		clock:      clock,
+	}
+}
+
+func (u *auctionUsecase) CreateAuction(ctx context.Context, supplierID string, l auction.Listing) (*auction.Auction, error) {
+	now := u.clock.Now()
+	if err := l.Validate(now); err != nil {
+		return nil, err
+	}
+	b, err := u.bundleRepo.GetBundleByID(ctx, l.BundleID)
+	if err != nil {
+		return nil, err
+	}
+	if b.SupplierID != supplierID {
+		return nil, auction.ErrNotSupplier
+	}
+	if b.IsAuctioned() {
+		return nil, auction.ErrAlreadyAuctioned
+	}
+	if b.Status != "available" {
+		return nil, bundle.ErrNotAvailable
+	}
+	if b.Reservation.Holds(now) {
+		return nil, offer.ErrReserved
+	}
+	if currency := money.OrSettlement(b.Price.Currency); l.StartingPrice.Currency != currency {
+		return nil, fmt.Errorf("%w: prices must be in the bundle's currency %s", auction.ErrInvalidAuction, currency)
+	}
+
+	a := &auction.Auction{
+		ID:               uuid.NewString(),
+		BundleID:         b.ID,
+		SupplierID:       supplierID,
+		Currency:         l.StartingPrice.Currency,
+		StartingPrice:    l.StartingPrice,
+		ReservePrice:     l.ReservePrice,
+		BidIncrement:     l.BidIncrement,
+		ExtensionMinutes: l.ExtensionMinutes,
+		EndsAt:           l.EndsAt,
+		Status:           auction.StatusOpen,
+		CreatedAt:        now,
+	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// Only an available bundle that is neither up for auction nor held for
+		// an accepted offer is switched, so two requests cannot both claim it.
+		if err := u.bundleRepo.SetListingMode(ctx, b.ID, bundle.Auctioned, now); err != nil {
+			return err
+		}
+		if err := u.repo.CreateAuction(ctx, a); err != nil {
+			return err
+		}
+		return u.scheduleClose(ctx, a, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (u *auctionUsecase) GetAuction(ctx context.Context, id string) (*auction.Auction, error) {
+	return u.repo.GetAuctionByID(ctx, id)
+}
+
+func (u *auctionUsecase) ListAuctions(ctx context.Context, status auction.Status) ([]*auction.Auction, error) {
+	return u.repo.ListAuctions(ctx, status)
+}
+
+// PlaceBid stores the bid together with the new high bid. The auction is
+// only updated if no other bid landed since it was read, so of two bids at
+// the same price only the first wins.
+func (u *auctionUsecase) PlaceBid(ctx context.Context, id, resellerID string, amount float64) (*auction.Auction, error) {
+	a, err := u.repo.GetAuctionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from, bids := a.Status, a.BidCount
+	bid, err := a.PlaceBid(resellerID, money.FromMajor(amount, a.Currency), u.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	bid.ID = uuid.NewString()
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateAuction(ctx, a, from, bids); err != nil {
+			return err
+		}
+		return u.repo.AddBid(ctx, bid)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (u *auctionUsecase) ListBids(ctx context.Context, id string) ([]*auction.Bid, error) {
+	if _, err := u.repo.GetAuctionByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.repo.ListBids(ctx, id)
+}
+
+// CloseAuction moves a won auction to closing before buying the bundle, so
+// that a purchase that fails is tried again when the job is retried. A
+// declined payment is not retried: the bundle is offered to the next bidder
+// instead, and the auction ends unsold once nobody is left.
+func (u *auctionUsecase) CloseAuction(ctx context.Context, id string) error {
+	a, err := u.repo.GetAuctionByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := u.clock.Now()
+	switch a.Status {
+	case auction.StatusSold, auction.StatusUnsold:
+		return nil
+	case auction.StatusOpen:
+		if !a.Ended(now) {
+			// A late bid extended the auction; close it at its new end.
+			return u.scheduleClose(ctx, a, now)
+		}
+		if !a.ReserveMet {
+			return u.closeUnsold(ctx, a, auction.StatusOpen, now, fmt.Sprintf("The auction for bundle %s ended without meeting its reserve price.", a.BundleID))
+		}
+		a.Status = auction.StatusClosing
+		if err := u.repo.UpdateAuction(ctx, a, auction.StatusOpen, a.BidCount); err != nil {
+			return err
+		}
+	}
+
+	bids, err := u.repo.ListBids(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+	for {
+		// The purchase is keyed on the auction, so a retry returns the order
+		// an earlier attempt placed.
+		o, err := u.purchaser.PurchaseAuctionedBundle(ctx, a.ID, a.BundleID, a.HighBidderID, a.HighBid)
+		if err == nil {
+			a.OrderID = o.ID
+			break
+		}
+		if !errors.Is(err, payment.ErrPaymentDeclined) {
+			return err
+		}
+		next := a.RunnerUp(bids)
+		if next == nil {
+			return u.closeUnsold(ctx, a, auction.StatusClosing, now, fmt.Sprintf("The auction for bundle %s ended without a sale.", a.BundleID))
+		}
+		// Recording the new winner first keeps a retried job from offering
+		// the bundle to the declined bidder again.
+		a.HighBid, a.HighBidderID = next.Amount, next.BidderID
+		if err := u.repo.UpdateAuction(ctx, a, auction.StatusClosing, a.BidCount); err != nil {
+			return err
+		}
+	}
+
+	a.Status, a.ClosedAt = auction.StatusSold, &now
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateAuction(ctx, a, auction.StatusClosing, a.BidCount); err != nil {
+			return err
+		}
+		won := fmt.Sprintf("You won the auction for bundle %s at %.2f %s.", a.BundleID, a.HighBid.Major(), a.Currency)
+		if err := u.notifier.Notify(ctx, a.HighBidderID, notification.TypeAuctionWon, won, auctionData(a)); err != nil {
+			return err
+		}
+		return u.notifyLosers(ctx, a, bids, fmt.Sprintf("The auction for bundle %s was won with a bid of %.2f %s.", a.BundleID, a.HighBid.Major(), a.Currency))
+	})
+}
+
+// closeUnsold ends an auction that found no buyer, tells its bidders message
+// and puts the bundle back on sale at its fixed price.
+func (u *auctionUsecase) closeUnsold(ctx context.Context, a *auction.Auction, from auction.Status, now time.Time, message string) error {
+	bids, err := u.repo.ListBids(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+	a.Status, a.ClosedAt = auction.StatusUnsold, &now
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateAuction(ctx, a, from, a.BidCount); err != nil {
+			return err
+		}
+		if err := u.bundleRepo.SetListingMode(ctx, a.BundleID, bundle.FixedPrice, now); err != nil {
+			return err
+		}
+		return u.notifyLosers(ctx, a, bids, message)
+	})
+}
+
+func (u *auctionUsecase) scheduleClose(ctx context.Context, a *auction.Auction, now time.Time) error {
+	_, err := u.scheduler.Schedule(ctx, job.TypeCloseAuction, map[string]string{"auction_id": a.ID}, a.EndsAt.Sub(now))
+	return err
+}
+
+// notifyLosers tells every bidder but the winner, once each, that the
+// auction is over.
+func (u *auctionUsecase) notifyLosers(ctx context.Context, a *auction.Auction, bids []*auction.Bid, message string) error {
+	told := map[string]bool{}
+	for _, b := range bids {
+		if told[b.BidderID] || (a.Status == auction.StatusSold && b.BidderID == a.HighBidderID) {
+			continue
+		}
+		told[b.BidderID] = true
+		if err := u.notifier.Notify(ctx, b.BidderID, notification.TypeAuctionLost, message, auctionData(a)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func auctionData(a *auction.Auction) map[string]string {
+	return map[string]string{"auction_id": a.ID, "bundle_id": a.BundleID}
+}
@@ -0,0 +1,552 @@
+package auctionusecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auction"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/notification"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuctionRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuctionRepo) CreateAuction(ctx context.Context, a *auction.Auction) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockAuctionRepo) GetAuctionByID(ctx context.Context, id string) (*auction.Auction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auction.Auction), args.Error(1)
+}
+
+func (m *MockAuctionRepo) ListAuctions(ctx context.Context, status auction.Status) ([]*auction.Auction, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auction.Auction), args.Error(1)
+}
+
+func (m *MockAuctionRepo) UpdateAuction(ctx context.Context, a *auction.Auction, from auction.Status, bids int) error {
+	args := m.Called(ctx, a, from, bids)
+	return args.Error(0)
+}
+
+func (m *MockAuctionRepo) AddBid(ctx context.Context, b *auction.Bid) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *MockAuctionRepo) ListBids(ctx context.Context, auctionID string) ([]*auction.Bid, error) {
+	args := m.Called(ctx, auctionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auction.Bid), args.Error(1)
+}
+
+type MockBundleRepo struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepo) CreateBundle(ctx context.Context, b *bundle.Bundle) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) GetBundleByID(ctx context.Context, id string) (*bundle.Bundle, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepo) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, supplierID)
+	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepo) ListAvailableBundles(ctx context.Context, q *bundle.Query, after *bundle.Cursor, limit int) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, q, after, limit)
+	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepo) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, resellerID)
+	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepo) UpdateBundleStatus(ctx context.Context, id string, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) SetListingMode(ctx context.Context, bundleID string, mode bundle.ListingMode, now time.Time) error {
+	args := m.Called(ctx, bundleID, mode, now)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) Reserve(ctx context.Context, bundleID string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, bundleID, r, now)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) Unreserve(ctx context.Context, bundleID, offerID string) error {
+	args := m.Called(ctx, bundleID, offerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) DeleteBundle(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) UpdateBundle(ctx context.Context, id string, updatedData map[string]interface{}) error {
+	args := m.Called(ctx, id, updatedData)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) DecreaseBundleQuantity(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) CountBundles(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBundleRepo) UpdateSupplierTrust(ctx context.Context, supplierID string, score int) error {
+	args := m.Called(ctx, supplierID, score)
+	return args.Error(0)
+}
+
+type MockPurchaser struct {
+	mock.Mock
+}
+
+func (m *MockPurchaser) PurchaseAuctionedBundle(ctx context.Context, auctionID, bundleID, resellerID string, price money.Money) (*order.Order, error) {
+	args := m.Called(ctx, auctionID, bundleID, resellerID, price)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+// sentNotification is one notification handed to recordingNotifier.
+type sentNotification struct {
+	userID, notificationType string
+}
+
+// recordingNotifier keeps notifications in memory instead of storing them.
+type recordingNotifier struct {
+	sent []sentNotification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, userID, notificationType, message string, data map[string]string) error {
+	n.sent = append(n.sent, sentNotification{userID, notificationType})
+	return nil
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// recordingScheduler keeps scheduled jobs in memory instead of queueing them.
+type recordingScheduler struct {
+	jobs []*job.Job
+}
+
+func (s *recordingScheduler) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	j := &job.Job{Type: jobType, Payload: payload, RunAt: testNow.Add(delay)}
+	s.jobs = append(s.jobs, j)
+	return j, nil
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	repo      *MockAuctionRepo
+	bundles   *MockBundleRepo
+	purchaser *MockPurchaser
+	notifier  *recordingNotifier
+	uow       *passthroughUnitOfWork
+	scheduler *recordingScheduler
+	uc        auction.Usecase
+}
+
+func newFixture(now time.Time) *fixture {
+	f := &fixture{
+		repo:      new(MockAuctionRepo),
+		bundles:   new(MockBundleRepo),
+		purchaser: new(MockPurchaser),
+		notifier:  &recordingNotifier{},
+		uow:       &passthroughUnitOfWork{},
+		scheduler: &recordingScheduler{},
+	}
+	f.uc = NewAuctionUsecase(f.repo, f.bundles, f.purchaser, f.notifier, f.uow, f.scheduler, fixedClock{now: now})
+	return f
+}
+
+// openAuction ends an hour after testNow with a reserve of 150 and no bids.
+func openAuction() *auction.Auction {
+	return &auction.Auction{
+		ID:            "auc1",
+		BundleID:      "bundle1",
+		SupplierID:    "supplier1",
+		Currency:      money.Settlement,
+		StartingPrice: money.InSettlement(100),
+		ReservePrice:  money.InSettlement(150),
+		BidIncrement:  money.InSettlement(10),
+		EndsAt:        testNow.Add(time.Hour),
+		Status:        auction.StatusOpen,
+	}
+}
+
+func TestCreateAuction(t *testing.T) {
+	f := newFixture(testNow)
+	f.bundles.On("GetBundleByID", mock.Anything, "bundle1").
+		Return(&bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Status: "available", Price: money.FromMajor(200, money.USD)}, nil)
+	f.bundles.On("SetListingMode", mock.Anything, "bundle1", bundle.Auctioned, testNow).Return(nil)
+	f.repo.On("CreateAuction", mock.Anything, mock.Anything).Return(nil)
+
+	a, err := f.uc.CreateAuction(context.Background(), "supplier1", auction.Listing{
+		BundleID:         "bundle1",
+		StartingPrice:    money.FromMajor(100, money.USD),
+		ReservePrice:     money.FromMajor(150, money.USD),
+		BidIncrement:     money.FromMajor(10, money.USD),
+		EndsAt:           testNow.Add(24 * time.Hour),
+		ExtensionMinutes: 5,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, auction.StatusOpen, a.Status)
+	assert.Equal(t, money.USD, a.Currency)
+	assert.Equal(t, 1, f.uow.calls)
+	if assert.Len(t, f.scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeCloseAuction, f.scheduler.jobs[0].Type)
+		assert.Equal(t, a.ID, f.scheduler.jobs[0].Payload["auction_id"])
+		assert.True(t, f.scheduler.jobs[0].RunAt.Equal(a.EndsAt))
+	}
+	f.bundles.AssertExpectations(t)
+}
+
+func TestCreateAuction_Rejected(t *testing.T) {
+	valid := auction.Listing{BundleID: "bundle1", StartingPrice: money.InSettlement(100), BidIncrement: money.InSettlement(10), EndsAt: testNow.Add(24 * time.Hour)}
+	tests := []struct {
+		name    string
+		listing func(l *auction.Listing)
+		bundle  *bundle.Bundle
+		wantErr error
+	}{
+		{"reserve below start", func(l *auction.Listing) { l.ReservePrice = money.InSettlement(50) }, nil, auction.ErrInvalidAuction},
+		{"too short", func(l *auction.Listing) { l.EndsAt = testNow.Add(10 * time.Minute) }, nil, auction.ErrInvalidAuction},
+		{"no increment", func(l *auction.Listing) { l.BidIncrement = money.Money{} }, nil, auction.ErrInvalidAuction},
+		{"prices in two currencies", func(l *auction.Listing) { l.BidIncrement = money.FromMajor(10, money.USD) }, nil, auction.ErrInvalidAuction},
+		{"not in the bundle's currency", func(*auction.Listing) {}, &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Status: "available", Price: money.FromMajor(200, money.USD)}, auction.ErrInvalidAuction},
+		{"someone else's bundle", func(*auction.Listing) {}, &bundle.Bundle{ID: "bundle1", SupplierID: "supplier2", Status: "available"}, auction.ErrNotSupplier},
+		{"already auctioned", func(*auction.Listing) {}, &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Status: "available", ListingMode: bundle.Auctioned}, auction.ErrAlreadyAuctioned},
+		{"sold", func(*auction.Listing) {}, &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Status: "purchased"}, bundle.ErrNotAvailable},
+		{"held for an accepted offer", func(*auction.Listing) {}, &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Status: "available",
+			Reservation: &offer.Reservation{OfferID: "offer1", BuyerID: "reseller1", Until: testNow.Add(time.Hour)}}, offer.ErrReserved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(testNow)
+			if tt.bundle != nil {
+				f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(tt.bundle, nil)
+			}
+			l := valid
+			tt.listing(&l)
+
+			_, err := f.uc.CreateAuction(context.Background(), "supplier1", l)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			f.repo.AssertNotCalled(t, "CreateAuction", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateAuction_LostRace(t *testing.T) {
+	f := newFixture(testNow)
+	f.bundles.On("GetBundleByID", mock.Anything, "bundle1").
+		Return(&bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Status: "available", Price: money.FromMajor(200, money.USD)}, nil)
+	f.bundles.On("SetListingMode", mock.Anything, "bundle1", bundle.Auctioned, testNow).Return(bundle.ErrNotAvailable)
+
+	_, err := f.uc.CreateAuction(context.Background(), "supplier1", auction.Listing{
+		BundleID:      "bundle1",
+		StartingPrice: money.FromMajor(100, money.USD),
+		BidIncrement:  money.FromMajor(10, money.USD),
+		EndsAt:        testNow.Add(24 * time.Hour),
+	})
+
+	assert.ErrorIs(t, err, bundle.ErrNotAvailable)
+	f.repo.AssertNotCalled(t, "CreateAuction", mock.Anything, mock.Anything)
+	assert.Empty(t, f.scheduler.jobs)
+}
+
+func TestPlaceBid(t *testing.T) {
+	f := newFixture(testNow)
+	a := openAuction()
+	a.BidCount, a.HighBid, a.HighBidderID = 2, money.InSettlement(140), "reseller2"
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusOpen, 2).Return(nil)
+	f.repo.On("AddBid", mock.Anything, mock.MatchedBy(func(b *auction.Bid) bool {
+		return b.ID != "" && b.BidderID == "reseller1" && b.Amount == money.InSettlement(150)
+	})).Return(nil)
+
+	got, err := f.uc.PlaceBid(context.Background(), "auc1", "reseller1", 150)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, got.BidCount)
+	assert.Equal(t, "reseller1", got.HighBidderID)
+	assert.True(t, got.ReserveMet)
+	assert.True(t, got.EndsAt.Equal(testNow.Add(time.Hour)))
+	f.repo.AssertExpectations(t)
+}
+
+func TestPlaceBid_ExtendsNearTheEnd(t *testing.T) {
+	f := newFixture(testNow)
+	a := openAuction()
+	a.EndsAt, a.ExtensionMinutes = testNow.Add(2*time.Minute), 5
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusOpen, 0).Return(nil)
+	f.repo.On("AddBid", mock.Anything, mock.Anything).Return(nil)
+
+	got, err := f.uc.PlaceBid(context.Background(), "auc1", "reseller1", 100)
+
+	assert.NoError(t, err)
+	assert.True(t, got.EndsAt.Equal(testNow.Add(5*time.Minute)))
+	assert.False(t, got.ReserveMet)
+}
+
+func TestPlaceBid_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		bidder  string
+		amount  float64
+		prepare func(a *auction.Auction)
+		wantErr error
+	}{
+		{"below starting price", "reseller1", 90, func(*auction.Auction) {}, auction.ErrBidTooLow},
+		{"below increment", "reseller1", 145, func(a *auction.Auction) { a.BidCount, a.HighBid = 1, money.InSettlement(140) }, auction.ErrBidTooLow},
+		{"own auction", "supplier1", 200, func(*auction.Auction) {}, auction.ErrOwnAuction},
+		{"ended", "reseller1", 200, func(a *auction.Auction) { a.EndsAt = testNow }, auction.ErrAuctionEnded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(testNow)
+			a := openAuction()
+			tt.prepare(a)
+			f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+
+			_, err := f.uc.PlaceBid(context.Background(), "auc1", tt.bidder, tt.amount)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			f.repo.AssertNotCalled(t, "AddBid", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPlaceBid_LostRace(t *testing.T) {
+	f := newFixture(testNow)
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(openAuction(), nil)
+	f.repo.On("UpdateAuction", mock.Anything, mock.Anything, auction.StatusOpen, 0).Return(auction.ErrAuctionChanged)
+
+	_, err := f.uc.PlaceBid(context.Background(), "auc1", "reseller1", 100)
+
+	assert.ErrorIs(t, err, auction.ErrAuctionChanged)
+	f.repo.AssertNotCalled(t, "AddBid", mock.Anything, mock.Anything)
+}
+
+func wonAuction() *auction.Auction {
+	a := openAuction()
+	a.EndsAt = testNow.Add(-time.Minute)
+	a.BidCount, a.HighBid, a.HighBidderID, a.ReserveMet = 3, money.InSettlement(160), "reseller1", true
+	return a
+}
+
+var wonBids = []*auction.Bid{
+	{BidderID: "reseller1", Amount: money.InSettlement(160)},
+	{BidderID: "reseller2", Amount: money.InSettlement(150)},
+	{BidderID: "reseller1", Amount: money.InSettlement(120)},
+	{BidderID: "reseller3", Amount: money.InSettlement(100)},
+}
+
+func TestCloseAuction_Sold(t *testing.T) {
+	f := newFixture(testNow)
+	a := wonAuction()
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusOpen, 3).Return(nil).Once()
+	f.purchaser.On("PurchaseAuctionedBundle", mock.Anything, "auc1", "bundle1", "reseller1", money.FromMajor(160, money.Settlement)).
+		Return(&order.Order{ID: "auc1"}, nil)
+	f.repo.On("ListBids", mock.Anything, "auc1").Return(wonBids, nil)
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusClosing, 3).Return(nil).Once()
+
+	err := f.uc.CloseAuction(context.Background(), "auc1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, auction.StatusSold, a.Status)
+	assert.Equal(t, "auc1", a.OrderID)
+	assert.Equal(t, []sentNotification{
+		{"reseller1", notification.TypeAuctionWon},
+		{"reseller2", notification.TypeAuctionLost},
+		{"reseller3", notification.TypeAuctionLost},
+	}, f.notifier.sent)
+	f.repo.AssertExpectations(t)
+	f.purchaser.AssertExpectations(t)
+}
+
+func TestCloseAuction_RetriesFailedPurchase(t *testing.T) {
+	f := newFixture(testNow)
+	a := wonAuction()
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusOpen, 3).Return(nil).Once()
+	f.repo.On("ListBids", mock.Anything, "auc1").Return(wonBids, nil)
+	f.purchaser.On("PurchaseAuctionedBundle", mock.Anything, "auc1", "bundle1", "reseller1", mock.Anything).
+		Return(nil, payment.ErrGatewayUnavailable).Once()
+
+	err := f.uc.CloseAuction(context.Background(), "auc1")
+
+	assert.Error(t, err)
+	assert.Equal(t, auction.StatusClosing, a.Status)
+	assert.Empty(t, f.notifier.sent)
+
+	// The retried job picks up from closing.
+	f.purchaser.On("PurchaseAuctionedBundle", mock.Anything, "auc1", "bundle1", "reseller1", mock.Anything).
+		Return(&order.Order{ID: "auc1"}, nil).Once()
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusClosing, 3).Return(nil).Once()
+
+	assert.NoError(t, f.uc.CloseAuction(context.Background(), "auc1"))
+	assert.Equal(t, auction.StatusSold, a.Status)
+}
+
+func TestCloseAuction_DeclinedGoesToNextBidder(t *testing.T) {
+	f := newFixture(testNow)
+	a := wonAuction()
+	a.Status = auction.StatusClosing
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+	f.repo.On("ListBids", mock.Anything, "auc1").Return(wonBids, nil)
+	f.purchaser.On("PurchaseAuctionedBundle", mock.Anything, "auc1", "bundle1", "reseller1", mock.Anything).
+		Return(nil, fmt.Errorf("payment failed: %w", payment.ErrPaymentDeclined)).Once()
+	f.purchaser.On("PurchaseAuctionedBundle", mock.Anything, "auc1", "bundle1", "reseller2", money.FromMajor(150, money.Settlement)).
+		Return(&order.Order{ID: "auc1"}, nil).Once()
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusClosing, 3).Return(nil)
+
+	err := f.uc.CloseAuction(context.Background(), "auc1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, auction.StatusSold, a.Status)
+	assert.Equal(t, "reseller2", a.HighBidderID)
+	assert.Equal(t, money.InSettlement(150), a.HighBid)
+	assert.Equal(t, []sentNotification{
+		{"reseller2", notification.TypeAuctionWon},
+		{"reseller1", notification.TypeAuctionLost},
+		{"reseller3", notification.TypeAuctionLost},
+	}, f.notifier.sent)
+	f.repo.AssertNumberOfCalls(t, "UpdateAuction", 2)
+	f.purchaser.AssertExpectations(t)
+}
+
+func TestCloseAuction_AllDeclinedEndsUnsold(t *testing.T) {
+	f := newFixture(testNow)
+	a := wonAuction()
+	a.Status = auction.StatusClosing
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+	f.repo.On("ListBids", mock.Anything, "auc1").Return(wonBids, nil)
+	f.purchaser.On("PurchaseAuctionedBundle", mock.Anything, "auc1", "bundle1", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("payment failed: %w", payment.ErrPaymentDeclined))
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusClosing, 3).Return(nil)
+	f.bundles.On("SetListingMode", mock.Anything, "bundle1", bundle.FixedPrice, testNow).Return(nil)
+
+	err := f.uc.CloseAuction(context.Background(), "auc1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, auction.StatusUnsold, a.Status)
+	// reseller3's bid of 100 is below the reserve, so it is never offered the bundle.
+	f.purchaser.AssertNumberOfCalls(t, "PurchaseAuctionedBundle", 2)
+	assert.Equal(t, []sentNotification{
+		{"reseller1", notification.TypeAuctionLost},
+		{"reseller2", notification.TypeAuctionLost},
+		{"reseller3", notification.TypeAuctionLost},
+	}, f.notifier.sent)
+	f.bundles.AssertExpectations(t)
+}
+
+func TestCloseAuction_ReserveNotMet(t *testing.T) {
+	f := newFixture(testNow)
+	a := openAuction()
+	a.EndsAt = testNow
+	a.BidCount, a.HighBid, a.HighBidderID = 2, money.InSettlement(110), "reseller1"
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+	f.repo.On("ListBids", mock.Anything, "auc1").
+		Return([]*auction.Bid{{BidderID: "reseller1", Amount: money.InSettlement(110)}, {BidderID: "reseller2", Amount: money.InSettlement(100)}}, nil)
+	f.repo.On("UpdateAuction", mock.Anything, a, auction.StatusOpen, 2).Return(nil)
+	f.bundles.On("SetListingMode", mock.Anything, "bundle1", bundle.FixedPrice, testNow).Return(nil)
+
+	err := f.uc.CloseAuction(context.Background(), "auc1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, auction.StatusUnsold, a.Status)
+	assert.Equal(t, []sentNotification{
+		{"reseller1", notification.TypeAuctionLost},
+		{"reseller2", notification.TypeAuctionLost},
+	}, f.notifier.sent)
+	f.purchaser.AssertNotCalled(t, "PurchaseAuctionedBundle", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	f.bundles.AssertExpectations(t)
+}
+
+func TestCloseAuction_Extended(t *testing.T) {
+	f := newFixture(testNow)
+	a := openAuction()
+	a.EndsAt = testNow.Add(3 * time.Minute)
+	f.repo.On("GetAuctionByID", mock.Anything, "auc1").Return(a, nil)
+
+	err := f.uc.CloseAuction(context.Background(), "auc1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, auction.StatusOpen, a.Status)
+	if assert.Len(t, f.scheduler.jobs, 1) {
+		assert.True(t, f.scheduler.jobs[0].RunAt.Equal(a.EndsAt))
+	}
+	f.repo.AssertNotCalled(t, "UpdateAuction", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -90,8 +90,35 @@ func (u *bundleUsecase) UpdateBundle(ctx context.Context, supplierID string, id
 	return u.bundleRepo.UpdateBundle(ctx, id, updatedData)
 }
 
-func (uc *bundleUsecase) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	return uc.bundleRepo.ListAvailableBundles(ctx)
+// ListAvailableBundles asks for one bundle more than a page holds to learn
+// whether another page follows.
+func (uc *bundleUsecase) ListAvailableBundles(ctx context.Context, q bundle.Query) (*bundle.Page, error) {
+	if err := q.Normalize(); err != nil {
+		return nil, err
+	}
+	var after *bundle.Cursor
+	if q.After != "" {
+		c, err := bundle.DecodeCursor(q.After, &q)
+		if err != nil {
+			return nil, err
+		}
+		after = c
+	}
+
+	bundles, err := uc.bundleRepo.ListAvailableBundles(ctx, &q, after, q.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &bundle.Page{Bundles: bundles}
+	if len(bundles) > q.Limit {
+		page.Bundles = bundles[:q.Limit]
+		next, err := bundle.CursorAfter(&q, page.Bundles[q.Limit-1])
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = next.Encode()
+	}
+	return page, nil
 }
 func (u *bundleUsecase) DecreaseRemainingItemCount(ctx context.Context, bundleID string) error {
 	b, err := u.bundleRepo.GetBundleByID(ctx, bundleID)
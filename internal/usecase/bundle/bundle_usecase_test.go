@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -39,14 +41,19 @@ func (m *MockRepository) ListBundles(ctx context.Context, supplierID string) ([]
 	return args.Get(0).([]*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockRepository) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
+func (m *MockRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query, after *bundle.Cursor, limit int) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, q, after, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*bundle.Bundle), args.Error(1)
 }
 
+func (m *MockRepository) UpdateSupplierTrust(ctx context.Context, supplierID string, score int) error {
+	args := m.Called(ctx, supplierID, score)
+	return args.Error(0)
+}
+
 func (m *MockRepository) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
 	args := m.Called(ctx, resellerID)
 	if args.Get(0) == nil {
@@ -65,6 +72,21 @@ func (m *MockRepository) MarkAsPurchased(ctx context.Context, bundleID string, r
 	return args.Error(0)
 }
 
+func (m *MockRepository) SetListingMode(ctx context.Context, bundleID string, mode bundle.ListingMode, now time.Time) error {
+	args := m.Called(ctx, bundleID, mode, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Reserve(ctx context.Context, bundleID string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, bundleID, r, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Unreserve(ctx context.Context, bundleID, offerID string) error {
+	args := m.Called(ctx, bundleID, offerID)
+	return args.Error(0)
+}
+
 func (m *MockRepository) DeleteBundle(ctx context.Context, bundleID string) error {
 	args := m.Called(ctx, bundleID)
 	return args.Error(0)
@@ -97,7 +119,7 @@ func createTestBundle(supplierID string) *bundle.Bundle {
 		SortingLevel:       bundle.Sorted,
 		EstimatedBreakdown: map[string]int{"shirts": 5, "pants": 5},
 		Type:               "clothing",
-		Price:              100.00,
+		Price:              money.InSettlement(100.00),
 		Status:             "available",
 		CreatedAt:          time.Now().Format(time.RFC3339),
 		DateListed:         time.Now(),
@@ -412,14 +434,16 @@ func (suite *BundleUsecaseTestSuite) TestListAvailableBundles() {
 		{
 			name: "Successful available bundles listing",
 			setupMock: func() {
-				suite.mockRepo.On("ListAvailableBundles", suite.ctx).Return([]*bundle.Bundle{createTestBundle("supplier-1")}, nil)
+				suite.mockRepo.On("ListAvailableBundles", suite.ctx, mock.Anything, (*bundle.Cursor)(nil), bundle.DefaultPageSize+1).
+					Return([]*bundle.Bundle{createTestBundle("supplier-1")}, nil)
 			},
 			expectError: false,
 		},
 		{
 			name: "Repository error",
 			setupMock: func() {
-				suite.mockRepo.On("ListAvailableBundles", suite.ctx).Return(([]*bundle.Bundle)(nil), errors.New("database error"))
+				suite.mockRepo.On("ListAvailableBundles", suite.ctx, mock.Anything, (*bundle.Cursor)(nil), bundle.DefaultPageSize+1).
+					Return(([]*bundle.Bundle)(nil), errors.New("database error"))
 			},
 			expectError: true,
 		},
@@ -429,19 +453,66 @@ func (suite *BundleUsecaseTestSuite) TestListAvailableBundles() {
 		suite.Run(tt.name, func() {
 			suite.mockRepo.ExpectedCalls = nil // Reset mock expectations
 			tt.setupMock()
-			bundles, err := suite.usecase.ListAvailableBundles(suite.ctx)
+			page, err := suite.usecase.ListAvailableBundles(suite.ctx, bundle.Query{})
 			if tt.expectError {
 				assert.Error(suite.T(), err)
-				assert.Nil(suite.T(), bundles)
+				assert.Nil(suite.T(), page)
 			} else {
 				assert.NoError(suite.T(), err)
-				assert.NotNil(suite.T(), bundles)
+				assert.Len(suite.T(), page.Bundles, 1)
+				assert.Empty(suite.T(), page.NextCursor)
 			}
 			suite.mockRepo.AssertExpectations(suite.T())
 		})
 	}
 }
 
+func (suite *BundleUsecaseTestSuite) TestListAvailableBundles_Pages() {
+	first, second, third := createTestBundle("supplier-1"), createTestBundle("supplier-1"), createTestBundle("supplier-2")
+	first.ID, second.ID, third.ID = "b1", "b2", "b3"
+	// The second bundle is listed in dollars; cursors hold settlement prices.
+	first.Price, second.Price, third.Price = money.InSettlement(50), money.FromMajor(2, money.USD), money.InSettlement(120)
+	rates := money.Rates{money.USD: 50}
+
+	suite.mockRepo.On("ListAvailableBundles", suite.ctx, mock.MatchedBy(func(q *bundle.Query) bool {
+		return q.Sort == bundle.SortPrice && !q.Desc
+	}), (*bundle.Cursor)(nil), 3).Return([]*bundle.Bundle{first, second, third}, nil).Once()
+
+	page, err := suite.usecase.ListAvailableBundles(suite.ctx, bundle.Query{Sort: bundle.SortPrice, Rates: rates, Limit: 2})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*bundle.Bundle{first, second}, page.Bundles)
+	assert.NotEmpty(suite.T(), page.NextCursor)
+
+	// The cursor resumes after the last bundle shown.
+	suite.mockRepo.On("ListAvailableBundles", suite.ctx, mock.Anything, mock.MatchedBy(func(c *bundle.Cursor) bool {
+		return c.ID == "b2" && c.Price == 10000
+	}), 3).Return([]*bundle.Bundle{third}, nil).Once()
+
+	page, err = suite.usecase.ListAvailableBundles(suite.ctx, bundle.Query{Sort: bundle.SortPrice, Rates: rates, Limit: 2, After: page.NextCursor})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*bundle.Bundle{third}, page.Bundles)
+	assert.Empty(suite.T(), page.NextCursor)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *BundleUsecaseTestSuite) TestListAvailableBundles_CursorFromOtherOrder() {
+	c, err := bundle.CursorAfter(&bundle.Query{Sort: bundle.SortPrice}, createTestBundle("supplier-1"))
+	assert.NoError(suite.T(), err)
+
+	page, err := suite.usecase.ListAvailableBundles(suite.ctx, bundle.Query{Sort: bundle.SortSupplierTrust, After: c.Encode()})
+	assert.ErrorIs(suite.T(), err, bundle.ErrInvalidCursor)
+	assert.Nil(suite.T(), page)
+	suite.mockRepo.AssertNotCalled(suite.T(), "ListAvailableBundles", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BundleUsecaseTestSuite) TestListAvailableBundles_InvalidQuery() {
+	lo, hi := 200.0, 100.0
+
+	page, err := suite.usecase.ListAvailableBundles(suite.ctx, bundle.Query{MinPrice: &lo, MaxPrice: &hi})
+	assert.ErrorIs(suite.T(), err, bundle.ErrInvalidQuery)
+	assert.Nil(suite.T(), page)
+}
+
 func (suite *BundleUsecaseTestSuite) TestDecreaseRemainingItemCount() {
 	tests := []struct {
 		name        string
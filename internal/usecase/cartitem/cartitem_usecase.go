@@ -4,27 +4,81 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/address"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/cartitem"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/promotion"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // UnavailableItem represents a cart item that failed validation.
 
+// gatewayTimeout bounds each round trip to the payment gateway.
+const gatewayTimeout = 10 * time.Second
+
 type cartItemUsecase struct {
 	repo        cartitem.Repository
 	productRepo product.Repository // Used to fetch product details
+	orderRepo   order.Repository
+	paymentRepo payment.Repository
+	gateway     payment.Gateway
+	addressUC   address.Usecase
+	fees        fee.Quoter
+	ledger      ledger.Recorder
+	promotions  promotion.Discounter
+	credits     credit.Payer
+	rates       money.Converter
+	taxes       tax.Assessor
+	invoices    invoice.Issuer
+	scheduler   job.Scheduler
+	unitOfWork  uow.UnitOfWork
 }
 
 // NewCartItemUsecase creates a new CartItem usecase instance.
-// Note: productRepo is used for product lookup and validation during checkout.
-func NewCartItemUsecase(repo cartitem.Repository, productRepo product.Repository) cartitem.Usecase {
+// Note: productRepo is used for product lookup and validation during checkout,
+// orderRepo and paymentRepo persist the order and payments a checkout produces,
+// gateway collects the consumer's money, addressUC finds where to ship it,
+// fees prices the platform's cut of each item, ledger books the sale,
+// promotions applies promo codes, credits pays from store credit, rates
+// prices listings in the settlement currency, taxes adds sales tax, invoices
+// issues each order's receipt, scheduler retries the undoing of a failed
+// checkout and unitOfWork commits a checkout's orders together.
+func NewCartItemUsecase(repo cartitem.Repository, productRepo product.Repository, orderRepo order.Repository, paymentRepo payment.Repository, gateway payment.Gateway, addressUC address.Usecase, fees fee.Quoter, ledger ledger.Recorder, promotions promotion.Discounter, credits credit.Payer, rates money.Converter, taxes tax.Assessor, invoices invoice.Issuer, scheduler job.Scheduler, unitOfWork uow.UnitOfWork) cartitem.Usecase {
 	return &cartItemUsecase{
 		repo:        repo,
 		productRepo: productRepo,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		gateway:     gateway,
+		addressUC:   addressUC,
+		fees:        fees,
+		ledger:      ledger,
+		promotions:  promotions,
+		credits:     credits,
+		rates:       rates,
+		taxes:       taxes,
+		invoices:    invoices,
+		scheduler:   scheduler,
+		unitOfWork:  unitOfWork,
 	}
 }
 
@@ -43,6 +97,9 @@ func (u *cartItemUsecase) AddCartItem(ctx context.Context, userID string, listin
 	if prod.Status != "available" {
 		return fmt.Errorf("product %s is not available", listingID)
 	}
+	if err := offerPrice(prod, userID); err != nil {
+		return err
+	}
 
 	// Build a new CartItem using the product details.
 	cartItem := &cartitem.CartItem{
@@ -70,7 +127,7 @@ func (u *cartItemUsecase) RemoveCartItem(ctx context.Context, userID string, lis
 }
 
 // CheckoutCart processes a full cart checkout.
-func (u *cartItemUsecase) CheckoutCart(ctx context.Context, userID string) (*models.CheckoutResponse, error) {
+func (u *cartItemUsecase) CheckoutCart(ctx context.Context, userID string, req models.CheckoutRequest) (*models.CheckoutResponse, error) {
 	// Retrieve all cart items.
 	items, err := u.repo.GetCartItems(ctx, userID)
 	if err != nil {
@@ -80,10 +137,8 @@ func (u *cartItemUsecase) CheckoutCart(ctx context.Context, userID string) (*mod
 		return nil, errors.New("cart is empty")
 	}
 
-	var total float64
-	var checkoutItems []models.CheckoutItemResponse
-
 	// Validate each item.
+	var products []*product.Product
 	for _, item := range items {
 		prod, err := u.productRepo.GetProductByID(ctx, item.ListingID)
 		if err != nil || prod == nil {
@@ -92,50 +147,32 @@ func (u *cartItemUsecase) CheckoutCart(ctx context.Context, userID string) (*mod
 		if prod.Status != "available" {
 			return nil, fmt.Errorf("item %q is no longer available", prod.Title)
 		}
-
-		// Accumulate for checkout.
-		total += prod.Price
-		checkoutItems = append(checkoutItems, models.CheckoutItemResponse{
-			ListingID: prod.ID,
-			Title:     prod.Title,
-			Price:     prod.Price,
-			SellerID:  prod.ResellerID.Hex(),
-			Status:    prod.Status,
-		})
-		// Mark product as sold (simulate update; ideally via product repository with a transaction).
-		prod.Status = "sold"
-		// (Update product in DB here if needed)
+		if err := offerPrice(prod, userID); err != nil {
+			return nil, err
+		}
+		products = append(products, prod)
 	}
 
-	// Calculate fees.
-	platformFee := total * 0.02
-	netPayable := total - platformFee
+	shipTo, err := u.addressUC.ResolveShippingAddress(ctx, userID, req.AddressID)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create PaymentRecord and Order here (simulate payment via Stripe).
-	// (This code would call another repository to save PaymentRecord)
+	resp, err := u.placeOrders(ctx, userID, req, shipTo.Snapshot(), products)
+	if err != nil {
+		return nil, err
+	}
 
 	// Clear the purchased items from the cart.
 	if err := u.repo.ClearCart(ctx, userID); err != nil {
 		return nil, err
 	}
 
-	// Launch a goroutine to simulate order delivery update after 3 minutes.
-	go func() {
-		time.Sleep(3 * time.Minute)
-		// Update order status to "Delivered".
-		// (Call order repository or similar here)
-	}()
-
-	return &models.CheckoutResponse{
-		TotalAmount: total,
-		Items:       checkoutItems,
-		PlatformFee: platformFee,
-		NetPayable:  netPayable,
-	}, nil
+	return resp, nil
 }
 
 // CheckoutSingleItem processes checkout for a single cart item.
-func (u *cartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listingID string) (*models.CheckoutResponse, error) {
+func (u *cartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listingID string, req models.CheckoutRequest) (*models.CheckoutResponse, error) {
 	// Fetch the specific cart item.
 	// (Option 1: Filter from GetCartItems; Option 2: Add a method to repo to get single item)
 	items, err := u.repo.GetCartItems(ctx, userID)
@@ -160,37 +197,383 @@ func (u *cartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listin
 	if prod.Status != "available" {
 		return nil, fmt.Errorf("item %q is no longer available", prod.Title)
 	}
+	if err := offerPrice(prod, userID); err != nil {
+		return nil, err
+	}
 
-	total := prod.Price
-	platformFee := total * 0.02
-	netPayable := total - platformFee
-
-	checkoutItem := models.CheckoutItemResponse{
-		ListingID: prod.ID,
-		Title:     prod.Title,
-		Price:     prod.Price,
-		SellerID:  prod.ResellerID.Hex(),
-		Status:    prod.Status,
+	shipTo, err := u.addressUC.ResolveShippingAddress(ctx, userID, req.AddressID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Mark product as sold and update db if required.
-	prod.Status = "sold"
+	resp, err := u.placeOrders(ctx, userID, req, shipTo.Snapshot(), []*product.Product{prod})
+	if err != nil {
+		return nil, err
+	}
 
-	// Simulate payment record creation & update.
 	// Remove the single item from cart.
 	if err := u.repo.DeleteCartItem(ctx, userID, listingID); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		time.Sleep(3 * time.Minute)
-		// Update order status to "Delivered".
-	}()
+	return resp, nil
+}
 
-	return &models.CheckoutResponse{
-		TotalAmount: total,
-		Items:       []models.CheckoutItemResponse{checkoutItem},
-		PlatformFee: platformFee,
-		NetPayable:  netPayable,
+// offerPrice prices a product reserved by an accepted offer at the agreed
+// price for its buyer, and refuses it to everyone else.
+func offerPrice(prod *product.Product, userID string) error {
+	price, err := prod.Reservation.PriceFor(userID, prod.Price, time.Now())
+	if err != nil {
+		return fmt.Errorf("item %q: %w", prod.Title, err)
+	}
+	prod.Price = price
+	return nil
+}
+
+// pricing is what a checkout's products cost in the settlement currency and
+// the rate the consumer pays at.
+type pricing struct {
+	prices map[string]float64 // by listing ID
+	payIn  money.Currency
+	rate   float64 // units of money.Settlement per unit of payIn
+}
+
+// newPricing converts every product's list price to the settlement currency
+// at the current rates.
+func (u *cartItemUsecase) newPricing(ctx context.Context, payIn string, products []*product.Product) (*pricing, error) {
+	pc := &pricing{prices: make(map[string]float64, len(products)), payIn: money.Settlement}
+	if payIn != "" {
+		c, err := money.ParseCurrency(payIn)
+		if err != nil {
+			return nil, err
+		}
+		pc.payIn = c
+	}
+
+	rates, err := u.rates.Rates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if pc.rate, err = rates.Of(pc.payIn); err != nil {
+		return nil, err
+	}
+	for _, prod := range products {
+		price, err := rates.Convert(prod.Price, money.Settlement)
+		if err != nil {
+			return nil, err
+		}
+		pc.prices[prod.ID] = price.Major()
+	}
+	return pc, nil
+}
+
+// charged is a settlement amount in the currency the consumer pays in.
+func (pc *pricing) charged(amount float64) money.Money {
+	return money.FromMajor(amount/pc.rate, pc.payIn)
+}
+
+// placeOrders splits the purchased products by reseller so that every
+// reseller gets an order and a payment of their own to fulfil and be paid on.
+// Every order carries its own copy of the ship-to address. Listings priced in
+// another currency are bought at their settlement-currency price, which is
+// what orders, fees and credit are booked in. A promo code lowers the price
+// of the items it covers. Tax is assessed on the discounted price by the
+// reseller's jurisdiction and the ship-to country, and fees are charged on
+// what the reseller sold for before tax. With UseCredit the consumer's store
+// credit pays first and only the remainder is charged.
+func (u *cartItemUsecase) placeOrders(ctx context.Context, userID string, req models.CheckoutRequest, shipTo *order.ShippingAddress, products []*product.Product) (*models.CheckoutResponse, error) {
+	pc, err := u.newPricing(ctx, req.Currency, products)
+	if err != nil {
+		return nil, err
+	}
+
+	var sellerIDs, orderIDs []string
+	bySeller := make(map[string][]*product.Product)
+	for _, prod := range products {
+		sellerID := prod.ResellerID.Hex()
+		if _, ok := bySeller[sellerID]; !ok {
+			sellerIDs = append(sellerIDs, sellerID)
+			// Order IDs are picked up front so that spent credit can name its orders.
+			orderIDs = append(orderIDs, primitive.NewObjectID().Hex())
+		}
+		bySeller[sellerID] = append(bySeller[sellerID], prod)
+	}
+
+	var discount *promotion.Discount
+	if req.PromoCode != "" {
+		lines := make([]promotion.Line, 0, len(products))
+		for _, prod := range products {
+			lines = append(lines, promotion.Line{ListingID: prod.ID, SellerID: prod.ResellerID.Hex(), ClothingType: prod.Type, Amount: pc.prices[prod.ID]})
+		}
+		d, err := u.promotions.Redeem(ctx, req.PromoCode, userID, lines)
+		if err != nil {
+			return nil, err
+		}
+		discount = d
+	}
+
+	var total float64
+	quotes := make(map[string]fee.Quote, len(products))
+	taxes := make(map[string]tax.Assessment, len(products))
+	for _, prod := range products {
+		price := pc.prices[prod.ID] - discount.For(prod.ID)
+		a, err := u.taxes.Assess(ctx, tax.Sale{Type: tax.SaleB2C, SellerID: prod.ResellerID.Hex(), BuyerID: userID, BuyerJurisdiction: shipTo.Country, Amount: price})
+		if err != nil {
+			u.releasePromotion(discount, userID)
+			return nil, err
+		}
+		q, err := u.fees.Quote(ctx, fee.Sale{Type: payment.B2C, Category: prod.Type, SellerID: prod.ResellerID.Hex(), Amount: a.Net})
+		if err != nil {
+			u.releasePromotion(discount, userID)
+			return nil, err
+		}
+		quotes[prod.ID] = q
+		taxes[prod.ID] = a
+		total += a.Gross
+	}
+
+	var creditUsed float64
+	if req.UseCredit {
+		used, err := u.credits.Spend(ctx, userID, total, orderIDs)
+		if err != nil {
+			u.releasePromotion(discount, userID)
+			return nil, err
+		}
+		creditUsed = used
+	}
+
+	// The consumer is charged once, in the currency they pay in, for whatever
+	// credit did not cover. A basket paid for entirely by discounts and credit
+	// is not charged at all.
+	var chargeID string
+	charge := pc.charged(total - creditUsed)
+	if charge.Amount > 0 {
+		var err error
+		chargeID, err = u.chargeConsumer(ctx, charge, userID)
+		if err != nil {
+			u.restoreCredit(userID, creditUsed, orderIDs)
+			u.releasePromotion(discount, userID)
+			return nil, err
+		}
+	}
+
+	// Every reseller's order commits together, so a checkout either places all
+	// of them or none and the consumer gets back everything they paid.
+	var resp *models.CheckoutResponse
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		resp = &models.CheckoutResponse{Currency: money.Settlement, ShippingAddress: shipTo}
+		if discount != nil {
+			resp.PromoCode = discount.Code
+		}
+		for i, sellerID := range sellerIDs {
+			addr := *shipTo
+			sub, err := u.placeSellerOrder(ctx, userID, sellerID, orderIDs[i], chargeID, &addr, bySeller[sellerID], pc, quotes, taxes, discount, creditUsed-resp.CreditUsed)
+			if err != nil {
+				return err
+			}
+			resp.Orders = append(resp.Orders, *sub)
+			resp.Items = append(resp.Items, sub.Items...)
+			resp.TotalAmount += sub.TotalAmount
+			resp.Discount += sub.Discount
+			resp.CreditUsed += sub.CreditUsed
+			resp.PlatformFee += sub.PlatformFee
+			resp.Tax += sub.Tax
+		}
+		return nil
+	})
+	if err != nil {
+		u.restoreCredit(userID, creditUsed, orderIDs)
+		u.releasePromotion(discount, userID)
+		u.refundCharge(chargeID, charge)
+		return nil, err
+	}
+	resp.NetPayable = resp.TotalAmount - resp.Tax - resp.PlatformFee
+	resp.Charged = pc.charged(resp.TotalAmount)
+
+	return resp, nil
+}
+
+// placeSellerOrder marks one reseller's products as sold, creates the
+// consumer order for them and records the B2C payment crediting the reseller.
+// The platform fee and tax are the sums of each product's quoted fee and tax,
+// and the order total is what the consumer paid after discount and with tax.
+// The order is paid from as much of the credit left over by earlier orders as
+// its total needs.
+func (u *cartItemUsecase) placeSellerOrder(ctx context.Context, userID, sellerID, orderID, chargeID string, shipTo *order.ShippingAddress, products []*product.Product, pc *pricing, quotes map[string]fee.Quote, taxes map[string]tax.Assessment, discount *promotion.Discount, creditLeft float64) (*models.CheckoutOrderResponse, error) {
+	placedAt := time.Now()
+	now := placedAt.Format(time.RFC3339)
+	zero := money.Money{Currency: money.Settlement}
+	o := &order.Order{
+		ID:              orderID,
+		ConsumerID:      userID,
+		ResellerID:      sellerID,
+		CreatedAt:       now,
+		ShippingAddress: shipTo,
+		TotalPrice:      zero,
+		PlatformFee:     zero,
+		Discount:        zero,
+		Tax:             zero,
+		LineTotals:      make(map[string]money.Money, len(products)),
+		Titles:          make(map[string]string, len(products)),
+	}
+	if discount != nil {
+		o.PromoCode = discount.Code
+	}
+	o.Place(order.OrderStatusPending, userID)
+
+	var checkoutItems []models.CheckoutItemResponse
+	var rules []string
+	policyVersion := 0
+	for _, prod := range products {
+		if err := u.productRepo.MarkAsSold(ctx, prod.ID, userID); err != nil {
+			if errors.Is(err, product.ErrNotAvailable) {
+				return nil, fmt.Errorf("item %q is no longer available: %w", prod.Title, err)
+			}
+			return nil, fmt.Errorf("failed to mark item %q as sold: %w", prod.Title, err)
+		}
+		prod.Status, prod.BuyerID = product.StatusSold, userID
+
+		off := discount.For(prod.ID)
+		o.ProductIDs = append(o.ProductIDs, prod.ID)
+		a := taxes[prod.ID]
+		o.TotalPrice = o.TotalPrice.Add(money.InSettlement(a.Gross))
+		o.LineTotals[prod.ID] = money.InSettlement(a.Gross)
+		o.Titles[prod.ID] = prod.Title
+		o.Discount = o.Discount.Add(money.InSettlement(off))
+		o.Tax = o.Tax.Add(money.InSettlement(a.Tax))
+		o.TaxLines = tax.AddLines(o.TaxLines, a.Lines...)
+		q := quotes[prod.ID]
+		o.PlatformFee = o.PlatformFee.Add(money.InSettlement(q.Fee))
+		if !slices.Contains(rules, q.Rule) {
+			rules = append(rules, q.Rule)
+		}
+		policyVersion = q.PolicyVersion
+		checkoutItems = append(checkoutItems, models.CheckoutItemResponse{
+			ListingID: prod.ID,
+			Title:     prod.Title,
+			Price:     pc.prices[prod.ID],
+			Discount:  off,
+			SellerID:  sellerID,
+			Status:    prod.Status,
+		})
+	}
+
+	o.SellerEarning = o.TotalPrice.Sub(o.Tax).Sub(o.PlatformFee)
+	charged := pc.charged(o.TotalPrice.Major())
+	o.Charged = &charged
+
+	if err := u.orderRepo.CreateOrder(ctx, o); err != nil {
+		return nil, err
+	}
+
+	p := &payment.Payment{
+		FromUserID:    userID,
+		ToUserID:      sellerID,
+		Amount:        o.TotalPrice,
+		Discount:      o.Discount,
+		PromoCode:     o.PromoCode,
+		CreditAmount:  money.InSettlement(math.Min(creditLeft, o.TotalPrice.Major())),
+		PlatformFee:   o.PlatformFee,
+		SellerEarning: o.SellerEarning,
+		Status:        payment.StatusPaid,
+		ReferenceID:   o.ID,
+		OrderID:       o.ID,
+		ChargeID:      chargeID,
+		Type:          payment.B2C,
+		FeeRule:       strings.Join(rules, ","),
+		FeeVersion:    policyVersion,
+		CreatedAt:     placedAt.UTC(),
+		Original:      charged,
+		ExchangeRate:  pc.rate,
+		Tax:           o.Tax,
+		TaxLines:      o.TaxLines,
+	}
+	if err := u.paymentRepo.RecordPayment(ctx, p); err != nil {
+		return nil, err
+	}
+	if err := u.ledger.RecordPayment(ctx, p); err != nil {
+		return nil, err
+	}
+	if _, err := u.invoices.IssueInvoice(ctx, o, p); err != nil {
+		return nil, err
+	}
+
+	return &models.CheckoutOrderResponse{
+		OrderID:       o.ID,
+		SellerID:      sellerID,
+		Items:         checkoutItems,
+		TotalAmount:   o.TotalPrice.Major(),
+		Charged:       charged,
+		Discount:      o.Discount.Major(),
+		CreditUsed:    p.CreditAmount.Major(),
+		PlatformFee:   o.PlatformFee.Major(),
+		Tax:           o.Tax.Major(),
+		SellerEarning: o.SellerEarning.Major(),
 	}, nil
 }
+
+// chargeConsumer authorizes and captures amount through the payment gateway.
+func (u *cartItemUsecase) chargeConsumer(ctx context.Context, amount money.Money, userID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, gatewayTimeout)
+	defer cancel()
+
+	charge, err := u.gateway.Authorize(ctx, amount, userID)
+	if err != nil {
+		return "", fmt.Errorf("payment failed: %w", err)
+	}
+	if _, err := u.gateway.Capture(ctx, charge.ID); err != nil {
+		return "", fmt.Errorf("payment failed: %w", err)
+	}
+	return charge.ID, nil
+}
+
+// refundCharge returns amount of a captured charge to the consumer, or
+// queues the refund if the gateway does not take it.
+func (u *cartItemUsecase) refundCharge(chargeID string, amount money.Money) {
+	if chargeID == "" || amount.Amount <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayTimeout)
+	defer cancel()
+
+	// No order was placed for the charge, so it is refunded at most once.
+	if _, err := u.gateway.Refund(ctx, chargeID, amount, chargeID); err == nil {
+		return
+	}
+	u.queue(job.TypeRefundCharge, map[string]string{"charge_id": chargeID, "amount": strconv.FormatFloat(amount.Major(), 'f', -1, 64), "currency": string(amount.Currency)})
+}
+
+// releasePromotion gives back the promo code use of a checkout that failed,
+// or queues the release if it cannot be done now.
+func (u *cartItemUsecase) releasePromotion(d *promotion.Discount, userID string) {
+	if d == nil {
+		return
+	}
+	if err := u.promotions.Release(context.Background(), d, userID); err == nil {
+		return
+	}
+	u.queue(job.TypeReleasePromotion, map[string]string{"promotion_id": d.PromotionID, "code": d.Code, "user_id": userID})
+}
+
+// restoreCredit gives back the store credit spent on a checkout that failed,
+// or queues the restore if it cannot be done now.
+func (u *cartItemUsecase) restoreCredit(userID string, amount float64, orderIDs []string) {
+	if amount <= 0 {
+		return
+	}
+	if err := u.credits.Restore(context.Background(), userID, amount, credit.EntryReversal, orderIDs); err == nil {
+		return
+	}
+	u.queue(job.TypeRestoreCredit, map[string]string{
+		"user_id":   userID,
+		"amount":    strconv.FormatFloat(amount, 'f', -1, 64),
+		"order_ids": strings.Join(orderIDs, ","),
+	})
+}
+
+// queue schedules the undoing of a failed checkout so a worker retries it.
+func (u *cartItemUsecase) queue(jobType string, payload map[string]string) {
+	if _, err := u.scheduler.Schedule(context.Background(), jobType, payload, 0); err != nil {
+		log.Println("Failed to queue", jobType, "job:", err)
+	}
+}
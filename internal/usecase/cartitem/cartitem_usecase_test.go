@@ -6,8 +6,21 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/address"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/cartitem"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/promotion"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/gateway"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -73,24 +86,326 @@ func (m *MockProductRepository) ListAvailableProducts(ctx context.Context, page,
 	return args.Get(0).([]*product.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) SearchProducts(ctx context.Context, q *product.Query) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
 func (m *MockProductRepository) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
 	args := m.Called(ctx, id, updates)
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Relist(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Reserve(ctx context.Context, id string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, id, r, now)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Unreserve(ctx context.Context, id, offerID string) error {
+	args := m.Called(ctx, id, offerID)
+	return args.Error(0)
+}
+
 func (m *MockProductRepository) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
 	args := m.Called(ctx, bundleID)
 	return args.Get(0).([]*product.Product), args.Error(1)
 }
 
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, consumerID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) TransitionStatus(ctx context.Context, orderID string, t order.StatusTransition) error {
+	args := m.Called(ctx, orderID, t)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) DeleteOrder(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) GetOrdersBySupplier(ctx context.Context, supplierID string) ([]*order.Order, error) {
+	args := m.Called(ctx, supplierID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, resellerID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepository) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID, pType)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetAllPlatformFees(ctx context.Context) (float64, float64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
+}
+
+func (m *MockPaymentRepository) ListUnsettledPayments(ctx context.Context) ([]*payment.Payment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) ListPaymentsBetween(ctx context.Context, from, to time.Time) ([]*payment.Payment, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) AssignPayout(ctx context.Context, paymentIDs []string, payoutID string) error {
+	args := m.Called(ctx, paymentIDs, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) ReleasePayout(ctx context.Context, payoutID string) error {
+	args := m.Called(ctx, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) UpdatePaymentStatus(ctx context.Context, id, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByOrder(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetPaymentByID(ctx context.Context, id string) (*payment.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) ReserveRefund(ctx context.Context, id string, refunded, amount money.Money) error {
+	args := m.Called(ctx, id, refunded, amount)
+	return args.Error(0)
+}
+
+type MockAddressUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAddressUsecase) AddAddress(ctx context.Context, userID string, a *address.Address) (*address.Address, error) {
+	args := m.Called(ctx, userID, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+func (m *MockAddressUsecase) ListAddresses(ctx context.Context, userID string) ([]*address.Address, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*address.Address), args.Error(1)
+}
+
+func (m *MockAddressUsecase) UpdateAddress(ctx context.Context, userID, id string, a *address.Address) (*address.Address, error) {
+	args := m.Called(ctx, userID, id, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+func (m *MockAddressUsecase) DeleteAddress(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAddressUsecase) SetDefaultAddress(ctx context.Context, userID, id string) (*address.Address, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+func (m *MockAddressUsecase) ResolveShippingAddress(ctx context.Context, userID, id string) (*address.Address, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*address.Address), args.Error(1)
+}
+
+// recordingLedger keeps posted payments in memory.
+type recordingLedger struct {
+	payments []*payment.Payment
+}
+
+func (l *recordingLedger) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	l.payments = append(l.payments, p)
+	return nil
+}
+
+// recordingInvoices keeps issued invoices in memory.
+type recordingInvoices struct {
+	issued []*invoice.Invoice
+}
+
+func (r *recordingInvoices) IssueInvoice(ctx context.Context, o *order.Order, paid *payment.Payment) (*invoice.Invoice, error) {
+	inv := &invoice.Invoice{ID: o.ID, OrderID: o.ID, PaymentID: paid.ID}
+	r.issued = append(r.issued, inv)
+	return inv, nil
+}
+
+type MockDiscounter struct {
+	mock.Mock
+}
+
+func (m *MockDiscounter) Redeem(ctx context.Context, code, userID string, lines []promotion.Line) (*promotion.Discount, error) {
+	args := m.Called(ctx, code, userID, lines)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotion.Discount), args.Error(1)
+}
+
+func (m *MockDiscounter) Release(ctx context.Context, d *promotion.Discount, userID string) error {
+	args := m.Called(ctx, d, userID)
+	return args.Error(0)
+}
+
+type MockCreditPayer struct {
+	mock.Mock
+}
+
+func (m *MockCreditPayer) Spend(ctx context.Context, userID string, amount float64, orderIDs []string) (float64, error) {
+	args := m.Called(ctx, userID, amount, orderIDs)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCreditPayer) Restore(ctx context.Context, userID string, amount float64, t credit.EntryType, orderIDs []string) error {
+	args := m.Called(ctx, userID, amount, t, orderIDs)
+	return args.Error(0)
+}
+
+// fixedRates is an exchange-rate table that never changes.
+type fixedRates money.Rates
+
+func (r fixedRates) Rates(ctx context.Context) (money.Rates, error) {
+	return money.Rates(r), nil
+}
+
+// flatTax assesses every sale with one policy; the zero value taxes nothing.
+type flatTax struct {
+	policy tax.Policy
+}
+
+func (t *flatTax) Assess(ctx context.Context, s tax.Sale) (tax.Assessment, error) {
+	return t.policy.Assess(s), nil
+}
+
+// defaultFees quotes with the built-in policy for a standard-tier seller.
+type defaultFees struct{}
+
+func (defaultFees) Quote(ctx context.Context, s fee.Sale) (fee.Quote, error) {
+	return fee.DefaultPolicy().Quote(s, fee.TierStandard), nil
+}
+
 // --- Test Suite ---
 
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// recordingScheduler keeps scheduled jobs in memory instead of queueing them.
+type recordingScheduler struct {
+	jobs []*job.Job
+}
+
+func (s *recordingScheduler) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	j := &job.Job{Type: jobType, Payload: payload, RunAt: time.Now().Add(delay)}
+	s.jobs = append(s.jobs, j)
+	return j, nil
+}
+
 type CartItemUsecaseTestSuite struct {
 	suite.Suite
 	ctx             context.Context
 	usecase         cartitem.Usecase
 	mockCartRepo    *MockCartItemRepository
 	mockProductRepo *MockProductRepository
+	mockOrderRepo   *MockOrderRepository
+	mockPaymentRepo *MockPaymentRepository
+	gateway         *gateway.FakeGateway
+	mockAddressUC   *MockAddressUsecase
+	ledger          *recordingLedger
+	promotions      *MockDiscounter
+	credits         *MockCreditPayer
+	taxes           *flatTax
+	invoices        *recordingInvoices
+	scheduler       *recordingScheduler
+	uow             *passthroughUnitOfWork
 	userID          string
 }
 
@@ -98,8 +413,23 @@ func (suite *CartItemUsecaseTestSuite) SetupTest() {
 	suite.ctx = context.Background()
 	suite.mockCartRepo = new(MockCartItemRepository)
 	suite.mockProductRepo = new(MockProductRepository)
-	suite.usecase = NewCartItemUsecase(suite.mockCartRepo, suite.mockProductRepo)
+	suite.mockOrderRepo = new(MockOrderRepository)
+	suite.mockPaymentRepo = new(MockPaymentRepository)
+	suite.gateway = gateway.NewFakeGateway("secret")
+	suite.mockAddressUC = new(MockAddressUsecase)
+	suite.ledger = &recordingLedger{}
+	suite.promotions = new(MockDiscounter)
+	suite.credits = new(MockCreditPayer)
+	suite.taxes = &flatTax{}
+	suite.invoices = &recordingInvoices{}
+	suite.scheduler = &recordingScheduler{}
+	suite.uow = &passthroughUnitOfWork{}
+	suite.usecase = NewCartItemUsecase(suite.mockCartRepo, suite.mockProductRepo, suite.mockOrderRepo, suite.mockPaymentRepo, suite.gateway, suite.mockAddressUC, defaultFees{}, suite.ledger, suite.promotions, suite.credits, fixedRates{money.USD: 50}, suite.taxes, suite.invoices, suite.scheduler, suite.uow)
 	suite.userID = "user123"
+
+	// Checkouts without an address ID ship to the default address.
+	defaultAddress := &address.Address{ID: "addr1", UserID: suite.userID, FullName: "Abebe Kebede", Phone: "+251911000000", Line1: "Bole Road", City: "Addis Ababa", Country: "ET", IsDefault: true}
+	suite.mockAddressUC.On("ResolveShippingAddress", mock.Anything, suite.userID, "").Return(defaultAddress, nil).Maybe()
 }
 
 // --- Helper: create a dummy product ---
@@ -108,7 +438,7 @@ func createTestProduct(id string, price float64, status string, title string) *p
 	return &product.Product{
 		ID:         id,
 		Title:      title,
-		Price:      price,
+		Price:      money.InSettlement(price),
 		ImageURL:   "image.jpg",
 		Grade:      "A",
 		Status:     status,
@@ -154,6 +484,31 @@ func (suite *CartItemUsecaseTestSuite) TestAddCartItem_ProductNotAvailable() {
 	suite.mockProductRepo.AssertExpectations(suite.T())
 }
 
+func (suite *CartItemUsecaseTestSuite) TestAddCartItem_ReservedAtAgreedPrice() {
+	testListingID := "prod123"
+	prod := createTestProduct(testListingID, 100.0, "available", "Test Product")
+	prod.Reservation = &offer.Reservation{OfferID: "offer1", BuyerID: suite.userID, Price: money.InSettlement(80), Until: time.Now().Add(time.Hour)}
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, testListingID).Return(prod, nil).Once()
+	suite.mockCartRepo.On("CreateCartItem", suite.ctx, mock.MatchedBy(func(item *cartitem.CartItem) bool {
+		return item.ListingID == prod.ID && item.Price == money.InSettlement(80)
+	})).Return(nil).Once()
+
+	err := suite.usecase.AddCartItem(suite.ctx, suite.userID, testListingID)
+	assert.NoError(suite.T(), err)
+	suite.mockCartRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestAddCartItem_ReservedForAnotherConsumer() {
+	testListingID := "prod123"
+	prod := createTestProduct(testListingID, 100.0, "available", "Test Product")
+	prod.Reservation = &offer.Reservation{OfferID: "offer1", BuyerID: "someone-else", Price: money.InSettlement(80), Until: time.Now().Add(time.Hour)}
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, testListingID).Return(prod, nil).Once()
+
+	err := suite.usecase.AddCartItem(suite.ctx, suite.userID, testListingID)
+	assert.ErrorIs(suite.T(), err, offer.ErrReserved)
+	suite.mockCartRepo.AssertNotCalled(suite.T(), "CreateCartItem", mock.Anything, mock.Anything)
+}
+
 // --- Tests for GetCartItems ---
 
 func (suite *CartItemUsecaseTestSuite) TestGetCartItems_Success() {
@@ -163,7 +518,7 @@ func (suite *CartItemUsecaseTestSuite) TestGetCartItems_Success() {
 			UserID:    suite.userID,
 			ListingID: "prod123",
 			Title:     "Test Product",
-			Price:     100.0,
+			Price:     money.InSettlement(100.0),
 			ImageURL:  "img.jpg",
 			Grade:     "A",
 			CreatedAt: time.Now(),
@@ -199,7 +554,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_Success() {
 			UserID:    suite.userID,
 			ListingID: "prod1",
 			Title:     "Test Product 1",
-			Price:     100.0,
+			Price:     money.InSettlement(100.0),
 			ImageURL:  "img1.jpg",
 			Grade:     "A",
 			CreatedAt: now,
@@ -209,7 +564,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_Success() {
 			UserID:    suite.userID,
 			ListingID: "prod2",
 			Title:     "Test Product 2",
-			Price:     200.0,
+			Price:     money.InSettlement(200.0),
 			ImageURL:  "img2.jpg",
 			Grade:     "B",
 			CreatedAt: now,
@@ -221,29 +576,486 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_Success() {
 	prod2 := createTestProduct("prod2", 200.0, "available", "Test Product 2")
 	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
 	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
+	// Each product is marked sold.
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod2", suite.userID).Return(nil).Once()
+	// The products belong to different resellers, so each gets its own order and payment.
+	for _, prod := range []*product.Product{prod1, prod2} {
+		sellerID := prod.ResellerID.Hex()
+		price := prod.Price
+		suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.MatchedBy(func(o *order.Order) bool {
+			return o.ConsumerID == suite.userID && o.ResellerID == sellerID && o.TotalPrice == price &&
+				o.PlatformFee == price.Times(0.02) && o.SellerEarning == price.Sub(price.Times(0.02)) &&
+				o.Status == order.OrderStatusPending && len(o.History) == 1 &&
+				o.ShippingAddress != nil && o.ShippingAddress.City == "Addis Ababa" &&
+				o.Titles[prod.ID] == prod.Title
+		})).Return(nil).Once()
+		suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+			return p.FromUserID == suite.userID && p.ToUserID == sellerID && p.Type == payment.B2C && p.Amount == price &&
+				p.ChargeID == "ch_fake_000001" && p.FeeRule == fee.RuleB2CDefault
+		})).Return(nil).Once()
+	}
 	// Expect ClearCart call.
 	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
 
-	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID)
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{})
 	assert.NoError(suite.T(), err)
 	// Total amount should be 300.0, fee = 6, net = 294.
 	assert.Equal(suite.T(), 300.0, resp.TotalAmount)
 	assert.Equal(suite.T(), 6.0, resp.PlatformFee)
 	assert.Equal(suite.T(), 294.0, resp.NetPayable)
 	assert.Len(suite.T(), resp.Items, 2)
+	assert.Len(suite.T(), resp.Orders, 2)
+	assert.Equal(suite.T(), prod1.ResellerID.Hex(), resp.Orders[0].SellerID)
+	assert.Equal(suite.T(), 98.0, resp.Orders[0].SellerEarning)
+	assert.Equal(suite.T(), prod2.ResellerID.Hex(), resp.Orders[1].SellerID)
+	assert.Equal(suite.T(), 196.0, resp.Orders[1].SellerEarning)
+	assert.Equal(suite.T(), "Bole Road", resp.ShippingAddress.Line1)
+	assert.Len(suite.T(), suite.ledger.payments, 2)
+	if assert.Len(suite.T(), suite.invoices.issued, 2) {
+		assert.Equal(suite.T(), resp.Orders[0].OrderID, suite.invoices.issued[0].OrderID)
+		assert.Equal(suite.T(), resp.Orders[1].OrderID, suite.invoices.issued[1].OrderID)
+	}
 	suite.mockCartRepo.AssertExpectations(suite.T())
 	suite.mockProductRepo.AssertExpectations(suite.T())
+	suite.mockOrderRepo.AssertExpectations(suite.T())
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_SameResellerSingleOrder() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+		{ID: "item2", UserID: suite.userID, ListingID: "prod2", Title: "Test Product 2", Price: money.InSettlement(50.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	prod2 := createTestProduct("prod2", 50.0, "available", "Test Product 2")
+	prod2.ResellerID = prod1.ResellerID
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, mock.Anything, suite.userID).Return(nil).Twice()
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.MatchedBy(func(o *order.Order) bool {
+		return len(o.ProductIDs) == 2 && o.TotalPrice == money.InSettlement(150.0)
+	})).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(150.0) && p.PlatformFee == money.InSettlement(3.0)
+	})).Return(nil).Once()
+	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), resp.Orders, 1)
+	assert.Len(suite.T(), resp.Orders[0].Items, 2)
+	suite.mockOrderRepo.AssertExpectations(suite.T())
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_PaysInUSD() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.FromMajor(10.0, money.USD)},
+		{ID: "item2", UserID: suite.userID, ListingID: "prod2", Title: "Test Product 2", Price: money.InSettlement(250.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 10.0, "available", "Test Product 1")
+	prod1.Price = money.FromMajor(10.0, money.USD)
+	prod2 := createTestProduct("prod2", 250.0, "available", "Test Product 2")
+	prod2.ResellerID = prod1.ResellerID
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, mock.Anything, suite.userID).Return(nil).Twice()
+	// The USD listing is booked at 10 * 50 = 500 ETB.
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.MatchedBy(func(o *order.Order) bool {
+		return o.TotalPrice == money.InSettlement(750.0) &&
+			o.Charged != nil && *o.Charged == money.Money{Amount: 1500, Currency: money.USD}
+	})).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(750.0) && p.ExchangeRate == 50 &&
+			p.Original == money.Money{Amount: 1500, Currency: money.USD}
+	})).Return(nil).Once()
+	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{Currency: "usd"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 750.0, resp.TotalAmount)
+	assert.Equal(suite.T(), money.Money{Amount: 1500, Currency: money.USD}, resp.Charged)
+	charge, _ := suite.gateway.GetCharge("ch_fake_000001")
+	assert.Equal(suite.T(), resp.Charged, charge.Amount)
+	suite.mockOrderRepo.AssertExpectations(suite.T())
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_UnsupportedCurrency() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+
+	_, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{Currency: "EUR"})
+	assert.ErrorIs(suite.T(), err, money.ErrUnsupportedCurrency)
+	suite.mockOrderRepo.AssertNotCalled(suite.T(), "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_AddsTax() {
+	suite.taxes.policy = tax.Policy{Version: 3, Mode: tax.ModeExclusive, Rules: []tax.Rule{
+		{Name: "et_vat", BuyerJurisdiction: "ET", Rate: 0.15},
+		{Name: "export", Rate: 0},
+	}}
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+	// The consumer pays 115; the fee is 2% of the 100 before tax.
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.MatchedBy(func(o *order.Order) bool {
+		return o.TotalPrice == money.InSettlement(115.0) && o.Tax == money.InSettlement(15.0) && o.PlatformFee == money.InSettlement(2.0) && o.SellerEarning == money.InSettlement(98.0) &&
+			len(o.TaxLines) == 1 && o.TaxLines[0].Rule == "et_vat" && o.TaxLines[0].BuyerJurisdiction == "ET"
+	})).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(115.0) && p.Tax == money.InSettlement(15.0) && p.SellerEarning == money.InSettlement(98.0) && len(p.TaxLines) == 1 &&
+			p.TaxLines[0].Taxable == 100.0 && p.TaxLines[0].PolicyVersion == 3
+	})).Return(nil).Once()
+	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 115.0, resp.TotalAmount)
+	assert.Equal(suite.T(), 15.0, resp.Tax)
+	assert.Equal(suite.T(), 98.0, resp.NetPayable)
+	charge, _ := suite.gateway.GetCharge("ch_fake_000001")
+	assert.Equal(suite.T(), money.InSettlement(115.0), charge.Amount)
+	suite.mockOrderRepo.AssertExpectations(suite.T())
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_TaxIncludedInPrice() {
+	suite.taxes.policy = tax.Policy{Mode: tax.ModeInclusive, Rules: []tax.Rule{{Name: "vat", Rate: 0.15}}}
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(115.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 115.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.Anything).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.Anything).Return(nil).Once()
+	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 115.0, resp.TotalAmount)
+	assert.InDelta(suite.T(), 15.0, resp.Tax, 1e-9)
+	assert.InDelta(suite.T(), 2.0, resp.PlatformFee, 1e-9)
+	assert.InDelta(suite.T(), 98.0, resp.NetPayable, 1e-9)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_ProductUpdateFails() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod1", suite.userID).Return(fmt.Errorf("db down")).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{})
+	assert.Nil(suite.T(), resp)
+	assert.Error(suite.T(), err)
+	// The consumer gets their money back.
+	charge, _ := suite.gateway.GetCharge("ch_fake_000001")
+	assert.Equal(suite.T(), payment.ChargeRefunded, charge.Status)
+	// Nothing is recorded and the cart is left intact.
+	suite.mockPaymentRepo.AssertNotCalled(suite.T(), "RecordPayment", mock.Anything, mock.Anything)
+	suite.mockOrderRepo.AssertNotCalled(suite.T(), "CreateOrder", mock.Anything, mock.Anything)
+	suite.mockCartRepo.AssertNotCalled(suite.T(), "ClearCart", mock.Anything, mock.Anything)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_ItemSoldMeanwhile() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+		{ID: "item2", UserID: suite.userID, ListingID: "prod2", Title: "Test Product 2", Price: money.InSettlement(50.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	prod2 := createTestProduct("prod2", 50.0, "available", "Test Product 2")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
+	// The first reseller's order goes through; another consumer bought prod2 first.
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.Anything).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.Anything).Return(nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod2", suite.userID).Return(product.ErrNotAvailable).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{})
+	assert.Nil(suite.T(), resp)
+	assert.ErrorIs(suite.T(), err, product.ErrNotAvailable)
+	// Both orders were placed in one unit of work, which aborts as a whole,
+	// and the whole charge is refunded.
+	assert.Equal(suite.T(), 1, suite.uow.calls)
+	charge, _ := suite.gateway.GetCharge("ch_fake_000001")
+	assert.Equal(suite.T(), payment.ChargeRefunded, charge.Status)
+	assert.Equal(suite.T(), money.InSettlement(150.0), charge.Refunded)
+	suite.mockCartRepo.AssertNotCalled(suite.T(), "ClearCart", mock.Anything, mock.Anything)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_UnknownAddress() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockAddressUC.On("ResolveShippingAddress", suite.ctx, suite.userID, "someone-elses").Return(nil, address.ErrAddressNotFound).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{AddressID: "someone-elses"})
+	assert.Nil(suite.T(), resp)
+	assert.ErrorIs(suite.T(), err, address.ErrAddressNotFound)
+	// The consumer is not charged.
+	_, charged := suite.gateway.GetCharge("ch_fake_000001")
+	assert.False(suite.T(), charged)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "MarkAsSold", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_EmptyCart() {
 	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return([]*cartitem.CartItem{}, nil).Once()
 
-	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID)
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{})
 	assert.Nil(suite.T(), resp)
 	assert.EqualError(suite.T(), err, "cart is empty")
 	suite.mockCartRepo.AssertExpectations(suite.T())
 }
 
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_PaymentDeclined() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.gateway.Decline = true
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{})
+	assert.Nil(suite.T(), resp)
+	assert.ErrorIs(suite.T(), err, payment.ErrPaymentDeclined)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "MarkAsSold", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockCartRepo.AssertNotCalled(suite.T(), "ClearCart", mock.Anything, mock.Anything)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_PromoCode() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+		{ID: "item2", UserID: suite.userID, ListingID: "prod2", Title: "Test Product 2", Price: money.InSettlement(50.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	prod2 := createTestProduct("prod2", 50.0, "available", "Test Product 2")
+	prod2.ResellerID = prod1.ResellerID
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, mock.Anything, suite.userID).Return(nil)
+	// Only prod1 is covered by the code.
+	suite.promotions.On("Redeem", suite.ctx, "summer10", suite.userID, mock.MatchedBy(func(lines []promotion.Line) bool {
+		return len(lines) == 2 && lines[0].ListingID == "prod1" && lines[0].Amount == 100.0
+	})).Return(&promotion.Discount{PromotionID: "promo1", Code: "SUMMER10", Total: 10, ByListing: map[string]float64{"prod1": 10}}, nil).Once()
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.MatchedBy(func(o *order.Order) bool {
+		return o.TotalPrice == money.InSettlement(140.0) && o.Discount == money.InSettlement(10.0) && o.PromoCode == "SUMMER10" && o.PlatformFee == money.InSettlement(140.0*0.02) &&
+			o.LineTotals["prod1"] == money.InSettlement(90.0) && o.LineTotals["prod2"] == money.InSettlement(50.0)
+	})).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(140.0) && p.Discount == money.InSettlement(10.0) && p.PromoCode == "SUMMER10"
+	})).Return(nil).Once()
+	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{PromoCode: "summer10"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 140.0, resp.TotalAmount)
+	assert.Equal(suite.T(), 10.0, resp.Discount)
+	assert.Equal(suite.T(), "SUMMER10", resp.PromoCode)
+	assert.Equal(suite.T(), 10.0, resp.Items[0].Discount)
+	suite.mockOrderRepo.AssertExpectations(suite.T())
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_FullyDiscountedIsNotCharged() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+	discount := &promotion.Discount{PromotionID: "promo1", Code: "FREE", Total: 100, ByListing: map[string]float64{"prod1": 100}}
+	suite.promotions.On("Redeem", suite.ctx, "FREE", suite.userID, mock.Anything).Return(discount, nil).Once()
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.MatchedBy(func(o *order.Order) bool {
+		return o.TotalPrice.IsZero() && o.Discount == money.InSettlement(100.0)
+	})).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount.IsZero() && p.ChargeID == ""
+	})).Return(nil).Once()
+	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
+	// Any charge would be declined.
+	suite.gateway.Decline = true
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{PromoCode: "FREE"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0.0, resp.TotalAmount)
+	_, charged := suite.gateway.GetCharge("ch_fake_000001")
+	assert.False(suite.T(), charged)
+	suite.mockOrderRepo.AssertExpectations(suite.T())
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_PromoCodeRejected() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.promotions.On("Redeem", suite.ctx, "BIG50", suite.userID, mock.Anything).Return(nil, promotion.ErrBasketTooSmall).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{PromoCode: "BIG50"})
+	assert.Nil(suite.T(), resp)
+	assert.ErrorIs(suite.T(), err, promotion.ErrBasketTooSmall)
+	_, charged := suite.gateway.GetCharge("ch_fake_000001")
+	assert.False(suite.T(), charged)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_PaymentDeclinedReleasesPromoCode() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	discount := &promotion.Discount{PromotionID: "promo1", Code: "SUMMER10", Total: 10, ByListing: map[string]float64{"prod1": 10}}
+	suite.promotions.On("Redeem", suite.ctx, "SUMMER10", suite.userID, mock.Anything).Return(discount, nil).Once()
+	suite.promotions.On("Release", mock.Anything, discount, suite.userID).Return(nil).Once()
+	suite.gateway.Decline = true
+
+	_, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{PromoCode: "SUMMER10"})
+	assert.ErrorIs(suite.T(), err, payment.ErrPaymentDeclined)
+	suite.promotions.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_PaysFullyWithCredit() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+	suite.credits.On("Spend", suite.ctx, suite.userID, 100.0, mock.Anything).Return(100.0, nil).Once()
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.Anything).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(100.0) && p.CreditAmount == money.InSettlement(100.0) && p.ChargeID == ""
+	})).Return(nil).Once()
+	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{UseCredit: true})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 100.0, resp.TotalAmount)
+	assert.Equal(suite.T(), 100.0, resp.CreditUsed)
+	_, charged := suite.gateway.GetCharge("ch_fake_000001")
+	assert.False(suite.T(), charged)
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_PaysPartlyWithCredit() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+		{ID: "item2", UserID: suite.userID, ListingID: "prod2", Title: "Test Product 2", Price: money.InSettlement(50.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	prod2 := createTestProduct("prod2", 50.0, "available", "Test Product 2")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, mock.Anything, suite.userID).Return(nil)
+	// Credit covers the first reseller's order in full and the second's in part.
+	suite.credits.On("Spend", suite.ctx, suite.userID, 150.0, mock.MatchedBy(func(ids []string) bool { return len(ids) == 2 })).Return(120.0, nil).Once()
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.Anything).Return(nil).Twice()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(100.0) && p.CreditAmount == money.InSettlement(100.0) && p.ChargeID == "ch_fake_000001"
+	})).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(50.0) && p.CreditAmount == money.InSettlement(20.0) && p.ChargeID == "ch_fake_000001"
+	})).Return(nil).Once()
+	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{UseCredit: true})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 150.0, resp.TotalAmount)
+	assert.Equal(suite.T(), 120.0, resp.CreditUsed)
+	charge, _ := suite.gateway.GetCharge("ch_fake_000001")
+	assert.Equal(suite.T(), money.InSettlement(30.0), charge.Amount)
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_PaymentDeclinedRestoresCredit() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.credits.On("Spend", suite.ctx, suite.userID, 100.0, mock.Anything).Return(40.0, nil).Once()
+	suite.credits.On("Restore", mock.Anything, suite.userID, 40.0, credit.EntryReversal, mock.Anything).Return(nil).Once()
+	suite.gateway.Decline = true
+
+	_, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{UseCredit: true})
+	assert.ErrorIs(suite.T(), err, payment.ErrPaymentDeclined)
+	suite.credits.AssertExpectations(suite.T())
+	suite.mockOrderRepo.AssertNotCalled(suite.T(), "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_QueuesFailedPromoRelease() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	discount := &promotion.Discount{PromotionID: "promo1", Code: "SUMMER10", Total: 10, ByListing: map[string]float64{"prod1": 10}}
+	suite.promotions.On("Redeem", suite.ctx, "SUMMER10", suite.userID, mock.Anything).Return(discount, nil).Once()
+	suite.promotions.On("Release", mock.Anything, discount, suite.userID).Return(fmt.Errorf("db down")).Once()
+	suite.gateway.Decline = true
+
+	_, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{PromoCode: "SUMMER10"})
+
+	assert.ErrorIs(suite.T(), err, payment.ErrPaymentDeclined)
+	if assert.Len(suite.T(), suite.scheduler.jobs, 1) {
+		assert.Equal(suite.T(), job.TypeReleasePromotion, suite.scheduler.jobs[0].Type)
+		assert.Equal(suite.T(), map[string]string{"promotion_id": "promo1", "code": "SUMMER10", "user_id": suite.userID}, suite.scheduler.jobs[0].Payload)
+	}
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_QueuesFailedCreditRestore() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1", Price: money.InSettlement(100.0)},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.credits.On("Spend", suite.ctx, suite.userID, 100.0, mock.Anything).Return(40.0, nil).Once()
+	suite.credits.On("Restore", mock.Anything, suite.userID, 40.0, credit.EntryReversal, mock.Anything).Return(fmt.Errorf("db down")).Once()
+	suite.gateway.Decline = true
+
+	_, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, models.CheckoutRequest{UseCredit: true})
+
+	assert.ErrorIs(suite.T(), err, payment.ErrPaymentDeclined)
+	if assert.Len(suite.T(), suite.scheduler.jobs, 1) {
+		j := suite.scheduler.jobs[0]
+		assert.Equal(suite.T(), job.TypeRestoreCredit, j.Type)
+		assert.Equal(suite.T(), suite.userID, j.Payload["user_id"])
+		assert.Equal(suite.T(), "40", j.Payload["amount"])
+	}
+}
+
 // --- Tests for CheckoutSingleItem ---
 
 func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_Success() {
@@ -255,7 +1067,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_Success() {
 			UserID:    suite.userID,
 			ListingID: "prod1",
 			Title:     "Test Product 1",
-			Price:     100.0,
+			Price:     money.InSettlement(100.0),
 			ImageURL:  "img1.jpg",
 			Grade:     "A",
 			CreatedAt: now,
@@ -264,25 +1076,35 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_Success() {
 	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
 	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
 	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("MarkAsSold", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.ToUserID == prod1.ResellerID.Hex() && p.SellerEarning == money.InSettlement(98.0)
+	})).Return(nil).Once()
+	suite.mockOrderRepo.On("CreateOrder", suite.ctx, mock.MatchedBy(func(o *order.Order) bool {
+		return o.ConsumerID == suite.userID && len(o.ProductIDs) == 1 && o.ProductIDs[0] == "prod1"
+	})).Return(nil).Once()
 	// Expect deletion of the single item from cart.
 	suite.mockCartRepo.On("DeleteCartItem", suite.ctx, suite.userID, "prod1").Return(nil).Once()
 
-	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1")
+	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1", models.CheckoutRequest{})
 	assert.NoError(suite.T(), err)
 	// Total should be 100, fee=2, net=98.
 	assert.Equal(suite.T(), 100.0, resp.TotalAmount)
 	assert.Equal(suite.T(), 2.0, resp.PlatformFee)
 	assert.Equal(suite.T(), 98.0, resp.NetPayable)
 	assert.Len(suite.T(), resp.Items, 1)
+	assert.Equal(suite.T(), "sold", resp.Items[0].Status)
 	suite.mockCartRepo.AssertExpectations(suite.T())
 	suite.mockProductRepo.AssertExpectations(suite.T())
+	suite.mockOrderRepo.AssertExpectations(suite.T())
+	suite.mockPaymentRepo.AssertExpectations(suite.T())
 }
 
 func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_ItemNotFoundInCart() {
 	// Empty cart scenario.
 	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return([]*cartitem.CartItem{}, nil).Once()
 
-	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1")
+	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1", models.CheckoutRequest{})
 	assert.Nil(suite.T(), resp)
 	assert.EqualError(suite.T(), err, "item not found in cart")
 	suite.mockCartRepo.AssertExpectations(suite.T())
@@ -297,7 +1119,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_ProductNotAvailabl
 			UserID:    suite.userID,
 			ListingID: "prod1",
 			Title:     "Test Product 1",
-			Price:     100.0,
+			Price:     money.InSettlement(100.0),
 			ImageURL:  "img1.jpg",
 			Grade:     "A",
 			CreatedAt: now,
@@ -307,7 +1129,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_ProductNotAvailabl
 	prod1 := createTestProduct("prod1", 100.0, "sold", "Test Product 1")
 	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
 
-	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1")
+	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1", models.CheckoutRequest{})
 	assert.Nil(suite.T(), resp)
 	expectedErr := fmt.Sprintf("item %q is no longer available", prod1.Title)
 	assert.EqualError(suite.T(), err, expectedErr)
@@ -0,0 +1,187 @@
+package creditusecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+type creditUsecase struct {
+	repo       credit.Repository
+	userRepo   user.Repository
+	unitOfWork uow.UnitOfWork
+	ledger     ledger.Usecase
+	clock      job.Clock
+}
+
+// NewCreditUsecase creates the store credit usecase. userRepo checks who
+// credit is issued to, and ledgerUC books credit the platform gives away.
+func NewCreditUsecase(repo credit.Repository, userRepo user.Repository, unitOfWork uow.UnitOfWork, ledgerUC ledger.Usecase, clock job.Clock) credit.Usecase {
+	return &creditUsecase{
+		repo:       repo,
+		userRepo:   userRepo,
+		unitOfWork: unitOfWork,
+		ledger:     ledgerUC,
+		clock:      clock,
+	}
+}
+
+func (u *creditUsecase) GetHistory(ctx context.Context, userID string) (*credit.History, error) {
+	w, err := u.repo.GetWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := u.repo.ListEntries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	h := &credit.History{UserID: userID, Balance: money.Money{Currency: money.Settlement}, Entries: entries}
+	if w != nil {
+		h.Balance = w.Balance
+	}
+	if h.Entries == nil {
+		h.Entries = []*credit.Entry{}
+	}
+	return h, nil
+}
+
+func (u *creditUsecase) IssueCredit(ctx context.Context, adminID, userID string, amount float64, reason string) (*credit.Entry, error) {
+	if amount <= 0 {
+		return nil, credit.ErrInvalidAmount
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, credit.ErrReasonRequired
+	}
+	consumer, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil || consumer == nil || consumer.Role != string(user.RoleConsumer) {
+		return nil, credit.ErrConsumerNotFound
+	}
+
+	e := u.newEntry(userID, credit.EntryIssued, money.InSettlement(amount), adminID)
+	e.Reason = reason
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.PostEntry(ctx, e); err != nil {
+			return err
+		}
+		return u.ledger.RecordCreditIssued(ctx, userID, amount, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (u *creditUsecase) IssueGiftCard(ctx context.Context, adminID string, amount float64, note string, expiresAt *time.Time) (*credit.GiftCard, error) {
+	if amount <= 0 {
+		return nil, credit.ErrInvalidAmount
+	}
+	code, err := credit.NewGiftCardCode()
+	if err != nil {
+		return nil, err
+	}
+	g := &credit.GiftCard{
+		Code:      code,
+		Amount:    money.InSettlement(amount),
+		Status:    credit.GiftCardActive,
+		Note:      strings.TrimSpace(note),
+		IssuedBy:  adminID,
+		ExpiresAt: expiresAt,
+		CreatedAt: u.clock.Now(),
+	}
+	if err := u.repo.CreateGiftCard(ctx, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (u *creditUsecase) ListGiftCards(ctx context.Context) ([]*credit.GiftCard, error) {
+	return u.repo.ListGiftCards(ctx)
+}
+
+// RedeemGiftCard claims the card and credits the consumer in one unit of
+// work, so a card is never used up without its credit being added.
+func (u *creditUsecase) RedeemGiftCard(ctx context.Context, userID, code string) (*credit.Entry, error) {
+	code = credit.NormalizeCode(code)
+	var e *credit.Entry
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		g, err := u.repo.ClaimGiftCard(ctx, code, userID, u.clock.Now())
+		if err != nil {
+			return err
+		}
+		e = u.newEntry(userID, credit.EntryGiftCard, g.Amount, userID)
+		e.GiftCardCode = g.Code
+		if err := u.repo.PostEntry(ctx, e); err != nil {
+			return err
+		}
+		return u.ledger.RecordGiftCardRedeemed(ctx, userID, g.Code, g.Amount.Major())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Spend takes whatever the balance covers of amount. A concurrent spend that
+// lowers the balance in between makes it fail with ErrInsufficientCredit
+// rather than overdraw the wallet.
+func (u *creditUsecase) Spend(ctx context.Context, userID string, amount float64, orderIDs []string) (float64, error) {
+	w, err := u.repo.GetWallet(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	wanted := money.InSettlement(amount)
+	if w == nil || w.Balance.Amount <= 0 || wanted.Amount <= 0 {
+		return 0, nil
+	}
+	taken := wanted
+	if w.Balance.Amount < taken.Amount {
+		taken = w.Balance
+	}
+
+	e := u.newEntry(userID, credit.EntryCheckout, taken.Neg(), userID)
+	e.OrderIDs = orderIDs
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return u.repo.PostEntry(ctx, e)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return taken.Major(), nil
+}
+
+func (u *creditUsecase) Restore(ctx context.Context, userID string, amount float64, t credit.EntryType, orderIDs []string) error {
+	restored := money.InSettlement(amount)
+	if restored.Amount <= 0 {
+		return nil
+	}
+	e := u.newEntry(userID, t, restored, userID)
+	e.OrderIDs = orderIDs
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return u.repo.PostEntry(ctx, e)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore store credit: %w", err)
+	}
+	return nil
+}
+
+func (u *creditUsecase) newEntry(userID string, t credit.EntryType, amount money.Money, createdBy string) *credit.Entry {
+	return &credit.Entry{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Type:      t,
+		Amount:    amount,
+		CreatedBy: createdBy,
+		CreatedAt: u.clock.Now(),
+	}
+}
@@ -0,0 +1,320 @@
+package creditusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCreditRepo struct {
+	mock.Mock
+}
+
+func (m *MockCreditRepo) GetWallet(ctx context.Context, userID string) (*credit.Wallet, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credit.Wallet), args.Error(1)
+}
+
+func (m *MockCreditRepo) PostEntry(ctx context.Context, e *credit.Entry) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockCreditRepo) ListEntries(ctx context.Context, userID string) ([]*credit.Entry, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*credit.Entry), args.Error(1)
+}
+
+func (m *MockCreditRepo) CreateGiftCard(ctx context.Context, g *credit.GiftCard) error {
+	args := m.Called(ctx, g)
+	return args.Error(0)
+}
+
+func (m *MockCreditRepo) ListGiftCards(ctx context.Context) ([]*credit.GiftCard, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*credit.GiftCard), args.Error(1)
+}
+
+func (m *MockCreditRepo) ClaimGiftCard(ctx context.Context, code, userID string, now time.Time) (*credit.GiftCard, error) {
+	args := m.Called(ctx, code, userID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credit.GiftCard), args.Error(1)
+}
+
+type MockUserRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserRepo) GetByID(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) CreateUser(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+func (m *MockUserRepo) CountActiveUsers(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserRepo) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) ListUsersByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
+	args := m.Called(ctx, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) FindUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateTrustData(ctx context.Context, user *user.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// recordingLedger keeps the store credit it is asked to book.
+type recordingLedger struct {
+	issued    map[string]float64
+	giftCards map[string]float64 // by code
+}
+
+func (l *recordingLedger) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordRelease(ctx context.Context, p *payment.Payment) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordPayout(ctx context.Context, sellerID, payoutID string, amount float64) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordCreditIssued(ctx context.Context, userID string, amount float64, memo string) error {
+	if l.issued == nil {
+		l.issued = make(map[string]float64)
+	}
+	l.issued[userID] += amount
+	return nil
+}
+
+func (l *recordingLedger) RecordGiftCardRedeemed(ctx context.Context, userID, code string, amount float64) error {
+	if l.giftCards == nil {
+		l.giftCards = make(map[string]float64)
+	}
+	l.giftCards[code] += amount
+	return nil
+}
+
+func (l *recordingLedger) GetBalance(ctx context.Context, userID string) (*ledger.Balance, error) {
+	return &ledger.Balance{UserID: userID}, nil
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func newTestUsecase() (credit.Usecase, *MockCreditRepo, *MockUserRepo, *recordingLedger) {
+	repo := new(MockCreditRepo)
+	users := new(MockUserRepo)
+	ledgerUC := &recordingLedger{}
+	return NewCreditUsecase(repo, users, &passthroughUnitOfWork{}, ledgerUC, fixedClock{now: testNow}), repo, users, ledgerUC
+}
+
+func TestIssueCredit(t *testing.T) {
+	uc, repo, users, ledgerUC := newTestUsecase()
+	users.On("GetByID", mock.Anything, "consumer1").Return(&user.User{ID: "consumer1", Role: string(user.RoleConsumer)}, nil)
+	repo.On("PostEntry", mock.Anything, mock.MatchedBy(func(e *credit.Entry) bool {
+		return e.UserID == "consumer1" && e.Type == credit.EntryIssued && e.Amount == money.InSettlement(15) &&
+			e.Reason == "late delivery" && e.CreatedBy == "admin1" && e.CreatedAt.Equal(testNow)
+	})).Return(nil)
+
+	e, err := uc.IssueCredit(context.Background(), "admin1", "consumer1", 15, " late delivery ")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, e.ID)
+	assert.Equal(t, 15.0, ledgerUC.issued["consumer1"])
+	repo.AssertExpectations(t)
+}
+
+func TestIssueCredit_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		amount  float64
+		reason  string
+		wantErr error
+	}{
+		{"zero amount", "consumer1", 0, "goodwill", credit.ErrInvalidAmount},
+		{"missing reason", "consumer1", 10, "  ", credit.ErrReasonRequired},
+		{"not a consumer", "reseller1", 10, "goodwill", credit.ErrConsumerNotFound},
+		{"unknown user", "ghost", 10, "goodwill", credit.ErrConsumerNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, users, _ := newTestUsecase()
+			users.On("GetByID", mock.Anything, "consumer1").Return(&user.User{ID: "consumer1", Role: string(user.RoleConsumer)}, nil).Maybe()
+			users.On("GetByID", mock.Anything, "reseller1").Return(&user.User{ID: "reseller1", Role: string(user.RoleReseller)}, nil).Maybe()
+			users.On("GetByID", mock.Anything, "ghost").Return(nil, errors.New("mongo: no documents in result")).Maybe()
+
+			_, err := uc.IssueCredit(context.Background(), "admin1", tt.userID, tt.amount, tt.reason)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertNotCalled(t, "PostEntry", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSpend_TakesWhatTheBalanceCovers(t *testing.T) {
+	uc, repo, _, _ := newTestUsecase()
+	repo.On("GetWallet", mock.Anything, "consumer1").Return(&credit.Wallet{UserID: "consumer1", Balance: money.InSettlement(30)}, nil)
+	repo.On("PostEntry", mock.Anything, mock.MatchedBy(func(e *credit.Entry) bool {
+		return e.Type == credit.EntryCheckout && e.Amount == money.InSettlement(-30) && len(e.OrderIDs) == 2
+	})).Return(nil)
+
+	taken, err := uc.Spend(context.Background(), "consumer1", 100, []string{"order1", "order2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, taken)
+	repo.AssertExpectations(t)
+}
+
+func TestSpend_NoCredit(t *testing.T) {
+	uc, repo, _, _ := newTestUsecase()
+	repo.On("GetWallet", mock.Anything, "consumer1").Return(nil, nil)
+
+	taken, err := uc.Spend(context.Background(), "consumer1", 100, []string{"order1"})
+
+	assert.NoError(t, err)
+	assert.Zero(t, taken)
+	repo.AssertNotCalled(t, "PostEntry", mock.Anything, mock.Anything)
+}
+
+func TestRedeemGiftCard(t *testing.T) {
+	uc, repo, _, ledgerUC := newTestUsecase()
+	repo.On("ClaimGiftCard", mock.Anything, "ABCD-EFGH", "consumer1", testNow).
+		Return(&credit.GiftCard{Code: "ABCD-EFGH", Amount: money.InSettlement(25), Status: credit.GiftCardRedeemed}, nil)
+	repo.On("PostEntry", mock.Anything, mock.MatchedBy(func(e *credit.Entry) bool {
+		return e.Type == credit.EntryGiftCard && e.Amount == money.InSettlement(25) && e.GiftCardCode == "ABCD-EFGH"
+	})).Return(nil)
+
+	e, err := uc.RedeemGiftCard(context.Background(), "consumer1", " abcd-efgh ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.InSettlement(25), e.Amount)
+	assert.Equal(t, 25.0, ledgerUC.giftCards["ABCD-EFGH"])
+	// A gift card is not goodwill credit.
+	assert.Empty(t, ledgerUC.issued)
+}
+
+func TestRedeemGiftCard_AlreadyRedeemed(t *testing.T) {
+	uc, repo, _, ledgerUC := newTestUsecase()
+	repo.On("ClaimGiftCard", mock.Anything, "ABCD-EFGH", "consumer2", testNow).Return(nil, credit.ErrGiftCardRedeemed)
+
+	_, err := uc.RedeemGiftCard(context.Background(), "consumer2", "ABCD-EFGH")
+
+	assert.ErrorIs(t, err, credit.ErrGiftCardRedeemed)
+	assert.Empty(t, ledgerUC.giftCards)
+	repo.AssertNotCalled(t, "PostEntry", mock.Anything, mock.Anything)
+}
+
+func TestIssueGiftCard(t *testing.T) {
+	uc, repo, _, _ := newTestUsecase()
+	repo.On("CreateGiftCard", mock.Anything, mock.MatchedBy(func(g *credit.GiftCard) bool {
+		return len(g.Code) == 19 && g.Amount == money.InSettlement(50) && g.Status == credit.GiftCardActive && g.IssuedBy == "admin1"
+	})).Return(nil)
+
+	g, err := uc.IssueGiftCard(context.Background(), "admin1", 50, "holiday promo", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, credit.NormalizeCode(g.Code), g.Code)
+
+	_, err = uc.IssueGiftCard(context.Background(), "admin1", -5, "", nil)
+	assert.ErrorIs(t, err, credit.ErrInvalidAmount)
+	repo.AssertNumberOfCalls(t, "CreateGiftCard", 1)
+}
+
+func TestGetHistory(t *testing.T) {
+	uc, repo, _, _ := newTestUsecase()
+	repo.On("GetWallet", mock.Anything, "consumer1").Return(nil, nil)
+	repo.On("ListEntries", mock.Anything, "consumer1").Return(nil, nil)
+
+	h, err := uc.GetHistory(context.Background(), "consumer1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.InSettlement(0), h.Balance)
+	assert.NotNil(t, h.Entries)
+}
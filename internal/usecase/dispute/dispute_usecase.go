@@ -0,0 +1,313 @@
+package disputeusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+type disputeUsecase struct {
+	repo       dispute.Repository
+	orders     dispute.Orders
+	unitOfWork uow.UnitOfWork
+	scheduler  job.Scheduler
+}
+
+func NewDisputeUsecase(repo dispute.Repository, orders dispute.Orders, unitOfWork uow.UnitOfWork, scheduler job.Scheduler) dispute.Usecase {
+	return &disputeUsecase{repo: repo, orders: orders, unitOfWork: unitOfWork, scheduler: scheduler}
+}
+
+// OpenDispute files a claim by the order's buyer. A disputed bundle purchase
+// also freezes the money still held in escrow for it.
+func (u *disputeUsecase) OpenDispute(ctx context.Context, orderID, buyerID string, reason dispute.Reason, first dispute.Message) (*dispute.Dispute, error) {
+	if !reason.IsValid() {
+		return nil, dispute.ErrInvalidReason
+	}
+	if err := first.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := u.orders.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	orderBuyerID, buyerRole, sellerID, sellerRole := parties(o)
+	if buyerID != orderBuyerID {
+		return nil, order.ErrNotOrderParty
+	}
+	if o.Status != order.OrderStatusShipped && !o.Status.IsFulfilled() {
+		return nil, dispute.ErrCannotDispute
+	}
+
+	existing, err := u.repo.GetOpenDisputeByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, dispute.ErrDisputeExists
+	}
+
+	now := time.Now()
+	first.ID = uuid.NewString()
+	first.AuthorID = buyerID
+	first.AuthorRole = buyerRole
+	first.CreatedAt = now
+	d := &dispute.Dispute{
+		ID:         uuid.NewString(),
+		OrderID:    orderID,
+		BuyerID:    buyerID,
+		SellerID:   sellerID,
+		SellerRole: sellerRole,
+		Reason:     reason,
+		Status:     dispute.StatusOpen,
+		Messages:   []dispute.Message{first},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.CreateDispute(ctx, d); err != nil {
+			return err
+		}
+		if !o.IsBundleOrder() {
+			return nil
+		}
+		_, err := u.orders.DisputeEscrow(ctx, orderID, buyerID, string(reason))
+		// The escrow may already be released or frozen; the dispute stands either way.
+		if err != nil && !errors.Is(err, escrow.ErrEscrowNotFound) && !errors.Is(err, escrow.ErrNotHeld) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (u *disputeUsecase) GetDispute(ctx context.Context, id, actorID string, role user.Role) (*dispute.Dispute, error) {
+	d, err := u.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !d.IsParty(actorID, role) {
+		// Other users' disputes are not revealed.
+		return nil, dispute.ErrDisputeNotFound
+	}
+	return d, nil
+}
+
+func (u *disputeUsecase) ListMyDisputes(ctx context.Context, userID string) ([]*dispute.Dispute, error) {
+	return u.repo.ListDisputesByUser(ctx, userID)
+}
+
+// AddMessage posts evidence or a reply to an unresolved dispute.
+func (u *disputeUsecase) AddMessage(ctx context.Context, id, actorID string, role user.Role, m dispute.Message) (*dispute.Dispute, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	d, err := u.GetDispute(ctx, id, actorID, role)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status.IsResolved() {
+		return nil, dispute.ErrDisputeResolved
+	}
+
+	m.ID = uuid.NewString()
+	m.AuthorID = actorID
+	m.AuthorRole = role
+	m.CreatedAt = time.Now()
+	status := d.StatusAfter(m)
+	if err := u.repo.AddMessage(ctx, d.ID, m, status); err != nil {
+		return nil, err
+	}
+	d.Messages = append(d.Messages, m)
+	d.Status = status
+	d.UpdatedAt = m.CreatedAt
+	return d, nil
+}
+
+func (u *disputeUsecase) ListDisputes(ctx context.Context, status dispute.Status) ([]*dispute.Dispute, error) {
+	return u.repo.ListDisputes(ctx, status)
+}
+
+func (u *disputeUsecase) UpdateStatus(ctx context.Context, id, adminID string, status dispute.Status) (*dispute.Dispute, error) {
+	if status.IsResolved() {
+		return nil, fmt.Errorf("%w: use resolve to decide a dispute", dispute.ErrInvalidTransition)
+	}
+	d, err := u.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status.IsResolved() {
+		return nil, dispute.ErrDisputeResolved
+	}
+	if !dispute.CanTransition(d.Status, status) {
+		return nil, fmt.Errorf("%w: %s to %s", dispute.ErrInvalidTransition, d.Status, status)
+	}
+
+	from := d.Status
+	d.Status = status
+	d.UpdatedAt = time.Now()
+	if err := u.repo.UpdateDispute(ctx, d, from); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Resolve records the decision before moving any money, so that two staff
+// members deciding at once cannot both trigger a refund. If the refund then
+// fails the dispute is put back as it was to be decided again. Once the
+// refund has gone through the dispute stays resolved; a lost dispute is
+// recorded against the supplier by a queued job.
+func (u *disputeUsecase) Resolve(ctx context.Context, id, adminID string, forBuyer bool, amount float64, note string) (*dispute.Dispute, error) {
+	d, err := u.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status.IsResolved() {
+		return nil, dispute.ErrDisputeResolved
+	}
+
+	from := d.Status
+	now := time.Now()
+	d.Status = dispute.StatusResolvedSeller
+	if forBuyer {
+		d.Status = dispute.StatusResolvedBuyer
+	}
+	d.Resolution = &dispute.Resolution{ResolvedBy: adminID, Note: note, ResolvedAt: now}
+	d.UpdatedAt = now
+	if err := u.repo.UpdateDispute(ctx, d, from); err != nil {
+		return nil, err
+	}
+
+	if !forBuyer {
+		if err := u.releaseEscrow(ctx, d, adminID, note); err != nil {
+			return nil, u.reopen(ctx, d, from, err)
+		}
+		return d, nil
+	}
+
+	refunded, err := u.refundBuyer(ctx, d, adminID, amount, note)
+	if err != nil {
+		return nil, u.reopen(ctx, d, from, err)
+	}
+	d.Resolution.RefundAmount = refunded
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateDispute(ctx, d, d.Status); err != nil {
+			return err
+		}
+		if d.SellerRole != user.RoleSupplier {
+			return nil
+		}
+		_, err := u.scheduler.Schedule(ctx, job.TypeRecordLostDispute, map[string]string{"dispute_id": d.ID, "supplier_id": d.SellerID}, 0)
+		return err
+	})
+	if err != nil {
+		log.Println("Failed to record refund of resolved dispute", d.ID+":", err)
+	}
+	return d, nil
+}
+
+// refundBuyer gives the buyer amount back, or everything still refundable
+// when amount is zero, and returns how much was refunded. Money frozen in
+// escrow is refunded straight from it when the refund is in full.
+func (u *disputeUsecase) refundBuyer(ctx context.Context, d *dispute.Dispute, adminID string, amount float64, note string) (float64, error) {
+	e, err := u.frozenEscrow(ctx, d, adminID)
+	if err != nil {
+		return 0, err
+	}
+	if e != nil {
+		if amount == 0 || money.InSettlement(amount) == e.Amount {
+			if _, err := u.orders.ResolveEscrow(ctx, d.OrderID, adminID, true, note); err != nil {
+				return 0, err
+			}
+			return e.Amount.Major(), nil
+		}
+		// A partial refund is taken from the seller once the escrow is paid out.
+		if _, err := u.orders.ResolveEscrow(ctx, d.OrderID, adminID, false, note); err != nil {
+			return 0, err
+		}
+	}
+
+	refund, err := u.orders.RefundOrder(ctx, d.OrderID, adminID, user.RoleAdmin, amount)
+	if err != nil {
+		return 0, err
+	}
+	return -refund.Amount.Major(), nil
+}
+
+// releaseEscrow pays out money frozen in escrow once the seller has won.
+func (u *disputeUsecase) releaseEscrow(ctx context.Context, d *dispute.Dispute, adminID, note string) error {
+	e, err := u.frozenEscrow(ctx, d, adminID)
+	if err != nil || e == nil {
+		return err
+	}
+	_, err = u.orders.ResolveEscrow(ctx, d.OrderID, adminID, false, note)
+	return err
+}
+
+// frozenEscrow returns the order's escrow if it is frozen by a dispute, and
+// nil if the order has no escrow or its money has already moved.
+func (u *disputeUsecase) frozenEscrow(ctx context.Context, d *dispute.Dispute, adminID string) (*escrow.Escrow, error) {
+	if d.SellerRole != user.RoleSupplier {
+		return nil, nil
+	}
+	e, err := u.orders.GetEscrow(ctx, d.OrderID, adminID, user.RoleAdmin)
+	if errors.Is(err, escrow.ErrEscrowNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != escrow.StatusDisputed {
+		return nil, nil
+	}
+	return e, nil
+}
+
+// reopen undoes a recorded decision whose money could not be moved and
+// returns cause, along with why the dispute could not be reopened if so.
+func (u *disputeUsecase) reopen(ctx context.Context, d *dispute.Dispute, status dispute.Status, cause error) error {
+	resolved := d.Status
+	d.Status = status
+	d.Resolution = nil
+	if err := u.repo.UpdateDispute(ctx, d, resolved); err != nil {
+		return fmt.Errorf("%w; reopening dispute %s also failed: %v", cause, d.ID, err)
+	}
+	return cause
+}
+
+func (u *disputeUsecase) get(ctx context.Context, id string) (*dispute.Dispute, error) {
+	d, err := u.repo.GetDisputeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, dispute.ErrDisputeNotFound
+	}
+	return d, nil
+}
+
+// parties returns the buyer and seller of an order with their roles.
+// Resellers buy bundles from suppliers and sell items to consumers.
+func parties(o *order.Order) (buyerID string, buyerRole user.Role, sellerID string, sellerRole user.Role) {
+	if o.IsBundleOrder() {
+		return o.ResellerID, user.RoleReseller, o.SupplierID, user.RoleSupplier
+	}
+	return o.ConsumerID, user.RoleConsumer, o.ResellerID, user.RoleReseller
+}
@@ -0,0 +1,362 @@
+package disputeusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDisputeRepo struct {
+	mock.Mock
+}
+
+func (m *MockDisputeRepo) CreateDispute(ctx context.Context, d *dispute.Dispute) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockDisputeRepo) GetDisputeByID(ctx context.Context, id string) (*dispute.Dispute, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeRepo) GetOpenDisputeByOrder(ctx context.Context, orderID string) (*dispute.Dispute, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeRepo) ListDisputesByUser(ctx context.Context, userID string) ([]*dispute.Dispute, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeRepo) ListDisputes(ctx context.Context, status dispute.Status) ([]*dispute.Dispute, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeRepo) AddMessage(ctx context.Context, id string, msg dispute.Message, status dispute.Status) error {
+	args := m.Called(ctx, id, msg, status)
+	return args.Error(0)
+}
+
+func (m *MockDisputeRepo) UpdateDispute(ctx context.Context, d *dispute.Dispute, from dispute.Status) error {
+	args := m.Called(ctx, d, from)
+	return args.Error(0)
+}
+
+type MockOrders struct {
+	mock.Mock
+}
+
+func (m *MockOrders) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrders) RefundOrder(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, actorID, role, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrders) GetEscrow(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, actorID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrders) DisputeEscrow(ctx context.Context, orderID, resellerID, reason string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, resellerID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockOrders) ResolveEscrow(ctx context.Context, orderID, adminID string, refund bool, note string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID, adminID, refund, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// recordingScheduler keeps scheduled jobs in memory instead of queueing them.
+type recordingScheduler struct {
+	jobs []*job.Job
+	err  error
+}
+
+func (s *recordingScheduler) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	j := &job.Job{Type: jobType, Payload: payload}
+	s.jobs = append(s.jobs, j)
+	return j, nil
+}
+
+func setup() (*MockDisputeRepo, *MockOrders, *recordingScheduler, dispute.Usecase) {
+	repo := new(MockDisputeRepo)
+	orders := new(MockOrders)
+	scheduler := &recordingScheduler{}
+	return repo, orders, scheduler, NewDisputeUsecase(repo, orders, &passthroughUnitOfWork{}, scheduler)
+}
+
+func TestOpenDispute_ConsumerOrder(t *testing.T) {
+	repo, orders, _, uc := setup()
+	ctx := context.Background()
+	orders.On("GetOrderByID", ctx, "order1").Return(&order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusDelivered}, nil)
+	repo.On("GetOpenDisputeByOrder", ctx, "order1").Return(nil, nil)
+	repo.On("CreateDispute", ctx, mock.AnythingOfType("*dispute.Dispute")).Return(nil)
+
+	d, err := uc.OpenDispute(ctx, "order1", "consumer1", dispute.ReasonNotAsDescribed, dispute.Message{
+		Body:        "The jacket is torn",
+		Attachments: []dispute.Attachment{{Name: "tear.jpg", URL: "https://img.example.com/tear.jpg"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "reseller1", d.SellerID)
+	assert.Equal(t, user.RoleReseller, d.SellerRole)
+	assert.Equal(t, dispute.StatusOpen, d.Status)
+	assert.Len(t, d.Messages, 1)
+	assert.Equal(t, user.RoleConsumer, d.Messages[0].AuthorRole)
+	orders.AssertNotCalled(t, "DisputeEscrow", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOpenDispute_BundleOrderFreezesEscrow(t *testing.T) {
+	repo, orders, _, uc := setup()
+	ctx := context.Background()
+	orders.On("GetOrderByID", ctx, "order1").Return(&order.Order{ID: "order1", ResellerID: "reseller1", SupplierID: "supplier1", BundleID: "b1", Status: order.OrderStatusCompleted}, nil)
+	repo.On("GetOpenDisputeByOrder", ctx, "order1").Return(nil, nil)
+	repo.On("CreateDispute", ctx, mock.AnythingOfType("*dispute.Dispute")).Return(nil)
+	orders.On("DisputeEscrow", ctx, "order1", "reseller1", "misrepresented").Return(&escrow.Escrow{Status: escrow.StatusDisputed}, nil)
+
+	d, err := uc.OpenDispute(ctx, "order1", "reseller1", dispute.ReasonMisrepresented, dispute.Message{Body: "Half the bundle is missing"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, user.RoleSupplier, d.SellerRole)
+	orders.AssertExpectations(t)
+}
+
+func TestOpenDispute_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   *order.Order
+		buyerID string
+		open    *dispute.Dispute
+		wantErr error
+	}{
+		{"not the buyer", &order.Order{ConsumerID: "consumer1", Status: order.OrderStatusDelivered}, "consumer2", nil, order.ErrNotOrderParty},
+		{"not shipped yet", &order.Order{ConsumerID: "consumer1", Status: order.OrderStatusProcessing}, "consumer1", nil, dispute.ErrCannotDispute},
+		{"already disputed", &order.Order{ConsumerID: "consumer1", Status: order.OrderStatusShipped}, "consumer1", &dispute.Dispute{ID: "d0"}, dispute.ErrDisputeExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, orders, _, uc := setup()
+			ctx := context.Background()
+			orders.On("GetOrderByID", ctx, "order1").Return(tt.order, nil)
+			repo.On("GetOpenDisputeByOrder", ctx, "order1").Return(tt.open, nil)
+
+			_, err := uc.OpenDispute(ctx, "order1", tt.buyerID, dispute.ReasonNotReceived, dispute.Message{Body: "Never arrived"})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertNotCalled(t, "CreateDispute", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOpenDispute_InvalidAttachment(t *testing.T) {
+	_, _, _, uc := setup()
+
+	_, err := uc.OpenDispute(context.Background(), "order1", "consumer1", dispute.ReasonNotAsDescribed, dispute.Message{
+		Attachments: []dispute.Attachment{{URL: "file:///etc/passwd"}},
+	})
+
+	assert.ErrorIs(t, err, dispute.ErrInvalidAttachment)
+}
+
+func TestAddMessage_SellerReplyMovesToReview(t *testing.T) {
+	repo, _, _, uc := setup()
+	ctx := context.Background()
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", BuyerID: "consumer1", SellerID: "reseller1", Status: dispute.StatusAwaitingSeller}, nil)
+	repo.On("AddMessage", ctx, "d1", mock.AnythingOfType("dispute.Message"), dispute.StatusUnderReview).Return(nil)
+
+	d, err := uc.AddMessage(ctx, "d1", "reseller1", user.RoleReseller, dispute.Message{Body: "It was shipped intact"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dispute.StatusUnderReview, d.Status)
+	repo.AssertExpectations(t)
+}
+
+func TestAddMessage_Outsider(t *testing.T) {
+	repo, _, _, uc := setup()
+	ctx := context.Background()
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", BuyerID: "consumer1", SellerID: "reseller1", Status: dispute.StatusOpen}, nil)
+
+	_, err := uc.AddMessage(ctx, "d1", "consumer2", user.RoleConsumer, dispute.Message{Body: "Me too"})
+
+	assert.ErrorIs(t, err, dispute.ErrDisputeNotFound)
+	repo.AssertNotCalled(t, "AddMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateStatus_RejectsResolution(t *testing.T) {
+	_, _, _, uc := setup()
+
+	_, err := uc.UpdateStatus(context.Background(), "d1", "admin1", dispute.StatusResolvedBuyer)
+
+	assert.ErrorIs(t, err, dispute.ErrInvalidTransition)
+}
+
+func TestResolve_ForBuyerAgainstSupplier(t *testing.T) {
+	repo, orders, scheduler, uc := setup()
+	ctx := context.Background()
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", SellerRole: user.RoleSupplier, Status: dispute.StatusUnderReview}, nil)
+	repo.On("UpdateDispute", ctx, mock.AnythingOfType("*dispute.Dispute"), dispute.StatusUnderReview).Return(nil).Once()
+	repo.On("UpdateDispute", ctx, mock.AnythingOfType("*dispute.Dispute"), dispute.StatusResolvedBuyer).Return(nil).Once()
+	orders.On("GetEscrow", ctx, "order1", "admin1", user.RoleAdmin).Return(&escrow.Escrow{OrderID: "order1", Amount: money.InSettlement(500), Status: escrow.StatusDisputed}, nil)
+	orders.On("ResolveEscrow", ctx, "order1", "admin1", true, "bundle misrepresented").Return(&escrow.Escrow{Status: escrow.StatusRefunded}, nil)
+
+	d, err := uc.Resolve(ctx, "d1", "admin1", true, 0, "bundle misrepresented")
+
+	assert.NoError(t, err)
+	assert.Equal(t, dispute.StatusResolvedBuyer, d.Status)
+	assert.Equal(t, 500.0, d.Resolution.RefundAmount)
+	orders.AssertNotCalled(t, "RefundOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	if assert.Len(t, scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeRecordLostDispute, scheduler.jobs[0].Type)
+		assert.Equal(t, "supplier1", scheduler.jobs[0].Payload["supplier_id"])
+	}
+}
+
+func TestResolve_PartialRefundAgainstReseller(t *testing.T) {
+	repo, orders, scheduler, uc := setup()
+	ctx := context.Background()
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "consumer1", SellerID: "reseller1", SellerRole: user.RoleReseller, Status: dispute.StatusUnderReview}, nil)
+	repo.On("UpdateDispute", ctx, mock.AnythingOfType("*dispute.Dispute"), mock.Anything).Return(nil)
+	orders.On("RefundOrder", ctx, "order1", "admin1", user.RoleAdmin, 20.0).Return(&payment.Payment{Amount: money.InSettlement(-20)}, nil)
+
+	d, err := uc.Resolve(ctx, "d1", "admin1", true, 20, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, d.Resolution.RefundAmount)
+	assert.Empty(t, scheduler.jobs)
+}
+
+func TestResolve_ForSellerReleasesEscrow(t *testing.T) {
+	repo, orders, scheduler, uc := setup()
+	ctx := context.Background()
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", OrderID: "order1", SellerID: "supplier1", SellerRole: user.RoleSupplier, Status: dispute.StatusOpen}, nil)
+	repo.On("UpdateDispute", ctx, mock.AnythingOfType("*dispute.Dispute"), dispute.StatusOpen).Return(nil)
+	orders.On("GetEscrow", ctx, "order1", "admin1", user.RoleAdmin).Return(&escrow.Escrow{OrderID: "order1", Amount: money.InSettlement(500), Status: escrow.StatusDisputed}, nil)
+	orders.On("ResolveEscrow", ctx, "order1", "admin1", false, "").Return(&escrow.Escrow{Status: escrow.StatusReleased}, nil)
+
+	d, err := uc.Resolve(ctx, "d1", "admin1", false, 0, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, dispute.StatusResolvedSeller, d.Status)
+	assert.Empty(t, scheduler.jobs)
+}
+
+func TestResolve_RefundFailureReopens(t *testing.T) {
+	repo, orders, _, uc := setup()
+	ctx := context.Background()
+	refundErr := errors.New("gateway down")
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", OrderID: "order1", SellerID: "reseller1", SellerRole: user.RoleReseller, Status: dispute.StatusUnderReview}, nil)
+	repo.On("UpdateDispute", ctx, mock.AnythingOfType("*dispute.Dispute"), dispute.StatusUnderReview).Return(nil)
+	repo.On("UpdateDispute", ctx, mock.MatchedBy(func(d *dispute.Dispute) bool {
+		return d.Status == dispute.StatusUnderReview && d.Resolution == nil
+	}), dispute.StatusResolvedBuyer).Return(nil)
+	orders.On("RefundOrder", ctx, "order1", "admin1", user.RoleAdmin, 0.0).Return(nil, refundErr)
+
+	_, err := uc.Resolve(ctx, "d1", "admin1", true, 0, "")
+
+	assert.ErrorIs(t, err, refundErr)
+	repo.AssertExpectations(t)
+}
+
+func TestResolve_ReopenFailureIsReported(t *testing.T) {
+	repo, orders, _, uc := setup()
+	ctx := context.Background()
+	refundErr := errors.New("gateway down")
+	reopenErr := errors.New("write conflict")
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", OrderID: "order1", SellerID: "reseller1", SellerRole: user.RoleReseller, Status: dispute.StatusUnderReview}, nil)
+	repo.On("UpdateDispute", ctx, mock.AnythingOfType("*dispute.Dispute"), dispute.StatusUnderReview).Return(nil)
+	repo.On("UpdateDispute", ctx, mock.AnythingOfType("*dispute.Dispute"), dispute.StatusResolvedBuyer).Return(reopenErr)
+	orders.On("RefundOrder", ctx, "order1", "admin1", user.RoleAdmin, 0.0).Return(nil, refundErr)
+
+	_, err := uc.Resolve(ctx, "d1", "admin1", true, 0, "")
+
+	assert.ErrorIs(t, err, refundErr)
+	assert.ErrorContains(t, err, "write conflict")
+}
+
+func TestResolve_QueueFailureKeepsRefund(t *testing.T) {
+	repo, orders, scheduler, uc := setup()
+	ctx := context.Background()
+	scheduler.err = errors.New("job store down")
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", SellerRole: user.RoleSupplier, Status: dispute.StatusUnderReview}, nil)
+	repo.On("UpdateDispute", ctx, mock.AnythingOfType("*dispute.Dispute"), mock.Anything).Return(nil)
+	orders.On("GetEscrow", ctx, "order1", "admin1", user.RoleAdmin).Return(&escrow.Escrow{OrderID: "order1", Amount: money.InSettlement(500), Status: escrow.StatusDisputed}, nil)
+	orders.On("ResolveEscrow", ctx, "order1", "admin1", true, "").Return(&escrow.Escrow{Status: escrow.StatusRefunded}, nil)
+
+	d, err := uc.Resolve(ctx, "d1", "admin1", true, 0, "")
+
+	// The refund has gone through, so the dispute stays resolved and is not
+	// reopened.
+	assert.NoError(t, err)
+	assert.Equal(t, dispute.StatusResolvedBuyer, d.Status)
+	repo.AssertNumberOfCalls(t, "UpdateDispute", 2)
+}
+
+func TestResolve_AlreadyResolved(t *testing.T) {
+	repo, _, _, uc := setup()
+	ctx := context.Background()
+	repo.On("GetDisputeByID", ctx, "d1").Return(&dispute.Dispute{ID: "d1", Status: dispute.StatusResolvedSeller}, nil)
+
+	_, err := uc.Resolve(ctx, "d1", "admin1", true, 0, "")
+
+	assert.ErrorIs(t, err, dispute.ErrDisputeResolved)
+}
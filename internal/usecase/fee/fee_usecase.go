@@ -0,0 +1,75 @@
+package feeusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+type feeUsecase struct {
+	repo     fee.Repository
+	userRepo user.Repository
+}
+
+// NewFeeUsecase creates the fee policy usecase. userRepo is used to find the
+// seller's tier when an override depends on it.
+func NewFeeUsecase(repo fee.Repository, userRepo user.Repository) fee.Usecase {
+	return &feeUsecase{repo: repo, userRepo: userRepo}
+}
+
+func (u *feeUsecase) GetPolicy(ctx context.Context) (*fee.Policy, error) {
+	p, err := u.repo.GetCurrentPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return fee.DefaultPolicy(), nil
+	}
+	return p, nil
+}
+
+func (u *feeUsecase) UpdatePolicy(ctx context.Context, adminID string, p *fee.Policy) (*fee.Policy, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	current, err := u.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Version = current.Version + 1
+	p.UpdatedBy = adminID
+	p.UpdatedAt = time.Now()
+	if p.Overrides == nil {
+		p.Overrides = []fee.Override{}
+	}
+	if err := u.repo.SavePolicy(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (u *feeUsecase) GetPolicyHistory(ctx context.Context) ([]*fee.Policy, error) {
+	return u.repo.ListPolicies(ctx)
+}
+
+func (u *feeUsecase) Quote(ctx context.Context, s fee.Sale) (fee.Quote, error) {
+	p, err := u.GetPolicy(ctx)
+	if err != nil {
+		return fee.Quote{}, err
+	}
+
+	tier := fee.TierStandard
+	if p.NeedsSellerTier() {
+		seller, err := u.userRepo.GetByID(ctx, s.SellerID)
+		if err != nil {
+			return fee.Quote{}, err
+		}
+		if seller != nil {
+			tier = fee.TierFor(seller.TrustScore)
+		}
+	}
+	return p.Quote(s, tier), nil
+}
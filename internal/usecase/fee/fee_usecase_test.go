@@ -0,0 +1,208 @@
+package feeusecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFeeRepo struct {
+	mock.Mock
+}
+
+func (m *MockFeeRepo) GetCurrentPolicy(ctx context.Context) (*fee.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Policy), args.Error(1)
+}
+
+func (m *MockFeeRepo) SavePolicy(ctx context.Context, p *fee.Policy) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockFeeRepo) ListPolicies(ctx context.Context) ([]*fee.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fee.Policy), args.Error(1)
+}
+
+type MockUserRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserRepo) CreateUser(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByID(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) ListUsersByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
+	args := m.Called(ctx, role)
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) FindUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateTrustData(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) CountActiveUsers(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func testPolicy() *fee.Policy {
+	return &fee.Policy{
+		Version: 3,
+		B2B:     fee.Rate{Rate: 0.02, MaxFee: 50},
+		B2C:     fee.Rate{Rate: 0.05, MinFee: 1},
+		Overrides: []fee.Override{
+			{Name: "jackets", Category: "Jacket", Rate: fee.Rate{Rate: 0.08}},
+			{Name: "top_rated_jackets", Category: "jacket", SellerTier: fee.TierTopRated, Rate: fee.Rate{Rate: 0.03}},
+			{Name: "low_trust", SaleType: payment.B2C, SellerTier: fee.TierLowTrust, Rate: fee.Rate{Rate: 0.1}},
+		},
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		name       string
+		sale       fee.Sale
+		trustScore int
+		wantFee    float64
+		wantRule   string
+	}{
+		{"b2c default", fee.Sale{Type: payment.B2C, Category: "dress", SellerID: "s1", Amount: 100}, 75, 5, fee.RuleB2CDefault},
+		{"b2c minimum fee", fee.Sale{Type: payment.B2C, Category: "dress", SellerID: "s1", Amount: 10}, 75, 1, fee.RuleB2CDefault},
+		{"b2b maximum fee", fee.Sale{Type: payment.B2B, Category: "mixed", SellerID: "s1", Amount: 5000}, 75, 50, fee.RuleB2BDefault},
+		{"category override", fee.Sale{Type: payment.B2C, Category: "jacket", SellerID: "s1", Amount: 100}, 75, 8, "jackets"},
+		{"most specific override wins", fee.Sale{Type: payment.B2C, Category: "jacket", SellerID: "s1", Amount: 100}, 95, 3, "top_rated_jackets"},
+		{"seller tier override", fee.Sale{Type: payment.B2C, Category: "dress", SellerID: "s1", Amount: 100}, 40, 10, "low_trust"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockFeeRepo)
+			userRepo := new(MockUserRepo)
+			uc := NewFeeUsecase(repo, userRepo)
+			repo.On("GetCurrentPolicy", mock.Anything).Return(testPolicy(), nil)
+			userRepo.On("GetByID", mock.Anything, "s1").Return(&user.User{ID: "s1", TrustScore: tt.trustScore}, nil)
+
+			q, err := uc.Quote(context.Background(), tt.sale)
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.wantFee, q.Fee, 1e-9)
+			assert.InDelta(t, tt.sale.Amount-tt.wantFee, q.SellerEarning, 1e-9)
+			assert.Equal(t, tt.wantRule, q.Rule)
+			assert.Equal(t, 3, q.PolicyVersion)
+		})
+	}
+}
+
+func TestQuote_DefaultPolicySkipsSellerLookup(t *testing.T) {
+	repo := new(MockFeeRepo)
+	userRepo := new(MockUserRepo)
+	uc := NewFeeUsecase(repo, userRepo)
+	repo.On("GetCurrentPolicy", mock.Anything).Return(nil, nil)
+
+	q, err := uc.Quote(context.Background(), fee.Sale{Type: payment.B2C, SellerID: "s1", Amount: 100})
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0, q.Fee, 1e-9)
+	assert.Equal(t, 0, q.PolicyVersion)
+	userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestUpdatePolicy_SavesNextVersion(t *testing.T) {
+	repo := new(MockFeeRepo)
+	uc := NewFeeUsecase(repo, new(MockUserRepo))
+	repo.On("GetCurrentPolicy", mock.Anything).Return(testPolicy(), nil)
+	repo.On("SavePolicy", mock.Anything, mock.MatchedBy(func(p *fee.Policy) bool {
+		return p.Version == 4 && p.UpdatedBy == "admin1" && p.Note == "summer promotion"
+	})).Return(nil)
+
+	p, err := uc.UpdatePolicy(context.Background(), "admin1", &fee.Policy{
+		B2B:  fee.Rate{Rate: 0.02},
+		B2C:  fee.Rate{Rate: 0.01},
+		Note: "summer promotion",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, p.Version)
+	assert.NotNil(t, p.Overrides)
+	repo.AssertExpectations(t)
+}
+
+func TestUpdatePolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *fee.Policy
+	}{
+		{"rate above 100%", &fee.Policy{B2C: fee.Rate{Rate: 1.5}}},
+		{"min above max", &fee.Policy{B2B: fee.Rate{Rate: 0.02, MinFee: 10, MaxFee: 5}}},
+		{"unnamed override", &fee.Policy{Overrides: []fee.Override{{Category: "jacket"}}}},
+		{"duplicate override", &fee.Policy{Overrides: []fee.Override{{Name: "a", Category: "jacket"}, {Name: "a", Category: "dress"}}}},
+		{"override without criteria", &fee.Policy{Overrides: []fee.Override{{Name: "all", Rate: fee.Rate{Rate: 0.1}}}}},
+		{"unknown seller tier", &fee.Policy{Overrides: []fee.Override{{Name: "vip", SellerTier: "vip"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockFeeRepo)
+			uc := NewFeeUsecase(repo, new(MockUserRepo))
+
+			_, err := uc.UpdatePolicy(context.Background(), "admin1", tt.policy)
+
+			assert.ErrorIs(t, err, fee.ErrInvalidPolicy)
+			repo.AssertNotCalled(t, "SavePolicy", mock.Anything, mock.Anything)
+		})
+	}
+}
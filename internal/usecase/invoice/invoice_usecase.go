@@ -0,0 +1,214 @@
+package invoiceusecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+type invoiceUsecase struct {
+	repo        invoice.Repository
+	orderRepo   order.Repository
+	paymentRepo payment.Repository
+	userRepo    user.Repository
+	unitOfWork  uow.UnitOfWork
+	renderer    invoice.Renderer
+	clock       job.Clock
+}
+
+// NewInvoiceUsecase creates the invoice usecase. Invoices are built from the
+// order and payment they bill; the user repository names the parties and
+// renderer lays an invoice out as a PDF.
+func NewInvoiceUsecase(repo invoice.Repository, orderRepo order.Repository, paymentRepo payment.Repository, userRepo user.Repository, unitOfWork uow.UnitOfWork, renderer invoice.Renderer, clock job.Clock) invoice.Usecase {
+	return &invoiceUsecase{
+		repo:        repo,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		userRepo:    userRepo,
+		unitOfWork:  unitOfWork,
+		renderer:    renderer,
+		clock:       clock,
+	}
+}
+
+func (u *invoiceUsecase) GetInvoice(ctx context.Context, orderID, actorID string, role user.Role) (*invoice.Invoice, error) {
+	o, err := u.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if !o.IsParty(actorID, role) {
+		return nil, order.ErrNotOrderParty
+	}
+
+	inv, err := u.repo.GetInvoiceByOrder(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inv == nil {
+		return u.issueMissing(ctx, o)
+	}
+	return inv, nil
+}
+
+// issueMissing invoices an order paid before invoices were issued, from the
+// payment recorded for it. Orders that were never paid have no invoice. If
+// another request issues it first, that invoice is returned instead.
+func (u *invoiceUsecase) issueMissing(ctx context.Context, o *order.Order) (*invoice.Invoice, error) {
+	payments, err := u.paymentRepo.GetPaymentsByOrder(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	var paid *payment.Payment
+	for _, p := range payments {
+		if p.RefundOf == "" {
+			paid = p
+			break
+		}
+	}
+	if paid == nil {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+
+	inv, err := u.IssueInvoice(ctx, o, paid)
+	if errors.Is(err, invoice.ErrInvoiceExists) {
+		inv, err = u.repo.GetInvoiceByOrder(ctx, o.ID)
+		if err == nil && inv == nil {
+			err = invoice.ErrInvoiceNotFound
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (u *invoiceUsecase) GetInvoicePDF(ctx context.Context, orderID, actorID string, role user.Role) (*invoice.Invoice, []byte, error) {
+	inv, err := u.GetInvoice(ctx, orderID, actorID, role)
+	if err != nil {
+		return nil, nil, err
+	}
+	pdf, err := u.renderer.RenderPDF(inv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inv, pdf, nil
+}
+
+// IssueInvoice builds the order's invoice and stores it under the next
+// number. The number is taken in the same unit of work as the insert, and
+// the caller's unit of work when there is one, so an order that does not
+// commit leaves no gap in the sequence.
+func (u *invoiceUsecase) IssueInvoice(ctx context.Context, o *order.Order, paid *payment.Payment) (*invoice.Invoice, error) {
+	inv := u.build(ctx, o, paid)
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		seq, err := u.repo.NextNumber(ctx)
+		if err != nil {
+			return err
+		}
+		inv.Number = invoice.FormatNumber(seq)
+		inv.IssuedAt = u.clock.Now()
+		return u.repo.CreateInvoice(ctx, inv)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// build fills in an invoice for the order from what was recorded when it was
+// paid, so listings deleted since do not change it. The bundle supplier or
+// the reseller is the seller; the reseller or the consumer is the buyer.
+func (u *invoiceUsecase) build(ctx context.Context, o *order.Order, paid *payment.Payment) *invoice.Invoice {
+	inv := &invoice.Invoice{
+		ID:             o.ID,
+		OrderID:        o.ID,
+		PaymentID:      paid.ID,
+		OrderDate:      o.CreatedAt,
+		ShipTo:         o.ShippingAddress,
+		Discount:       o.Discount.Major(),
+		PromoCode:      o.PromoCode,
+		Tax:            paid.Tax.Major(),
+		TaxLines:       paid.TaxLines,
+		Total:          paid.Amount.Major(),
+		Charged:        o.Charged,
+		PaidWithCredit: paid.CreditAmount.Major(),
+		PlatformFee:    paid.PlatformFee.Major(),
+		SellerEarning:  paid.SellerEarning.Major(),
+	}
+
+	buyerID, sellerID := o.ConsumerID, o.ResellerID
+	if o.IsBundleOrder() {
+		inv.Kind = invoice.KindInvoice
+		buyerID, sellerID = o.ResellerID, o.SupplierID
+	} else {
+		inv.Kind = invoice.KindReceipt
+	}
+	inv.Items = lines(o)
+	subtotal := money.Money{Currency: money.Settlement}
+	for _, l := range inv.Items {
+		subtotal = subtotal.Add(money.InSettlement(l.Amount))
+	}
+	inv.Subtotal = subtotal.Major()
+	inv.Buyer = u.party(ctx, buyerID)
+	inv.Seller = u.party(ctx, sellerID)
+	return inv
+}
+
+// party names a user on the invoice. A user who has since been removed is
+// still listed by ID.
+func (u *invoiceUsecase) party(ctx context.Context, userID string) invoice.Party {
+	p := invoice.Party{UserID: userID}
+	if usr, err := u.userRepo.GetByID(ctx, userID); err == nil && usr != nil {
+		p.Name = usr.Name
+		if p.Name == "" {
+			p.Name = usr.Username
+		}
+		p.Email = usr.Email
+	}
+	return p
+}
+
+// lines lists what the order paid for each item, less its share of the tax
+// added on top. A bundle order has a single line. The last line takes what
+// is left of the tax so the lines add up to the total without it.
+func lines(o *order.Order) []invoice.Line {
+	added := money.InSettlement(tax.ExclusiveTotal(o.TaxLines))
+	if o.IsBundleOrder() {
+		price := o.TotalPrice.Sub(added).Major()
+		return []invoice.Line{{Reference: o.BundleID, Description: describe(o, o.BundleID, "Bundle "), Quantity: 1, UnitPrice: price, Amount: price}}
+	}
+
+	taxLeft := added
+	items := make([]invoice.Line, 0, len(o.ProductIDs))
+	for i, id := range o.ProductIDs {
+		paid := o.LineTotal(id)
+		share := taxLeft
+		if i < len(o.ProductIDs)-1 && o.TotalPrice.Amount != 0 {
+			share = added.Times(float64(paid.Amount) / float64(o.TotalPrice.Amount))
+		}
+		taxLeft = taxLeft.Sub(share)
+		price := paid.Sub(share).Major()
+		items = append(items, invoice.Line{Reference: id, Description: describe(o, id, "Listing "), Quantity: 1, UnitPrice: price, Amount: price})
+	}
+	return items
+}
+
+// describe names an item by the title it was bought under. Orders placed
+// before titles were recorded name it by ID.
+func describe(o *order.Order, id, kind string) string {
+	if title := o.Titles[id]; title != "" {
+		return title
+	}
+	return kind + id
+}
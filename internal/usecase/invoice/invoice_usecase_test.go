@@ -0,0 +1,483 @@
+package invoiceusecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInvoiceRepo struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceRepo) GetInvoiceByOrder(ctx context.Context, orderID string) (*invoice.Invoice, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invoice.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepo) NextNumber(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockInvoiceRepo) CreateInvoice(ctx context.Context, inv *invoice.Invoice) error {
+	args := m.Called(ctx, inv)
+	return args.Error(0)
+}
+
+type MockOrderRepo struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepo) CreateOrder(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, consumerID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) TransitionStatus(ctx context.Context, orderID string, t order.StatusTransition) error {
+	args := m.Called(ctx, orderID, t)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) DeleteOrder(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) GetOrdersBySupplier(ctx context.Context, supplierID string) ([]*order.Order, error) {
+	args := m.Called(ctx, supplierID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, resellerID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+type MockUserRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserRepo) GetByID(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) CreateUser(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) CountActiveUsers(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserRepo) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) ListUsersByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
+	args := m.Called(ctx, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) FindUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateTrustData(ctx context.Context, user *user.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+type MockPaymentRepo struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepo) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByUser(ctx context.Context, userID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID, pType)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByOrder(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentByID(ctx context.Context, id string) (*payment.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) ReserveRefund(ctx context.Context, id string, refunded, amount money.Money) error {
+	args := m.Called(ctx, id, refunded, amount)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) GetAllPlatformFees(ctx context.Context) (float64, float64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
+}
+
+func (m *MockPaymentRepo) ListUnsettledPayments(ctx context.Context) ([]*payment.Payment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) ListPaymentsBetween(ctx context.Context, from, to time.Time) ([]*payment.Payment, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) AssignPayout(ctx context.Context, paymentIDs []string, payoutID string) error {
+	args := m.Called(ctx, paymentIDs, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) ReleasePayout(ctx context.Context, payoutID string) error {
+	args := m.Called(ctx, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) UpdatePaymentStatus(ctx context.Context, id, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// stubRenderer renders every invoice as its number.
+type stubRenderer struct{}
+
+func (stubRenderer) RenderPDF(inv *invoice.Invoice) ([]byte, error) {
+	return []byte(inv.Number), nil
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	invoices *MockInvoiceRepo
+	orders   *MockOrderRepo
+	payments *MockPaymentRepo
+	users    *MockUserRepo
+	uow      *passthroughUnitOfWork
+	uc       invoice.Usecase
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		invoices: new(MockInvoiceRepo),
+		orders:   new(MockOrderRepo),
+		payments: new(MockPaymentRepo),
+		users:    new(MockUserRepo),
+		uow:      &passthroughUnitOfWork{},
+	}
+	f.uc = NewInvoiceUsecase(f.invoices, f.orders, f.payments, f.users, f.uow, stubRenderer{}, fixedClock{now: testNow})
+	f.users.On("GetByID", mock.Anything, "consumer1").Return(&user.User{ID: "consumer1", Username: "abebe", Email: "abebe@example.com"}, nil).Maybe()
+	f.users.On("GetByID", mock.Anything, "reseller1").Return(&user.User{ID: "reseller1", Name: "Merkato Threads", Email: "shop@example.com"}, nil).Maybe()
+	f.users.On("GetByID", mock.Anything, "supplier1").Return(&user.User{ID: "supplier1", Name: "Addis Bales"}, nil).Maybe()
+	return f
+}
+
+func consumerOrder() *order.Order {
+	return &order.Order{
+		ID:         "order1",
+		ConsumerID: "consumer1",
+		ResellerID: "reseller1",
+		ProductIDs: []string{"p1", "p2"},
+		TotalPrice: money.InSettlement(54),
+		Discount:   money.InSettlement(6),
+		PromoCode:  "SUMMER",
+		CreatedAt:  "2025-06-14T10:00:00Z",
+		LineTotals: map[string]money.Money{"p1": money.InSettlement(36), "p2": money.InSettlement(18)},
+		Titles:     map[string]string{"p1": "Denim jacket"},
+	}
+}
+
+func TestIssueInvoice_ReceiptForConsumerOrder(t *testing.T) {
+	f := newFixture()
+	paid := &payment.Payment{ID: "pay1", Amount: money.InSettlement(54), CreditAmount: money.InSettlement(4), PlatformFee: money.InSettlement(1.08), SellerEarning: money.InSettlement(52.92)}
+	f.invoices.On("NextNumber", mock.Anything).Return(int64(1), nil)
+	f.invoices.On("CreateInvoice", mock.Anything, mock.Anything).Return(nil)
+
+	inv, err := f.uc.IssueInvoice(context.Background(), consumerOrder(), paid)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-000001", inv.Number)
+	assert.Equal(t, invoice.KindReceipt, inv.Kind)
+	assert.Equal(t, "pay1", inv.PaymentID)
+	assert.True(t, inv.IssuedAt.Equal(testNow))
+	assert.Equal(t, invoice.Party{UserID: "consumer1", Name: "abebe", Email: "abebe@example.com"}, inv.Buyer)
+	assert.Equal(t, "Merkato Threads", inv.Seller.Name)
+	// Lines are what was paid after the discount; p2 was bought before
+	// titles were recorded.
+	assert.Equal(t, []invoice.Line{
+		{Reference: "p1", Description: "Denim jacket", Quantity: 1, UnitPrice: 36, Amount: 36},
+		{Reference: "p2", Description: "Listing p2", Quantity: 1, UnitPrice: 18, Amount: 18},
+	}, inv.Items)
+	assert.Equal(t, 54.0, inv.Subtotal)
+	assert.Equal(t, 6.0, inv.Discount)
+	assert.Equal(t, 54.0, inv.Total)
+	assert.Equal(t, 4.0, inv.PaidWithCredit)
+	assert.Equal(t, 1.08, inv.PlatformFee)
+	assert.Equal(t, 1, f.uow.calls)
+	f.invoices.AssertCalled(t, "CreateInvoice", mock.Anything, inv)
+	f.orders.AssertNotCalled(t, "GetOrderByID", mock.Anything, mock.Anything)
+}
+
+func TestIssueInvoice_InvoiceForBundleOrder(t *testing.T) {
+	f := newFixture()
+	charged := money.FromMajor(8.7, money.USD)
+	o := &order.Order{ID: "order2", ResellerID: "reseller1", SupplierID: "supplier1", BundleID: "b1", TotalPrice: money.InSettlement(500), Charged: &charged, Titles: map[string]string{"b1": "Summer dresses"}}
+	paid := &payment.Payment{ID: "pay2", Amount: money.InSettlement(500), PlatformFee: money.InSettlement(10), SellerEarning: money.InSettlement(490)}
+	f.invoices.On("NextNumber", mock.Anything).Return(int64(42), nil)
+	f.invoices.On("CreateInvoice", mock.Anything, mock.Anything).Return(nil)
+
+	inv, err := f.uc.IssueInvoice(context.Background(), o, paid)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-000042", inv.Number)
+	assert.Equal(t, invoice.KindInvoice, inv.Kind)
+	assert.Equal(t, "reseller1", inv.Buyer.UserID)
+	assert.Equal(t, "Addis Bales", inv.Seller.Name)
+	assert.Equal(t, []invoice.Line{{Reference: "b1", Description: "Summer dresses", Quantity: 1, UnitPrice: 500, Amount: 500}}, inv.Items)
+	assert.Equal(t, &charged, inv.Charged)
+	assert.Equal(t, 490.0, inv.SellerEarning)
+}
+
+func TestIssueInvoice_ListsTaxAddedOnTop(t *testing.T) {
+	f := newFixture()
+	lines := []tax.Line{{Rule: "VAT", Rate: 0.15, Taxable: 200, Amount: 30}}
+	o := &order.Order{ID: "order3", ResellerID: "reseller1", SupplierID: "supplier1", BundleID: "b1", TotalPrice: money.InSettlement(230), Tax: money.InSettlement(30), TaxLines: lines}
+	paid := &payment.Payment{ID: "pay3", Amount: money.InSettlement(230), Tax: money.InSettlement(30), TaxLines: lines, PlatformFee: money.InSettlement(4), SellerEarning: money.InSettlement(196)}
+	f.invoices.On("NextNumber", mock.Anything).Return(int64(43), nil)
+	f.invoices.On("CreateInvoice", mock.Anything, mock.Anything).Return(nil)
+
+	inv, err := f.uc.IssueInvoice(context.Background(), o, paid)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bundle b1", inv.Items[0].Description)
+	assert.Equal(t, 200.0, inv.Items[0].Amount)
+	assert.Equal(t, 200.0, inv.Subtotal)
+	assert.Equal(t, 30.0, inv.Tax)
+	assert.Equal(t, lines, inv.TaxLines)
+	assert.Equal(t, 230.0, inv.Total)
+}
+
+func TestIssueInvoice_SplitsTaxAddedOnTopBetweenLines(t *testing.T) {
+	f := newFixture()
+	lines := []tax.Line{{Rule: "VAT", Rate: 0.15, Taxable: 30, Amount: 4.5}}
+	o := &order.Order{
+		ID: "order4", ConsumerID: "consumer1", ResellerID: "reseller1",
+		ProductIDs: []string{"p1", "p2", "p3"},
+		TotalPrice: money.InSettlement(34.5),
+		TaxLines:   lines,
+		LineTotals: map[string]money.Money{"p1": money.InSettlement(11.5), "p2": money.InSettlement(11.5), "p3": money.InSettlement(11.5)},
+	}
+	f.invoices.On("NextNumber", mock.Anything).Return(int64(44), nil)
+	f.invoices.On("CreateInvoice", mock.Anything, mock.Anything).Return(nil)
+
+	inv, err := f.uc.IssueInvoice(context.Background(), o, &payment.Payment{ID: "pay4", Amount: money.InSettlement(34.5), TaxLines: lines})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, inv.Items[0].Amount)
+	assert.Equal(t, 10.0, inv.Items[1].Amount)
+	assert.Equal(t, 10.0, inv.Items[2].Amount)
+	assert.Equal(t, 30.0, inv.Subtotal)
+}
+
+func TestIssueInvoice_NumberingFails(t *testing.T) {
+	f := newFixture()
+	f.invoices.On("NextNumber", mock.Anything).Return(int64(0), assert.AnError)
+
+	_, err := f.uc.IssueInvoice(context.Background(), consumerOrder(), &payment.Payment{ID: "pay1"})
+
+	assert.ErrorIs(t, err, assert.AnError)
+	f.invoices.AssertNotCalled(t, "CreateInvoice", mock.Anything, mock.Anything)
+}
+
+func TestGetInvoice_ReturnsIssuedInvoice(t *testing.T) {
+	f := newFixture()
+	existing := &invoice.Invoice{ID: "order1", Number: "INV-000007"}
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(), nil)
+	f.invoices.On("GetInvoiceByOrder", mock.Anything, "order1").Return(existing, nil)
+
+	inv, err := f.uc.GetInvoice(context.Background(), "order1", "reseller1", user.RoleReseller)
+
+	assert.NoError(t, err)
+	assert.Same(t, existing, inv)
+	f.invoices.AssertNotCalled(t, "NextNumber", mock.Anything)
+}
+
+func TestGetInvoice_IssuesMissingInvoice(t *testing.T) {
+	f := newFixture()
+	paid := &payment.Payment{ID: "pay1", OrderID: "order1", Amount: money.InSettlement(54)}
+	refund := &payment.Payment{ID: "refund1", OrderID: "order1", Amount: money.InSettlement(-10), RefundOf: "pay1"}
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(), nil)
+	f.invoices.On("GetInvoiceByOrder", mock.Anything, "order1").Return(nil, nil)
+	f.payments.On("GetPaymentsByOrder", mock.Anything, "order1").Return([]*payment.Payment{refund, paid}, nil)
+	f.invoices.On("NextNumber", mock.Anything).Return(int64(12), nil)
+	f.invoices.On("CreateInvoice", mock.Anything, mock.AnythingOfType("*invoice.Invoice")).Return(nil)
+
+	inv, err := f.uc.GetInvoice(context.Background(), "order1", "consumer1", user.RoleConsumer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-000012", inv.Number)
+	assert.Equal(t, "pay1", inv.PaymentID)
+	assert.Equal(t, 54.0, inv.Total)
+}
+
+func TestGetInvoice_MissingInvoiceIssuedConcurrently(t *testing.T) {
+	f := newFixture()
+	stored := &invoice.Invoice{ID: "order1", Number: "INV-000011"}
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(), nil)
+	f.invoices.On("GetInvoiceByOrder", mock.Anything, "order1").Return(nil, nil).Once()
+	f.invoices.On("GetInvoiceByOrder", mock.Anything, "order1").Return(stored, nil).Once()
+	f.payments.On("GetPaymentsByOrder", mock.Anything, "order1").Return([]*payment.Payment{{ID: "pay1", OrderID: "order1", Amount: money.InSettlement(54)}}, nil)
+	f.invoices.On("NextNumber", mock.Anything).Return(int64(12), nil)
+	f.invoices.On("CreateInvoice", mock.Anything, mock.Anything).Return(invoice.ErrInvoiceExists)
+
+	inv, err := f.uc.GetInvoice(context.Background(), "order1", "consumer1", user.RoleConsumer)
+
+	assert.NoError(t, err)
+	assert.Same(t, stored, inv)
+}
+
+func TestGetInvoice_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   *order.Order
+		actorID string
+		role    user.Role
+		wantErr error
+	}{
+		{name: "unknown order", actorID: "consumer1", role: user.RoleConsumer, wantErr: order.ErrOrderNotFound},
+		{name: "not a party", order: consumerOrder(), actorID: "consumer2", role: user.RoleConsumer, wantErr: order.ErrNotOrderParty},
+		{name: "supplier on consumer order", order: consumerOrder(), actorID: "supplier1", role: user.RoleSupplier, wantErr: order.ErrNotOrderParty},
+		{name: "never paid", order: consumerOrder(), actorID: "admin1", role: user.RoleAdmin, wantErr: invoice.ErrInvoiceNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture()
+			if tt.order == nil {
+				f.orders.On("GetOrderByID", mock.Anything, "order1").Return(nil, nil)
+			} else {
+				f.orders.On("GetOrderByID", mock.Anything, "order1").Return(tt.order, nil)
+			}
+			f.invoices.On("GetInvoiceByOrder", mock.Anything, "order1").Return(nil, nil).Maybe()
+			f.payments.On("GetPaymentsByOrder", mock.Anything, "order1").Return([]*payment.Payment{}, nil).Maybe()
+
+			_, err := f.uc.GetInvoice(context.Background(), "order1", tt.actorID, tt.role)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			f.invoices.AssertNotCalled(t, "NextNumber", mock.Anything)
+		})
+	}
+}
+
+func TestGetInvoicePDF(t *testing.T) {
+	f := newFixture()
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(), nil)
+	f.invoices.On("GetInvoiceByOrder", mock.Anything, "order1").Return(&invoice.Invoice{ID: "order1", Number: "INV-000007"}, nil)
+
+	inv, pdf, err := f.uc.GetInvoicePDF(context.Background(), "order1", "consumer1", user.RoleConsumer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-000007", inv.Number)
+	assert.Equal(t, []byte("INV-000007"), pdf)
+}
@@ -0,0 +1,177 @@
+package jobusecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auction"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payout"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/promotion"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/returns"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/trust"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+)
+
+// NewListWarehouseItemHandler lists a purchased bundle's warehouse item once
+// it is due. The payload carries the item under "item_id". Listing is
+// simulated, so it says nothing about the bundle having arrived and leaves
+// the payment held for it alone.
+func NewListWarehouseItemHandler(repo warehouse.Repository) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return repo.MarkItemAsListed(ctx, j.Payload["item_id"])
+	}
+}
+
+// NewReleaseEscrowHandler releases an order's escrow when its dispute window
+// ends, unless a dispute is open. The payload carries the order under
+// "order_id".
+func NewReleaseEscrowHandler(releaser escrow.Releaser) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return releaser.ReleaseEscrow(ctx, j.Payload["order_id"])
+	}
+}
+
+// NewSettleRefundHandler returns a pending refund's money through the
+// gateway. The payload carries the refund under "payment_id".
+func NewSettleRefundHandler(settler payment.RefundSettler) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return settler.SettleRefund(ctx, j.Payload["payment_id"])
+	}
+}
+
+// NewRefundChargeHandler refunds a charge whose purchase was never recorded.
+// The payload carries the charge under "charge_id", the amount to refund
+// under "amount" and its currency under "currency", which defaults to
+// money.Settlement. The charge ID is the refund's idempotency key, so a retry
+// never refunds twice.
+func NewRefundChargeHandler(gateway payment.Gateway) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		amount, err := strconv.ParseFloat(j.Payload["amount"], 64)
+		if err != nil {
+			return fmt.Errorf("invalid refund amount %q: %w", j.Payload["amount"], err)
+		}
+		currency := money.Settlement
+		if c := j.Payload["currency"]; c != "" {
+			if currency, err = money.ParseCurrency(c); err != nil {
+				return fmt.Errorf("invalid refund currency %q: %w", c, err)
+			}
+		}
+		_, err = gateway.Refund(ctx, j.Payload["charge_id"], money.FromMajor(amount, currency), j.Payload["charge_id"])
+		return err
+	}
+}
+
+// NewReleasePromotionHandler gives back the promo code use of a checkout
+// that failed. The payload carries the promotion under "promotion_id", its
+// code under "code" and the consumer under "user_id".
+func NewReleasePromotionHandler(promotions promotion.Discounter) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		d := &promotion.Discount{PromotionID: j.Payload["promotion_id"], Code: j.Payload["code"]}
+		return promotions.Release(ctx, d, j.Payload["user_id"])
+	}
+}
+
+// NewRestoreCreditHandler gives back the store credit spent on a checkout
+// that failed. The payload carries the consumer under "user_id", the amount
+// under "amount" and the checkout's orders, comma separated, under
+// "order_ids".
+func NewRestoreCreditHandler(credits credit.Payer) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		amount, err := strconv.ParseFloat(j.Payload["amount"], 64)
+		if err != nil {
+			return fmt.Errorf("invalid credit amount %q: %w", j.Payload["amount"], err)
+		}
+		var orderIDs []string
+		if ids := j.Payload["order_ids"]; ids != "" {
+			orderIDs = strings.Split(ids, ",")
+		}
+		return credits.Restore(ctx, j.Payload["user_id"], amount, credit.EntryReversal, orderIDs)
+	}
+}
+
+// NewRecordLostDisputeHandler lowers a supplier's trust score for a dispute
+// decided against them. The payload carries the supplier under
+// "supplier_id" and the dispute under "dispute_id".
+func NewRecordLostDisputeHandler(uc trust.Usecase) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return uc.RecordLostDispute(ctx, j.Payload["supplier_id"])
+	}
+}
+
+// NewRecurringHandler runs h every interval. Each run queues the next one
+// before doing its work, so a run that keeps failing does not end the
+// schedule.
+func NewRecurringHandler(uc *jobUsecase, interval time.Duration, h job.Handler) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		if _, err := uc.ScheduleRecurring(ctx, j.Type, interval); err != nil {
+			return err
+		}
+		return h(ctx, j)
+	}
+}
+
+// NewSettlePayoutsHandler batches sellers' settled earnings into payouts.
+func NewSettlePayoutsHandler(uc payout.Usecase) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		_, err := uc.RunSettlement(ctx)
+		return err
+	}
+}
+
+// NewSendPayoutHandler sends a pending payout. The payload carries it under
+// "payout_id".
+func NewSendPayoutHandler(uc payout.Usecase) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return uc.SendPayout(ctx, j.Payload["payout_id"])
+	}
+}
+
+// NewTrackShipmentHandler checks a shipment with its carrier. The payload
+// carries the shipped order under "order_id".
+func NewTrackShipmentHandler(uc shipment.Usecase) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return uc.SyncTracking(ctx, j.Payload["order_id"])
+	}
+}
+
+// NewCloseAuctionHandler closes an auction at its end. The payload carries
+// the auction under "auction_id".
+func NewCloseAuctionHandler(uc auction.Usecase) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return uc.CloseAuction(ctx, j.Payload["auction_id"])
+	}
+}
+
+// NewExpireOfferHandler expires an offer nobody answered in time. The
+// payload carries the offer under "offer_id".
+func NewExpireOfferHandler(uc offer.Usecase) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return uc.ExpireOffer(ctx, j.Payload["offer_id"])
+	}
+}
+
+// NewReleaseOfferHandler ends an accepted offer's checkout window. The
+// payload carries the offer under "offer_id".
+func NewReleaseOfferHandler(uc offer.Usecase) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return uc.ReleaseOffer(ctx, j.Payload["offer_id"])
+	}
+}
+
+// NewApproveReturnHandler approves a return its reseller did not answer in
+// time. The payload carries the return under "return_id".
+func NewApproveReturnHandler(uc returns.Usecase) job.Handler {
+	return func(ctx context.Context, j *job.Job) error {
+		return uc.ApproveOverdue(ctx, j.Payload["return_id"])
+	}
+}
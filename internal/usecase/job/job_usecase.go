@@ -0,0 +1,81 @@
+package jobusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+)
+
+type jobUsecase struct {
+	repo  job.Repository
+	clock job.Clock
+}
+
+// NewJobUsecase returns the job queue front end. It schedules work for the
+// other usecases and lets admins inspect and retry jobs.
+func NewJobUsecase(repo job.Repository, clock job.Clock) *jobUsecase {
+	return &jobUsecase{repo: repo, clock: clock}
+}
+
+func (u *jobUsecase) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	now := u.clock.Now()
+	j := &job.Job{
+		Type:        jobType,
+		Payload:     payload,
+		Status:      job.StatusQueued,
+		RunAt:       now.Add(delay),
+		MaxAttempts: job.DefaultMaxAttempts,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := u.repo.Enqueue(ctx, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// ScheduleRecurring queues the next run of a job that repeats every interval.
+// Runs fall on multiples of the interval and are identified by their due
+// time, so any number of API instances scheduling the same run queue it once.
+func (u *jobUsecase) ScheduleRecurring(ctx context.Context, jobType string, interval time.Duration) (*job.Job, error) {
+	now := u.clock.Now()
+	runAt := now.Truncate(interval).Add(interval)
+	j := &job.Job{
+		ID:          fmt.Sprintf("%s@%s", jobType, runAt.UTC().Format(time.RFC3339)),
+		Type:        jobType,
+		Payload:     map[string]string{},
+		Status:      job.StatusQueued,
+		RunAt:       runAt,
+		MaxAttempts: job.DefaultMaxAttempts,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := u.repo.Enqueue(ctx, j); err != nil && !errors.Is(err, job.ErrDuplicateJob) {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (u *jobUsecase) ListJobs(ctx context.Context, status job.Status, page, limit int) ([]*job.Job, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	return u.repo.ListJobs(ctx, status, page, limit)
+}
+
+func (u *jobUsecase) GetJob(ctx context.Context, id string) (*job.Job, error) {
+	return u.repo.GetJobByID(ctx, id)
+}
+
+func (u *jobUsecase) RetryJob(ctx context.Context, id string) (*job.Job, error) {
+	if err := u.repo.Requeue(ctx, id, u.clock.Now()); err != nil {
+		return nil, err
+	}
+	return u.repo.GetJobByID(ctx, id)
+}
@@ -0,0 +1,231 @@
+package jobusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobRepo struct {
+	mock.Mock
+}
+
+func (m *MockJobRepo) Enqueue(ctx context.Context, j *job.Job) error {
+	args := m.Called(ctx, j)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*job.Job, error) {
+	args := m.Called(ctx, now, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*job.Job), args.Error(1)
+}
+
+func (m *MockJobRepo) Complete(ctx context.Context, id, lease string, now time.Time) error {
+	args := m.Called(ctx, id, lease, now)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) Retry(ctx context.Context, id, lease string, now, runAt time.Time, lastError string) error {
+	args := m.Called(ctx, id, lease, now, runAt, lastError)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) Fail(ctx context.Context, id, lease string, now time.Time, lastError string) error {
+	args := m.Called(ctx, id, lease, now, lastError)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) Requeue(ctx context.Context, id string, now time.Time) error {
+	args := m.Called(ctx, id, now)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) GetJobByID(ctx context.Context, id string) (*job.Job, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*job.Job), args.Error(1)
+}
+
+func (m *MockJobRepo) ListJobs(ctx context.Context, status job.Status, page, limit int) ([]*job.Job, error) {
+	args := m.Called(ctx, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*job.Job), args.Error(1)
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestSchedule(t *testing.T) {
+	repo := new(MockJobRepo)
+	uc := NewJobUsecase(repo, fixedClock{now: testNow})
+	ctx := context.Background()
+
+	repo.On("Enqueue", ctx, mock.MatchedBy(func(j *job.Job) bool {
+		return j.Type == job.TypeTrackShipment && j.Status == job.StatusQueued &&
+			j.RunAt.Equal(testNow.Add(3*time.Minute)) && j.MaxAttempts == job.DefaultMaxAttempts
+	})).Return(nil)
+
+	j, err := uc.Schedule(ctx, job.TypeTrackShipment, map[string]string{"order_id": "order1"}, 3*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "order1", j.Payload["order_id"])
+	repo.AssertExpectations(t)
+}
+
+func TestScheduleRecurring(t *testing.T) {
+	repo := new(MockJobRepo)
+	uc := NewJobUsecase(repo, fixedClock{now: testNow})
+	ctx := context.Background()
+
+	nextRun := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	repo.On("Enqueue", ctx, mock.MatchedBy(func(j *job.Job) bool {
+		return j.ID == "payout.settle@2025-01-02T00:00:00Z" && j.RunAt.Equal(nextRun)
+	})).Return(job.ErrDuplicateJob)
+
+	// Another instance queued the same run first; that is not an error.
+	j, err := uc.ScheduleRecurring(ctx, job.TypeSettlePayouts, 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, job.TypeSettlePayouts, j.Type)
+	repo.AssertExpectations(t)
+}
+
+func TestRetryJob_NotFailed(t *testing.T) {
+	repo := new(MockJobRepo)
+	uc := NewJobUsecase(repo, fixedClock{now: testNow})
+	ctx := context.Background()
+
+	repo.On("Requeue", ctx, "job1", testNow).Return(job.ErrNotRetryable)
+
+	_, err := uc.RetryJob(ctx, "job1")
+
+	assert.ErrorIs(t, err, job.ErrNotRetryable)
+}
+
+func TestRunOnce(t *testing.T) {
+	handlerErr := errors.New("warehouse unavailable")
+
+	tests := []struct {
+		name       string
+		job        *job.Job
+		handlerErr error
+		expect     func(repo *MockJobRepo)
+	}{
+		{
+			name:   "Success - Job completes",
+			job:    &job.Job{ID: "job1", Type: "test", Attempts: 1, MaxAttempts: 5, Lease: "lease1"},
+			expect: func(repo *MockJobRepo) { repo.On("Complete", mock.Anything, "job1", "lease1", testNow).Return(nil) },
+		},
+		{
+			name:       "Error - Retried with backoff",
+			job:        &job.Job{ID: "job1", Type: "test", Attempts: 3, MaxAttempts: 5, Lease: "lease1"},
+			handlerErr: handlerErr,
+			expect: func(repo *MockJobRepo) {
+				repo.On("Retry", mock.Anything, "job1", "lease1", testNow, testNow.Add(2*time.Minute), handlerErr.Error()).Return(nil)
+			},
+		},
+		{
+			name:       "Error - Attempts exhausted",
+			job:        &job.Job{ID: "job1", Type: "test", Attempts: 5, MaxAttempts: 5, Lease: "lease1"},
+			handlerErr: handlerErr,
+			expect: func(repo *MockJobRepo) {
+				repo.On("Fail", mock.Anything, "job1", "lease1", testNow, handlerErr.Error()).Return(nil)
+			},
+		},
+		{
+			name: "Error - Unknown job type",
+			job:  &job.Job{ID: "job1", Type: "unknown", Attempts: 1, MaxAttempts: 5, Lease: "lease1"},
+			expect: func(repo *MockJobRepo) {
+				repo.On("Fail", mock.Anything, "job1", "lease1", testNow, `no handler for job type "unknown"`).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockJobRepo)
+			pool := NewWorkerPool(repo, fixedClock{now: testNow}, 1, time.Second)
+			pool.Register("test", func(ctx context.Context, j *job.Job) error { return tt.handlerErr })
+			ctx := context.Background()
+
+			repo.On("ClaimDue", ctx, testNow, jobLease).Return(tt.job, nil)
+			tt.expect(repo)
+
+			ran, err := pool.RunOnce(ctx)
+
+			assert.NoError(t, err)
+			assert.True(t, ran)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRunOnce_RecoversPanic(t *testing.T) {
+	repo := new(MockJobRepo)
+	pool := NewWorkerPool(repo, fixedClock{now: testNow}, 1, time.Second)
+	pool.Register("test", func(ctx context.Context, j *job.Job) error { panic("nil payload") })
+	ctx := context.Background()
+
+	repo.On("ClaimDue", ctx, testNow, jobLease).Return(&job.Job{ID: "job1", Type: "test", Attempts: 1, MaxAttempts: 5, Lease: "lease1"}, nil)
+	repo.On("Retry", ctx, "job1", "lease1", testNow, testNow.Add(30*time.Second), "job panicked: nil payload").Return(nil)
+
+	ran, err := pool.RunOnce(ctx)
+
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	repo.AssertExpectations(t)
+}
+
+func TestRunOnce_LeaseLost(t *testing.T) {
+	repo := new(MockJobRepo)
+	pool := NewWorkerPool(repo, fixedClock{now: testNow}, 1, time.Second)
+	pool.Register("test", func(ctx context.Context, j *job.Job) error { return nil })
+	ctx := context.Background()
+
+	repo.On("ClaimDue", ctx, testNow, jobLease).Return(&job.Job{ID: "job1", Type: "test", Attempts: 1, MaxAttempts: 5, Lease: "lease1"}, nil)
+	repo.On("Complete", ctx, "job1", "lease1", testNow).Return(job.ErrLeaseLost)
+
+	ran, err := pool.RunOnce(ctx)
+
+	assert.ErrorIs(t, err, job.ErrLeaseLost)
+	assert.True(t, ran)
+}
+
+func TestRunOnce_NothingDue(t *testing.T) {
+	repo := new(MockJobRepo)
+	pool := NewWorkerPool(repo, fixedClock{now: testNow}, 1, time.Second)
+	ctx := context.Background()
+
+	repo.On("ClaimDue", ctx, testNow, jobLease).Return(nil, nil)
+
+	ran, err := pool.RunOnce(ctx)
+
+	assert.NoError(t, err)
+	assert.False(t, ran)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, time.Minute, backoff(2))
+	assert.Equal(t, maxBackoff, backoff(20))
+}
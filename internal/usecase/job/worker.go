@@ -0,0 +1,122 @@
+package jobusecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+)
+
+const (
+	// jobLease is how long a claimed job stays locked before another worker
+	// may pick it up again.
+	jobLease = 5 * time.Minute
+	// baseBackoff is the delay before the first retry; it doubles with every
+	// further attempt up to maxBackoff.
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 30 * time.Minute
+)
+
+// WorkerPool runs due jobs with a fixed number of workers polling the queue.
+type WorkerPool struct {
+	repo         job.Repository
+	clock        job.Clock
+	workers      int
+	pollInterval time.Duration
+	handlers     map[string]job.Handler
+}
+
+func NewWorkerPool(repo job.Repository, clock job.Clock, workers int, pollInterval time.Duration) *WorkerPool {
+	return &WorkerPool{
+		repo:         repo,
+		clock:        clock,
+		workers:      workers,
+		pollInterval: pollInterval,
+		handlers:     make(map[string]job.Handler),
+	}
+}
+
+// Register sets the handler for a job type. It must be called before Start.
+func (p *WorkerPool) Register(jobType string, h job.Handler) {
+	p.handlers[jobType] = h
+}
+
+// Start runs the workers until ctx is canceled and waits for them to stop.
+func (p *WorkerPool) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *WorkerPool) work(ctx context.Context) {
+	for {
+		ran, err := p.RunOnce(ctx)
+		if err != nil {
+			log.Println("job worker:", err)
+		}
+		if ran {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// RunOnce claims and runs a single due job. It reports whether a job was run.
+func (p *WorkerPool) RunOnce(ctx context.Context) (bool, error) {
+	j, err := p.repo.ClaimDue(ctx, p.clock.Now(), jobLease)
+	if err != nil || j == nil {
+		return false, err
+	}
+
+	h, ok := p.handlers[j.Type]
+	if !ok {
+		return true, p.repo.Fail(ctx, j.ID, j.Lease, p.clock.Now(), fmt.Sprintf("no handler for job type %q", j.Type))
+	}
+
+	runErr := run(ctx, h, j)
+	now := p.clock.Now()
+	switch {
+	case runErr == nil:
+		return true, p.repo.Complete(ctx, j.ID, j.Lease, now)
+	case j.Attempts >= j.MaxAttempts:
+		return true, p.repo.Fail(ctx, j.ID, j.Lease, now, runErr.Error())
+	default:
+		return true, p.repo.Retry(ctx, j.ID, j.Lease, now, now.Add(backoff(j.Attempts)), runErr.Error())
+	}
+}
+
+// run calls h, turning a panic into an error so that one bad job is retried
+// like any other failure instead of taking its worker down.
+func run(ctx context.Context, h job.Handler, j *job.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return h(ctx, j)
+}
+
+// backoff returns the delay before retrying a job that has failed attempts times.
+func backoff(attempts int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
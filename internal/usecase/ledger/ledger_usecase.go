@@ -0,0 +1,152 @@
+package ledgerusecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/google/uuid"
+)
+
+type ledgerUsecase struct {
+	repo ledger.Repository
+}
+
+func NewLedgerUsecase(repo ledger.Repository) ledger.Usecase {
+	return &ledgerUsecase{repo: repo}
+}
+
+// RecordPayment posts a sale, its fee and its tax for a payment, or a refund
+// and the matching fee and tax reversals for a negative payment. A payment
+// held in escrow only moves the money into the escrow account. The seller is
+// the payee. The part of a payment made with store credit is drawn from, and
+// refunded to, the store credit account rather than cash.
+func (u *ledgerUsecase) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	seller := ledger.SellerAccount(p.ToUserID)
+	credit := math.Abs(p.CreditAmount.Major())
+	cash := math.Abs(p.Amount.Major()) - credit
+	fee := math.Abs(p.PlatformFee.Major())
+	tax := math.Abs(p.Tax.Major())
+
+	var entries []ledger.Entry
+	switch {
+	case p.Status == payment.StatusHeld:
+		entries = append(entries, fromBuyer(ledger.KindEscrowHold, ledger.EscrowAccount, cash, credit))
+	case p.Amount.Amount >= 0:
+		entries = append(entries, fromBuyer(ledger.KindSale, seller, cash, credit))
+		if fee > 0 {
+			entries = append(entries, ledger.Transfer(ledger.KindFee, seller, ledger.RevenueAccount, fee))
+		}
+		if tax > 0 {
+			entries = append(entries, ledger.Transfer(ledger.KindTax, seller, ledger.TaxAccount, tax))
+		}
+	default:
+		entries = append(entries, toBuyer(ledger.KindRefund, seller, cash, credit))
+		if fee > 0 {
+			entries = append(entries, ledger.Transfer(ledger.KindFeeReversal, ledger.RevenueAccount, seller, fee))
+		}
+		if tax > 0 {
+			entries = append(entries, ledger.Transfer(ledger.KindTaxReversal, ledger.TaxAccount, seller, tax))
+		}
+	}
+	return u.post(ctx, p, entries)
+}
+
+// fromBuyer moves what a buyer paid in cash and store credit to account.
+func fromBuyer(kind ledger.Kind, account string, cash, credit float64) ledger.Entry {
+	e := ledger.Entry{Kind: kind}
+	if cash > 0 {
+		e.Lines = append(e.Lines, ledger.Line{Account: ledger.CashAccount, Debit: cash})
+	}
+	if credit > 0 {
+		e.Lines = append(e.Lines, ledger.Line{Account: ledger.StoreCreditAccount, Debit: credit})
+	}
+	e.Lines = append(e.Lines, ledger.Line{Account: account, Credit: cash + credit})
+	return e
+}
+
+// toBuyer gives money back from account the way the buyer paid it.
+func toBuyer(kind ledger.Kind, account string, cash, credit float64) ledger.Entry {
+	e := ledger.Entry{Kind: kind, Lines: []ledger.Line{{Account: account, Debit: cash + credit}}}
+	if cash > 0 {
+		e.Lines = append(e.Lines, ledger.Line{Account: ledger.CashAccount, Credit: cash})
+	}
+	if credit > 0 {
+		e.Lines = append(e.Lines, ledger.Line{Account: ledger.StoreCreditAccount, Credit: credit})
+	}
+	return e
+}
+
+func (u *ledgerUsecase) RecordRelease(ctx context.Context, p *payment.Payment) error {
+	seller := ledger.SellerAccount(p.ToUserID)
+	entries := []ledger.Entry{ledger.Transfer(ledger.KindSale, ledger.EscrowAccount, seller, p.Amount.Major())}
+	if p.PlatformFee.Amount > 0 {
+		entries = append(entries, ledger.Transfer(ledger.KindFee, seller, ledger.RevenueAccount, p.PlatformFee.Major()))
+	}
+	if p.Tax.Amount > 0 {
+		entries = append(entries, ledger.Transfer(ledger.KindTax, seller, ledger.TaxAccount, p.Tax.Major()))
+	}
+	return u.post(ctx, p, entries)
+}
+
+// post stamps entries with the payment they book and stores them.
+func (u *ledgerUsecase) post(ctx context.Context, p *payment.Payment, entries []ledger.Entry) error {
+	now := time.Now()
+	for i := range entries {
+		e := &entries[i]
+		e.ID = uuid.NewString()
+		e.PaymentID = p.ID
+		e.OrderID = p.OrderID
+		e.Memo = fmt.Sprintf("%s %s payment from %s", e.Kind, p.Type, p.FromUserID)
+		e.CreatedAt = now
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return u.repo.PostEntries(ctx, entries)
+}
+
+func (u *ledgerUsecase) RecordPayout(ctx context.Context, sellerID, payoutID string, amount float64) error {
+	e := ledger.Transfer(ledger.KindPayout, ledger.SellerAccount(sellerID), ledger.CashAccount, amount)
+	e.ID = uuid.NewString()
+	e.PayoutID = payoutID
+	e.Memo = fmt.Sprintf("payout %s to %s", payoutID, sellerID)
+	e.CreatedAt = time.Now()
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	return u.repo.PostEntries(ctx, []ledger.Entry{e})
+}
+
+func (u *ledgerUsecase) RecordCreditIssued(ctx context.Context, userID string, amount float64, memo string) error {
+	e := ledger.Transfer(ledger.KindCreditIssue, ledger.GoodwillAccount, ledger.StoreCreditAccount, amount)
+	e.ID = uuid.NewString()
+	e.Memo = fmt.Sprintf("store credit for %s: %s", userID, memo)
+	e.CreatedAt = time.Now()
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	return u.repo.PostEntries(ctx, []ledger.Entry{e})
+}
+
+func (u *ledgerUsecase) RecordGiftCardRedeemed(ctx context.Context, userID, code string, amount float64) error {
+	e := ledger.Transfer(ledger.KindGiftCard, ledger.GiftCardAccount, ledger.StoreCreditAccount, amount)
+	e.ID = uuid.NewString()
+	e.Memo = fmt.Sprintf("gift card %s redeemed by %s", code, userID)
+	e.CreatedAt = time.Now()
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	return u.repo.PostEntries(ctx, []ledger.Entry{e})
+}
+
+func (u *ledgerUsecase) GetBalance(ctx context.Context, userID string) (*ledger.Balance, error) {
+	totals, err := u.repo.AccountTotals(ctx, ledger.SellerAccount(userID))
+	if err != nil {
+		return nil, err
+	}
+	return ledger.NewBalance(userID, totals), nil
+}
@@ -0,0 +1,232 @@
+package ledgerusecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLedgerRepo struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepo) PostEntries(ctx context.Context, entries []ledger.Entry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepo) AccountTotals(ctx context.Context, account string) (map[ledger.Kind]ledger.Totals, error) {
+	args := m.Called(ctx, account)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[ledger.Kind]ledger.Totals), args.Error(1)
+}
+
+// capturePosted records the entries handed to PostEntries.
+func capturePosted(repo *MockLedgerRepo) *[]ledger.Entry {
+	var posted []ledger.Entry
+	repo.On("PostEntries", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		posted = args.Get(1).([]ledger.Entry)
+	}).Return(nil)
+	return &posted
+}
+
+func TestRecordPayment_Sale(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	posted := capturePosted(repo)
+
+	err := uc.RecordPayment(context.Background(), &payment.Payment{ID: "pay1", OrderID: "order1", FromUserID: "consumer1", ToUserID: "reseller1", Amount: money.InSettlement(100), PlatformFee: money.InSettlement(2), Type: payment.B2C})
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 2) {
+		sale, fee := (*posted)[0], (*posted)[1]
+		assert.Equal(t, ledger.KindSale, sale.Kind)
+		assert.Equal(t, []ledger.Line{{Account: ledger.CashAccount, Debit: 100}, {Account: "seller:reseller1", Credit: 100}}, sale.Lines)
+		assert.Equal(t, ledger.KindFee, fee.Kind)
+		assert.Equal(t, []ledger.Line{{Account: "seller:reseller1", Debit: 2}, {Account: ledger.RevenueAccount, Credit: 2}}, fee.Lines)
+		assert.Equal(t, "pay1", fee.PaymentID)
+		assert.Equal(t, "order1", fee.OrderID)
+		assert.NotEqual(t, sale.ID, fee.ID)
+	}
+}
+
+func TestRecordPayment_Refund(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	posted := capturePosted(repo)
+
+	err := uc.RecordPayment(context.Background(), &payment.Payment{ID: "pay2", RefundOf: "pay1", ToUserID: "reseller1", Amount: money.InSettlement(-25), PlatformFee: money.InSettlement(-0.5), Type: payment.B2C})
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 2) {
+		assert.Equal(t, ledger.KindRefund, (*posted)[0].Kind)
+		assert.Equal(t, []ledger.Line{{Account: "seller:reseller1", Debit: 25}, {Account: ledger.CashAccount, Credit: 25}}, (*posted)[0].Lines)
+		assert.Equal(t, ledger.KindFeeReversal, (*posted)[1].Kind)
+		assert.Equal(t, []ledger.Line{{Account: ledger.RevenueAccount, Debit: 0.5}, {Account: "seller:reseller1", Credit: 0.5}}, (*posted)[1].Lines)
+	}
+}
+
+func TestRecordPayment_SaleAndRefundWithTax(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	posted := capturePosted(repo)
+
+	err := uc.RecordPayment(context.Background(), &payment.Payment{ID: "pay1", ToUserID: "reseller1", Amount: money.InSettlement(115), PlatformFee: money.InSettlement(2), Tax: money.InSettlement(15), Type: payment.B2C})
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 3) {
+		assert.Equal(t, ledger.KindTax, (*posted)[2].Kind)
+		assert.Equal(t, []ledger.Line{{Account: "seller:reseller1", Debit: 15}, {Account: ledger.TaxAccount, Credit: 15}}, (*posted)[2].Lines)
+	}
+
+	err = uc.RecordPayment(context.Background(), &payment.Payment{ID: "pay2", RefundOf: "pay1", ToUserID: "reseller1", Amount: money.InSettlement(-115), PlatformFee: money.InSettlement(-2), Tax: money.InSettlement(-15), Type: payment.B2C})
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 3) {
+		assert.Equal(t, ledger.KindTaxReversal, (*posted)[2].Kind)
+		assert.Equal(t, []ledger.Line{{Account: ledger.TaxAccount, Debit: 15}, {Account: "seller:reseller1", Credit: 15}}, (*posted)[2].Lines)
+	}
+}
+
+func TestRecordPayment_PaidPartlyWithCredit(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	posted := capturePosted(repo)
+
+	err := uc.RecordPayment(context.Background(), &payment.Payment{ID: "pay1", ToUserID: "reseller1", Amount: money.InSettlement(100), CreditAmount: money.InSettlement(30), Type: payment.B2C})
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 1) {
+		assert.Equal(t, []ledger.Line{{Account: ledger.CashAccount, Debit: 70}, {Account: ledger.StoreCreditAccount, Debit: 30}, {Account: "seller:reseller1", Credit: 100}}, (*posted)[0].Lines)
+	}
+
+	err = uc.RecordPayment(context.Background(), &payment.Payment{ID: "pay2", RefundOf: "pay1", ToUserID: "reseller1", Amount: money.InSettlement(-50), CreditAmount: money.InSettlement(-15), Type: payment.B2C})
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 1) {
+		assert.Equal(t, []ledger.Line{{Account: "seller:reseller1", Debit: 50}, {Account: ledger.CashAccount, Credit: 35}, {Account: ledger.StoreCreditAccount, Credit: 15}}, (*posted)[0].Lines)
+	}
+}
+
+func TestRecordPayment_HeldThenReleased(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	posted := capturePosted(repo)
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", FromUserID: "reseller1", ToUserID: "supplier1", Amount: money.InSettlement(100), PlatformFee: money.InSettlement(2), Status: payment.StatusHeld, Type: payment.B2B}
+
+	err := uc.RecordPayment(context.Background(), p)
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 1) {
+		assert.Equal(t, ledger.KindEscrowHold, (*posted)[0].Kind)
+		assert.Equal(t, []ledger.Line{{Account: ledger.CashAccount, Debit: 100}, {Account: ledger.EscrowAccount, Credit: 100}}, (*posted)[0].Lines)
+	}
+
+	err = uc.RecordRelease(context.Background(), p)
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 2) {
+		assert.Equal(t, ledger.KindSale, (*posted)[0].Kind)
+		assert.Equal(t, []ledger.Line{{Account: ledger.EscrowAccount, Debit: 100}, {Account: "seller:supplier1", Credit: 100}}, (*posted)[0].Lines)
+		assert.Equal(t, ledger.KindFee, (*posted)[1].Kind)
+		assert.Equal(t, "pay1", (*posted)[1].PaymentID)
+	}
+}
+
+func TestRecordPayout(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	posted := capturePosted(repo)
+
+	err := uc.RecordPayout(context.Background(), "reseller1", "po1", 73.5)
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 1) {
+		assert.Equal(t, ledger.KindPayout, (*posted)[0].Kind)
+		assert.Equal(t, "po1", (*posted)[0].PayoutID)
+		assert.Equal(t, []ledger.Line{{Account: "seller:reseller1", Debit: 73.5}, {Account: ledger.CashAccount, Credit: 73.5}}, (*posted)[0].Lines)
+	}
+}
+
+func TestRecordCreditIssued(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	posted := capturePosted(repo)
+
+	err := uc.RecordCreditIssued(context.Background(), "consumer1", 20, "late delivery")
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 1) {
+		assert.Equal(t, ledger.KindCreditIssue, (*posted)[0].Kind)
+		assert.Equal(t, []ledger.Line{{Account: ledger.GoodwillAccount, Debit: 20}, {Account: ledger.StoreCreditAccount, Credit: 20}}, (*posted)[0].Lines)
+	}
+}
+
+func TestRecordGiftCardRedeemed(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	posted := capturePosted(repo)
+
+	err := uc.RecordGiftCardRedeemed(context.Background(), "consumer1", "ABCD-EFGH", 25)
+
+	assert.NoError(t, err)
+	if assert.Len(t, *posted, 1) {
+		assert.Equal(t, ledger.KindGiftCard, (*posted)[0].Kind)
+		assert.Equal(t, []ledger.Line{{Account: ledger.GiftCardAccount, Debit: 25}, {Account: ledger.StoreCreditAccount, Credit: 25}}, (*posted)[0].Lines)
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	repo := new(MockLedgerRepo)
+	uc := NewLedgerUsecase(repo)
+	repo.On("AccountTotals", mock.Anything, "seller:supplier1").Return(map[ledger.Kind]ledger.Totals{
+		ledger.KindSale:        {Credit: 300},
+		ledger.KindFee:         {Debit: 6},
+		ledger.KindRefund:      {Debit: 50},
+		ledger.KindFeeReversal: {Credit: 1},
+		ledger.KindPayout:      {Debit: 100},
+		ledger.KindTax:         {Debit: 30},
+		ledger.KindTaxReversal: {Credit: 5},
+	}, nil)
+
+	bal, err := uc.GetBalance(context.Background(), "supplier1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 300.0, bal.Sales)
+	assert.Equal(t, 5.0, bal.Fees)
+	assert.Equal(t, 25.0, bal.Taxes)
+	assert.Equal(t, 50.0, bal.Refunds)
+	assert.Equal(t, 100.0, bal.Payouts)
+	assert.Equal(t, 220.0, bal.Earnings)
+	assert.Equal(t, 120.0, bal.Available)
+}
+
+func TestEntryValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry ledger.Entry
+		valid bool
+	}{
+		{"transfer", ledger.Transfer(ledger.KindSale, "a", "b", 10), true},
+		{"single line", ledger.Entry{Lines: []ledger.Line{{Account: "a", Debit: 10}}}, false},
+		{"unbalanced", ledger.Entry{Lines: []ledger.Line{{Account: "a", Debit: 10}, {Account: "b", Credit: 9}}}, false},
+		{"line debits and credits", ledger.Entry{Lines: []ledger.Line{{Account: "a", Debit: 10, Credit: 10}, {Account: "b", Credit: 10}}}, false},
+		{"no account", ledger.Entry{Lines: []ledger.Line{{Debit: 10}, {Account: "b", Credit: 10}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entry.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ledger.ErrUnbalancedEntry)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package moneyusecase
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type moneyUsecase struct {
+	repo  money.Repository
+	clock job.Clock
+}
+
+// NewMoneyUsecase creates the exchange-rate usecase.
+func NewMoneyUsecase(repo money.Repository, clock job.Clock) money.Usecase {
+	return &moneyUsecase{repo: repo, clock: clock}
+}
+
+func (u *moneyUsecase) ListRates(ctx context.Context) ([]*money.Rate, error) {
+	rates, err := u.repo.ListRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append([]*money.Rate{{Currency: money.Settlement, Rate: 1}}, rates...), nil
+}
+
+func (u *moneyUsecase) SetRate(ctx context.Context, adminID string, c money.Currency, rate float64) (*money.Rate, error) {
+	if c == money.Settlement {
+		return nil, money.ErrSettlementRate
+	}
+	if rate <= 0 {
+		return nil, money.ErrInvalidRate
+	}
+
+	r := &money.Rate{Currency: c, Rate: rate, UpdatedBy: adminID, UpdatedAt: u.clock.Now()}
+	if err := u.repo.SaveRate(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (u *moneyUsecase) Rates(ctx context.Context) (money.Rates, error) {
+	rates, err := u.repo.ListRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return money.NewRates(rates), nil
+}
@@ -0,0 +1,120 @@
+package moneyusecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMoneyRepo struct {
+	mock.Mock
+}
+
+func (m *MockMoneyRepo) ListRates(ctx context.Context) ([]*money.Rate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*money.Rate), args.Error(1)
+}
+
+func (m *MockMoneyRepo) SaveRate(ctx context.Context, r *money.Rate) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func TestSetRate(t *testing.T) {
+	repo := new(MockMoneyRepo)
+	uc := NewMoneyUsecase(repo, fixedClock{now: testNow})
+	repo.On("SaveRate", mock.Anything, mock.MatchedBy(func(r *money.Rate) bool {
+		return r.Currency == money.USD && r.Rate == 57.5 && r.UpdatedBy == "admin1" && r.UpdatedAt.Equal(testNow)
+	})).Return(nil)
+
+	r, err := uc.SetRate(context.Background(), "admin1", money.USD, 57.5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 57.5, r.Rate)
+	repo.AssertExpectations(t)
+}
+
+func TestSetRate_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency money.Currency
+		rate     float64
+		wantErr  error
+	}{
+		{"settlement currency", money.ETB, 1, money.ErrSettlementRate},
+		{"zero rate", money.USD, 0, money.ErrInvalidRate},
+		{"negative rate", money.USD, -3, money.ErrInvalidRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockMoneyRepo)
+			uc := NewMoneyUsecase(repo, fixedClock{now: testNow})
+
+			_, err := uc.SetRate(context.Background(), "admin1", tt.currency, tt.rate)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertNotCalled(t, "SaveRate", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRates_Convert(t *testing.T) {
+	repo := new(MockMoneyRepo)
+	uc := NewMoneyUsecase(repo, fixedClock{now: testNow})
+	repo.On("ListRates", mock.Anything).Return([]*money.Rate{{Currency: money.USD, Rate: 50}}, nil)
+
+	rates, err := uc.Rates(context.Background())
+	assert.NoError(t, err)
+
+	etb, err := rates.Convert(money.Money{Amount: 1250, Currency: money.USD}, money.ETB)
+	assert.NoError(t, err)
+	assert.Equal(t, money.Money{Amount: 62500, Currency: money.ETB}, etb)
+
+	usd, err := rates.Convert(money.Money{Amount: 10000, Currency: money.ETB}, money.USD)
+	assert.NoError(t, err)
+	assert.Equal(t, money.Money{Amount: 200, Currency: money.USD}, usd)
+}
+
+func TestRates_MissingRate(t *testing.T) {
+	repo := new(MockMoneyRepo)
+	uc := NewMoneyUsecase(repo, fixedClock{now: testNow})
+	repo.On("ListRates", mock.Anything).Return(nil, nil)
+
+	rates, err := uc.Rates(context.Background())
+	assert.NoError(t, err)
+
+	_, err = rates.Convert(money.Money{Amount: 100, Currency: money.ETB}, money.USD)
+	assert.ErrorIs(t, err, money.ErrNoRate)
+}
+
+func TestListRates_IncludesSettlement(t *testing.T) {
+	repo := new(MockMoneyRepo)
+	uc := NewMoneyUsecase(repo, fixedClock{now: testNow})
+	repo.On("ListRates", mock.Anything).Return([]*money.Rate{{Currency: money.USD, Rate: 50}}, nil)
+
+	rates, err := uc.ListRates(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, rates, 2)
+	assert.Equal(t, money.Settlement, rates[0].Currency)
+	assert.Equal(t, 1.0, rates[0].Rate)
+}
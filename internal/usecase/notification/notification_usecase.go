@@ -0,0 +1,37 @@
+package notificationusecase
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/notification"
+	"github.com/google/uuid"
+)
+
+type notificationUsecase struct {
+	repo  notification.Repository
+	clock job.Clock
+}
+
+func NewNotificationUsecase(repo notification.Repository, clock job.Clock) notification.Usecase {
+	return &notificationUsecase{repo: repo, clock: clock}
+}
+
+func (u *notificationUsecase) Notify(ctx context.Context, userID, notificationType, message string, data map[string]string) error {
+	return u.repo.CreateNotification(ctx, &notification.Notification{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Type:      notificationType,
+		Message:   message,
+		Data:      data,
+		CreatedAt: u.clock.Now(),
+	})
+}
+
+func (u *notificationUsecase) ListNotifications(ctx context.Context, userID string) ([]*notification.Notification, error) {
+	return u.repo.ListByUser(ctx, userID)
+}
+
+func (u *notificationUsecase) MarkRead(ctx context.Context, id, userID string) error {
+	return u.repo.MarkRead(ctx, id, userID)
+}
@@ -0,0 +1,326 @@
+package offerusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type offerUsecase struct {
+	repo        offer.Repository
+	bundleRepo  bundle.Repository
+	productRepo product.Repository
+	unitOfWork  uow.UnitOfWork
+	scheduler   job.Scheduler
+	clock       job.Clock
+}
+
+func NewOfferUsecase(repo offer.Repository, bundleRepo bundle.Repository, productRepo product.Repository, unitOfWork uow.UnitOfWork, scheduler job.Scheduler, clock job.Clock) offer.Usecase {
+	return &offerUsecase{
+		repo:        repo,
+		bundleRepo:  bundleRepo,
+		productRepo: productRepo,
+		unitOfWork:  unitOfWork,
+		scheduler:   scheduler,
+		clock:       clock,
+	}
+}
+
+// listing is what an offer needs to know about the bundle or product it is
+// made on.
+type listing struct {
+	sellerID    string
+	price       float64
+	currency    money.Currency
+	available   bool
+	auctioned   bool
+	soldTo      func(buyerID string) bool
+	reservation *offer.Reservation
+}
+
+func (u *offerUsecase) MakeOffer(ctx context.Context, buyerID string, role user.Role, req offer.Request) (*offer.Offer, error) {
+	switch req.ListingType {
+	case offer.ListingBundle:
+		if role != user.RoleReseller {
+			return nil, offer.ErrWrongBuyer
+		}
+	case offer.ListingProduct:
+		if role != user.RoleConsumer {
+			return nil, offer.ErrWrongBuyer
+		}
+	default:
+		return nil, offer.ErrInvalidListingType
+	}
+
+	l, err := u.listing(ctx, req.ListingType, req.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	now := u.clock.Now()
+	if err := u.offerable(l, buyerID, now); err != nil {
+		return nil, err
+	}
+	if l.sellerID == buyerID {
+		return nil, offer.ErrOwnListing
+	}
+	existing, err := u.repo.GetOpenOffer(ctx, req.ListingID, buyerID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, offer.ErrOfferExists
+	}
+
+	o := &offer.Offer{
+		ID:          uuid.NewString(),
+		ListingType: req.ListingType,
+		ListingID:   req.ListingID,
+		BuyerID:     buyerID,
+		SellerID:    l.sellerID,
+		Currency:    l.currency,
+		ListPrice:   l.price,
+		Status:      offer.StatusOpen,
+		CreatedAt:   now,
+	}
+	if err := o.Propose(buyerID, req.Price, req.Note, now); err != nil {
+		return nil, err
+	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.CreateOffer(ctx, o); err != nil {
+			return err
+		}
+		return u.schedule(ctx, job.TypeExpireOffer, o, o.ExpiresAt.Sub(now))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (u *offerUsecase) GetOffer(ctx context.Context, id, actorID string, role user.Role) (*offer.Offer, error) {
+	o, err := u.repo.GetOfferByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !o.IsParty(actorID, role) {
+		// Other users' offers are not revealed.
+		return nil, offer.ErrOfferNotFound
+	}
+	return o, nil
+}
+
+func (u *offerUsecase) ListMyOffers(ctx context.Context, userID string) ([]*offer.Offer, error) {
+	return u.repo.ListOffersByUser(ctx, userID)
+}
+
+// Respond stores the answer only if nobody else answered the offer since it
+// was read. Every counter restarts the response window.
+func (u *offerUsecase) Respond(ctx context.Context, id, actorID string, r offer.Response) (*offer.Offer, error) {
+	o, err := u.repo.GetOfferByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if actorID != o.BuyerID && actorID != o.SellerID {
+		return nil, offer.ErrOfferNotFound
+	}
+
+	now := u.clock.Now()
+	from, rounds := o.Status, len(o.Thread)
+	switch r.Action {
+	case offer.ActionAccept:
+		return u.accept(ctx, o, actorID, now)
+	case offer.ActionReject:
+		if err := o.Reject(actorID, now); err != nil {
+			return nil, err
+		}
+		if err := u.repo.UpdateOffer(ctx, o, from, rounds); err != nil {
+			return nil, err
+		}
+		return o, nil
+	case offer.ActionCounter:
+		if err := o.Counter(actorID, r.Price, r.Note, now); err != nil {
+			return nil, err
+		}
+		err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			if err := u.repo.UpdateOffer(ctx, o, from, rounds); err != nil {
+				return err
+			}
+			return u.schedule(ctx, job.TypeExpireOffer, o, o.ExpiresAt.Sub(now))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return o, nil
+	default:
+		return nil, offer.ErrInvalidAction
+	}
+}
+
+// accept agrees to the last proposal and holds the listing for the buyer
+// until the checkout window closes.
+func (u *offerUsecase) accept(ctx context.Context, o *offer.Offer, actorID string, now time.Time) (*offer.Offer, error) {
+	from, rounds := o.Status, len(o.Thread)
+	reservation, err := o.Accept(actorID, now)
+	if err != nil {
+		return nil, err
+	}
+	l, err := u.listing(ctx, o.ListingType, o.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.offerable(l, o.BuyerID, now); err != nil {
+		return nil, err
+	}
+
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateOffer(ctx, o, from, rounds); err != nil {
+			return err
+		}
+		if err := u.reserve(ctx, o, reservation, now); err != nil {
+			return err
+		}
+		return u.schedule(ctx, job.TypeReleaseOffer, o, offer.CheckoutWindow)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (u *offerUsecase) ExpireOffer(ctx context.Context, id string) error {
+	o, err := u.repo.GetOfferByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	now := u.clock.Now()
+	// A counter after this job was queued moved the offer's deadline.
+	if o.Status != offer.StatusOpen || now.Before(o.ExpiresAt) {
+		return nil
+	}
+	o.Status, o.AwaitingID, o.UpdatedAt = offer.StatusExpired, "", now
+	return u.repo.UpdateOffer(ctx, o, offer.StatusOpen, len(o.Thread))
+}
+
+// ReleaseOffer completes the offer if its buyer bought the listing in time.
+// Otherwise the offer lapses and the listing goes back on sale, unless a
+// later offer already holds it.
+func (u *offerUsecase) ReleaseOffer(ctx context.Context, id string) error {
+	o, err := u.repo.GetOfferByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if o.Status != offer.StatusAccepted {
+		return nil
+	}
+	now := u.clock.Now()
+	if o.ReservedUntil != nil && now.Before(*o.ReservedUntil) {
+		return u.schedule(ctx, job.TypeReleaseOffer, o, o.ReservedUntil.Sub(now))
+	}
+
+	l, err := u.listing(ctx, o.ListingType, o.ListingID)
+	if err != nil {
+		return err
+	}
+	o.UpdatedAt = now
+	if l.soldTo(o.BuyerID) {
+		o.Status = offer.StatusCompleted
+		return u.repo.UpdateOffer(ctx, o, offer.StatusAccepted, len(o.Thread))
+	}
+	o.Status = offer.StatusLapsed
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateOffer(ctx, o, offer.StatusAccepted, len(o.Thread)); err != nil {
+			return err
+		}
+		if l.reservation == nil || l.reservation.OfferID != o.ID {
+			return nil
+		}
+		return u.unreserve(ctx, o)
+	})
+}
+
+// offerable checks that buyerID may still negotiate for the listing at now.
+func (u *offerUsecase) offerable(l *listing, buyerID string, now time.Time) error {
+	if l.auctioned {
+		return bundle.ErrAuctioned
+	}
+	if !l.available {
+		return offer.ErrNotAvailable
+	}
+	if l.reservation.Holds(now) {
+		if l.reservation.BuyerID == buyerID {
+			return offer.ErrOfferExists
+		}
+		return offer.ErrReserved
+	}
+	return nil
+}
+
+func (u *offerUsecase) listing(ctx context.Context, t offer.ListingType, id string) (*listing, error) {
+	if t == offer.ListingBundle {
+		b, err := u.bundleRepo.GetBundleByID(ctx, id)
+		if errors.Is(err, bundle.ErrBundleNotFound) || (err == nil && b == nil) {
+			return nil, fmt.Errorf("%w: bundle %s", offer.ErrListingNotFound, id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bundle %s: %w", id, err)
+		}
+		return &listing{
+			sellerID:    b.SupplierID,
+			price:       b.Price.Major(),
+			currency:    b.Price.Currency,
+			available:   b.Status == "available",
+			auctioned:   b.IsAuctioned(),
+			soldTo:      func(buyerID string) bool { return b.Status == "purchased" && b.ResellerID == buyerID },
+			reservation: b.Reservation,
+		}, nil
+	}
+
+	p, err := u.productRepo.GetProductByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) || (err == nil && p == nil) {
+		return nil, fmt.Errorf("%w: product %s", offer.ErrListingNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load product %s: %w", id, err)
+	}
+	return &listing{
+		sellerID:    p.ResellerID.Hex(),
+		price:       p.Price.Major(),
+		currency:    p.Price.Currency,
+		available:   p.Status == product.StatusAvailable,
+		soldTo:      func(buyerID string) bool { return p.Status == product.StatusSold && p.BuyerID == buyerID },
+		reservation: p.Reservation,
+	}, nil
+}
+
+// reserve holds the offer's listing with r. It fails with offer.ErrReserved
+// if another offer accepted since the listing was read holds it at now.
+func (u *offerUsecase) reserve(ctx context.Context, o *offer.Offer, r *offer.Reservation, now time.Time) error {
+	if o.ListingType == offer.ListingBundle {
+		return u.bundleRepo.Reserve(ctx, o.ListingID, r, now)
+	}
+	return u.productRepo.Reserve(ctx, o.ListingID, r, now)
+}
+
+// unreserve releases the offer's listing unless another offer holds it.
+func (u *offerUsecase) unreserve(ctx context.Context, o *offer.Offer) error {
+	if o.ListingType == offer.ListingBundle {
+		return u.bundleRepo.Unreserve(ctx, o.ListingID, o.ID)
+	}
+	return u.productRepo.Unreserve(ctx, o.ListingID, o.ID)
+}
+
+func (u *offerUsecase) schedule(ctx context.Context, jobType string, o *offer.Offer, delay time.Duration) error {
+	_, err := u.scheduler.Schedule(ctx, jobType, map[string]string{"offer_id": o.ID}, delay)
+	return err
+}
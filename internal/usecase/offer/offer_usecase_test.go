@@ -0,0 +1,590 @@
+package offerusecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockOfferRepo struct {
+	mock.Mock
+}
+
+func (m *MockOfferRepo) CreateOffer(ctx context.Context, o *offer.Offer) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOfferRepo) GetOfferByID(ctx context.Context, id string) (*offer.Offer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*offer.Offer), args.Error(1)
+}
+
+func (m *MockOfferRepo) GetOpenOffer(ctx context.Context, listingID, buyerID string) (*offer.Offer, error) {
+	args := m.Called(ctx, listingID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*offer.Offer), args.Error(1)
+}
+
+func (m *MockOfferRepo) ListOffersByUser(ctx context.Context, userID string) ([]*offer.Offer, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*offer.Offer), args.Error(1)
+}
+
+func (m *MockOfferRepo) UpdateOffer(ctx context.Context, o *offer.Offer, from offer.Status, rounds int) error {
+	args := m.Called(ctx, o, from, rounds)
+	return args.Error(0)
+}
+
+type MockBundleRepo struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepo) CreateBundle(ctx context.Context, b *bundle.Bundle) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) GetBundleByID(ctx context.Context, id string) (*bundle.Bundle, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepo) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, supplierID)
+	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepo) ListAvailableBundles(ctx context.Context, q *bundle.Query, after *bundle.Cursor, limit int) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, q, after, limit)
+	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepo) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, resellerID)
+	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepo) UpdateBundleStatus(ctx context.Context, id string, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) SetListingMode(ctx context.Context, bundleID string, mode bundle.ListingMode, now time.Time) error {
+	args := m.Called(ctx, bundleID, mode, now)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) Reserve(ctx context.Context, bundleID string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, bundleID, r, now)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) Unreserve(ctx context.Context, bundleID, offerID string) error {
+	args := m.Called(ctx, bundleID, offerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) DeleteBundle(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) UpdateBundle(ctx context.Context, id string, updatedData map[string]interface{}) error {
+	args := m.Called(ctx, id, updatedData)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) DecreaseBundleQuantity(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) CountBundles(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBundleRepo) UpdateSupplierTrust(ctx context.Context, supplierID string, score int) error {
+	args := m.Called(ctx, supplierID, score)
+	return args.Error(0)
+}
+
+type MockProductRepo struct {
+	mock.Mock
+}
+
+func (m *MockProductRepo) AddProduct(ctx context.Context, p *product.Product) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) GetProductByID(ctx context.Context, id string) (*product.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
+	args := m.Called(ctx, resellerID, page, limit)
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
+	args := m.Called(ctx, page, limit)
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) SearchProducts(ctx context.Context, q *product.Query) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
+func (m *MockProductRepo) DeleteProduct(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Relist(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Reserve(ctx context.Context, id string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, id, r, now)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Unreserve(ctx context.Context, id, offerID string) error {
+	args := m.Called(ctx, id, offerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
+	args := m.Called(ctx, bundleID)
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// recordingScheduler keeps scheduled jobs in memory instead of queueing them.
+type recordingScheduler struct {
+	jobs []*job.Job
+}
+
+func (s *recordingScheduler) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	j := &job.Job{Type: jobType, Payload: payload, RunAt: testNow.Add(delay)}
+	s.jobs = append(s.jobs, j)
+	return j, nil
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	repo      *MockOfferRepo
+	bundles   *MockBundleRepo
+	products  *MockProductRepo
+	uow       *passthroughUnitOfWork
+	scheduler *recordingScheduler
+	uc        offer.Usecase
+}
+
+func newFixture(now time.Time) *fixture {
+	f := &fixture{
+		repo:      new(MockOfferRepo),
+		bundles:   new(MockBundleRepo),
+		products:  new(MockProductRepo),
+		uow:       &passthroughUnitOfWork{},
+		scheduler: &recordingScheduler{},
+	}
+	f.uc = NewOfferUsecase(f.repo, f.bundles, f.products, f.uow, f.scheduler, fixedClock{now: now})
+	return f
+}
+
+func availableBundle() *bundle.Bundle {
+	return &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Status: "available", Price: money.FromMajor(200, money.USD)}
+}
+
+// openOffer is reseller1's offer of 150 on bundle1, waiting on the supplier.
+func openOffer() *offer.Offer {
+	return &offer.Offer{
+		ID:          "offer1",
+		ListingType: offer.ListingBundle,
+		ListingID:   "bundle1",
+		BuyerID:     "reseller1",
+		SellerID:    "supplier1",
+		Currency:    money.USD,
+		ListPrice:   200,
+		Price:       150,
+		Thread:      []offer.Proposal{{ByID: "reseller1", Price: 150, At: testNow.Add(-time.Hour)}},
+		Status:      offer.StatusOpen,
+		AwaitingID:  "supplier1",
+		ExpiresAt:   testNow.Add(offer.ResponseWindow - time.Hour),
+	}
+}
+
+func TestMakeOffer(t *testing.T) {
+	f := newFixture(testNow)
+	f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(availableBundle(), nil)
+	f.repo.On("GetOpenOffer", mock.Anything, "bundle1", "reseller1").Return(nil, nil)
+	f.repo.On("CreateOffer", mock.Anything, mock.Anything).Return(nil)
+
+	o, err := f.uc.MakeOffer(context.Background(), "reseller1", user.RoleReseller, offer.Request{
+		ListingType: offer.ListingBundle,
+		ListingID:   "bundle1",
+		Price:       150,
+		Note:        "Would you take 150?",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusOpen, o.Status)
+	assert.Equal(t, "supplier1", o.SellerID)
+	assert.Equal(t, "supplier1", o.AwaitingID)
+	assert.Equal(t, money.USD, o.Currency)
+	assert.Len(t, o.Thread, 1)
+	if assert.Len(t, f.scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeExpireOffer, f.scheduler.jobs[0].Type)
+		assert.Equal(t, o.ID, f.scheduler.jobs[0].Payload["offer_id"])
+		assert.True(t, f.scheduler.jobs[0].RunAt.Equal(testNow.Add(offer.ResponseWindow)))
+	}
+	f.repo.AssertExpectations(t)
+}
+
+func TestMakeOffer_Rejected(t *testing.T) {
+	reserved := availableBundle()
+	reserved.Reservation = &offer.Reservation{OfferID: "offer2", BuyerID: "reseller2", Price: money.InSettlement(180), Until: testNow.Add(time.Hour)}
+	auctioned := availableBundle()
+	auctioned.ListingMode = bundle.Auctioned
+	sold := availableBundle()
+	sold.Status = "purchased"
+
+	tests := []struct {
+		name    string
+		role    user.Role
+		price   float64
+		bundle  *bundle.Bundle
+		open    *offer.Offer
+		wantErr error
+	}{
+		{"consumer on a bundle", user.RoleConsumer, 150, nil, nil, offer.ErrWrongBuyer},
+		{"above list price", user.RoleReseller, 250, availableBundle(), nil, offer.ErrInvalidPrice},
+		{"zero price", user.RoleReseller, 0, availableBundle(), nil, offer.ErrInvalidPrice},
+		{"reserved for someone else", user.RoleReseller, 150, reserved, nil, offer.ErrReserved},
+		{"auctioned", user.RoleReseller, 150, auctioned, nil, bundle.ErrAuctioned},
+		{"sold", user.RoleReseller, 150, sold, nil, offer.ErrNotAvailable},
+		{"already negotiating", user.RoleReseller, 150, availableBundle(), openOffer(), offer.ErrOfferExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(testNow)
+			if tt.bundle != nil {
+				f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(tt.bundle, nil)
+			}
+			f.repo.On("GetOpenOffer", mock.Anything, "bundle1", "reseller1").Return(tt.open, nil)
+
+			_, err := f.uc.MakeOffer(context.Background(), "reseller1", tt.role, offer.Request{
+				ListingType: offer.ListingBundle,
+				ListingID:   "bundle1",
+				Price:       tt.price,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			f.repo.AssertNotCalled(t, "CreateOffer", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMakeOffer_ListingLookupFails(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{"missing bundle", bundle.ErrBundleNotFound, offer.ErrListingNotFound},
+		{"database down", context.DeadlineExceeded, context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(testNow)
+			f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(nil, tt.err)
+
+			_, err := f.uc.MakeOffer(context.Background(), "reseller1", user.RoleReseller, offer.Request{
+				ListingType: offer.ListingBundle,
+				ListingID:   "bundle1",
+				Price:       150,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != offer.ErrListingNotFound {
+				assert.NotErrorIs(t, err, offer.ErrListingNotFound)
+			}
+		})
+	}
+}
+
+func TestMakeOffer_OwnProduct(t *testing.T) {
+	f := newFixture(testNow)
+	resellerID := primitive.NewObjectID()
+	f.products.On("GetProductByID", mock.Anything, "p1").
+		Return(&product.Product{ID: "p1", ResellerID: resellerID, Price: money.InSettlement(40), Status: product.StatusAvailable}, nil)
+
+	_, err := f.uc.MakeOffer(context.Background(), resellerID.Hex(), user.RoleConsumer, offer.Request{
+		ListingType: offer.ListingProduct,
+		ListingID:   "p1",
+		Price:       30,
+	})
+
+	assert.ErrorIs(t, err, offer.ErrOwnListing)
+}
+
+func TestRespond_Counter(t *testing.T) {
+	f := newFixture(testNow)
+	o := openOffer()
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+	f.repo.On("UpdateOffer", mock.Anything, o, offer.StatusOpen, 1).Return(nil)
+
+	got, err := f.uc.Respond(context.Background(), "offer1", "supplier1", offer.Response{Action: offer.ActionCounter, Price: 180, Note: "Meet me at 180"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusOpen, got.Status)
+	assert.Equal(t, 180.0, got.Price)
+	assert.Equal(t, "reseller1", got.AwaitingID)
+	if assert.Len(t, got.Thread, 2) {
+		assert.Equal(t, "supplier1", got.Thread[1].ByID)
+		assert.Equal(t, "Meet me at 180", got.Thread[1].Note)
+	}
+	assert.True(t, got.ExpiresAt.Equal(testNow.Add(offer.ResponseWindow)))
+	if assert.Len(t, f.scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeExpireOffer, f.scheduler.jobs[0].Type)
+	}
+}
+
+func TestRespond_Accept(t *testing.T) {
+	f := newFixture(testNow)
+	o := openOffer()
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+	f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(availableBundle(), nil)
+	f.repo.On("UpdateOffer", mock.Anything, o, offer.StatusOpen, 1).Return(nil)
+	until := testNow.Add(offer.CheckoutWindow)
+	f.bundles.On("Reserve", mock.Anything, "bundle1",
+		&offer.Reservation{OfferID: "offer1", BuyerID: "reseller1", Price: money.FromMajor(150, money.USD), Until: until}, testNow).Return(nil)
+
+	got, err := f.uc.Respond(context.Background(), "offer1", "supplier1", offer.Response{Action: offer.ActionAccept})
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusAccepted, got.Status)
+	assert.Empty(t, got.AwaitingID)
+	assert.True(t, got.ReservedUntil.Equal(until))
+	assert.Equal(t, 1, f.uow.calls)
+	if assert.Len(t, f.scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeReleaseOffer, f.scheduler.jobs[0].Type)
+		assert.True(t, f.scheduler.jobs[0].RunAt.Equal(until))
+	}
+	f.bundles.AssertExpectations(t)
+}
+
+func TestRespond_AcceptLostRace(t *testing.T) {
+	f := newFixture(testNow)
+	o := openOffer()
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+	f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(availableBundle(), nil)
+	f.repo.On("UpdateOffer", mock.Anything, o, offer.StatusOpen, 1).Return(nil)
+	// Another offer on the bundle was accepted after it was read.
+	f.bundles.On("Reserve", mock.Anything, "bundle1", mock.Anything, testNow).Return(offer.ErrReserved)
+
+	_, err := f.uc.Respond(context.Background(), "offer1", "supplier1", offer.Response{Action: offer.ActionAccept})
+
+	assert.ErrorIs(t, err, offer.ErrReserved)
+	assert.Empty(t, f.scheduler.jobs)
+}
+
+func TestRespond_Rejected(t *testing.T) {
+	expired := openOffer()
+	expired.ExpiresAt = testNow
+	closed := openOffer()
+	closed.Status = offer.StatusRejected
+
+	tests := []struct {
+		name    string
+		offer   *offer.Offer
+		actorID string
+		resp    offer.Response
+		wantErr error
+	}{
+		{"buyer accepts own proposal", openOffer(), "reseller1", offer.Response{Action: offer.ActionAccept}, offer.ErrNotYourTurn},
+		{"stranger", openOffer(), "reseller2", offer.Response{Action: offer.ActionReject}, offer.ErrOfferNotFound},
+		{"expired", expired, "supplier1", offer.Response{Action: offer.ActionAccept}, offer.ErrOfferExpired},
+		{"closed", closed, "supplier1", offer.Response{Action: offer.ActionCounter, Price: 180}, offer.ErrNotOpen},
+		{"counter above list price", openOffer(), "supplier1", offer.Response{Action: offer.ActionCounter, Price: 220}, offer.ErrInvalidPrice},
+		{"unknown action", openOffer(), "supplier1", offer.Response{Action: "haggle"}, offer.ErrInvalidAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(testNow)
+			f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(tt.offer, nil)
+
+			_, err := f.uc.Respond(context.Background(), "offer1", tt.actorID, tt.resp)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			f.repo.AssertNotCalled(t, "UpdateOffer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestExpireOffer(t *testing.T) {
+	f := newFixture(testNow.Add(offer.ResponseWindow))
+	o := openOffer()
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+	f.repo.On("UpdateOffer", mock.Anything, o, offer.StatusOpen, 1).Return(nil)
+
+	err := f.uc.ExpireOffer(context.Background(), "offer1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusExpired, o.Status)
+	assert.Empty(t, o.AwaitingID)
+}
+
+func TestExpireOffer_CounteredSince(t *testing.T) {
+	f := newFixture(testNow)
+	o := openOffer()
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+
+	err := f.uc.ExpireOffer(context.Background(), "offer1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusOpen, o.Status)
+	f.repo.AssertNotCalled(t, "UpdateOffer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// acceptedOffer is openOffer accepted at 150, its window ending at testNow.
+func acceptedOffer() *offer.Offer {
+	o := openOffer()
+	until := testNow
+	o.Status, o.AwaitingID, o.ReservedUntil = offer.StatusAccepted, "", &until
+	return o
+}
+
+func TestReleaseOffer_Completed(t *testing.T) {
+	f := newFixture(testNow)
+	o := acceptedOffer()
+	b := availableBundle()
+	b.Status, b.ResellerID = "purchased", "reseller1"
+	b.Reservation = &offer.Reservation{OfferID: "offer1", BuyerID: "reseller1", Price: money.InSettlement(150), Until: testNow}
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+	f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(b, nil)
+	f.repo.On("UpdateOffer", mock.Anything, o, offer.StatusAccepted, 1).Return(nil)
+
+	err := f.uc.ReleaseOffer(context.Background(), "offer1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusCompleted, o.Status)
+	f.bundles.AssertNotCalled(t, "Unreserve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReleaseOffer_Lapsed(t *testing.T) {
+	f := newFixture(testNow)
+	o := acceptedOffer()
+	b := availableBundle()
+	b.Reservation = &offer.Reservation{OfferID: "offer1", BuyerID: "reseller1", Price: money.InSettlement(150), Until: testNow}
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+	f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(b, nil)
+	f.repo.On("UpdateOffer", mock.Anything, o, offer.StatusAccepted, 1).Return(nil)
+	f.bundles.On("Unreserve", mock.Anything, "bundle1", "offer1").Return(nil)
+
+	err := f.uc.ReleaseOffer(context.Background(), "offer1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusLapsed, o.Status)
+	f.bundles.AssertExpectations(t)
+}
+
+func TestReleaseOffer_HeldByLaterOffer(t *testing.T) {
+	f := newFixture(testNow)
+	o := acceptedOffer()
+	b := availableBundle()
+	b.Reservation = &offer.Reservation{OfferID: "offer2", BuyerID: "reseller2", Price: money.InSettlement(170), Until: testNow.Add(time.Hour)}
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+	f.bundles.On("GetBundleByID", mock.Anything, "bundle1").Return(b, nil)
+	f.repo.On("UpdateOffer", mock.Anything, o, offer.StatusAccepted, 1).Return(nil)
+
+	err := f.uc.ReleaseOffer(context.Background(), "offer1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusLapsed, o.Status)
+	f.bundles.AssertNotCalled(t, "Unreserve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReleaseOffer_ProductSoldToSomeoneElse(t *testing.T) {
+	f := newFixture(testNow)
+	o := acceptedOffer()
+	o.ListingType, o.ListingID, o.BuyerID = offer.ListingProduct, "p1", "consumer1"
+	p := &product.Product{ID: "p1", ResellerID: primitive.NewObjectID(), Price: money.InSettlement(40), Status: product.StatusSold, BuyerID: "consumer2"}
+	p.Reservation = &offer.Reservation{OfferID: "offer1", BuyerID: "consumer1", Price: money.InSettlement(30), Until: testNow}
+	f.repo.On("GetOfferByID", mock.Anything, "offer1").Return(o, nil)
+	f.products.On("GetProductByID", mock.Anything, "p1").Return(p, nil)
+	f.repo.On("UpdateOffer", mock.Anything, o, offer.StatusAccepted, 1).Return(nil)
+	f.products.On("Unreserve", mock.Anything, "p1", "offer1").Return(nil)
+
+	err := f.uc.ReleaseOffer(context.Background(), "offer1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, offer.StatusLapsed, o.Status)
+}
@@ -4,8 +4,18 @@ import (
 	"context"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 )
@@ -22,4 +32,15 @@ type orderUseCaseImpl struct {
 	warehouseRepo warehouse.Repository
 	paymentRepo   payment.Repository
 	userRepo      user.Repository
+	productRepo   product.Repository
+	unitOfWork    uow.UnitOfWork
+	gateway       payment.Gateway
+	scheduler     job.Scheduler
+	fees          fee.Quoter
+	ledger        ledger.Usecase
+	escrowRepo    escrow.Repository
+	credits       credit.Payer
+	rates         money.Converter
+	taxes         tax.Assessor
+	invoices      invoice.Issuer
 }
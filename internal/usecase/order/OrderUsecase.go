@@ -4,39 +4,88 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
+	"log"
+	"math"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func NewOrderUsecase(bRepo bundle.Repository, oRepo order.Repository, wRepo warehouse.Repository, pRepo payment.Repository, uRepo user.Repository) *orderUseCaseImpl {
+// gatewayTimeout bounds each round trip to the payment gateway.
+const gatewayTimeout = 10 * time.Second
+
+// warehouseArrivalDelay is how long after purchase a bundle is simulated to
+// arrive at the warehouse and be listed.
+const warehouseArrivalDelay = 3 * time.Minute
+
+func NewOrderUsecase(bRepo bundle.Repository, oRepo order.Repository, wRepo warehouse.Repository, pRepo payment.Repository, uRepo user.Repository, prRepo product.Repository, unitOfWork uow.UnitOfWork, gateway payment.Gateway, scheduler job.Scheduler, fees fee.Quoter, ledgerUC ledger.Usecase, escrowRepo escrow.Repository, credits credit.Payer, rates money.Converter, taxes tax.Assessor, invoices invoice.Issuer) *orderUseCaseImpl {
 	return &orderUseCaseImpl{
 		bundleRepo:    bRepo,
 		orderRepo:     oRepo,
 		warehouseRepo: wRepo,
 		paymentRepo:   pRepo,
 		userRepo:      uRepo,
+		productRepo:   prRepo,
+		unitOfWork:    unitOfWork,
+		gateway:       gateway,
+		scheduler:     scheduler,
+		fees:          fees,
+		ledger:        ledgerUC,
+		escrowRepo:    escrowRepo,
+		credits:       credits,
+		rates:         rates,
+		taxes:         taxes,
+		invoices:      invoices,
 	}
 }
 
-func simulateStripePayment(total float64) (string, error) {
-	time.Sleep(500 * time.Millisecond)
-	return fmt.Sprintf("ch_%d", rand.Intn(1000000)), nil
+// processPayment authorizes and captures total through the payment gateway.
+func (uc *orderUseCaseImpl) processPayment(ctx context.Context, total money.Money, referenceID string) (chargeID string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, gatewayTimeout)
+	defer cancel()
+
+	charge, err := uc.gateway.Authorize(ctx, total, referenceID)
+	if err != nil {
+		return "", fmt.Errorf("payment failed: %w", err)
+	}
+	if _, err := uc.gateway.Capture(ctx, charge.ID); err != nil {
+		return "", fmt.Errorf("payment failed: %w", err)
+	}
+	return charge.ID, nil
 }
 
-func processPayment(total float64) (fee float64, net float64, err error) {
-	fee = total * 0.02
-	net = total - fee
-	_, err = simulateStripePayment(total)
-	return
+// refundPayment gives a captured charge back when the purchase it paid for
+// could not be recorded. A refund the gateway does not take is queued as a
+// job and retried until it goes through.
+func (uc *orderUseCaseImpl) refundPayment(chargeID string, amount money.Money) {
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayTimeout)
+	defer cancel()
+
+	if _, err := uc.gateway.Refund(ctx, chargeID, amount, chargeID); err == nil {
+		return
+	}
+	payload := map[string]string{"charge_id": chargeID, "amount": strconv.FormatFloat(amount.Major(), 'f', -1, 64), "currency": string(amount.Currency)}
+	if _, err := uc.scheduler.Schedule(context.Background(), job.TypeRefundCharge, payload, 0); err != nil {
+		log.Println("Failed to queue refund of charge", chargeID+":", err)
+	}
 }
 
 func (uc *orderUseCaseImpl) PurchaseBundle(ctx context.Context, bundleID, resellerID string) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
@@ -45,86 +94,187 @@ func (uc *orderUseCaseImpl) PurchaseBundle(ctx context.Context, bundleID, resell
 		return nil, nil, nil, err
 	}
 
-	availables, err := uc.bundleRepo.ListAvailableBundles(ctx)
+	if b.Status != "available" {
+		return nil, nil, nil, bundle.ErrNotAvailable
+	}
+
+	if b.IsAuctioned() {
+		return nil, nil, nil, bundle.ErrAuctioned
+	}
+	// A bundle held by an accepted offer sells to its buyer at the agreed
+	// price and to nobody else.
+	price, err := b.Reservation.PriceFor(resellerID, b.Price, time.Now())
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	return uc.purchase(ctx, primitive.NewObjectID().Hex(), b, resellerID, price)
+}
 
-	found := false
-	for _, available := range availables {
-		if available.ID == b.ID {
-			found = true
-			break
-		}
+// PurchaseAuctionedBundle buys an auctioned bundle for the winning bid,
+// through the same path as a fixed-price purchase. The order takes the
+// auction's ID, so a retried close finds the order it already placed
+// instead of charging the winner again.
+func (uc *orderUseCaseImpl) PurchaseAuctionedBundle(ctx context.Context, auctionID, bundleID, resellerID string, price money.Money) (*order.Order, error) {
+	placed, err := uc.orderRepo.GetOrderByID(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if placed != nil {
+		return placed, nil
+	}
+	b, err := uc.bundleRepo.GetBundleByID(ctx, bundleID)
+	if err != nil {
+		return nil, err
 	}
-	if !found {
-		return nil, nil, nil, errors.New("bundle not available")
+	if b.Status != "available" || !b.IsAuctioned() {
+		return nil, bundle.ErrNotAvailable
 	}
+	o, _, _, err := uc.purchase(ctx, auctionID, b, resellerID, price)
+	return o, err
+}
 
+// purchase charges the reseller listPrice for b and records the sale as
+// order orderID.
+func (uc *orderUseCaseImpl) purchase(ctx context.Context, orderID string, b *bundle.Bundle, resellerID string, listPrice money.Money) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
 	if b.SupplierID == resellerID {
 		return nil, nil, nil, errors.New("reseller cannot purchase their own bundle")
 	}
 
-	fee, net, err := processPayment(b.Price)
+	// The reseller pays the list price in its own currency; the purchase is
+	// booked at what that is worth in the settlement currency.
+	rates, err := uc.rates.Rates(ctx)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-
-	order := &order.Order{
-		ID:          primitive.NewObjectID().Hex(),
-		BundleID:    b.ID,
-		ResellerID:  resellerID,
-		SupplierID:  b.SupplierID,
-		TotalPrice:  b.Price,
-		PlatformFee: fee,
-		Status:      order.OrderStatusProcessing,
-		CreatedAt:   time.Now().Add(-5 * time.Minute).Format(time.RFC3339),
+	rate, err := rates.Of(listPrice.Currency)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	if err := uc.orderRepo.CreateOrder(ctx, order); err != nil {
+	settled, err := rates.Convert(listPrice, money.Settlement)
+	if err != nil {
 		return nil, nil, nil, err
 	}
-
-	payment := &payment.Payment{
-		FromUserID:    resellerID,
-		ToUserID:      b.SupplierID,
-		Amount:        b.Price,
-		PlatformFee:   fee,
-		SellerEarning: net,
-		Status:        "Paid",
-		ReferenceID:   b.ID,
-		Type:          payment.B2B,
-		CreatedAt:     time.Now().Add(-5 * time.Minute).Format(time.RFC3339),
+	// Tax and the fee are settled before charging so a policy error leaves
+	// nothing to refund. The fee is charged on the price before tax.
+	assessed, err := uc.taxes.Assess(ctx, tax.Sale{Type: tax.SaleB2B, SellerID: b.SupplierID, BuyerID: resellerID, Amount: settled.Major()})
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	if err := uc.paymentRepo.RecordPayment(ctx, payment); err != nil {
+	quote, err := uc.fees.Quote(ctx, fee.Sale{Type: payment.B2B, Category: b.Type, SellerID: b.SupplierID, Amount: assessed.Net})
+	if err != nil {
 		return nil, nil, nil, err
 	}
+	price := assessed.Gross
+	charged := money.FromMajor(price/rate, listPrice.Currency)
 
-	if err := uc.bundleRepo.MarkAsPurchased(ctx, b.ID, resellerID); err != nil {
+	// The gateway takes the charge in the reseller's currency, as recorded.
+	chargeID, err := uc.processPayment(ctx, charged, b.ID)
+	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	paidAt := time.Now().Add(-5 * time.Minute)
+	now := paidAt.Format(time.RFC3339)
+	o := &order.Order{
+		ID:            orderID,
+		BundleID:      b.ID,
+		ResellerID:    resellerID,
+		SupplierID:    b.SupplierID,
+		TotalPrice:    money.InSettlement(price),
+		PlatformFee:   money.InSettlement(quote.Fee),
+		SellerEarning: money.InSettlement(quote.SellerEarning),
+		CreatedAt:     now,
+		Charged:       &charged,
+		Tax:           money.InSettlement(assessed.Tax),
+		TaxLines:      assessed.Lines,
+		Titles:        map[string]string{b.ID: b.Title},
+	}
+	o.Place(order.OrderStatusProcessing, resellerID)
+	// The bundle is handed over as soon as the purchase commits.
+	completion, err := o.Transition(order.OrderStatusCompleted, resellerID, "bundle purchased")
+	if err != nil {
+		uc.refundPayment(chargeID, charged)
+		return nil, nil, nil, err
+	}
+	// The supplier is only paid once the bundle's arrival is confirmed or the
+	// dispute window ends; see ConfirmArrival and ReleaseEscrow.
+	p := &payment.Payment{
+		ID:            primitive.NewObjectID().Hex(),
+		FromUserID:    resellerID,
+		ToUserID:      b.SupplierID,
+		Amount:        money.InSettlement(price),
+		PlatformFee:   money.InSettlement(quote.Fee),
+		SellerEarning: money.InSettlement(quote.SellerEarning),
+		Status:        payment.StatusHeld,
+		ReferenceID:   b.ID,
+		OrderID:       o.ID,
+		ChargeID:      chargeID,
+		Type:          payment.B2B,
+		FeeRule:       quote.Rule,
+		FeeVersion:    quote.PolicyVersion,
+		CreatedAt:     paidAt.UTC(),
+		Original:      charged,
+		ExchangeRate:  rate,
+		Tax:           money.InSettlement(assessed.Tax),
+		TaxLines:      assessed.Lines,
+	}
 	warehouseItem := &warehouse.WarehouseItem{
 		ID:         primitive.NewObjectID().Hex(),
 		BundleID:   b.ID,
 		ResellerID: resellerID,
 		Status:     "pending",
 	}
-	if err := uc.warehouseRepo.AddItem(ctx, warehouseItem); err != nil {
-		return nil, nil, nil, err
+	held := &escrow.Escrow{
+		OrderID:         o.ID,
+		PaymentID:       p.ID,
+		SupplierID:      b.SupplierID,
+		ResellerID:      resellerID,
+		WarehouseItemID: warehouseItem.ID,
+		Amount:          money.InSettlement(price),
+		Status:          escrow.StatusHeld,
+		ReleaseBy:       time.Now().Add(escrowDisputeWindow),
+		CreatedAt:       time.Now(),
 	}
 
-	if err := uc.orderRepo.UpdateOrderStatus(ctx, order.ID, "completed"); err != nil {
+	// All writes commit together. MarkAsPurchased goes first and only matches an
+	// available bundle, so a second buyer aborts before anything else is written.
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := uc.bundleRepo.MarkAsPurchased(ctx, b.ID, resellerID); err != nil {
+			return err
+		}
+		if err := uc.orderRepo.CreateOrder(ctx, o); err != nil {
+			return err
+		}
+		if err := uc.paymentRepo.RecordPayment(ctx, p); err != nil {
+			return err
+		}
+		if err := uc.ledger.RecordPayment(ctx, p); err != nil {
+			return err
+		}
+		if _, err := uc.invoices.IssueInvoice(ctx, o, p); err != nil {
+			return err
+		}
+		if err := uc.escrowRepo.CreateEscrow(ctx, held); err != nil {
+			return err
+		}
+		if err := uc.warehouseRepo.AddItem(ctx, warehouseItem); err != nil {
+			return err
+		}
+		if _, err := uc.scheduler.Schedule(ctx, job.TypeListWarehouseItem, map[string]string{"item_id": warehouseItem.ID}, warehouseArrivalDelay); err != nil {
+			return err
+		}
+		if _, err := uc.scheduler.Schedule(ctx, job.TypeReleaseEscrow, map[string]string{"order_id": o.ID}, escrowDisputeWindow); err != nil {
+			return err
+		}
+		return uc.orderRepo.TransitionStatus(ctx, o.ID, completion)
+	})
+	if err != nil {
+		uc.refundPayment(chargeID, charged)
 		return nil, nil, nil, err
 	}
+	o.Apply(completion)
 
-	go func(itemID string) {
-		time.Sleep(3 * time.Minute)
-		if err := uc.warehouseRepo.MarkItemAsListed(context.Background(), itemID); err != nil {
-			fmt.Println("Failed to mark warehouse item as listed:", err)
-		}
-	}(warehouseItem.ID)
-
-	return order, payment, warehouseItem, nil
+	return o, p, warehouseItem, nil
 }
 
 func (uc *orderUseCaseImpl) GetDashboardMetrics(ctx context.Context, supplierID string) (*order.DashboardMetrics, error) {
@@ -133,7 +283,6 @@ func (uc *orderUseCaseImpl) GetDashboardMetrics(ctx context.Context, supplierID
 		return nil, err
 	}
 
-	totalSales := 0.0
 	activeCount := 0
 	soldCount := 0
 	bestSelling := 0.0
@@ -143,14 +292,19 @@ func (uc *orderUseCaseImpl) GetDashboardMetrics(ctx context.Context, supplierID
 	if err != nil {
 		return nil, err
 	}
+	rates, err := uc.rates.Rates(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, b := range bundles {
 		if b.Status == "purchased" {
-			totalSales += b.Price
 			soldCount++
-			if b.Price > bestSelling {
-				bestSelling = b.Price
+			price, err := rates.Convert(b.Price, money.Settlement)
+			if err != nil {
+				return nil, err
 			}
+			bestSelling = math.Max(bestSelling, price.Major())
 		} else if b.Status == "available" {
 			activeCount++
 			activeBundles = append(activeBundles, b)
@@ -161,8 +315,16 @@ func (uc *orderUseCaseImpl) GetDashboardMetrics(ctx context.Context, supplierID
 		return activeBundles[i].DateListed.After(activeBundles[j].DateListed)
 	})
 
+	// Sales come from the ledger so that refunds are netted out.
+	bal, err := uc.ledger.GetBalance(ctx, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &order.DashboardMetrics{
-		TotalSales:         totalSales,
+		TotalSales:         bal.Sales - bal.Refunds,
+		Earnings:           bal.Earnings,
+		Balance:            bal.Available,
 		ActiveBundles:      activeBundles,
 		PerformanceMetrics: order.PerformanceMetrics{TotalBundlesListed: len(bundles), ActiveCount: activeCount, SoldCount: soldCount},
 		Rating:             userData.TrustScore,
@@ -189,7 +351,7 @@ func (uc *orderUseCaseImpl) GetResellerMetrics(ctx context.Context, resellerID s
 	totalItemsSold := 0
 	bestSelling := 0.0
 	for _, order := range orders {
-		if order.Status == "completed" {
+		if order.Status.IsFulfilled() {
 			if len(order.ProductIDs) > 0 {
 				totalItemsSold += len(order.ProductIDs)
 			}
@@ -201,10 +363,21 @@ func (uc *orderUseCaseImpl) GetResellerMetrics(ctx context.Context, resellerID s
 			}
 		}
 	}
+	rates, err := uc.rates.Rates(ctx)
+	if err != nil {
+		return nil, err
+	}
 	for _, b := range bundles {
-		if b.Price > bestSelling {
-			bestSelling = b.Price
+		price, err := rates.Convert(b.Price, money.Settlement)
+		if err != nil {
+			return nil, err
 		}
+		bestSelling = math.Max(bestSelling, price.Major())
+	}
+
+	bal, err := uc.ledger.GetBalance(ctx, resellerID)
+	if err != nil {
+		return nil, err
 	}
 
 	return &order.ResellerMetrics{
@@ -212,6 +385,8 @@ func (uc *orderUseCaseImpl) GetResellerMetrics(ctx context.Context, resellerID s
 		TotalItemsSold:     totalItemsSold,
 		Rating:             userData.TrustScore,
 		BestSelling:        bestSelling,
+		Earnings:           bal.Earnings,
+		Balance:            bal.Available,
 		BoughtBundles:      bundles,
 	}, nil
 }
@@ -264,3 +439,22 @@ func (uc *orderUseCaseImpl) GetAdminDashboardMetrics(ctx context.Context) (*admi
 func (uc *orderUseCaseImpl) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
 	return uc.orderRepo.GetOrderByID(ctx, orderID)
 }
+
+func (uc *orderUseCaseImpl) GetOrdersToFulfil(ctx context.Context, resellerID string) ([]*order.Order, error) {
+	orders, err := uc.orderRepo.GetOrdersByReseller(ctx, resellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The reseller's own bundle purchases are also stored under their ID.
+	sales := []*order.Order{}
+	for _, o := range orders {
+		if !o.IsBundleOrder() {
+			sales = append(sales, o)
+		}
+	}
+	sort.Slice(sales, func(i, j int) bool {
+		return sales[i].CreatedAt > sales[j].CreatedAt
+	})
+	return sales, nil
+}
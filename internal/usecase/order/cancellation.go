@@ -0,0 +1,286 @@
+package OrderUsecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CancelOrder cancels an order on behalf of one of its parties, refunds what
+// is left of its payment and puts the goods back on sale: sold products become
+// available again and a bundle returns to the supplier's listings.
+func (uc *orderUseCaseImpl) CancelOrder(ctx context.Context, orderID, actorID string, role user.Role, reason string) (*order.Order, error) {
+	o, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if err := o.CanCancel(actorID, role); err != nil {
+		return nil, err
+	}
+
+	var warehouseItemIDs []string
+	if o.IsBundleOrder() {
+		items, err := uc.warehouseRepo.GetItemsByBundle(ctx, o.BundleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.ResellerID != o.ResellerID {
+				continue
+			}
+			// Once the bundle has arrived the reseller owns it; only pending deliveries can be undone.
+			if item.Status != "pending" {
+				return nil, fmt.Errorf("%w: bundle has already arrived at the warehouse", order.ErrCannotCancel)
+			}
+			warehouseItemIDs = append(warehouseItemIDs, item.ID)
+		}
+	}
+
+	original, remaining, err := uc.refundableBalance(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shipped goods are not in the seller's hands, so they are only listed
+	// again once they come back.
+	relist := o.Status != order.OrderStatusShipped
+	cancellation, err := o.Transition(order.OrderStatusCanceled, actorID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	var refundEntry *payment.Payment
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		refundEntry = nil
+		if err := uc.orderRepo.TransitionStatus(ctx, o.ID, cancellation); err != nil {
+			return err
+		}
+		if o.IsBundleOrder() {
+			if err := uc.bundleRepo.UpdateBundleStatus(ctx, o.BundleID, "available"); err != nil {
+				return err
+			}
+			for _, id := range warehouseItemIDs {
+				if err := uc.warehouseRepo.DeleteItem(ctx, id); err != nil {
+					return err
+				}
+			}
+		}
+		if relist {
+			for _, productID := range o.ProductIDs {
+				if err := uc.productRepo.Relist(ctx, productID); err != nil {
+					return err
+				}
+			}
+		}
+		if original == nil || remaining.Amount <= 0 {
+			return nil
+		}
+		var err error
+		refundEntry, err = uc.refund(ctx, original, remaining, remaining)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.trySettleRefund(ctx, refundEntry)
+
+	o.Apply(cancellation)
+	return o, nil
+}
+
+// RefundOrder gives back amount of the order's payment to the buyer. An amount
+// of zero refunds everything that has not been refunded yet.
+func (uc *orderUseCaseImpl) RefundOrder(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	refundEntry, err := uc.RecordRefund(ctx, orderID, actorID, role, amount)
+	if err != nil {
+		return nil, err
+	}
+	uc.trySettleRefund(ctx, refundEntry)
+	return refundEntry, nil
+}
+
+func (uc *orderUseCaseImpl) RecordRefund(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	o, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if err := o.CanRefund(actorID, role); err != nil {
+		return nil, err
+	}
+
+	original, remaining, err := uc.refundableBalance(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, errors.New("order has no payment to refund")
+	}
+	// Held money is given back by canceling the order or resolving a dispute.
+	if original.Status == payment.StatusHeld {
+		return nil, escrow.ErrFundsHeld
+	}
+	refundAmount := money.InSettlement(amount)
+	if amount == 0 {
+		refundAmount = remaining
+	}
+	if refundAmount.Amount <= 0 || refundAmount.Amount > remaining.Amount {
+		return nil, order.ErrInvalidRefundAmount
+	}
+
+	var refundEntry *payment.Payment
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		refundEntry, err = uc.refund(ctx, original, remaining, refundAmount)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refundEntry, nil
+}
+
+// refundableBalance finds the payment that settled the order and how much of
+// it is still refundable once earlier refunds are netted off.
+func (uc *orderUseCaseImpl) refundableBalance(ctx context.Context, orderID string) (*payment.Payment, money.Money, error) {
+	remaining := money.Money{Currency: money.Settlement}
+	payments, err := uc.paymentRepo.GetPaymentsByOrder(ctx, orderID)
+	if err != nil {
+		return nil, remaining, err
+	}
+
+	var original *payment.Payment
+	for _, p := range payments {
+		if p.RefundOf == "" && original == nil {
+			original = p
+		}
+		remaining = remaining.Add(p.Amount)
+	}
+	return original, remaining, nil
+}
+
+// refund records amount of the original payment as refunded: a pending
+// negative payment linked to the original, its ledger entries and a job that
+// returns the money once the unit of work commits. remaining is what was
+// still refundable when read; the original's refunded total is only raised if
+// no other refund got in first. The platform fee, tax, seller earning and any
+// store credit the buyer paid with are reversed in proportion to the amount
+// refunded, and the credit goes back to the buyer's wallet at once. The
+// refund is in the buyer's currency at the rate they paid at. A payment still
+// held in escrow is settled first, closing the escrow as refunded. Callers
+// run it inside a unit of work.
+func (uc *orderUseCaseImpl) refund(ctx context.Context, original *payment.Payment, remaining, amount money.Money) (*payment.Payment, error) {
+	if original.Status == payment.StatusHeld {
+		e, err := uc.getEscrow(ctx, original.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.settleEscrow(ctx, e, original, escrow.StatusRefunded); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.paymentRepo.ReserveRefund(ctx, original.ID, original.Amount.Sub(remaining), amount); err != nil {
+		return nil, err
+	}
+
+	share := float64(amount.Amount) / float64(original.Amount.Amount)
+	creditBack := original.CreditAmount.Times(share)
+	refundEntry := &payment.Payment{
+		ID:            primitive.NewObjectID().Hex(),
+		FromUserID:    original.FromUserID,
+		ToUserID:      original.ToUserID,
+		Amount:        amount.Neg(),
+		CreditAmount:  creditBack.Neg(),
+		PlatformFee:   original.PlatformFee.Times(share).Neg(),
+		SellerEarning: original.SellerEarning.Times(share).Neg(),
+		Status:        payment.StatusRefundPending,
+		ReferenceID:   original.ReferenceID,
+		OrderID:       original.OrderID,
+		ChargeID:      original.ChargeID,
+		RefundOf:      original.ID,
+		Type:          original.Type,
+		FeeRule:       original.FeeRule,
+		FeeVersion:    original.FeeVersion,
+		CreatedAt:     time.Now().UTC(),
+		Original:      original.InOriginal(amount.Neg()),
+		ExchangeRate:  original.ExchangeRate,
+		Tax:           original.Tax.Times(share).Neg(),
+		TaxLines:      tax.ScaleLines(original.TaxLines, -share),
+	}
+	if err := uc.paymentRepo.RecordPayment(ctx, refundEntry); err != nil {
+		return nil, err
+	}
+	if err := uc.ledger.RecordPayment(ctx, refundEntry); err != nil {
+		return nil, err
+	}
+
+	if creditBack.Amount > 0 {
+		if err := uc.credits.Restore(ctx, original.FromUserID, creditBack.Major(), credit.EntryRefund, []string{original.OrderID}); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := uc.scheduler.Schedule(ctx, job.TypeSettleRefund, map[string]string{"payment_id": refundEntry.ID}, 0); err != nil {
+		return nil, err
+	}
+	return refundEntry, nil
+}
+
+// SettleRefund returns the money of a pending refund through the gateway,
+// keyed on the refund's ID so that a retry cannot refund twice, and marks the
+// refund refunded. Settled refunds are left alone.
+func (uc *orderUseCaseImpl) SettleRefund(ctx context.Context, refundID string) error {
+	r, err := uc.paymentRepo.GetPaymentByID(ctx, refundID)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return payment.ErrPaymentNotFound
+	}
+	return uc.settleRefund(ctx, r)
+}
+
+func (uc *orderUseCaseImpl) settleRefund(ctx context.Context, r *payment.Payment) error {
+	if r.Status != payment.StatusRefundPending {
+		return nil
+	}
+	// Refunds are negative; the part not given back as store credit goes
+	// through the gateway, in the currency the buyer was charged in.
+	if cash := r.CreditAmount.Sub(r.Amount); r.ChargeID != "" && cash.Amount > 0 {
+		gatewayCtx, cancel := context.WithTimeout(ctx, gatewayTimeout)
+		defer cancel()
+		if _, err := uc.gateway.Refund(gatewayCtx, r.ChargeID, r.InOriginal(cash), r.ID); err != nil {
+			return fmt.Errorf("refund failed: %w", err)
+		}
+	}
+	if err := uc.paymentRepo.UpdatePaymentStatus(ctx, r.ID, payment.StatusRefunded); err != nil {
+		return err
+	}
+	r.Status = payment.StatusRefunded
+	return nil
+}
+
+// trySettleRefund settles a refund straight after the unit of work that
+// recorded it commits. A refund the gateway cannot take yet stays pending
+// and is retried by the job queued with it.
+func (uc *orderUseCaseImpl) trySettleRefund(ctx context.Context, r *payment.Payment) {
+	if r == nil {
+		return
+	}
+	_ = uc.settleRefund(ctx, r)
+}
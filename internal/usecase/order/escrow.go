@@ -0,0 +1,207 @@
+package OrderUsecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+)
+
+// escrowDisputeWindow is how long a reseller has to dispute a bundle purchase
+// before its payment is released to the supplier if nobody confirmed the
+// bundle's arrival sooner.
+const escrowDisputeWindow = 72 * time.Hour
+
+func (uc *orderUseCaseImpl) GetEscrow(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	e, err := uc.getEscrow(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsParty(actorID, role) {
+		return nil, order.ErrNotOrderParty
+	}
+	return e, nil
+}
+
+func (uc *orderUseCaseImpl) DisputeEscrow(ctx context.Context, orderID, resellerID, reason string) (*escrow.Escrow, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, escrow.ErrDisputeReasonRequired
+	}
+	e, err := uc.getEscrow(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if e.ResellerID != resellerID {
+		return nil, order.ErrNotOrderParty
+	}
+	if e.Status != escrow.StatusHeld {
+		return nil, escrow.ErrNotHeld
+	}
+
+	e.Status = escrow.StatusDisputed
+	e.Dispute = &escrow.Dispute{Reason: reason, OpenedAt: time.Now()}
+	if err := uc.escrowRepo.UpdateEscrow(ctx, e, escrow.StatusHeld); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ResolveEscrow refunds everything still refundable on the order when refund
+// is set, and otherwise pays the supplier as if the bundle had arrived.
+func (uc *orderUseCaseImpl) ResolveEscrow(ctx context.Context, orderID, adminID string, refund bool, note string) (*escrow.Escrow, error) {
+	e, err := uc.getEscrow(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != escrow.StatusDisputed {
+		return nil, escrow.ErrNotDisputed
+	}
+	original, remaining, err := uc.refundableBalance(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, errors.New("order has no payment to settle")
+	}
+
+	now := time.Now()
+	e.Dispute.ResolvedBy = adminID
+	e.Dispute.Resolution = note
+	e.Dispute.ResolvedAt = &now
+	to := escrow.StatusReleased
+	if refund {
+		to = escrow.StatusRefunded
+	}
+
+	var refundEntry *payment.Payment
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		refundEntry = nil
+		if err := uc.settleEscrow(ctx, e, original, to); err != nil {
+			return err
+		}
+		if !refund || remaining.Amount <= 0 {
+			return nil
+		}
+		var err error
+		refundEntry, err = uc.refund(ctx, original, remaining, remaining)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.trySettleRefund(ctx, refundEntry)
+	return e, nil
+}
+
+// ConfirmArrival releases a held bundle payment once the buying reseller or
+// an admin confirms the bundle arrived and passed inspection, and records the
+// arrival on its warehouse item. A disputed escrow waits for its resolution
+// instead.
+func (uc *orderUseCaseImpl) ConfirmArrival(ctx context.Context, orderID, actorID string, role user.Role) (*escrow.Escrow, error) {
+	e, err := uc.getEscrow(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if role != user.RoleAdmin && (role != user.RoleReseller || e.ResellerID != actorID) {
+		return nil, order.ErrNotOrderParty
+	}
+	if e.Status != escrow.StatusHeld {
+		return nil, escrow.ErrNotHeld
+	}
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		err := uc.warehouseRepo.MarkItemAsArrived(ctx, e.WarehouseItemID, time.Now())
+		if err != nil && !errors.Is(err, warehouse.ErrAlreadyArrived) {
+			return err
+		}
+		return uc.release(ctx, e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ReleaseOnArrival pays out the escrow held for a warehouse item that has
+// just been unpacked. Items bought without an escrow, and escrows that are
+// disputed or already settled, are left alone.
+func (uc *orderUseCaseImpl) ReleaseOnArrival(ctx context.Context, warehouseItemID string) error {
+	e, err := uc.escrowRepo.GetEscrowByWarehouseItem(ctx, warehouseItemID)
+	if err != nil {
+		return err
+	}
+	if e == nil || e.Status != escrow.StatusHeld {
+		return nil
+	}
+	return uc.release(ctx, e)
+}
+
+// ReleaseEscrow pays a held bundle payment out to its supplier at the end of
+// the dispute window. It does nothing for orders without an escrow, whose
+// window is still running or whose escrow has been disputed or already
+// settled.
+func (uc *orderUseCaseImpl) ReleaseEscrow(ctx context.Context, orderID string) error {
+	e, err := uc.escrowRepo.GetEscrowByOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if e == nil || e.Status != escrow.StatusHeld || time.Now().Before(e.ReleaseBy) {
+		return nil
+	}
+	err = uc.release(ctx, e)
+	if errors.Is(err, escrow.ErrEscrowChanged) {
+		// A dispute was opened or the order was canceled in the meantime.
+		return nil
+	}
+	return err
+}
+
+// release pays a held escrow out to its supplier.
+func (uc *orderUseCaseImpl) release(ctx context.Context, e *escrow.Escrow) error {
+	original, _, err := uc.refundableBalance(ctx, e.OrderID)
+	if err != nil {
+		return err
+	}
+	if original == nil {
+		return errors.New("order has no payment to settle")
+	}
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return uc.settleEscrow(ctx, e, original, escrow.StatusReleased)
+	})
+}
+
+func (uc *orderUseCaseImpl) getEscrow(ctx context.Context, orderID string) (*escrow.Escrow, error) {
+	e, err := uc.escrowRepo.GetEscrowByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, escrow.ErrEscrowNotFound
+	}
+	return e, nil
+}
+
+// settleEscrow closes an open escrow. The held payment becomes an ordinary
+// paid sale either way; a refund to the buyer is then issued against it like
+// any other. Callers run it inside a unit of work.
+func (uc *orderUseCaseImpl) settleEscrow(ctx context.Context, e *escrow.Escrow, held *payment.Payment, to escrow.Status) error {
+	from := e.Status
+	if err := uc.paymentRepo.UpdatePaymentStatus(ctx, held.ID, payment.StatusPaid); err != nil {
+		return err
+	}
+	held.Status = payment.StatusPaid
+	if err := uc.ledger.RecordRelease(ctx, held); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	e.Status = to
+	e.ClosedAt = &now
+	return uc.escrowRepo.UpdateEscrow(ctx, e, from)
+}
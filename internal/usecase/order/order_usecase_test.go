@@ -7,10 +7,21 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/credit"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/gateway"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -41,14 +52,19 @@ func (m *MockBundleRepo) ListBundles(ctx context.Context, supplierID string) ([]
 	return args.Get(0).([]*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockBundleRepo) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
+func (m *MockBundleRepo) ListAvailableBundles(ctx context.Context, q *bundle.Query, after *bundle.Cursor, limit int) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, q, after, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*bundle.Bundle), args.Error(1)
 }
 
+func (m *MockBundleRepo) UpdateSupplierTrust(ctx context.Context, supplierID string, score int) error {
+	args := m.Called(ctx, supplierID, score)
+	return args.Error(0)
+}
+
 func (m *MockBundleRepo) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
 	args := m.Called(ctx, resellerID)
 	if args.Get(0) == nil {
@@ -67,6 +83,21 @@ func (m *MockBundleRepo) MarkAsPurchased(ctx context.Context, bundleID string, r
 	return args.Error(0)
 }
 
+func (m *MockBundleRepo) SetListingMode(ctx context.Context, bundleID string, mode bundle.ListingMode, now time.Time) error {
+	args := m.Called(ctx, bundleID, mode, now)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) Reserve(ctx context.Context, bundleID string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, bundleID, r, now)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepo) Unreserve(ctx context.Context, bundleID, offerID string) error {
+	args := m.Called(ctx, bundleID, offerID)
+	return args.Error(0)
+}
+
 func (m *MockBundleRepo) DeleteBundle(ctx context.Context, bundleID string) error {
 	args := m.Called(ctx, bundleID)
 	return args.Error(0)
@@ -107,8 +138,8 @@ func (m *MockOrderRepo) GetOrderByID(ctx context.Context, orderID string) (*orde
 	return args.Get(0).(*order.Order), args.Error(1)
 }
 
-func (m *MockOrderRepo) UpdateOrderStatus(ctx context.Context, orderID string, status order.OrderStatus) error {
-	args := m.Called(ctx, orderID, status)
+func (m *MockOrderRepo) TransitionStatus(ctx context.Context, orderID string, t order.StatusTransition) error {
+	args := m.Called(ctx, orderID, t)
 	return args.Error(0)
 }
 
@@ -167,6 +198,19 @@ func (m *MockWarehouseRepo) MarkItemAsSkipped(ctx context.Context, itemID string
 	args := m.Called(ctx, itemID)
 	return args.Error(0)
 }
+
+func (m *MockWarehouseRepo) MarkItemAsArrived(ctx context.Context, itemID string, at time.Time) error {
+	args := m.Called(ctx, itemID, at)
+	return args.Error(0)
+}
+
+func (m *MockWarehouseRepo) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
 func (m *MockWarehouseRepo) CountByStatus(ctx context.Context, status string) (int, error) {
 	args := m.Called(ctx, status)
 	return args.Int(0), args.Error(1)
@@ -203,6 +247,58 @@ func (m *MockPaymentRepo) GetAllPlatformFees(ctx context.Context) (float64, floa
 	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
 }
 
+func (m *MockPaymentRepo) ListUnsettledPayments(ctx context.Context) ([]*payment.Payment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) ListPaymentsBetween(ctx context.Context, from, to time.Time) ([]*payment.Payment, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) AssignPayout(ctx context.Context, paymentIDs []string, payoutID string) error {
+	args := m.Called(ctx, paymentIDs, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) ReleasePayout(ctx context.Context, payoutID string) error {
+	args := m.Called(ctx, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) UpdatePaymentStatus(ctx context.Context, id, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByOrder(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentByID(ctx context.Context, id string) (*payment.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) ReserveRefund(ctx context.Context, id string, refunded, amount money.Money) error {
+	args := m.Called(ctx, id, refunded, amount)
+	return args.Error(0)
+}
+
 func (m *MockPaymentRepo) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
 	args := m.Called(ctx, userID, pType)
 	if args.Get(0) == nil {
@@ -283,6 +379,224 @@ func (m *MockBundleRepo) CountBundles(ctx context.Context) (int, error) {
 	return args.Int(0), args.Error(1)
 }
 
+type MockProductRepo struct {
+	mock.Mock
+}
+
+func (m *MockProductRepo) AddProduct(ctx context.Context, p *product.Product) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) GetProductByID(ctx context.Context, id string) (*product.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
+	args := m.Called(ctx, resellerID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) SearchProducts(ctx context.Context, q *product.Query) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
+func (m *MockProductRepo) DeleteProduct(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Relist(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Reserve(ctx context.Context, id string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, id, r, now)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Unreserve(ctx context.Context, id, offerID string) error {
+	args := m.Called(ctx, id, offerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
+	args := m.Called(ctx, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+type MockEscrowRepo struct {
+	mock.Mock
+}
+
+func (m *MockEscrowRepo) CreateEscrow(ctx context.Context, e *escrow.Escrow) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockEscrowRepo) GetEscrowByOrder(ctx context.Context, orderID string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockEscrowRepo) GetEscrowByWarehouseItem(ctx context.Context, itemID string) (*escrow.Escrow, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*escrow.Escrow), args.Error(1)
+}
+
+func (m *MockEscrowRepo) UpdateEscrow(ctx context.Context, e *escrow.Escrow, from escrow.Status) error {
+	args := m.Called(ctx, e, from)
+	return args.Error(0)
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// defaultFees quotes with the built-in policy for a standard-tier seller.
+type defaultFees struct{}
+
+func (defaultFees) Quote(ctx context.Context, s fee.Sale) (fee.Quote, error) {
+	return fee.DefaultPolicy().Quote(s, fee.TierStandard), nil
+}
+
+// recordingLedger keeps posted payments and escrow releases in memory and
+// serves fixed balances.
+type recordingLedger struct {
+	payments []*payment.Payment
+	releases []*payment.Payment
+	balances map[string]*ledger.Balance
+}
+
+func (l *recordingLedger) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	l.payments = append(l.payments, p)
+	return nil
+}
+
+func (l *recordingLedger) RecordRelease(ctx context.Context, p *payment.Payment) error {
+	l.releases = append(l.releases, p)
+	return nil
+}
+
+func (l *recordingLedger) RecordPayout(ctx context.Context, sellerID, payoutID string, amount float64) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordCreditIssued(ctx context.Context, userID string, amount float64, memo string) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordGiftCardRedeemed(ctx context.Context, userID, code string, amount float64) error {
+	return nil
+}
+
+func (l *recordingLedger) GetBalance(ctx context.Context, userID string) (*ledger.Balance, error) {
+	if b, ok := l.balances[userID]; ok {
+		return b, nil
+	}
+	return &ledger.Balance{UserID: userID}, nil
+}
+
+// recordingCredits keeps store credit given back to buyers in memory.
+type recordingCredits struct {
+	restored map[string]float64
+}
+
+func (c *recordingCredits) Spend(ctx context.Context, userID string, amount float64, orderIDs []string) (float64, error) {
+	return 0, nil
+}
+
+func (c *recordingCredits) Restore(ctx context.Context, userID string, amount float64, t credit.EntryType, orderIDs []string) error {
+	if c.restored == nil {
+		c.restored = make(map[string]float64)
+	}
+	c.restored[userID] += amount
+	return nil
+}
+
+// fixedRates is an exchange-rate table that never changes.
+type fixedRates money.Rates
+
+func (r fixedRates) Rates(ctx context.Context) (money.Rates, error) {
+	return money.Rates(r), nil
+}
+
+// flatTax assesses every sale with one policy; the zero value taxes nothing.
+type flatTax struct {
+	policy tax.Policy
+}
+
+func (t *flatTax) Assess(ctx context.Context, s tax.Sale) (tax.Assessment, error) {
+	return t.policy.Assess(s), nil
+}
+
+// recordingInvoices keeps issued invoices in memory.
+type recordingInvoices struct {
+	issued []*invoice.Invoice
+}
+
+func (r *recordingInvoices) IssueInvoice(ctx context.Context, o *order.Order, paid *payment.Payment) (*invoice.Invoice, error) {
+	inv := &invoice.Invoice{ID: o.ID, OrderID: o.ID, PaymentID: paid.ID}
+	r.issued = append(r.issued, inv)
+	return inv, nil
+}
+
+// recordingScheduler keeps scheduled jobs in memory instead of queueing them.
+type recordingScheduler struct {
+	jobs []*job.Job
+}
+
+func (s *recordingScheduler) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	j := &job.Job{Type: jobType, Payload: payload, RunAt: time.Now().Add(delay)}
+	s.jobs = append(s.jobs, j)
+	return j, nil
+}
+
 // Test Cases
 func TestNewOrderUsecase(t *testing.T) {
 	// Arrange
@@ -293,7 +607,7 @@ func TestNewOrderUsecase(t *testing.T) {
 	mockUserRepo := new(MockUserRepo)
 
 	// Act
-	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo)
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo, new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
 
 	// Assert
 	assert.NotNil(t, useCase)
@@ -316,7 +630,7 @@ func TestPurchaseBundle(t *testing.T) {
 			mockBundle: &bundle.Bundle{
 				ID:         "bundle1",
 				SupplierID: "supplier1",
-				Price:      100.0,
+				Price:      money.InSettlement(100.0),
 				Status:     "available",
 			},
 			mockError:   nil,
@@ -338,7 +652,7 @@ func TestPurchaseBundle(t *testing.T) {
 			mockBundle: &bundle.Bundle{
 				ID:         "bundle1",
 				SupplierID: "supplier1",
-				Price:      100.0,
+				Price:      money.InSettlement(100.0),
 				Status:     "available",
 			},
 			mockError:    nil,
@@ -355,20 +669,26 @@ func TestPurchaseBundle(t *testing.T) {
 			mockWarehouseRepo := new(MockWarehouseRepo)
 			mockPaymentRepo := new(MockPaymentRepo)
 			mockUserRepo := new(MockUserRepo)
-			useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo)
+			scheduler := &recordingScheduler{}
+			ledgerUC := &recordingLedger{}
+			mockEscrowRepo := new(MockEscrowRepo)
+			invoices := &recordingInvoices{}
+			useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo, new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), scheduler, defaultFees{}, ledgerUC, mockEscrowRepo, &recordingCredits{}, fixedRates{}, &flatTax{}, invoices)
 			ctx := context.Background()
 
 			mockBundleRepo.On("GetBundleByID", ctx, tt.bundleID).Return(tt.mockBundle, tt.mockError)
-			if tt.mockBundle != nil {
-				mockBundleRepo.On("ListAvailableBundles", ctx).Return([]*bundle.Bundle{tt.mockBundle}, nil)
-			}
 
 			if !tt.expectError {
 				mockOrderRepo.On("CreateOrder", ctx, mock.AnythingOfType("*order.Order")).Return(nil)
 				mockPaymentRepo.On("RecordPayment", ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
 				mockBundleRepo.On("MarkAsPurchased", ctx, tt.bundleID, tt.resellerID).Return(nil)
 				mockWarehouseRepo.On("AddItem", ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(nil)
-				mockOrderRepo.On("UpdateOrderStatus", ctx, mock.AnythingOfType("string"), order.OrderStatus("completed")).Return(nil)
+				mockEscrowRepo.On("CreateEscrow", ctx, mock.MatchedBy(func(e *escrow.Escrow) bool {
+					return e.Status == escrow.StatusHeld && e.PaymentID != "" && e.Amount == money.InSettlement(tt.mockBundle.Price.Major())
+				})).Return(nil)
+				mockOrderRepo.On("TransitionStatus", ctx, mock.AnythingOfType("string"), mock.MatchedBy(func(t order.StatusTransition) bool {
+					return t.From == order.OrderStatusProcessing && t.To == order.OrderStatusCompleted
+				})).Return(nil)
 			}
 
 			// Act
@@ -381,13 +701,33 @@ func TestPurchaseBundle(t *testing.T) {
 				assert.Nil(t, order)
 				assert.Nil(t, payment)
 				assert.Nil(t, warehouseItem)
+				assert.Empty(t, invoices.issued)
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, order)
 				assert.NotNil(t, payment)
 				assert.NotNil(t, warehouseItem)
+				assert.NotEmpty(t, payment.ChargeID)
+				assert.Equal(t, fee.RuleB2BDefault, payment.FeeRule)
+				assert.Equal(t, tt.mockBundle.Price.Times(0.02), payment.PlatformFee)
+				if assert.Len(t, ledgerUC.payments, 1) {
+					assert.Same(t, payment, ledgerUC.payments[0])
+				}
+				assert.Equal(t, "held", payment.Status)
+				assert.Equal(t, tt.mockBundle.Title, order.Titles[tt.bundleID])
+				if assert.Len(t, invoices.issued, 1) {
+					assert.Equal(t, order.ID, invoices.issued[0].OrderID)
+					assert.Equal(t, payment.ID, invoices.issued[0].PaymentID)
+				}
+				if assert.Len(t, scheduler.jobs, 2) {
+					assert.Equal(t, job.TypeListWarehouseItem, scheduler.jobs[0].Type)
+					assert.Equal(t, warehouseItem.ID, scheduler.jobs[0].Payload["item_id"])
+					assert.Equal(t, job.TypeReleaseEscrow, scheduler.jobs[1].Type)
+					assert.Equal(t, order.ID, scheduler.jobs[1].Payload["order_id"])
+				}
 			}
 			mockBundleRepo.AssertExpectations(t)
+			mockEscrowRepo.AssertExpectations(t)
 			mockOrderRepo.AssertExpectations(t)
 			mockWarehouseRepo.AssertExpectations(t)
 			mockPaymentRepo.AssertExpectations(t)
@@ -395,6 +735,664 @@ func TestPurchaseBundle(t *testing.T) {
 	}
 }
 
+func TestPurchaseBundle_PricedInUSD(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	mockWarehouseRepo := new(MockWarehouseRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	mockEscrowRepo := new(MockEscrowRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, mockEscrowRepo, &recordingCredits{}, fixedRates{money.USD: 57.5}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.FromMajor(10, money.USD), Status: "available"}, nil)
+	mockBundleRepo.On("MarkAsPurchased", ctx, "bundle1", "reseller1").Return(nil)
+	mockOrderRepo.On("CreateOrder", ctx, mock.Anything).Return(nil)
+	mockOrderRepo.On("TransitionStatus", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.Anything).Return(nil)
+	mockWarehouseRepo.On("AddItem", ctx, mock.Anything).Return(nil)
+	mockEscrowRepo.On("CreateEscrow", ctx, mock.MatchedBy(func(e *escrow.Escrow) bool { return e.Amount == money.InSettlement(575) })).Return(nil)
+
+	o, p, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.InSettlement(575.0), o.TotalPrice)
+	assert.Equal(t, &money.Money{Amount: 1000, Currency: money.USD}, o.Charged)
+	assert.Equal(t, money.InSettlement(575.0), p.Amount)
+	assert.Equal(t, money.InSettlement(11.5), p.PlatformFee)
+	assert.Equal(t, money.Money{Amount: 1000, Currency: money.USD}, p.Original)
+	assert.Equal(t, 57.5, p.ExchangeRate)
+	charge, _ := fakeGateway.GetCharge(p.ChargeID)
+	assert.Equal(t, money.Money{Amount: 1000, Currency: money.USD}, charge.Amount)
+}
+
+func TestPurchaseBundle_NoExchangeRate(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	useCase := NewOrderUsecase(mockBundleRepo, new(MockOrderRepo), new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.FromMajor(10, money.USD), Status: "available"}, nil)
+
+	_, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.ErrorIs(t, err, money.ErrNoRate)
+	mockBundleRepo.AssertNotCalled(t, "MarkAsPurchased", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPurchaseBundle_AddsTax(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	mockWarehouseRepo := new(MockWarehouseRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	mockEscrowRepo := new(MockEscrowRepo)
+	taxes := &flatTax{policy: tax.Policy{Mode: tax.ModeExclusive, Rules: []tax.Rule{
+		{Name: "b2c_vat", SaleType: tax.SaleB2C, Rate: 0.15},
+		{Name: "b2b_vat", SaleType: tax.SaleB2B, Rate: 0.1},
+	}}}
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, mockEscrowRepo, &recordingCredits{}, fixedRates{}, taxes, &recordingInvoices{})
+	ctx := context.Background()
+
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(200), Status: "available"}, nil)
+	mockBundleRepo.On("MarkAsPurchased", ctx, "bundle1", "reseller1").Return(nil)
+	mockOrderRepo.On("CreateOrder", ctx, mock.Anything).Return(nil)
+	mockOrderRepo.On("TransitionStatus", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.Anything).Return(nil)
+	mockWarehouseRepo.On("AddItem", ctx, mock.Anything).Return(nil)
+	mockEscrowRepo.On("CreateEscrow", ctx, mock.MatchedBy(func(e *escrow.Escrow) bool { return e.Amount == money.InSettlement(220) })).Return(nil)
+
+	o, p, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.InSettlement(220.0), o.TotalPrice)
+	assert.Equal(t, money.InSettlement(20.0), o.Tax)
+	assert.Equal(t, money.InSettlement(220.0), p.Amount)
+	assert.Equal(t, money.InSettlement(20.0), p.Tax)
+	assert.Equal(t, money.InSettlement(4.0), p.PlatformFee)
+	assert.Equal(t, money.InSettlement(196.0), p.SellerEarning)
+	assert.Len(t, p.TaxLines, 1)
+	assert.Equal(t, "b2b_vat", p.TaxLines[0].Rule)
+	assert.Equal(t, &money.Money{Amount: 22000, Currency: money.ETB}, o.Charged)
+}
+
+func TestPurchaseBundle_AlreadySold(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	mockWarehouseRepo := new(MockWarehouseRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	mockUserRepo := new(MockUserRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo, new(MockProductRepo), unitOfWork, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(100.0), Status: "available"}
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(b, nil)
+	// Another reseller bought the bundle after it was read, so the conditional update matches nothing.
+	mockBundleRepo.On("MarkAsPurchased", ctx, "bundle1", "reseller1").Return(bundle.ErrNotAvailable)
+
+	o, p, item, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.ErrorIs(t, err, bundle.ErrNotAvailable)
+	assert.Nil(t, o)
+	assert.Nil(t, p)
+	assert.Nil(t, item)
+	assert.Equal(t, 1, unitOfWork.calls)
+	mockOrderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	mockPaymentRepo.AssertNotCalled(t, "RecordPayment", mock.Anything, mock.Anything)
+	mockWarehouseRepo.AssertNotCalled(t, "AddItem", mock.Anything, mock.Anything)
+}
+
+func TestPurchaseBundle_NotAvailable(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	useCase := NewOrderUsecase(mockBundleRepo, new(MockOrderRepo), new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), unitOfWork, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(100.0), Status: "purchased"}
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(b, nil)
+
+	_, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.ErrorIs(t, err, bundle.ErrNotAvailable)
+	assert.Equal(t, 0, unitOfWork.calls)
+	mockBundleRepo.AssertNotCalled(t, "MarkAsPurchased", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPurchaseBundle_Auctioned(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	useCase := NewOrderUsecase(mockBundleRepo, new(MockOrderRepo), new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), unitOfWork, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(100.0), Status: "available", ListingMode: bundle.Auctioned}
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(b, nil)
+
+	_, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.ErrorIs(t, err, bundle.ErrAuctioned)
+	assert.Equal(t, 0, unitOfWork.calls)
+}
+
+func TestPurchaseAuctionedBundle_ChargesWinningBid(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	mockWarehouseRepo := new(MockWarehouseRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	mockEscrowRepo := new(MockEscrowRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, mockEscrowRepo, &recordingCredits{}, fixedRates{money.USD: 57.5}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	mockOrderRepo.On("GetOrderByID", ctx, "auc1").Return(nil, nil)
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.FromMajor(10, money.USD), Status: "available", ListingMode: bundle.Auctioned}, nil)
+	mockBundleRepo.On("MarkAsPurchased", ctx, "bundle1", "reseller1").Return(nil)
+	mockOrderRepo.On("CreateOrder", ctx, mock.Anything).Return(nil)
+	mockOrderRepo.On("TransitionStatus", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.Anything).Return(nil)
+	mockWarehouseRepo.On("AddItem", ctx, mock.Anything).Return(nil)
+	mockEscrowRepo.On("CreateEscrow", ctx, mock.Anything).Return(nil)
+
+	o, err := useCase.PurchaseAuctionedBundle(ctx, "auc1", "bundle1", "reseller1", money.FromMajor(12, money.USD))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "auc1", o.ID)
+	assert.Equal(t, money.InSettlement(690.0), o.TotalPrice)
+	assert.Equal(t, &money.Money{Amount: 1200, Currency: money.USD}, o.Charged)
+}
+
+func TestPurchaseAuctionedBundle_Retried(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), unitOfWork, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	placed := &order.Order{ID: "auc1", BundleID: "bundle1", ResellerID: "reseller1"}
+	mockOrderRepo.On("GetOrderByID", ctx, "auc1").Return(placed, nil)
+
+	o, err := useCase.PurchaseAuctionedBundle(ctx, "auc1", "bundle1", "reseller1", money.FromMajor(12, money.USD))
+
+	assert.NoError(t, err)
+	assert.Same(t, placed, o)
+	assert.Equal(t, 0, unitOfWork.calls)
+	mockBundleRepo.AssertNotCalled(t, "GetBundleByID", mock.Anything, mock.Anything)
+}
+
+func TestPurchaseBundle_ReservedForAnotherReseller(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	useCase := NewOrderUsecase(mockBundleRepo, new(MockOrderRepo), new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), unitOfWork, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(100.0), Status: "available",
+		Reservation: &offer.Reservation{OfferID: "offer1", BuyerID: "reseller2", Price: money.InSettlement(80), Until: time.Now().Add(time.Hour)}}
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(b, nil)
+
+	_, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.ErrorIs(t, err, offer.ErrReserved)
+	assert.Equal(t, 0, unitOfWork.calls)
+}
+
+func TestPurchaseBundle_ReservedChargesAgreedPrice(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	mockWarehouseRepo := new(MockWarehouseRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	mockEscrowRepo := new(MockEscrowRepo)
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, mockEscrowRepo, &recordingCredits{}, fixedRates{money.USD: 57.5}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.FromMajor(10, money.USD), Status: "available",
+		Reservation: &offer.Reservation{OfferID: "offer1", BuyerID: "reseller1", Price: money.FromMajor(8, money.USD), Until: time.Now().Add(time.Hour)}}, nil)
+	mockBundleRepo.On("MarkAsPurchased", ctx, "bundle1", "reseller1").Return(nil)
+	mockOrderRepo.On("CreateOrder", ctx, mock.Anything).Return(nil)
+	mockOrderRepo.On("TransitionStatus", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.Anything).Return(nil)
+	mockWarehouseRepo.On("AddItem", ctx, mock.Anything).Return(nil)
+	mockEscrowRepo.On("CreateEscrow", ctx, mock.Anything).Return(nil)
+
+	o, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.InSettlement(460.0), o.TotalPrice)
+	assert.Equal(t, &money.Money{Amount: 800, Currency: money.USD}, o.Charged)
+}
+
+func TestPurchaseBundle_PaymentDeclined(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	fakeGateway := gateway.NewFakeGateway("secret")
+	fakeGateway.Decline = true
+	useCase := NewOrderUsecase(mockBundleRepo, new(MockOrderRepo), new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), unitOfWork, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(100.0), Status: "available"}
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(b, nil)
+
+	_, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.ErrorIs(t, err, payment.ErrPaymentDeclined)
+	assert.Equal(t, 0, unitOfWork.calls)
+}
+
+func TestPurchaseBundle_GatewayTimeout(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	fakeGateway := gateway.NewFakeGateway("secret")
+	fakeGateway.Delay = time.Second
+	useCase := NewOrderUsecase(mockBundleRepo, new(MockOrderRepo), new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), unitOfWork, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(100.0), Status: "available"}
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(b, nil)
+
+	_, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, unitOfWork.calls)
+}
+
+func TestPurchaseBundle_RefundsWhenWritesFail(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(100.0), Status: "available"}
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(b, nil)
+	mockBundleRepo.On("MarkAsPurchased", ctx, "bundle1", "reseller1").Return(nil)
+	mockOrderRepo.On("CreateOrder", ctx, mock.AnythingOfType("*order.Order")).Return(errors.New("write conflict"))
+
+	_, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.EqualError(t, err, "write conflict")
+	charge, ok := fakeGateway.GetCharge("ch_fake_000001")
+	assert.True(t, ok)
+	assert.Equal(t, payment.ChargeRefunded, charge.Status)
+}
+
+func TestPurchaseBundle_QueuesRefundTheGatewayRejects(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	scheduler := &recordingScheduler{}
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, scheduler, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.InSettlement(100.0), Status: "available"}
+	mockBundleRepo.On("GetBundleByID", ctx, "bundle1").Return(b, nil)
+	mockBundleRepo.On("MarkAsPurchased", ctx, "bundle1", "reseller1").Return(nil)
+	// The gateway goes down after charging, so the refund fails too.
+	mockOrderRepo.On("CreateOrder", ctx, mock.AnythingOfType("*order.Order")).
+		Run(func(mock.Arguments) { fakeGateway.Fail = payment.ErrGatewayUnavailable }).
+		Return(errors.New("write conflict"))
+
+	_, _, _, err := useCase.PurchaseBundle(ctx, "bundle1", "reseller1")
+
+	assert.EqualError(t, err, "write conflict")
+	if assert.Len(t, scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeRefundCharge, scheduler.jobs[0].Type)
+		assert.Equal(t, map[string]string{"charge_id": "ch_fake_000001", "amount": "100", "currency": "ETB"}, scheduler.jobs[0].Payload)
+	}
+}
+
+// capturedCharge takes amount through the fake gateway so that tests can refund it.
+func capturedCharge(t *testing.T, g *gateway.FakeGateway, amount float64) string {
+	charge, err := g.Authorize(context.Background(), money.InSettlement(amount), "ref")
+	assert.NoError(t, err)
+	_, err = g.Capture(context.Background(), charge.ID)
+	assert.NoError(t, err)
+	return charge.ID
+}
+
+func TestCancelOrder_ConsumerOrder(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	mockProductRepo := new(MockProductRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	unitOfWork := &passthroughUnitOfWork{}
+	ledgerUC := &recordingLedger{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), mockProductRepo, unitOfWork, fakeGateway, &recordingScheduler{}, defaultFees{}, ledgerUC, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 100.0)
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", ProductIDs: []string{"p1", "p2"}, Status: order.OrderStatusPending}
+	paid := &payment.Payment{ID: "pay1", FromUserID: "consumer1", ToUserID: "reseller1", Amount: money.InSettlement(100.0), PlatformFee: money.InSettlement(2.0), SellerEarning: money.InSettlement(98.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusPaid, Type: payment.B2C}
+
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	mockOrderRepo.On("TransitionStatus", ctx, "order1", mock.MatchedBy(func(t order.StatusTransition) bool {
+		return t.From == order.OrderStatusPending && t.To == order.OrderStatusCanceled && t.ActorID == "consumer1" && t.Reason == "changed my mind"
+	})).Return(nil)
+	mockProductRepo.On("Relist", ctx, "p1").Return(nil)
+	mockProductRepo.On("Relist", ctx, "p2").Return(nil)
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(0), money.InSettlement(100.0)).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(-100.0) && p.PlatformFee == money.InSettlement(-2.0) && p.RefundOf == "pay1" && p.Status == payment.StatusRefundPending
+	})).Return(nil)
+	mockPaymentRepo.On("UpdatePaymentStatus", ctx, mock.AnythingOfType("string"), payment.StatusRefunded).Return(nil)
+
+	canceled, err := useCase.CancelOrder(ctx, "order1", "consumer1", user.RoleConsumer, "changed my mind")
+
+	assert.NoError(t, err)
+	assert.Equal(t, order.OrderStatusCanceled, canceled.Status)
+	assert.Equal(t, "changed my mind", canceled.History[len(canceled.History)-1].Reason)
+	assert.Equal(t, 1, unitOfWork.calls)
+	if assert.Len(t, ledgerUC.payments, 1) {
+		assert.Equal(t, money.InSettlement(-100.0), ledgerUC.payments[0].Amount)
+	}
+	charge, _ := fakeGateway.GetCharge(chargeID)
+	assert.Equal(t, payment.ChargeRefunded, charge.Status)
+	mockOrderRepo.AssertExpectations(t)
+	mockPaymentRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestCancelOrder_ShippedOrderIsNotRelisted(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	mockProductRepo := new(MockProductRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), mockProductRepo, &passthroughUnitOfWork{}, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 100.0)
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", ProductIDs: []string{"p1"}, Status: order.OrderStatusShipped}
+	paid := &payment.Payment{ID: "pay1", FromUserID: "consumer1", ToUserID: "reseller1", Amount: money.InSettlement(100.0), PlatformFee: money.InSettlement(2.0), SellerEarning: money.InSettlement(98.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusPaid, Type: payment.B2C}
+
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	mockOrderRepo.On("TransitionStatus", ctx, "order1", mock.Anything).Return(nil)
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(0), money.InSettlement(100.0)).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.Anything).Return(nil)
+	mockPaymentRepo.On("UpdatePaymentStatus", ctx, mock.AnythingOfType("string"), payment.StatusRefunded).Return(nil)
+
+	canceled, err := useCase.CancelOrder(ctx, "order1", "admin1", user.RoleAdmin, "lost in transit")
+
+	assert.NoError(t, err)
+	assert.Equal(t, order.OrderStatusCanceled, canceled.Status)
+	mockProductRepo.AssertNotCalled(t, "Relist", mock.Anything, mock.Anything)
+	mockPaymentRepo.AssertExpectations(t)
+}
+
+func TestCancelOrder_Rules(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   *order.Order
+		actorID string
+		role    user.Role
+		wantErr error
+	}{
+		{
+			name:    "Consumer cannot cancel a delivered order",
+			order:   &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusDelivered},
+			actorID: "consumer1",
+			role:    user.RoleConsumer,
+			wantErr: order.ErrCannotCancel,
+		},
+		{
+			name:    "Another consumer is not a party",
+			order:   &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusPending},
+			actorID: "consumer2",
+			role:    user.RoleConsumer,
+			wantErr: order.ErrNotOrderParty,
+		},
+		{
+			name:    "Supplier is not a party to a consumer order",
+			order:   &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", SupplierID: "supplier1", Status: order.OrderStatusProcessing},
+			actorID: "supplier1",
+			role:    user.RoleSupplier,
+			wantErr: order.ErrNotOrderParty,
+		},
+		{
+			name:    "Canceled order cannot be canceled again",
+			order:   &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusCanceled},
+			actorID: "consumer1",
+			role:    user.RoleConsumer,
+			wantErr: order.ErrAlreadyCanceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrderRepo := new(MockOrderRepo)
+			unitOfWork := &passthroughUnitOfWork{}
+			useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), unitOfWork, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+			ctx := context.Background()
+
+			mockOrderRepo.On("GetOrderByID", ctx, tt.order.ID).Return(tt.order, nil)
+
+			_, err := useCase.CancelOrder(ctx, tt.order.ID, tt.actorID, tt.role, "")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, 0, unitOfWork.calls)
+		})
+	}
+}
+
+func TestCancelOrder_BundleOrder(t *testing.T) {
+	mockBundleRepo := new(MockBundleRepo)
+	mockOrderRepo := new(MockOrderRepo)
+	mockWarehouseRepo := new(MockWarehouseRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 100.0)
+	o := &order.Order{ID: "order1", BundleID: "bundle1", ResellerID: "reseller1", SupplierID: "supplier1", Status: order.OrderStatusCompleted}
+	paid := &payment.Payment{ID: "pay1", FromUserID: "reseller1", ToUserID: "supplier1", Amount: money.InSettlement(100.0), PlatformFee: money.InSettlement(2.0), SellerEarning: money.InSettlement(98.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusPaid, Type: payment.B2B}
+
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockWarehouseRepo.On("GetItemsByBundle", ctx, "bundle1").Return([]*warehouse.WarehouseItem{{ID: "item1", BundleID: "bundle1", ResellerID: "reseller1", Status: "pending"}}, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	mockOrderRepo.On("TransitionStatus", ctx, "order1", mock.AnythingOfType("order.StatusTransition")).Return(nil)
+	mockBundleRepo.On("UpdateBundleStatus", ctx, "bundle1", "available").Return(nil)
+	mockWarehouseRepo.On("DeleteItem", ctx, "item1").Return(nil)
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(0), money.InSettlement(100.0)).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	mockPaymentRepo.On("UpdatePaymentStatus", ctx, mock.AnythingOfType("string"), payment.StatusRefunded).Return(nil)
+
+	_, err := useCase.CancelOrder(ctx, "order1", "supplier1", user.RoleSupplier, "out of stock")
+
+	assert.NoError(t, err)
+	mockBundleRepo.AssertExpectations(t)
+	mockWarehouseRepo.AssertExpectations(t)
+	mockPaymentRepo.AssertExpectations(t)
+}
+
+func TestCancelOrder_BundleAlreadyArrived(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockWarehouseRepo := new(MockWarehouseRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, mockWarehouseRepo, new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), unitOfWork, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	o := &order.Order{ID: "order1", BundleID: "bundle1", ResellerID: "reseller1", SupplierID: "supplier1", Status: order.OrderStatusCompleted}
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockWarehouseRepo.On("GetItemsByBundle", ctx, "bundle1").Return([]*warehouse.WarehouseItem{{ID: "item1", BundleID: "bundle1", ResellerID: "reseller1", Status: "listed"}}, nil)
+
+	_, err := useCase.CancelOrder(ctx, "order1", "reseller1", user.RoleReseller, "")
+
+	assert.ErrorIs(t, err, order.ErrCannotCancel)
+	assert.Equal(t, 0, unitOfWork.calls)
+}
+
+func TestRefundOrder_Partial(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	unitOfWork := &passthroughUnitOfWork{}
+	ledgerUC := &recordingLedger{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), unitOfWork, fakeGateway, &recordingScheduler{}, defaultFees{}, ledgerUC, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 100.0)
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusDelivered}
+	paid := &payment.Payment{ID: "pay1", Amount: money.InSettlement(100.0), PlatformFee: money.InSettlement(2.0), SellerEarning: money.InSettlement(98.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusPaid}
+	earlier := &payment.Payment{ID: "pay2", Amount: money.InSettlement(-30.0), OrderID: "order1", RefundOf: "pay1", Status: payment.StatusRefunded}
+
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{paid, earlier}, nil)
+	// The earlier refund is what the original has been refunded so far.
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(30.0), money.InSettlement(25.0)).Return(nil).Once()
+	mockPaymentRepo.On("RecordPayment", ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	mockPaymentRepo.On("UpdatePaymentStatus", ctx, mock.AnythingOfType("string"), payment.StatusRefunded).Return(nil)
+
+	refund, err := useCase.RefundOrder(ctx, "order1", "reseller1", user.RoleReseller, 25.0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, payment.StatusRefunded, refund.Status)
+	assert.Equal(t, money.InSettlement(-25.0), refund.Amount)
+	assert.Equal(t, money.InSettlement(-0.5), refund.PlatformFee)
+	assert.Equal(t, "pay1", refund.RefundOf)
+	assert.Equal(t, 1, unitOfWork.calls)
+	assert.Equal(t, []*payment.Payment{refund}, ledgerUC.payments)
+	charge, _ := fakeGateway.GetCharge(chargeID)
+	assert.Equal(t, payment.ChargeCaptured, charge.Status)
+	assert.Equal(t, money.InSettlement(25.0), charge.Refunded)
+
+	// Only 70 of the original 100 had been left before this refund.
+	_, err = useCase.RefundOrder(ctx, "order1", "reseller1", user.RoleReseller, 80.0)
+	assert.ErrorIs(t, err, order.ErrInvalidRefundAmount)
+}
+
+func TestRefundOrder_PaidPartlyWithCredit(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	credits := &recordingCredits{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), credits, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 60.0)
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusDelivered}
+	paid := &payment.Payment{ID: "pay1", FromUserID: "consumer1", Amount: money.InSettlement(100.0), CreditAmount: money.InSettlement(40.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusPaid}
+
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(0), money.InSettlement(50.0)).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	mockPaymentRepo.On("UpdatePaymentStatus", ctx, mock.AnythingOfType("string"), payment.StatusRefunded).Return(nil)
+
+	refund, err := useCase.RefundOrder(ctx, "order1", "reseller1", user.RoleReseller, 50.0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.InSettlement(-50.0), refund.Amount)
+	assert.Equal(t, money.InSettlement(-20.0), refund.CreditAmount)
+	assert.Equal(t, 20.0, credits.restored["consumer1"])
+	charge, _ := fakeGateway.GetCharge(chargeID)
+	assert.Equal(t, money.InSettlement(30.0), charge.Refunded)
+}
+
+func TestRefundOrder_ConsumerCannotRefund(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusDelivered}
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+
+	_, err := useCase.RefundOrder(ctx, "order1", "consumer1", user.RoleConsumer, 0)
+
+	assert.ErrorIs(t, err, order.ErrNotOrderParty)
+}
+
+func TestRefundOrder_ConcurrentRefund(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	scheduler := &recordingScheduler{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, scheduler, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 100.0)
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusDelivered}
+	paid := &payment.Payment{ID: "pay1", Amount: money.InSettlement(100.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusPaid}
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	// Another refund was recorded between reading the balance and reserving this one.
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(0), money.InSettlement(100.0)).Return(payment.ErrPaymentChanged)
+
+	_, err := useCase.RefundOrder(ctx, "order1", "reseller1", user.RoleReseller, 0)
+
+	assert.ErrorIs(t, err, payment.ErrPaymentChanged)
+	mockPaymentRepo.AssertNotCalled(t, "RecordPayment", mock.Anything, mock.Anything)
+	assert.Empty(t, scheduler.jobs)
+	charge, _ := fakeGateway.GetCharge(chargeID)
+	assert.Equal(t, money.InSettlement(0.0), charge.Refunded)
+}
+
+func TestRefundOrder_SettledLaterWhenGatewayIsDown(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	scheduler := &recordingScheduler{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, scheduler, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 100.0)
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusDelivered}
+	paid := &payment.Payment{ID: "pay1", Amount: money.InSettlement(100.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusPaid}
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(0), money.InSettlement(40.0)).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+
+	fakeGateway.Fail = payment.ErrGatewayUnavailable
+	refund, err := useCase.RefundOrder(ctx, "order1", "reseller1", user.RoleReseller, 40.0)
+
+	// The refund is on the books and waits for its job.
+	assert.NoError(t, err)
+	assert.Equal(t, payment.StatusRefundPending, refund.Status)
+	if assert.Len(t, scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeSettleRefund, scheduler.jobs[0].Type)
+		assert.Equal(t, refund.ID, scheduler.jobs[0].Payload["payment_id"])
+	}
+
+	// The job runs once the gateway is back; running it again refunds nothing more.
+	fakeGateway.Fail = nil
+	pending := *refund
+	mockPaymentRepo.On("GetPaymentByID", ctx, refund.ID).Return(&pending, nil).Once()
+	mockPaymentRepo.On("UpdatePaymentStatus", ctx, refund.ID, payment.StatusRefunded).Return(nil).Twice()
+	assert.NoError(t, useCase.SettleRefund(ctx, refund.ID))
+	retried := *refund
+	mockPaymentRepo.On("GetPaymentByID", ctx, refund.ID).Return(&retried, nil).Once()
+	assert.NoError(t, useCase.SettleRefund(ctx, refund.ID))
+
+	charge, _ := fakeGateway.GetCharge(chargeID)
+	assert.Equal(t, money.InSettlement(40.0), charge.Refunded)
+	mockPaymentRepo.AssertExpectations(t)
+}
+
+func TestRecordRefund_LeavesMoneyWithGateway(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	scheduler := &recordingScheduler{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, fakeGateway, scheduler, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 100.0)
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1", Status: order.OrderStatusDelivered}
+	paid := &payment.Payment{ID: "pay1", Amount: money.InSettlement(100.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusPaid}
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(0), money.InSettlement(30.0)).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+
+	refund, err := useCase.RecordRefund(ctx, "order1", "reseller1", user.RoleReseller, 30.0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, payment.StatusRefundPending, refund.Status)
+	assert.Len(t, scheduler.jobs, 1)
+	charge, _ := fakeGateway.GetCharge(chargeID)
+	assert.Zero(t, charge.Refunded.Amount)
+	mockPaymentRepo.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestGetDashboardMetrics(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -403,6 +1401,7 @@ func TestGetDashboardMetrics(t *testing.T) {
 		mockUser       *user.User
 		mockError      error
 		expectError    bool
+		balance        *ledger.Balance
 		expectedSales  float64
 		expectedCounts order.PerformanceMetrics
 		expectedRating int
@@ -415,13 +1414,13 @@ func TestGetDashboardMetrics(t *testing.T) {
 				{
 					ID:         "bundle1",
 					Status:     "purchased",
-					Price:      100.0,
+					Price:      money.InSettlement(100.0),
 					DateListed: time.Now(),
 				},
 				{
 					ID:         "bundle2",
 					Status:     "available",
-					Price:      200.0,
+					Price:      money.InSettlement(200.0),
 					DateListed: time.Now(),
 				},
 			},
@@ -429,9 +1428,11 @@ func TestGetDashboardMetrics(t *testing.T) {
 				ID:         "supplier1",
 				TrustScore: 85,
 			},
-			mockError:     nil,
-			expectError:   false,
-			expectedSales: 100.0,
+			mockError:   nil,
+			expectError: false,
+			// 20 of the 100 sale was later refunded.
+			balance:       &ledger.Balance{UserID: "supplier1", Sales: 100.0, Fees: 1.6, Refunds: 20.0, Earnings: 78.4, Available: 78.4},
+			expectedSales: 80.0,
 			expectedCounts: order.PerformanceMetrics{
 				TotalBundlesListed: 2,
 				ActiveCount:        1,
@@ -481,7 +1482,11 @@ func TestGetDashboardMetrics(t *testing.T) {
 			mockWarehouseRepo := new(MockWarehouseRepo)
 			mockPaymentRepo := new(MockPaymentRepo)
 			mockUserRepo := new(MockUserRepo)
-			useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo)
+			ledgerUC := &recordingLedger{balances: map[string]*ledger.Balance{}}
+			if tt.balance != nil {
+				ledgerUC.balances[tt.supplierID] = tt.balance
+			}
+			useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo, new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, ledgerUC, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
 			ctx := context.Background()
 
 			mockBundleRepo.On("ListBundles", ctx, tt.supplierID).Return(tt.mockBundles, tt.mockError)
@@ -500,6 +1505,10 @@ func TestGetDashboardMetrics(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, metrics)
 				assert.Equal(t, tt.expectedSales, metrics.TotalSales)
+				if tt.balance != nil {
+					assert.Equal(t, tt.balance.Earnings, metrics.Earnings)
+					assert.Equal(t, tt.balance.Available, metrics.Balance)
+				}
 				assert.Equal(t, tt.expectedCounts, metrics.PerformanceMetrics)
 				assert.Equal(t, tt.expectedRating, metrics.Rating)
 				assert.Equal(t, tt.expectedBest, metrics.BestSelling)
@@ -546,7 +1555,7 @@ func TestGetOrderByID(t *testing.T) {
 			mockWarehouseRepo := new(MockWarehouseRepo)
 			mockPaymentRepo := new(MockPaymentRepo)
 			mockUserRepo := new(MockUserRepo)
-			useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo)
+			useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo, new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
 			ctx := context.Background()
 
 			mockOrderRepo.On("GetOrderByID", ctx, tt.orderID).Return(tt.mockOrder, tt.mockError)
@@ -568,6 +1577,32 @@ func TestGetOrderByID(t *testing.T) {
 	}
 }
 
+func TestGetOrdersToFulfil(t *testing.T) {
+	// Arrange
+	mockOrderRepo := new(MockOrderRepo)
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	shipTo := &order.ShippingAddress{FullName: "Abebe Kebede", Line1: "Bole Road", City: "Addis Ababa", Country: "ET"}
+	mockOrderRepo.On("GetOrdersByReseller", ctx, "reseller1").Return([]*order.Order{
+		{ID: "older", ResellerID: "reseller1", ConsumerID: "consumer1", CreatedAt: "2025-01-01T10:00:00Z", ShippingAddress: shipTo},
+		{ID: "bundle", ResellerID: "reseller1", BundleID: "bundle1", CreatedAt: "2025-01-02T10:00:00Z"},
+		{ID: "newer", ResellerID: "reseller1", ConsumerID: "consumer2", CreatedAt: "2025-01-03T10:00:00Z", ShippingAddress: shipTo},
+	}, nil)
+
+	// Act
+	orders, err := useCase.GetOrdersToFulfil(ctx, "reseller1")
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, orders, 2) {
+		assert.Equal(t, "newer", orders[0].ID)
+		assert.Equal(t, "older", orders[1].ID)
+		assert.Equal(t, "Bole Road", orders[1].ShippingAddress.Line1)
+	}
+	mockOrderRepo.AssertExpectations(t)
+}
+
 func TestGetSoldBundleHistory(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -622,7 +1657,7 @@ func TestGetSoldBundleHistory(t *testing.T) {
 			mockWarehouseRepo := new(MockWarehouseRepo)
 			mockPaymentRepo := new(MockPaymentRepo)
 			mockUserRepo := new(MockUserRepo)
-			useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo)
+			useCase := NewOrderUsecase(mockBundleRepo, mockOrderRepo, mockWarehouseRepo, mockPaymentRepo, mockUserRepo, new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
 			ctx := context.Background()
 
 			mockOrderRepo.On("GetOrdersBySupplier", ctx, tt.supplierID).Return(tt.mockOrders, tt.mockError)
@@ -647,3 +1682,237 @@ func TestGetSoldBundleHistory(t *testing.T) {
 		})
 	}
 }
+
+func TestReleaseEscrow(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      escrow.Status
+		releaseBy   time.Time
+		wantRelease bool
+	}{
+		{"held escrow is released", escrow.StatusHeld, time.Now().Add(-time.Minute), true},
+		{"window still running", escrow.StatusHeld, time.Now().Add(time.Hour), false},
+		{"disputed escrow stays frozen", escrow.StatusDisputed, time.Now().Add(-time.Minute), false},
+		{"settled escrow is left alone", escrow.StatusRefunded, time.Now().Add(-time.Minute), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPaymentRepo := new(MockPaymentRepo)
+			mockEscrowRepo := new(MockEscrowRepo)
+			ledgerUC := &recordingLedger{}
+			useCase := NewOrderUsecase(new(MockBundleRepo), new(MockOrderRepo), new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, ledgerUC, mockEscrowRepo, &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+			ctx := context.Background()
+
+			e := &escrow.Escrow{OrderID: "order1", PaymentID: "pay1", SupplierID: "supplier1", ResellerID: "reseller1", Amount: money.InSettlement(100), Status: tt.status, ReleaseBy: tt.releaseBy}
+			held := &payment.Payment{ID: "pay1", ToUserID: "supplier1", Amount: money.InSettlement(100.0), PlatformFee: money.InSettlement(2.0), OrderID: "order1", Status: payment.StatusHeld}
+			mockEscrowRepo.On("GetEscrowByOrder", ctx, "order1").Return(e, nil)
+			if tt.wantRelease {
+				mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{held}, nil)
+				mockPaymentRepo.On("UpdatePaymentStatus", ctx, "pay1", payment.StatusPaid).Return(nil)
+				mockEscrowRepo.On("UpdateEscrow", ctx, e, escrow.StatusHeld).Return(nil)
+			}
+
+			err := useCase.ReleaseEscrow(ctx, "order1")
+
+			assert.NoError(t, err)
+			if tt.wantRelease {
+				assert.Equal(t, escrow.StatusReleased, e.Status)
+				assert.NotNil(t, e.ClosedAt)
+				assert.Equal(t, []*payment.Payment{held}, ledgerUC.releases)
+			} else {
+				assert.Equal(t, tt.status, e.Status)
+				assert.Empty(t, ledgerUC.releases)
+			}
+			mockPaymentRepo.AssertExpectations(t)
+			mockEscrowRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConfirmArrival(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID string
+		role    user.Role
+		status  escrow.Status
+		wantErr error
+	}{
+		{"reseller confirms", "reseller1", user.RoleReseller, escrow.StatusHeld, nil},
+		{"admin confirms", "admin1", user.RoleAdmin, escrow.StatusHeld, nil},
+		{"other reseller", "reseller2", user.RoleReseller, escrow.StatusHeld, order.ErrNotOrderParty},
+		{"supplier cannot confirm", "supplier1", user.RoleSupplier, escrow.StatusHeld, order.ErrNotOrderParty},
+		{"disputed escrow waits for resolution", "reseller1", user.RoleReseller, escrow.StatusDisputed, escrow.ErrNotHeld},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPaymentRepo := new(MockPaymentRepo)
+			mockEscrowRepo := new(MockEscrowRepo)
+			mockWarehouseRepo := new(MockWarehouseRepo)
+			ledgerUC := &recordingLedger{}
+			useCase := NewOrderUsecase(new(MockBundleRepo), new(MockOrderRepo), mockWarehouseRepo, mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, ledgerUC, mockEscrowRepo, &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+			ctx := context.Background()
+
+			// The dispute window is still running; arrival releases the money early.
+			e := &escrow.Escrow{OrderID: "order1", PaymentID: "pay1", SupplierID: "supplier1", ResellerID: "reseller1", WarehouseItemID: "item1", Amount: money.InSettlement(100), Status: tt.status, ReleaseBy: time.Now().Add(time.Hour)}
+			held := &payment.Payment{ID: "pay1", ToUserID: "supplier1", Amount: money.InSettlement(100.0), OrderID: "order1", Status: payment.StatusHeld}
+			mockEscrowRepo.On("GetEscrowByOrder", ctx, "order1").Return(e, nil)
+			if tt.wantErr == nil {
+				mockWarehouseRepo.On("MarkItemAsArrived", ctx, "item1", mock.AnythingOfType("time.Time")).Return(nil)
+				mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{held}, nil)
+				mockPaymentRepo.On("UpdatePaymentStatus", ctx, "pay1", payment.StatusPaid).Return(nil)
+				mockEscrowRepo.On("UpdateEscrow", ctx, e, escrow.StatusHeld).Return(nil)
+			}
+
+			released, err := useCase.ConfirmArrival(ctx, "order1", tt.actorID, tt.role)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.status, e.Status)
+				assert.Empty(t, ledgerUC.releases)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, escrow.StatusReleased, released.Status)
+				assert.Equal(t, []*payment.Payment{held}, ledgerUC.releases)
+			}
+			mockPaymentRepo.AssertExpectations(t)
+			mockEscrowRepo.AssertExpectations(t)
+			mockWarehouseRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReleaseOnArrival(t *testing.T) {
+	tests := []struct {
+		name     string
+		escrow   *escrow.Escrow
+		released bool
+	}{
+		{"held escrow is released", &escrow.Escrow{OrderID: "order1", WarehouseItemID: "item1", Status: escrow.StatusHeld}, true},
+		{"disputed escrow waits for resolution", &escrow.Escrow{OrderID: "order1", WarehouseItemID: "item1", Status: escrow.StatusDisputed}, false},
+		{"item bought without escrow", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPaymentRepo := new(MockPaymentRepo)
+			mockEscrowRepo := new(MockEscrowRepo)
+			ledgerUC := &recordingLedger{}
+			useCase := NewOrderUsecase(new(MockBundleRepo), new(MockOrderRepo), new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, ledgerUC, mockEscrowRepo, &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+			ctx := context.Background()
+
+			held := &payment.Payment{ID: "pay1", Amount: money.InSettlement(100.0), OrderID: "order1", Status: payment.StatusHeld}
+			mockEscrowRepo.On("GetEscrowByWarehouseItem", ctx, "item1").Return(tt.escrow, nil)
+			if tt.released {
+				mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{held}, nil)
+				mockPaymentRepo.On("UpdatePaymentStatus", ctx, "pay1", payment.StatusPaid).Return(nil)
+				mockEscrowRepo.On("UpdateEscrow", ctx, tt.escrow, escrow.StatusHeld).Return(nil)
+			}
+
+			err := useCase.ReleaseOnArrival(ctx, "item1")
+
+			assert.NoError(t, err)
+			if tt.released {
+				assert.Equal(t, escrow.StatusReleased, tt.escrow.Status)
+				assert.Equal(t, []*payment.Payment{held}, ledgerUC.releases)
+			} else {
+				assert.Empty(t, ledgerUC.releases)
+			}
+			mockPaymentRepo.AssertExpectations(t)
+			mockEscrowRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisputeEscrow(t *testing.T) {
+	tests := []struct {
+		name       string
+		resellerID string
+		reason     string
+		status     escrow.Status
+		wantErr    error
+	}{
+		{"opens dispute", "reseller1", "bundle never arrived", escrow.StatusHeld, nil},
+		{"reason required", "reseller1", "  ", escrow.StatusHeld, escrow.ErrDisputeReasonRequired},
+		{"other reseller", "reseller2", "bundle never arrived", escrow.StatusHeld, order.ErrNotOrderParty},
+		{"already released", "reseller1", "bundle never arrived", escrow.StatusReleased, escrow.ErrNotHeld},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEscrowRepo := new(MockEscrowRepo)
+			useCase := NewOrderUsecase(new(MockBundleRepo), new(MockOrderRepo), new(MockWarehouseRepo), new(MockPaymentRepo), new(MockUserRepo), new(MockProductRepo), &passthroughUnitOfWork{}, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, mockEscrowRepo, &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+			ctx := context.Background()
+
+			e := &escrow.Escrow{OrderID: "order1", ResellerID: "reseller1", Status: tt.status}
+			mockEscrowRepo.On("GetEscrowByOrder", ctx, "order1").Return(e, nil).Maybe()
+			mockEscrowRepo.On("UpdateEscrow", ctx, e, escrow.StatusHeld).Return(nil).Maybe()
+
+			got, err := useCase.DisputeEscrow(ctx, "order1", tt.resellerID, tt.reason)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, escrow.StatusDisputed, got.Status)
+				assert.Equal(t, tt.reason, got.Dispute.Reason)
+			} else {
+				mockEscrowRepo.AssertNotCalled(t, "UpdateEscrow", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestResolveEscrow_Refund(t *testing.T) {
+	mockPaymentRepo := new(MockPaymentRepo)
+	mockEscrowRepo := new(MockEscrowRepo)
+	fakeGateway := gateway.NewFakeGateway("secret")
+	unitOfWork := &passthroughUnitOfWork{}
+	ledgerUC := &recordingLedger{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), new(MockOrderRepo), new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), unitOfWork, fakeGateway, &recordingScheduler{}, defaultFees{}, ledgerUC, mockEscrowRepo, &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	chargeID := capturedCharge(t, fakeGateway, 100.0)
+	e := &escrow.Escrow{OrderID: "order1", PaymentID: "pay1", ResellerID: "reseller1", Amount: money.InSettlement(100), Status: escrow.StatusDisputed, Dispute: &escrow.Dispute{Reason: "wrong items"}}
+	held := &payment.Payment{ID: "pay1", FromUserID: "reseller1", ToUserID: "supplier1", Amount: money.InSettlement(100.0), PlatformFee: money.InSettlement(2.0), SellerEarning: money.InSettlement(98.0), OrderID: "order1", ChargeID: chargeID, Status: payment.StatusHeld, Type: payment.B2B}
+
+	mockEscrowRepo.On("GetEscrowByOrder", ctx, "order1").Return(e, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{held}, nil)
+	mockPaymentRepo.On("UpdatePaymentStatus", ctx, "pay1", payment.StatusPaid).Return(nil)
+	mockEscrowRepo.On("UpdateEscrow", ctx, e, escrow.StatusDisputed).Return(nil)
+	mockPaymentRepo.On("ReserveRefund", ctx, "pay1", money.InSettlement(0), money.InSettlement(100.0)).Return(nil)
+	mockPaymentRepo.On("RecordPayment", ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.Amount == money.InSettlement(-100.0) && p.RefundOf == "pay1"
+	})).Return(nil)
+	mockPaymentRepo.On("UpdatePaymentStatus", ctx, mock.AnythingOfType("string"), payment.StatusRefunded).Return(nil)
+
+	got, err := useCase.ResolveEscrow(ctx, "order1", "admin1", true, "supplier sent the wrong bundle")
+
+	assert.NoError(t, err)
+	assert.Equal(t, escrow.StatusRefunded, got.Status)
+	assert.Equal(t, "admin1", got.Dispute.ResolvedBy)
+	assert.Equal(t, 1, unitOfWork.calls)
+	assert.Len(t, ledgerUC.releases, 1)
+	assert.Len(t, ledgerUC.payments, 1)
+	charge, _ := fakeGateway.GetCharge(chargeID)
+	assert.Equal(t, money.InSettlement(100.0), charge.Refunded)
+	mockPaymentRepo.AssertExpectations(t)
+	mockEscrowRepo.AssertExpectations(t)
+}
+
+func TestRefundOrder_FundsHeld(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockPaymentRepo := new(MockPaymentRepo)
+	unitOfWork := &passthroughUnitOfWork{}
+	useCase := NewOrderUsecase(new(MockBundleRepo), mockOrderRepo, new(MockWarehouseRepo), mockPaymentRepo, new(MockUserRepo), new(MockProductRepo), unitOfWork, gateway.NewFakeGateway("secret"), &recordingScheduler{}, defaultFees{}, &recordingLedger{}, new(MockEscrowRepo), &recordingCredits{}, fixedRates{}, &flatTax{}, &recordingInvoices{})
+	ctx := context.Background()
+
+	o := &order.Order{ID: "order1", BundleID: "bundle1", ResellerID: "reseller1", SupplierID: "supplier1", Status: order.OrderStatusCompleted}
+	held := &payment.Payment{ID: "pay1", Amount: money.InSettlement(100.0), OrderID: "order1", Status: payment.StatusHeld}
+	mockOrderRepo.On("GetOrderByID", ctx, "order1").Return(o, nil)
+	mockPaymentRepo.On("GetPaymentsByOrder", ctx, "order1").Return([]*payment.Payment{held}, nil)
+
+	_, err := useCase.RefundOrder(ctx, "order1", "supplier1", user.RoleSupplier, 0)
+
+	assert.ErrorIs(t, err, escrow.ErrFundsHeld)
+	assert.Equal(t, 0, unitOfWork.calls)
+}
@@ -0,0 +1,235 @@
+package payoutusecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payout"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/google/uuid"
+)
+
+type payoutUsecase struct {
+	repo        payout.Repository
+	paymentRepo payment.Repository
+	orderRepo   order.Repository
+	unitOfWork  uow.UnitOfWork
+	scheduler   job.Scheduler
+	clock       job.Clock
+	provider    payout.Provider
+	ledger      ledger.Usecase
+	holdPeriod  time.Duration
+}
+
+// NewPayoutUsecase creates the payout usecase. Earnings become due for payout
+// holdPeriod after their order is delivered.
+func NewPayoutUsecase(repo payout.Repository, paymentRepo payment.Repository, orderRepo order.Repository, unitOfWork uow.UnitOfWork, scheduler job.Scheduler, clock job.Clock, provider payout.Provider, ledgerUC ledger.Usecase, holdPeriod time.Duration) payout.Usecase {
+	return &payoutUsecase{
+		repo:        repo,
+		paymentRepo: paymentRepo,
+		orderRepo:   orderRepo,
+		unitOfWork:  unitOfWork,
+		scheduler:   scheduler,
+		clock:       clock,
+		provider:    provider,
+		ledger:      ledgerUC,
+		holdPeriod:  holdPeriod,
+	}
+}
+
+func (u *payoutUsecase) SetPayoutMethod(ctx context.Context, userID string, m *payout.Method) (*payout.Method, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	m.UserID = userID
+	m.UpdatedAt = u.clock.Now()
+	if err := u.repo.SaveMethod(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (u *payoutUsecase) GetPayoutMethod(ctx context.Context, userID string) (*payout.Method, error) {
+	m, err := u.repo.GetMethod(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, payout.ErrNoMethod
+	}
+	return m, nil
+}
+
+func (u *payoutUsecase) ListPayouts(ctx context.Context, sellerID string) ([]*payout.Payout, error) {
+	return u.repo.ListPayoutsBySeller(ctx, sellerID)
+}
+
+func (u *payoutUsecase) GetPayout(ctx context.Context, sellerID, id string) (*payout.Payout, error) {
+	p, err := u.repo.GetPayoutByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil || p.SellerID != sellerID {
+		return nil, payout.ErrPayoutNotFound
+	}
+	return p, nil
+}
+
+// RunSettlement pays out every payment whose order was delivered at least the
+// hold period ago. A refund is settled together with its sale, or in the next
+// batch if the sale has already been paid out.
+func (u *payoutUsecase) RunSettlement(ctx context.Context) ([]*payout.Payout, error) {
+	payments, err := u.paymentRepo.ListUnsettledPayments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderIDs []string
+	byOrder := make(map[string][]*payment.Payment)
+	for _, p := range payments {
+		if p.OrderID == "" {
+			continue
+		}
+		if _, seen := byOrder[p.OrderID]; !seen {
+			orderIDs = append(orderIDs, p.OrderID)
+		}
+		byOrder[p.OrderID] = append(byOrder[p.OrderID], p)
+	}
+
+	now := u.clock.Now()
+	cutoff := now.Add(-u.holdPeriod)
+	var sellerIDs []string
+	bySeller := make(map[string][]*payment.Payment)
+	for _, orderID := range orderIDs {
+		o, err := u.orderRepo.GetOrderByID(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		if o == nil {
+			continue
+		}
+		fulfilledAt, ok := o.FulfilledAt()
+		if !ok || fulfilledAt.After(cutoff) {
+			continue
+		}
+		for _, p := range byOrder[orderID] {
+			if _, seen := bySeller[p.ToUserID]; !seen {
+				sellerIDs = append(sellerIDs, p.ToUserID)
+			}
+			bySeller[p.ToUserID] = append(bySeller[p.ToUserID], p)
+		}
+	}
+
+	var created []*payout.Payout
+	for _, sellerID := range sellerIDs {
+		p, err := u.createPayout(ctx, sellerID, bySeller[sellerID], now)
+		if errors.Is(err, payment.ErrAlreadySettled) {
+			// A concurrent settlement run batched these payments first.
+			continue
+		}
+		if err != nil {
+			return created, err
+		}
+		if p != nil {
+			created = append(created, p)
+		}
+	}
+	return created, nil
+}
+
+// createPayout batches a seller's payments into a pending payout and queues
+// it to be sent. Nothing is created while the payments net to zero or less,
+// or while the seller has no payout method; the payments wait for a later run.
+func (u *payoutUsecase) createPayout(ctx context.Context, sellerID string, payments []*payment.Payment, now time.Time) (*payout.Payout, error) {
+	earned := money.Money{Currency: money.Settlement}
+	paymentIDs := make([]string, 0, len(payments))
+	for _, p := range payments {
+		earned = earned.Add(p.SellerEarning)
+		paymentIDs = append(paymentIDs, p.ID)
+	}
+	amount := earned.Major()
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	method, err := u.repo.GetMethod(ctx, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	if method == nil {
+		return nil, nil
+	}
+
+	p := &payout.Payout{
+		ID:         uuid.NewString(),
+		SellerID:   sellerID,
+		Amount:     amount,
+		Status:     payout.StatusPending,
+		PaymentIDs: paymentIDs,
+		Method:     *method,
+		CreatedAt:  now,
+	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.CreatePayout(ctx, p); err != nil {
+			return err
+		}
+		if err := u.paymentRepo.AssignPayout(ctx, paymentIDs, p.ID); err != nil {
+			return err
+		}
+		_, err := u.scheduler.Schedule(ctx, job.TypeSendPayout, map[string]string{"payout_id": p.ID}, 0)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SendPayout sends a pending payout. A payout the provider rejects fails and
+// its payments go back into the seller's next batch; other provider errors
+// are returned so that the job is retried.
+func (u *payoutUsecase) SendPayout(ctx context.Context, id string) error {
+	p, err := u.repo.GetPayoutByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return payout.ErrPayoutNotFound
+	}
+	if p.Status != payout.StatusPending {
+		return nil
+	}
+
+	ref, err := u.provider.Send(ctx, p)
+	now := u.clock.Now()
+	if errors.Is(err, payout.ErrPayoutRejected) {
+		p.Status = payout.StatusFailed
+		p.FailureReason = err.Error()
+		p.SettledAt = &now
+		return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			if err := u.repo.UpdatePayout(ctx, p); err != nil {
+				return err
+			}
+			return u.paymentRepo.ReleasePayout(ctx, p.ID)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	p.Status = payout.StatusPaid
+	p.ProviderRef = ref
+	p.SettledAt = &now
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdatePayout(ctx, p); err != nil {
+			return err
+		}
+		return u.ledger.RecordPayout(ctx, p.SellerID, p.ID, p.Amount)
+	})
+}
@@ -0,0 +1,403 @@
+package payoutusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payout"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPayoutRepo struct {
+	mock.Mock
+}
+
+func (m *MockPayoutRepo) SaveMethod(ctx context.Context, method *payout.Method) error {
+	args := m.Called(ctx, method)
+	return args.Error(0)
+}
+
+func (m *MockPayoutRepo) GetMethod(ctx context.Context, userID string) (*payout.Method, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payout.Method), args.Error(1)
+}
+
+func (m *MockPayoutRepo) CreatePayout(ctx context.Context, p *payout.Payout) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPayoutRepo) UpdatePayout(ctx context.Context, p *payout.Payout) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPayoutRepo) GetPayoutByID(ctx context.Context, id string) (*payout.Payout, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payout.Payout), args.Error(1)
+}
+
+func (m *MockPayoutRepo) ListPayoutsBySeller(ctx context.Context, sellerID string) ([]*payout.Payout, error) {
+	args := m.Called(ctx, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payout.Payout), args.Error(1)
+}
+
+type MockPaymentRepo struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepo) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByUser(ctx context.Context, userID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID, pType)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByOrder(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentByID(ctx context.Context, id string) (*payment.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) ReserveRefund(ctx context.Context, id string, refunded, amount money.Money) error {
+	args := m.Called(ctx, id, refunded, amount)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) GetAllPlatformFees(ctx context.Context) (float64, float64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
+}
+
+func (m *MockPaymentRepo) ListUnsettledPayments(ctx context.Context) ([]*payment.Payment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) ListPaymentsBetween(ctx context.Context, from, to time.Time) ([]*payment.Payment, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) AssignPayout(ctx context.Context, paymentIDs []string, payoutID string) error {
+	args := m.Called(ctx, paymentIDs, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) ReleasePayout(ctx context.Context, payoutID string) error {
+	args := m.Called(ctx, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) UpdatePaymentStatus(ctx context.Context, id, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+type MockOrderRepo struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepo) CreateOrder(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, consumerID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) TransitionStatus(ctx context.Context, orderID string, t order.StatusTransition) error {
+	args := m.Called(ctx, orderID, t)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) DeleteOrder(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) GetOrdersBySupplier(ctx context.Context, supplierID string) ([]*order.Order, error) {
+	args := m.Called(ctx, supplierID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, resellerID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// recordingScheduler keeps scheduled jobs in memory instead of queueing them.
+type recordingScheduler struct {
+	jobs []*job.Job
+}
+
+func (s *recordingScheduler) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	j := &job.Job{Type: jobType, Payload: payload, RunAt: testNow.Add(delay)}
+	s.jobs = append(s.jobs, j)
+	return j, nil
+}
+
+// recordingLedger keeps posted payouts in memory.
+type recordingLedger struct {
+	payouts map[string]float64
+}
+
+func (l *recordingLedger) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordRelease(ctx context.Context, p *payment.Payment) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordCreditIssued(ctx context.Context, userID string, amount float64, memo string) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordGiftCardRedeemed(ctx context.Context, userID, code string, amount float64) error {
+	return nil
+}
+
+func (l *recordingLedger) RecordPayout(ctx context.Context, sellerID, payoutID string, amount float64) error {
+	l.payouts[payoutID] = amount
+	return nil
+}
+
+func (l *recordingLedger) GetBalance(ctx context.Context, userID string) (*ledger.Balance, error) {
+	return &ledger.Balance{UserID: userID}, nil
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+const holdPeriod = 7 * 24 * time.Hour
+
+type fixture struct {
+	payouts   *MockPayoutRepo
+	payments  *MockPaymentRepo
+	orders    *MockOrderRepo
+	scheduler *recordingScheduler
+	provider  *gateway.FakePayoutProvider
+	ledger    *recordingLedger
+	uc        payout.Usecase
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		payouts:   new(MockPayoutRepo),
+		payments:  new(MockPaymentRepo),
+		orders:    new(MockOrderRepo),
+		scheduler: &recordingScheduler{},
+		provider:  gateway.NewFakePayoutProvider(),
+		ledger:    &recordingLedger{payouts: make(map[string]float64)},
+	}
+	f.uc = NewPayoutUsecase(f.payouts, f.payments, f.orders, &passthroughUnitOfWork{}, f.scheduler, fixedClock{now: testNow}, f.provider, f.ledger, holdPeriod)
+	return f
+}
+
+// deliveredOrder returns an order that was delivered the given time ago.
+func deliveredOrder(id string, ago time.Duration) *order.Order {
+	return &order.Order{ID: id, Status: order.OrderStatusDelivered, History: []order.StatusTransition{
+		{To: order.OrderStatusPending, At: testNow.Add(-ago - time.Hour)},
+		{From: order.OrderStatusShipped, To: order.OrderStatusDelivered, At: testNow.Add(-ago)},
+	}}
+}
+
+var bankAccount = &payout.Method{UserID: "reseller1", Type: payout.MethodBankTransfer, AccountName: "Abebe Kebede", AccountNumber: "1000123456", Institution: "CBE"}
+
+func TestRunSettlement_BatchesEarningsPastHoldPeriod(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	f.payments.On("ListUnsettledPayments", ctx).Return([]*payment.Payment{
+		{ID: "pay1", OrderID: "order1", ToUserID: "reseller1", Amount: money.InSettlement(100), SellerEarning: money.InSettlement(98), Status: payment.StatusPaid},
+		{ID: "pay2", OrderID: "order1", ToUserID: "reseller1", Amount: money.InSettlement(-25), SellerEarning: money.InSettlement(-24.5), RefundOf: "pay1", Status: payment.StatusRefunded},
+		{ID: "pay3", OrderID: "order2", ToUserID: "reseller1", Amount: money.InSettlement(50), SellerEarning: money.InSettlement(49), Status: payment.StatusPaid},
+		{ID: "pay4", OrderID: "order3", ToUserID: "reseller1", Amount: money.InSettlement(10), SellerEarning: money.InSettlement(9.8), Status: payment.StatusPaid},
+		{ID: "pay5", OrderID: "order4", ToUserID: "supplier1", Amount: money.InSettlement(200), SellerEarning: money.InSettlement(196), Status: payment.StatusPaid, Type: payment.B2B},
+	}, nil)
+	f.orders.On("GetOrderByID", ctx, "order1").Return(deliveredOrder("order1", 8*24*time.Hour), nil)
+	// Delivered too recently: still held for returns.
+	f.orders.On("GetOrderByID", ctx, "order2").Return(deliveredOrder("order2", 2*24*time.Hour), nil)
+	f.orders.On("GetOrderByID", ctx, "order3").Return(&order.Order{ID: "order3", Status: order.OrderStatusPending}, nil)
+	bundleOrder := &order.Order{ID: "order4", Status: order.OrderStatusCompleted, History: []order.StatusTransition{{To: order.OrderStatusCompleted, At: testNow.Add(-30 * 24 * time.Hour)}}}
+	f.orders.On("GetOrderByID", ctx, "order4").Return(bundleOrder, nil)
+	f.payouts.On("GetMethod", ctx, "reseller1").Return(bankAccount, nil)
+	// The supplier has no payout method yet, so their earnings wait.
+	f.payouts.On("GetMethod", ctx, "supplier1").Return(nil, nil)
+	f.payouts.On("CreatePayout", ctx, mock.MatchedBy(func(p *payout.Payout) bool {
+		return p.SellerID == "reseller1" && p.Amount == 73.5 && p.Status == payout.StatusPending && p.Method.Institution == "CBE"
+	})).Return(nil)
+	f.payments.On("AssignPayout", ctx, []string{"pay1", "pay2"}, mock.AnythingOfType("string")).Return(nil)
+
+	created, err := f.uc.RunSettlement(ctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, created, 1) && assert.Len(t, f.scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeSendPayout, f.scheduler.jobs[0].Type)
+		assert.Equal(t, created[0].ID, f.scheduler.jobs[0].Payload["payout_id"])
+	}
+	f.payouts.AssertExpectations(t)
+	f.payments.AssertExpectations(t)
+}
+
+func TestRunSettlement_RefundsExceedEarnings(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	// The sale was paid out in an earlier batch; only its late refund is left.
+	f.payments.On("ListUnsettledPayments", ctx).Return([]*payment.Payment{
+		{ID: "pay2", OrderID: "order1", ToUserID: "reseller1", Amount: money.InSettlement(-25), SellerEarning: money.InSettlement(-24.5), RefundOf: "pay1", Status: payment.StatusRefunded},
+	}, nil)
+	f.orders.On("GetOrderByID", ctx, "order1").Return(deliveredOrder("order1", 10*24*time.Hour), nil)
+
+	created, err := f.uc.RunSettlement(ctx)
+
+	assert.NoError(t, err)
+	assert.Empty(t, created)
+	f.payouts.AssertNotCalled(t, "CreatePayout", mock.Anything, mock.Anything)
+	f.payments.AssertNotCalled(t, "AssignPayout", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSendPayout_Paid(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	f.payouts.On("GetPayoutByID", ctx, "po1").Return(&payout.Payout{ID: "po1", SellerID: "reseller1", Amount: 73.5, Status: payout.StatusPending}, nil)
+	f.payouts.On("UpdatePayout", ctx, mock.MatchedBy(func(p *payout.Payout) bool {
+		return p.Status == payout.StatusPaid && p.ProviderRef == "po_fake_000001" && p.SettledAt.Equal(testNow)
+	})).Return(nil)
+
+	err := f.uc.SendPayout(ctx, "po1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"po1": 73.5}, f.ledger.payouts)
+	f.payouts.AssertExpectations(t)
+}
+
+func TestSendPayout_Rejected(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	f.provider.Reject = true
+	f.payouts.On("GetPayoutByID", ctx, "po1").Return(&payout.Payout{ID: "po1", SellerID: "reseller1", Amount: 73.5, Status: payout.StatusPending}, nil)
+	f.payouts.On("UpdatePayout", ctx, mock.MatchedBy(func(p *payout.Payout) bool {
+		return p.Status == payout.StatusFailed && p.FailureReason != ""
+	})).Return(nil)
+	f.payments.On("ReleasePayout", ctx, "po1").Return(nil)
+
+	err := f.uc.SendPayout(ctx, "po1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, f.ledger.payouts)
+	f.payouts.AssertExpectations(t)
+	f.payments.AssertExpectations(t)
+}
+
+func TestSendPayout_ProviderUnavailable(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	outage := errors.New("provider unavailable")
+	f.provider.Fail = outage
+	f.payouts.On("GetPayoutByID", ctx, "po1").Return(&payout.Payout{ID: "po1", SellerID: "reseller1", Amount: 73.5, Status: payout.StatusPending}, nil)
+
+	err := f.uc.SendPayout(ctx, "po1")
+
+	assert.ErrorIs(t, err, outage)
+	f.payouts.AssertNotCalled(t, "UpdatePayout", mock.Anything, mock.Anything)
+}
+
+func TestSendPayout_AlreadyPaid(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	f.payouts.On("GetPayoutByID", ctx, "po1").Return(&payout.Payout{ID: "po1", SellerID: "reseller1", Amount: 73.5, Status: payout.StatusPaid}, nil)
+
+	err := f.uc.SendPayout(ctx, "po1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, f.ledger.payouts)
+	f.payouts.AssertNotCalled(t, "UpdatePayout", mock.Anything, mock.Anything)
+}
+
+func TestSetPayoutMethod_Invalid(t *testing.T) {
+	f := newFixture()
+
+	_, err := f.uc.SetPayoutMethod(context.Background(), "reseller1", &payout.Method{Type: payout.MethodMobileMoney, AccountName: "Abebe Kebede", Institution: "telebirr"})
+
+	assert.ErrorIs(t, err, payout.ErrInvalidMethod)
+	assert.EqualError(t, err, "invalid payout method: account_number is required")
+	f.payouts.AssertNotCalled(t, "SaveMethod", mock.Anything, mock.Anything)
+}
+
+func TestGetPayout_OtherSeller(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	f.payouts.On("GetPayoutByID", ctx, "po1").Return(&payout.Payout{ID: "po1", SellerID: "reseller2"}, nil)
+
+	_, err := f.uc.GetPayout(ctx, "reseller1", "po1")
+
+	assert.ErrorIs(t, err, payout.ErrPayoutNotFound)
+}
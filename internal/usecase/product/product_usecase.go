@@ -60,6 +60,13 @@ func (uc *productUsecase) ListAvailableProducts(ctx context.Context, page, limit
 	return uc.repo.ListAvailableProducts(ctx, page, limit)
 }
 
+func (uc *productUsecase) SearchProducts(ctx context.Context, q product.Query) (*product.SearchResult, error) {
+	if err := q.Normalize(); err != nil {
+		return nil, err
+	}
+	return uc.repo.SearchProducts(ctx, &q)
+}
+
 func (uc *productUsecase) DeleteProduct(ctx context.Context, id string) error {
 	return uc.repo.DeleteProduct(ctx, id)
 }
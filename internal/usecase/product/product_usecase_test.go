@@ -3,9 +3,13 @@ package productusecase
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -45,6 +49,14 @@ func (m *MockRepository) ListAvailableProducts(ctx context.Context, page, limit
 	return args.Get(0).([]*product.Product), args.Error(1)
 }
 
+func (m *MockRepository) SearchProducts(ctx context.Context, q *product.Query) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
 func (m *MockRepository) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
 	args := m.Called(ctx, bundleID)
 	if args.Get(0) == nil {
@@ -63,6 +75,26 @@ func (m *MockRepository) UpdateProduct(ctx context.Context, id string, updates m
 	return args.Error(0)
 }
 
+func (m *MockRepository) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Relist(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Reserve(ctx context.Context, id string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, id, r, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Unreserve(ctx context.Context, id, offerID string) error {
+	args := m.Called(ctx, id, offerID)
+	return args.Error(0)
+}
+
 type MockBundleRepository struct {
 	mock.Mock
 }
@@ -85,11 +117,16 @@ func (m *MockBundleRepository) ListBundles(ctx context.Context, supplierID strin
 	return args.Get(0).([]*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
+func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query, after *bundle.Cursor, limit int) ([]*bundle.Bundle, error) {
+	args := m.Called(ctx, q, after, limit)
 	return args.Get(0).([]*bundle.Bundle), args.Error(1)
 }
 
+func (m *MockBundleRepository) UpdateSupplierTrust(ctx context.Context, supplierID string, score int) error {
+	args := m.Called(ctx, supplierID, score)
+	return args.Error(0)
+}
+
 func (m *MockBundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
 	args := m.Called(ctx, resellerID)
 	return args.Get(0).([]*bundle.Bundle), args.Error(1)
@@ -105,6 +142,21 @@ func (m *MockBundleRepository) MarkAsPurchased(ctx context.Context, bundleID str
 	return args.Error(0)
 }
 
+func (m *MockBundleRepository) SetListingMode(ctx context.Context, bundleID string, mode bundle.ListingMode, now time.Time) error {
+	args := m.Called(ctx, bundleID, mode, now)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) Reserve(ctx context.Context, bundleID string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, bundleID, r, now)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) Unreserve(ctx context.Context, bundleID, offerID string) error {
+	args := m.Called(ctx, bundleID, offerID)
+	return args.Error(0)
+}
+
 func (m *MockBundleRepository) DeleteBundle(ctx context.Context, bundleID string) error {
 	args := m.Called(ctx, bundleID)
 	return args.Error(0)
@@ -137,6 +189,44 @@ func (suite *ProductUsecaseTestSuite) SetupTest() {
 
 // Add your test functions here (TestAddProduct, etc) like before...
 
+func (suite *ProductUsecaseTestSuite) TestSearchProducts_Defaults() {
+	suite.mockRepo.On("SearchProducts", mock.Anything, &product.Query{
+		Currency: money.Settlement,
+		Status:   product.StatusAvailable,
+		Sort:     product.SortNewest,
+		Page:     1,
+		Limit:    product.DefaultPageSize,
+	}).Return(&product.SearchResult{}, nil)
+
+	_, err := suite.usecase.SearchProducts(context.Background(), product.Query{})
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUsecaseTestSuite) TestSearchProducts_RelevanceNeedsText() {
+	suite.mockRepo.On("SearchProducts", mock.Anything, mock.MatchedBy(func(q *product.Query) bool {
+		return q.Text == "leather" && q.Sort == product.SortRelevance
+	})).Return(&product.SearchResult{}, nil)
+
+	_, err := suite.usecase.SearchProducts(context.Background(), product.Query{Text: "  leather "})
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProductUsecaseTestSuite) TestSearchProducts_InvalidQuery() {
+	lo, hi := 50.0, 10.0
+	for _, q := range []product.Query{
+		{Status: "reserved"},
+		{Sort: "cheapest"},
+		{MinPrice: &lo, MaxPrice: &hi},
+		{Limit: product.MaxPageSize + 1},
+	} {
+		_, err := suite.usecase.SearchProducts(context.Background(), q)
+		assert.ErrorIs(suite.T(), err, product.ErrInvalidQuery)
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "SearchProducts", mock.Anything, mock.Anything)
+}
+
 func TestProductUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductUsecaseTestSuite))
 }
@@ -0,0 +1,87 @@
+package promotionusecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/promotion"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type promotionUsecase struct {
+	repo  promotion.Repository
+	clock job.Clock
+}
+
+// NewPromotionUsecase creates the promotions usecase. clock decides whether
+// a code is inside its date window.
+func NewPromotionUsecase(repo promotion.Repository, clock job.Clock) promotion.Usecase {
+	return &promotionUsecase{repo: repo, clock: clock}
+}
+
+func (u *promotionUsecase) CreatePromotion(ctx context.Context, adminID string, p *promotion.Promotion) (*promotion.Promotion, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	p.ID = primitive.NewObjectID().Hex()
+	p.Active = true
+	p.UsedCount = 0
+	p.CreatedBy = adminID
+	p.CreatedAt = u.clock.Now()
+	if err := u.repo.CreatePromotion(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (u *promotionUsecase) ListPromotions(ctx context.Context) ([]*promotion.Promotion, error) {
+	return u.repo.ListPromotions(ctx)
+}
+
+// SetActive pauses or resumes a code without losing its usage counts.
+func (u *promotionUsecase) SetActive(ctx context.Context, code string, active bool) (*promotion.Promotion, error) {
+	code = promotion.NormalizeCode(code)
+	if err := u.repo.SetActive(ctx, code, active); err != nil {
+		return nil, err
+	}
+	return u.get(ctx, code)
+}
+
+// Redeem checks the code against the basket before recording the use, so a
+// code that does not apply is never counted.
+func (u *promotionUsecase) Redeem(ctx context.Context, code, userID string, lines []promotion.Line) (*promotion.Discount, error) {
+	p, err := u.get(ctx, promotion.NormalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	d, err := p.Apply(lines, u.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.Redeem(ctx, p, userID); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (u *promotionUsecase) Release(ctx context.Context, d *promotion.Discount, userID string) error {
+	if d == nil {
+		return nil
+	}
+	if err := u.repo.Unredeem(ctx, d.PromotionID, userID); err != nil {
+		return fmt.Errorf("failed to release promo code %s: %w", d.Code, err)
+	}
+	return nil
+}
+
+func (u *promotionUsecase) get(ctx context.Context, code string) (*promotion.Promotion, error) {
+	p, err := u.repo.GetPromotionByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, promotion.ErrPromotionNotFound
+	}
+	return p, nil
+}
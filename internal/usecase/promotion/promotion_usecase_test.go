@@ -0,0 +1,239 @@
+package promotionusecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/promotion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPromotionRepo struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepo) CreatePromotion(ctx context.Context, p *promotion.Promotion) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepo) GetPromotionByCode(ctx context.Context, code string) (*promotion.Promotion, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotion.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepo) ListPromotions(ctx context.Context) ([]*promotion.Promotion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*promotion.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepo) SetActive(ctx context.Context, code string, active bool) error {
+	args := m.Called(ctx, code, active)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepo) Redeem(ctx context.Context, p *promotion.Promotion, userID string) error {
+	args := m.Called(ctx, p, userID)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepo) Unredeem(ctx context.Context, promotionID, userID string) error {
+	args := m.Called(ctx, promotionID, userID)
+	return args.Error(0)
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func basket() []promotion.Line {
+	return []promotion.Line{
+		{ListingID: "p1", SellerID: "reseller1", ClothingType: "Jacket", Amount: 60},
+		{ListingID: "p2", SellerID: "reseller1", ClothingType: "dress", Amount: 30},
+		{ListingID: "p3", SellerID: "reseller2", ClothingType: "jacket", Amount: 10},
+	}
+}
+
+func TestRedeem(t *testing.T) {
+	yesterday, tomorrow := testNow.Add(-24*time.Hour), testNow.Add(24*time.Hour)
+
+	tests := []struct {
+		name      string
+		promo     promotion.Promotion
+		wantTotal float64
+		wantLines map[string]float64
+		wantErr   error
+	}{
+		{
+			name:      "percentage on whole basket",
+			promo:     promotion.Promotion{Type: promotion.TypePercentage, Value: 0.1},
+			wantTotal: 10,
+			wantLines: map[string]float64{"p1": 6, "p2": 3, "p3": 1},
+		},
+		{
+			name:      "fixed spread by price",
+			promo:     promotion.Promotion{Type: promotion.TypeFixed, Value: 20},
+			wantTotal: 20,
+			wantLines: map[string]float64{"p1": 12, "p2": 6, "p3": 2},
+		},
+		{
+			name:      "fixed split rounded to cents",
+			promo:     promotion.Promotion{Type: promotion.TypeFixed, Value: 10, ResellerID: "reseller1"},
+			wantTotal: 10,
+			wantLines: map[string]float64{"p1": 6.67, "p2": 3.33},
+		},
+		{
+			name:      "rounding remainder goes to last item",
+			promo:     promotion.Promotion{Type: promotion.TypePercentage, Value: 0.0125},
+			wantTotal: 1.25,
+			wantLines: map[string]float64{"p1": 0.75, "p2": 0.38, "p3": 0.12},
+		},
+		{
+			name:      "fixed capped at covered items",
+			promo:     promotion.Promotion{Type: promotion.TypeFixed, Value: 50, ResellerID: "reseller2"},
+			wantTotal: 10,
+			wantLines: map[string]float64{"p3": 10},
+		},
+		{
+			name:      "scoped to clothing type",
+			promo:     promotion.Promotion{Type: promotion.TypePercentage, Value: 0.5, ClothingType: "jacket"},
+			wantTotal: 35,
+			wantLines: map[string]float64{"p1": 30, "p3": 5},
+		},
+		{
+			name:      "scoped to reseller and type",
+			promo:     promotion.Promotion{Type: promotion.TypePercentage, Value: 0.5, ResellerID: "reseller1", ClothingType: "jacket"},
+			wantTotal: 30,
+			wantLines: map[string]float64{"p1": 30},
+		},
+		{
+			name:    "nothing covered",
+			promo:   promotion.Promotion{Type: promotion.TypePercentage, Value: 0.1, ClothingType: "shoes"},
+			wantErr: promotion.ErrNotApplicable,
+		},
+		{
+			name:    "covered items below minimum",
+			promo:   promotion.Promotion{Type: promotion.TypeFixed, Value: 5, ResellerID: "reseller1", MinBasketTotal: 100},
+			wantErr: promotion.ErrBasketTooSmall,
+		},
+		{
+			name:    "not started",
+			promo:   promotion.Promotion{Type: promotion.TypeFixed, Value: 5, StartsAt: &tomorrow},
+			wantErr: promotion.ErrPromotionInactive,
+		},
+		{
+			name:    "ended",
+			promo:   promotion.Promotion{Type: promotion.TypeFixed, Value: 5, StartsAt: &yesterday, EndsAt: &testNow},
+			wantErr: promotion.ErrPromotionInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockPromotionRepo)
+			uc := NewPromotionUsecase(repo, fixedClock{now: testNow})
+			p := tt.promo
+			p.ID, p.Code, p.Active = "promo1", "SUMMER", true
+			repo.On("GetPromotionByCode", mock.Anything, "SUMMER").Return(&p, nil)
+			repo.On("Redeem", mock.Anything, &p, "consumer1").Return(nil).Maybe()
+
+			d, err := uc.Redeem(context.Background(), " summer ", "consumer1", basket())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.wantTotal, d.Total, 1e-9)
+			assert.Len(t, d.ByListing, len(tt.wantLines))
+			for id, want := range tt.wantLines {
+				assert.InDelta(t, want, d.For(id), 1e-9, id)
+			}
+			repo.AssertCalled(t, "Redeem", mock.Anything, &p, "consumer1")
+		})
+	}
+}
+
+func TestRedeem_UsageLimitReached(t *testing.T) {
+	repo := new(MockPromotionRepo)
+	uc := NewPromotionUsecase(repo, fixedClock{now: testNow})
+	p := &promotion.Promotion{ID: "promo1", Code: "ONCE", Type: promotion.TypeFixed, Value: 5, MaxUsesPerUser: 1, Active: true}
+	repo.On("GetPromotionByCode", mock.Anything, "ONCE").Return(p, nil)
+	repo.On("Redeem", mock.Anything, p, "consumer1").Return(promotion.ErrUserLimitReached)
+
+	_, err := uc.Redeem(context.Background(), "once", "consumer1", basket())
+
+	assert.ErrorIs(t, err, promotion.ErrUserLimitReached)
+}
+
+func TestRedeem_UnknownOrPausedCode(t *testing.T) {
+	repo := new(MockPromotionRepo)
+	uc := NewPromotionUsecase(repo, fixedClock{now: testNow})
+	repo.On("GetPromotionByCode", mock.Anything, "NOPE").Return(nil, nil)
+	repo.On("GetPromotionByCode", mock.Anything, "PAUSED").Return(&promotion.Promotion{Code: "PAUSED", Type: promotion.TypeFixed, Value: 5}, nil)
+
+	_, err := uc.Redeem(context.Background(), "nope", "consumer1", basket())
+	assert.ErrorIs(t, err, promotion.ErrPromotionNotFound)
+
+	_, err = uc.Redeem(context.Background(), "paused", "consumer1", basket())
+	assert.ErrorIs(t, err, promotion.ErrPromotionInactive)
+}
+
+func TestCreatePromotion(t *testing.T) {
+	repo := new(MockPromotionRepo)
+	uc := NewPromotionUsecase(repo, fixedClock{now: testNow})
+	repo.On("CreatePromotion", mock.Anything, mock.MatchedBy(func(p *promotion.Promotion) bool {
+		return p.Code == "WELCOME5" && p.Active && p.CreatedBy == "admin1" && p.CreatedAt.Equal(testNow)
+	})).Return(nil)
+
+	p, err := uc.CreatePromotion(context.Background(), "admin1", &promotion.Promotion{Code: "welcome5", Type: promotion.TypeFixed, Value: 5})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, p.ID)
+	repo.AssertExpectations(t)
+}
+
+func TestCreatePromotion_Invalid(t *testing.T) {
+	start := testNow
+	end := testNow.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		promo promotion.Promotion
+	}{
+		{"missing code", promotion.Promotion{Type: promotion.TypeFixed, Value: 5}},
+		{"unknown type", promotion.Promotion{Code: "X", Type: "bogo", Value: 5}},
+		{"percentage above 100%", promotion.Promotion{Code: "X", Type: promotion.TypePercentage, Value: 1.5}},
+		{"zero fixed value", promotion.Promotion{Code: "X", Type: promotion.TypeFixed}},
+		{"window ends before it starts", promotion.Promotion{Code: "X", Type: promotion.TypeFixed, Value: 5, StartsAt: &start, EndsAt: &end}},
+		{"negative limit", promotion.Promotion{Code: "X", Type: promotion.TypeFixed, Value: 5, MaxUses: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockPromotionRepo)
+			uc := NewPromotionUsecase(repo, fixedClock{now: testNow})
+
+			_, err := uc.CreatePromotion(context.Background(), "admin1", &tt.promo)
+
+			assert.ErrorIs(t, err, promotion.ErrInvalidPromotion)
+			repo.AssertNotCalled(t, "CreatePromotion", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package returnsusecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/returns"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+type returnsUsecase struct {
+	repo        returns.Repository
+	orders      returns.Orders
+	productRepo product.Repository
+	unitOfWork  uow.UnitOfWork
+	scheduler   job.Scheduler
+	clock       job.Clock
+}
+
+func NewReturnsUsecase(repo returns.Repository, orders returns.Orders, productRepo product.Repository, unitOfWork uow.UnitOfWork, scheduler job.Scheduler, clock job.Clock) returns.Usecase {
+	return &returnsUsecase{
+		repo:        repo,
+		orders:      orders,
+		productRepo: productRepo,
+		unitOfWork:  unitOfWork,
+		scheduler:   scheduler,
+		clock:       clock,
+	}
+}
+
+// RequestReturn opens the return and queues its approval for when the
+// reseller's response window ends.
+func (u *returnsUsecase) RequestReturn(ctx context.Context, consumerID string, req returns.Request) (*returns.Return, error) {
+	if !req.Reason.IsValid() {
+		return nil, returns.ErrInvalidReason
+	}
+	if err := returns.ValidatePhotos(req.Photos); err != nil {
+		return nil, err
+	}
+
+	o, err := u.orders.GetOrderByID(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if o.IsBundleOrder() || o.ConsumerID != consumerID {
+		return nil, order.ErrNotOrderParty
+	}
+	if !slices.Contains(o.ProductIDs, req.ProductID) {
+		return nil, returns.ErrNotInOrder
+	}
+	deliveredAt, ok := o.FulfilledAt()
+	if !ok || !o.Status.IsFulfilled() {
+		return nil, returns.ErrNotReturnable
+	}
+
+	policy, err := u.GetPolicy(ctx, o.ResellerID)
+	if err != nil {
+		return nil, err
+	}
+	now := u.clock.Now()
+	if policy.WindowDays == 0 {
+		return nil, returns.ErrReturnsNotAccepted
+	}
+	if now.After(policy.Closes(deliveredAt)) {
+		return nil, returns.ErrWindowClosed
+	}
+
+	existing, err := u.repo.GetReturnByProduct(ctx, o.ID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, returns.ErrReturnExists
+	}
+
+	r := &returns.Return{
+		ID:         uuid.NewString(),
+		OrderID:    o.ID,
+		ProductID:  req.ProductID,
+		ConsumerID: consumerID,
+		ResellerID: o.ResellerID,
+		Reason:     req.Reason,
+		Note:       strings.TrimSpace(req.Note),
+		Photos:     req.Photos,
+		Status:     returns.StatusRequested,
+		Amount:     o.LineTotal(req.ProductID).Major(),
+		RespondBy:  now.Add(returns.ResponseWindow),
+		History:    []returns.Transition{{To: returns.StatusRequested, ActorID: consumerID, At: now}},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.CreateReturn(ctx, r); err != nil {
+			return err
+		}
+		_, err := u.scheduler.Schedule(ctx, job.TypeApproveReturn, map[string]string{"return_id": r.ID}, returns.ResponseWindow)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (u *returnsUsecase) GetReturn(ctx context.Context, id, actorID string, role user.Role) (*returns.Return, error) {
+	r, err := u.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsParty(actorID, role) {
+		// Other users' returns are not revealed.
+		return nil, returns.ErrReturnNotFound
+	}
+	return r, nil
+}
+
+func (u *returnsUsecase) ListMyReturns(ctx context.Context, userID string) ([]*returns.Return, error) {
+	return u.repo.ListReturnsByUser(ctx, userID)
+}
+
+func (u *returnsUsecase) Decide(ctx context.Context, id, resellerID string, approve bool, note string) (*returns.Return, error) {
+	r, err := u.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.ResellerID != resellerID {
+		return nil, order.ErrNotOrderParty
+	}
+
+	now := u.clock.Now()
+	note = strings.TrimSpace(note)
+	to := returns.StatusApproved
+	if !approve {
+		if note == "" {
+			return nil, returns.ErrReasonRequired
+		}
+		if r.Status == returns.StatusRequested && now.After(r.RespondBy) {
+			return nil, returns.ErrResponseOverdue
+		}
+		to = returns.StatusRejected
+	}
+
+	from := r.Status
+	if err := r.Move(to, resellerID, note, now); err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpdateReturn(ctx, r, from); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (u *returnsUsecase) MarkShipped(ctx context.Context, id, consumerID, trackingNumber string) (*returns.Return, error) {
+	r, err := u.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.ConsumerID != consumerID {
+		return nil, order.ErrNotOrderParty
+	}
+
+	from := r.Status
+	if err := r.Move(returns.StatusShippedBack, consumerID, "", u.clock.Now()); err != nil {
+		return nil, err
+	}
+	r.TrackingNumber = strings.TrimSpace(trackingNumber)
+	if err := u.repo.UpdateReturn(ctx, r, from); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConfirmReceipt records the return as received, restocks or writes off the
+// product and books the consumer's refund in one unit of work, so that a
+// refund that cannot be booked leaves the return open to be confirmed
+// again. The money goes back once the unit of work commits.
+func (u *returnsUsecase) ConfirmReceipt(ctx context.Context, id, resellerID string, condition returns.Condition) (*returns.Return, error) {
+	if !condition.IsValid() {
+		return nil, returns.ErrInvalidCondition
+	}
+	r, err := u.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.ResellerID != resellerID {
+		return nil, order.ErrNotOrderParty
+	}
+
+	from := r.Status
+	if err := r.Move(returns.StatusReceived, resellerID, string(condition), u.clock.Now()); err != nil {
+		return nil, err
+	}
+	r.Condition = condition
+
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateReturn(ctx, r, from); err != nil {
+			return err
+		}
+		if err := u.productRepo.UpdateProduct(ctx, r.ProductID, map[string]interface{}{"status": condition.ProductStatus()}); err != nil {
+			return err
+		}
+		refund, err := u.orders.RecordRefund(ctx, r.OrderID, resellerID, user.RoleReseller, r.Amount)
+		if err != nil {
+			return err
+		}
+		r.RefundID = refund.ID
+		return u.repo.UpdateReturn(ctx, r, returns.StatusReceived)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// A refund the gateway cannot take yet stays pending and is retried by
+	// the job queued with it.
+	_ = u.orders.SettleRefund(ctx, r.RefundID)
+	return r, nil
+}
+
+// ApproveOverdue does nothing for returns the reseller has answered or whose
+// window is still open, so it is safe to run more than once.
+func (u *returnsUsecase) ApproveOverdue(ctx context.Context, id string) error {
+	r, err := u.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	now := u.clock.Now()
+	if r.Status != returns.StatusRequested || now.Before(r.RespondBy) {
+		return nil
+	}
+
+	if err := r.Move(returns.StatusApproved, order.SystemActor, "not answered in time", now); err != nil {
+		return err
+	}
+	err = u.repo.UpdateReturn(ctx, r, returns.StatusRequested)
+	if errors.Is(err, returns.ErrReturnChanged) {
+		// The reseller answered in the meantime.
+		return nil
+	}
+	return err
+}
+
+func (u *returnsUsecase) GetPolicy(ctx context.Context, resellerID string) (*returns.Policy, error) {
+	p, err := u.repo.GetPolicy(ctx, resellerID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return returns.DefaultPolicy(resellerID), nil
+	}
+	return p, nil
+}
+
+func (u *returnsUsecase) SetPolicy(ctx context.Context, resellerID string, windowDays int) (*returns.Policy, error) {
+	p := &returns.Policy{ResellerID: resellerID, WindowDays: windowDays, UpdatedAt: u.clock.Now()}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if err := u.repo.SavePolicy(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (u *returnsUsecase) get(ctx context.Context, id string) (*returns.Return, error) {
+	r, err := u.repo.GetReturnByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, returns.ErrReturnNotFound
+	}
+	return r, nil
+}
@@ -0,0 +1,555 @@
+package returnsusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/returns"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReturnsRepo struct {
+	mock.Mock
+}
+
+func (m *MockReturnsRepo) CreateReturn(ctx context.Context, r *returns.Return) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockReturnsRepo) GetReturnByID(ctx context.Context, id string) (*returns.Return, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsRepo) GetReturnByProduct(ctx context.Context, orderID, productID string) (*returns.Return, error) {
+	args := m.Called(ctx, orderID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsRepo) ListReturnsByUser(ctx context.Context, userID string) ([]*returns.Return, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*returns.Return), args.Error(1)
+}
+
+func (m *MockReturnsRepo) UpdateReturn(ctx context.Context, r *returns.Return, from returns.Status) error {
+	args := m.Called(ctx, r, from)
+	return args.Error(0)
+}
+
+func (m *MockReturnsRepo) GetPolicy(ctx context.Context, resellerID string) (*returns.Policy, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*returns.Policy), args.Error(1)
+}
+
+func (m *MockReturnsRepo) SavePolicy(ctx context.Context, p *returns.Policy) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+type MockOrders struct {
+	mock.Mock
+}
+
+func (m *MockOrders) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrders) RecordRefund(ctx context.Context, orderID, actorID string, role user.Role, amount float64) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, actorID, role, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrders) SettleRefund(ctx context.Context, refundID string) error {
+	args := m.Called(ctx, refundID)
+	return args.Error(0)
+}
+
+type MockProductRepo struct {
+	mock.Mock
+}
+
+func (m *MockProductRepo) AddProduct(ctx context.Context, p *product.Product) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) GetProductByID(ctx context.Context, id string) (*product.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
+	args := m.Called(ctx, resellerID, page, limit)
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
+	args := m.Called(ctx, page, limit)
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) SearchProducts(ctx context.Context, q *product.Query) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
+func (m *MockProductRepo) DeleteProduct(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Relist(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Reserve(ctx context.Context, id string, r *offer.Reservation, now time.Time) error {
+	args := m.Called(ctx, id, r, now)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Unreserve(ctx context.Context, id, offerID string) error {
+	args := m.Called(ctx, id, offerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
+	args := m.Called(ctx, bundleID)
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// recordingScheduler keeps scheduled jobs in memory instead of queueing them.
+type recordingScheduler struct {
+	jobs []*job.Job
+}
+
+func (s *recordingScheduler) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	j := &job.Job{Type: jobType, Payload: payload, RunAt: testNow.Add(delay)}
+	s.jobs = append(s.jobs, j)
+	return j, nil
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	repo      *MockReturnsRepo
+	orders    *MockOrders
+	products  *MockProductRepo
+	uow       *passthroughUnitOfWork
+	scheduler *recordingScheduler
+	uc        returns.Usecase
+}
+
+func newFixture(now time.Time) *fixture {
+	f := &fixture{
+		repo:      new(MockReturnsRepo),
+		orders:    new(MockOrders),
+		products:  new(MockProductRepo),
+		uow:       &passthroughUnitOfWork{},
+		scheduler: &recordingScheduler{},
+	}
+	f.uc = NewReturnsUsecase(f.repo, f.orders, f.products, f.uow, f.scheduler, fixedClock{now: now})
+	return f
+}
+
+// deliveredOrder is a consumer order delivered the given number of days
+// before testNow.
+func deliveredOrder(daysAgo int) *order.Order {
+	o := &order.Order{
+		ID:         "order1",
+		ConsumerID: "consumer1",
+		ResellerID: "reseller1",
+		ProductIDs: []string{"p1", "p2"},
+		TotalPrice: money.InSettlement(90),
+		LineTotals: map[string]money.Money{"p1": money.InSettlement(60), "p2": money.InSettlement(30)},
+		Status:     order.OrderStatusDelivered,
+	}
+	o.History = []order.StatusTransition{
+		{To: order.OrderStatusPending, At: testNow.AddDate(0, 0, -daysAgo-5)},
+		{From: order.OrderStatusShipped, To: order.OrderStatusDelivered, At: testNow.AddDate(0, 0, -daysAgo)},
+	}
+	return o
+}
+
+func returnIn(status returns.Status) *returns.Return {
+	return &returns.Return{
+		ID:         "ret1",
+		OrderID:    "order1",
+		ProductID:  "p2",
+		ConsumerID: "consumer1",
+		ResellerID: "reseller1",
+		Reason:     returns.ReasonWrongSize,
+		Status:     status,
+		Amount:     30,
+		RespondBy:  testNow.Add(time.Hour),
+	}
+}
+
+func TestRequestReturn(t *testing.T) {
+	f := newFixture(testNow)
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(deliveredOrder(3), nil)
+	f.repo.On("GetPolicy", mock.Anything, "reseller1").Return(nil, nil)
+	f.repo.On("GetReturnByProduct", mock.Anything, "order1", "p2").Return(nil, nil)
+	f.repo.On("CreateReturn", mock.Anything, mock.Anything).Return(nil)
+
+	r, err := f.uc.RequestReturn(context.Background(), "consumer1", returns.Request{
+		OrderID:   "order1",
+		ProductID: "p2",
+		Reason:    returns.ReasonWrongSize,
+		Note:      " too small ",
+		Photos:    []returns.Photo{{URL: "https://img.example.com/tag.jpg"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, returns.StatusRequested, r.Status)
+	assert.Equal(t, "reseller1", r.ResellerID)
+	assert.Equal(t, 30.0, r.Amount)
+	assert.Equal(t, "too small", r.Note)
+	assert.True(t, r.RespondBy.Equal(testNow.Add(returns.ResponseWindow)))
+	assert.Equal(t, 1, f.uow.calls)
+	if assert.Len(t, f.scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeApproveReturn, f.scheduler.jobs[0].Type)
+		assert.Equal(t, r.ID, f.scheduler.jobs[0].Payload["return_id"])
+	}
+}
+
+func TestRequestReturn_RequestedConcurrently(t *testing.T) {
+	f := newFixture(testNow)
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(deliveredOrder(3), nil)
+	f.repo.On("GetPolicy", mock.Anything, "reseller1").Return(nil, nil)
+	f.repo.On("GetReturnByProduct", mock.Anything, "order1", "p2").Return(nil, nil)
+	// Another request for the line was created after the check above.
+	f.repo.On("CreateReturn", mock.Anything, mock.Anything).Return(returns.ErrReturnExists)
+
+	_, err := f.uc.RequestReturn(context.Background(), "consumer1", returns.Request{OrderID: "order1", ProductID: "p2", Reason: returns.ReasonWrongSize})
+
+	assert.ErrorIs(t, err, returns.ErrReturnExists)
+	assert.Empty(t, f.scheduler.jobs)
+}
+
+func TestRequestReturn_Rejected(t *testing.T) {
+	shipped := deliveredOrder(0)
+	shipped.Status = order.OrderStatusShipped
+	shipped.History = shipped.History[:1]
+
+	tests := []struct {
+		name     string
+		order    *order.Order
+		policy   *returns.Policy
+		existing *returns.Return
+		req      returns.Request
+		wantErr  error
+	}{
+		{
+			name:    "unknown reason",
+			order:   deliveredOrder(3),
+			req:     returns.Request{OrderID: "order1", ProductID: "p1", Reason: "ugly"},
+			wantErr: returns.ErrInvalidReason,
+		},
+		{
+			name:    "photo is not a link",
+			order:   deliveredOrder(3),
+			req:     returns.Request{OrderID: "order1", ProductID: "p1", Reason: returns.ReasonDamaged, Photos: []returns.Photo{{URL: "file:///tmp/a.jpg"}}},
+			wantErr: returns.ErrInvalidPhoto,
+		},
+		{
+			name:    "product not in order",
+			order:   deliveredOrder(3),
+			req:     returns.Request{OrderID: "order1", ProductID: "p9", Reason: returns.ReasonDamaged},
+			wantErr: returns.ErrNotInOrder,
+		},
+		{
+			name:    "not delivered yet",
+			order:   shipped,
+			req:     returns.Request{OrderID: "order1", ProductID: "p1", Reason: returns.ReasonDamaged},
+			wantErr: returns.ErrNotReturnable,
+		},
+		{
+			name:    "default window has closed",
+			order:   deliveredOrder(returns.DefaultWindowDays + 1),
+			req:     returns.Request{OrderID: "order1", ProductID: "p1", Reason: returns.ReasonDamaged},
+			wantErr: returns.ErrWindowClosed,
+		},
+		{
+			name:    "shop window has closed",
+			order:   deliveredOrder(8),
+			policy:  &returns.Policy{ResellerID: "reseller1", WindowDays: 7},
+			req:     returns.Request{OrderID: "order1", ProductID: "p1", Reason: returns.ReasonDamaged},
+			wantErr: returns.ErrWindowClosed,
+		},
+		{
+			name:    "shop takes no returns",
+			order:   deliveredOrder(1),
+			policy:  &returns.Policy{ResellerID: "reseller1"},
+			req:     returns.Request{OrderID: "order1", ProductID: "p1", Reason: returns.ReasonDamaged},
+			wantErr: returns.ErrReturnsNotAccepted,
+		},
+		{
+			name:     "already returned",
+			order:    deliveredOrder(1),
+			existing: returnIn(returns.StatusReceived),
+			req:      returns.Request{OrderID: "order1", ProductID: "p1", Reason: returns.ReasonDamaged},
+			wantErr:  returns.ErrReturnExists,
+		},
+		{
+			name:    "someone else's order",
+			order:   &order.Order{ID: "order1", ConsumerID: "consumer2", ProductIDs: []string{"p1"}},
+			req:     returns.Request{OrderID: "order1", ProductID: "p1", Reason: returns.ReasonDamaged},
+			wantErr: order.ErrNotOrderParty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(testNow)
+			f.orders.On("GetOrderByID", mock.Anything, "order1").Return(tt.order, nil)
+			f.repo.On("GetPolicy", mock.Anything, "reseller1").Return(tt.policy, nil).Maybe()
+			f.repo.On("GetReturnByProduct", mock.Anything, "order1", "p1").Return(tt.existing, nil).Maybe()
+
+			_, err := f.uc.RequestReturn(context.Background(), "consumer1", tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			f.repo.AssertNotCalled(t, "CreateReturn", mock.Anything, mock.Anything)
+			assert.Empty(t, f.scheduler.jobs)
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name       string
+		now        time.Time
+		approve    bool
+		note       string
+		wantStatus returns.Status
+		wantErr    error
+	}{
+		{name: "approve", now: testNow, approve: true, wantStatus: returns.StatusApproved},
+		{name: "reject with reason", now: testNow, note: "worn after delivery", wantStatus: returns.StatusRejected},
+		{name: "reject without reason", now: testNow, note: " ", wantErr: returns.ErrReasonRequired},
+		{name: "reject after deadline", now: testNow.Add(2 * time.Hour), note: "worn", wantErr: returns.ErrResponseOverdue},
+		{name: "approve after deadline", now: testNow.Add(2 * time.Hour), approve: true, wantStatus: returns.StatusApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.now)
+			f.repo.On("GetReturnByID", mock.Anything, "ret1").Return(returnIn(returns.StatusRequested), nil)
+			f.repo.On("UpdateReturn", mock.Anything, mock.Anything, returns.StatusRequested).Return(nil).Maybe()
+
+			r, err := f.uc.Decide(context.Background(), "ret1", "reseller1", tt.approve, tt.note)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				f.repo.AssertNotCalled(t, "UpdateReturn", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, r.Status)
+			assert.Equal(t, "reseller1", r.History[len(r.History)-1].ActorID)
+		})
+	}
+}
+
+func TestDecide_NotTheSeller(t *testing.T) {
+	f := newFixture(testNow)
+	f.repo.On("GetReturnByID", mock.Anything, "ret1").Return(returnIn(returns.StatusRequested), nil)
+
+	_, err := f.uc.Decide(context.Background(), "ret1", "reseller2", true, "")
+
+	assert.ErrorIs(t, err, order.ErrNotOrderParty)
+}
+
+func TestMarkShipped_BeforeApproval(t *testing.T) {
+	f := newFixture(testNow)
+	f.repo.On("GetReturnByID", mock.Anything, "ret1").Return(returnIn(returns.StatusRequested), nil)
+
+	_, err := f.uc.MarkShipped(context.Background(), "ret1", "consumer1", "TRK1")
+
+	assert.ErrorIs(t, err, returns.ErrInvalidTransition)
+}
+
+func TestConfirmReceipt(t *testing.T) {
+	tests := []struct {
+		name          string
+		condition     returns.Condition
+		productStatus string
+	}{
+		{"resellable goes back on sale", returns.ConditionResellable, "available"},
+		{"damaged is taken off sale", returns.ConditionDamaged, "damaged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(testNow)
+			f.repo.On("GetReturnByID", mock.Anything, "ret1").Return(returnIn(returns.StatusShippedBack), nil)
+			f.repo.On("UpdateReturn", mock.Anything, mock.Anything, returns.StatusShippedBack).Return(nil)
+			f.repo.On("UpdateReturn", mock.Anything, mock.Anything, returns.StatusReceived).Return(nil)
+			f.products.On("UpdateProduct", mock.Anything, "p2", map[string]interface{}{"status": tt.productStatus}).Return(nil)
+			f.orders.On("RecordRefund", mock.Anything, "order1", "reseller1", user.RoleReseller, 30.0).Return(&payment.Payment{ID: "refund1", Amount: money.InSettlement(-30)}, nil)
+			f.orders.On("SettleRefund", mock.Anything, "refund1").Return(nil)
+
+			r, err := f.uc.ConfirmReceipt(context.Background(), "ret1", "reseller1", tt.condition)
+
+			assert.NoError(t, err)
+			assert.Equal(t, returns.StatusReceived, r.Status)
+			assert.Equal(t, tt.condition, r.Condition)
+			assert.Equal(t, "refund1", r.RefundID)
+			assert.Equal(t, 1, f.uow.calls)
+			f.products.AssertExpectations(t)
+			f.orders.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConfirmReceipt_RefundFails(t *testing.T) {
+	f := newFixture(testNow)
+	f.repo.On("GetReturnByID", mock.Anything, "ret1").Return(returnIn(returns.StatusApproved), nil)
+	f.repo.On("UpdateReturn", mock.Anything, mock.Anything, returns.StatusApproved).Return(nil)
+	f.products.On("UpdateProduct", mock.Anything, "p2", mock.Anything).Return(nil)
+	f.orders.On("RecordRefund", mock.Anything, "order1", "reseller1", user.RoleReseller, 30.0).Return(nil, errors.New("ledger down"))
+
+	_, err := f.uc.ConfirmReceipt(context.Background(), "ret1", "reseller1", returns.ConditionResellable)
+
+	assert.EqualError(t, err, "ledger down")
+	f.repo.AssertNotCalled(t, "UpdateReturn", mock.Anything, mock.Anything, returns.StatusReceived)
+	f.orders.AssertNotCalled(t, "SettleRefund", mock.Anything, mock.Anything)
+}
+
+func TestConfirmReceipt_GatewayDownLeavesRefundPending(t *testing.T) {
+	f := newFixture(testNow)
+	f.repo.On("GetReturnByID", mock.Anything, "ret1").Return(returnIn(returns.StatusApproved), nil)
+	f.repo.On("UpdateReturn", mock.Anything, mock.Anything, returns.StatusApproved).Return(nil)
+	f.repo.On("UpdateReturn", mock.Anything, mock.Anything, returns.StatusReceived).Return(nil)
+	f.products.On("UpdateProduct", mock.Anything, "p2", mock.Anything).Return(nil)
+	f.orders.On("RecordRefund", mock.Anything, "order1", "reseller1", user.RoleReseller, 30.0).Return(&payment.Payment{ID: "refund1", Status: payment.StatusRefundPending}, nil)
+	f.orders.On("SettleRefund", mock.Anything, "refund1").Return(errors.New("gateway down"))
+
+	r, err := f.uc.ConfirmReceipt(context.Background(), "ret1", "reseller1", returns.ConditionResellable)
+
+	assert.NoError(t, err)
+	assert.Equal(t, returns.StatusReceived, r.Status)
+	assert.Equal(t, "refund1", r.RefundID)
+}
+
+func TestApproveOverdue(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     returns.Status
+		now        time.Time
+		wantUpdate bool
+	}{
+		{"unanswered after deadline", returns.StatusRequested, testNow.Add(2 * time.Hour), true},
+		{"deadline not reached", returns.StatusRequested, testNow, false},
+		{"already answered", returns.StatusRejected, testNow.Add(2 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.now)
+			r := returnIn(tt.status)
+			f.repo.On("GetReturnByID", mock.Anything, "ret1").Return(r, nil)
+			f.repo.On("UpdateReturn", mock.Anything, r, returns.StatusRequested).Return(nil).Maybe()
+
+			err := f.uc.ApproveOverdue(context.Background(), "ret1")
+
+			assert.NoError(t, err)
+			if tt.wantUpdate {
+				assert.Equal(t, returns.StatusApproved, r.Status)
+				assert.Equal(t, order.SystemActor, r.History[len(r.History)-1].ActorID)
+				f.repo.AssertExpectations(t)
+			} else {
+				f.repo.AssertNotCalled(t, "UpdateReturn", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestApproveOverdue_ResellerAnsweredMeanwhile(t *testing.T) {
+	f := newFixture(testNow.Add(2 * time.Hour))
+	f.repo.On("GetReturnByID", mock.Anything, "ret1").Return(returnIn(returns.StatusRequested), nil)
+	f.repo.On("UpdateReturn", mock.Anything, mock.Anything, returns.StatusRequested).Return(returns.ErrReturnChanged)
+
+	err := f.uc.ApproveOverdue(context.Background(), "ret1")
+
+	assert.NoError(t, err)
+}
+
+func TestSetPolicy(t *testing.T) {
+	f := newFixture(testNow)
+	f.repo.On("SavePolicy", mock.Anything, &returns.Policy{ResellerID: "reseller1", WindowDays: 30, UpdatedAt: testNow}).Return(nil)
+
+	p, err := f.uc.SetPolicy(context.Background(), "reseller1", 30)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30, p.WindowDays)
+
+	_, err = f.uc.SetPolicy(context.Background(), "reseller1", returns.MaxWindowDays+1)
+	assert.ErrorIs(t, err, returns.ErrInvalidPolicy)
+	f.repo.AssertNumberOfCalls(t, "SavePolicy", 1)
+}
@@ -28,7 +28,7 @@ func (u *reviewUsecase) SubmitReview(ctx context.Context, r *review.Review) erro
 	if err != nil || order == nil {
 		return errors.New("order not found")
 	}
-	if order.Status != "Delivered" { 
+	if !order.Status.IsFulfilled() {
 		return errors.New("cannot review before delivery")
 	}
 
@@ -0,0 +1,228 @@
+package shipmentusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// trackingPollInterval is how often a shipment in transit is checked with its carrier.
+const trackingPollInterval = time.Minute
+
+type shipmentUsecase struct {
+	shipmentRepo shipment.Repository
+	orderRepo    order.Repository
+	unitOfWork   uow.UnitOfWork
+	scheduler    job.Scheduler
+	clock        job.Clock
+	carriers     map[string]shipment.Carrier
+}
+
+// NewShipmentUsecase creates the fulfilment usecase. Resellers can ship with
+// any carrier; parcels sent with one of the given carriers, looked up by name,
+// are tracked until delivered. Other parcels are delivered when the consumer
+// confirms delivery.
+func NewShipmentUsecase(shipmentRepo shipment.Repository, orderRepo order.Repository, unitOfWork uow.UnitOfWork, scheduler job.Scheduler, clock job.Clock, carriers ...shipment.Carrier) shipment.Usecase {
+	byName := make(map[string]shipment.Carrier, len(carriers))
+	for _, c := range carriers {
+		byName[c.Name()] = c
+	}
+	return &shipmentUsecase{
+		shipmentRepo: shipmentRepo,
+		orderRepo:    orderRepo,
+		unitOfWork:   unitOfWork,
+		scheduler:    scheduler,
+		clock:        clock,
+		carriers:     byName,
+	}
+}
+
+func (u *shipmentUsecase) ShipOrder(ctx context.Context, orderID, resellerID, carrierName, trackingNumber string) (*shipment.Shipment, error) {
+	if carrierName == "" {
+		return nil, shipment.ErrCarrierRequired
+	}
+	if trackingNumber == "" {
+		return nil, shipment.ErrTrackingNumberRequired
+	}
+
+	o, err := u.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if o.IsBundleOrder() {
+		return nil, shipment.ErrNotConsumerOrder
+	}
+	if o.ResellerID != resellerID {
+		return nil, order.ErrNotOrderParty
+	}
+
+	t, err := o.Transition(order.OrderStatusShipped, resellerID, fmt.Sprintf("shipped with %s, tracking number %s", carrierName, trackingNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.clock.Now()
+	s := &shipment.Shipment{
+		ID:             uuid.NewString(),
+		OrderID:        o.ID,
+		ResellerID:     o.ResellerID,
+		ConsumerID:     o.ConsumerID,
+		Carrier:        carrierName,
+		TrackingNumber: trackingNumber,
+		Status:         shipment.StatusInTransit,
+		ShippedAt:      now,
+	}
+	carrier, tracked := u.carriers[carrierName]
+	if tracked {
+		eta := carrier.EstimateDelivery(now)
+		s.EstimatedDelivery = &eta
+	}
+
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.orderRepo.TransitionStatus(ctx, o.ID, t); err != nil {
+			return err
+		}
+		if err := u.shipmentRepo.CreateShipment(ctx, s); err != nil {
+			return err
+		}
+		if !tracked {
+			return nil
+		}
+		_, err := u.scheduler.Schedule(ctx, job.TypeTrackShipment, map[string]string{"order_id": o.ID}, trackingPollInterval)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (u *shipmentUsecase) GetTracking(ctx context.Context, orderID, actorID string, role user.Role) (*shipment.Shipment, error) {
+	o, err := u.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if !o.IsParty(actorID, role) {
+		return nil, order.ErrNotOrderParty
+	}
+	return u.getShipment(ctx, orderID)
+}
+
+func (u *shipmentUsecase) ConfirmDelivery(ctx context.Context, orderID, actorID string, role user.Role) (*shipment.Shipment, error) {
+	o, err := u.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if role != user.RoleAdmin && (role != user.RoleConsumer || o.ConsumerID != actorID) {
+		return nil, order.ErrNotOrderParty
+	}
+
+	s, err := u.getShipment(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	t, err := o.Transition(order.OrderStatusDelivered, actorID, "delivery confirmed")
+	if err != nil {
+		return nil, err
+	}
+
+	events := append(s.Events, shipment.TrackingEvent{
+		Status:      shipment.StatusDelivered,
+		Description: "Delivery confirmed by recipient",
+		At:          u.clock.Now(),
+	})
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.orderRepo.TransitionStatus(ctx, o.ID, t); err != nil {
+			return err
+		}
+		return u.shipmentRepo.UpdateTracking(ctx, s.ID, shipment.StatusDelivered, events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.Status = shipment.StatusDelivered
+	s.Events = events
+	return s, nil
+}
+
+func (u *shipmentUsecase) SyncTracking(ctx context.Context, orderID string) error {
+	s, err := u.getShipment(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if s.Status == shipment.StatusDelivered {
+		return nil
+	}
+	carrier, ok := u.carriers[s.Carrier]
+	if !ok {
+		// Nothing to pull; the consumer confirms the delivery.
+		return nil
+	}
+
+	events, err := carrier.Track(ctx, s.TrackingNumber, s.ShippedAt)
+	if err != nil {
+		return err
+	}
+	status := s.Status
+	if len(events) > 0 {
+		status = events[len(events)-1].Status
+	}
+	if err := u.shipmentRepo.UpdateTracking(ctx, s.ID, status, events); err != nil {
+		return err
+	}
+
+	if status != shipment.StatusDelivered {
+		_, err := u.scheduler.Schedule(ctx, job.TypeTrackShipment, map[string]string{"order_id": orderID}, trackingPollInterval)
+		return err
+	}
+	return u.deliverOrder(ctx, orderID)
+}
+
+// deliverOrder records the carrier's delivery on the order. An order that
+// was confirmed delivered or canceled in the meantime is left as it is.
+func (u *shipmentUsecase) deliverOrder(ctx context.Context, orderID string) error {
+	o, err := u.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if o == nil {
+		return order.ErrOrderNotFound
+	}
+	t, err := o.Transition(order.OrderStatusDelivered, order.SystemActor, "delivered by carrier")
+	if errors.Is(err, order.ErrInvalidTransition) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return u.orderRepo.TransitionStatus(ctx, o.ID, t)
+}
+
+func (u *shipmentUsecase) getShipment(ctx context.Context, orderID string) (*shipment.Shipment, error) {
+	s, err := u.shipmentRepo.GetShipmentByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, shipment.ErrShipmentNotFound
+	}
+	return s, nil
+}
@@ -0,0 +1,312 @@
+package shipmentusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/carrier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockShipmentRepo struct {
+	mock.Mock
+}
+
+func (m *MockShipmentRepo) CreateShipment(ctx context.Context, s *shipment.Shipment) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockShipmentRepo) GetShipmentByOrder(ctx context.Context, orderID string) (*shipment.Shipment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipment.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepo) UpdateTracking(ctx context.Context, id string, status shipment.Status, events []shipment.TrackingEvent) error {
+	args := m.Called(ctx, id, status, events)
+	return args.Error(0)
+}
+
+type MockOrderRepo struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepo) CreateOrder(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, consumerID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) TransitionStatus(ctx context.Context, orderID string, t order.StatusTransition) error {
+	args := m.Called(ctx, orderID, t)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) DeleteOrder(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepo) GetOrdersBySupplier(ctx context.Context, supplierID string) ([]*order.Order, error) {
+	args := m.Called(ctx, supplierID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepo) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, error) {
+	args := m.Called(ctx, resellerID)
+	return args.Get(0).([]*order.Order), args.Error(1)
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
+// recordingScheduler keeps scheduled jobs in memory instead of queueing them.
+type recordingScheduler struct {
+	jobs []*job.Job
+}
+
+func (s *recordingScheduler) Schedule(ctx context.Context, jobType string, payload map[string]string, delay time.Duration) (*job.Job, error) {
+	j := &job.Job{Type: jobType, Payload: payload, RunAt: testNow.Add(delay)}
+	s.jobs = append(s.jobs, j)
+	return j, nil
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	shipments *MockShipmentRepo
+	orders    *MockOrderRepo
+	uow       *passthroughUnitOfWork
+	scheduler *recordingScheduler
+	uc        shipment.Usecase
+}
+
+func newFixture(now time.Time) *fixture {
+	f := &fixture{
+		shipments: new(MockShipmentRepo),
+		orders:    new(MockOrderRepo),
+		uow:       &passthroughUnitOfWork{},
+		scheduler: &recordingScheduler{},
+	}
+	clock := fixedClock{now: now}
+	f.uc = NewShipmentUsecase(f.shipments, f.orders, f.uow, f.scheduler, clock, carrier.NewLocalCarrier(clock))
+	return f
+}
+
+func consumerOrder(status order.OrderStatus) *order.Order {
+	return &order.Order{
+		ID:         "order1",
+		ResellerID: "reseller1",
+		ConsumerID: "consumer1",
+		ProductIDs: []string{"product1"},
+		Status:     status,
+	}
+}
+
+func TestShipOrder(t *testing.T) {
+	f := newFixture(testNow)
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(order.OrderStatusPending), nil)
+	f.orders.On("TransitionStatus", mock.Anything, "order1", mock.MatchedBy(func(tr order.StatusTransition) bool {
+		return tr.From == order.OrderStatusPending && tr.To == order.OrderStatusShipped && tr.ActorID == "reseller1"
+	})).Return(nil)
+	f.shipments.On("CreateShipment", mock.Anything, mock.MatchedBy(func(s *shipment.Shipment) bool {
+		return s.OrderID == "order1" && s.ConsumerID == "consumer1" && s.TrackingNumber == "TRK123"
+	})).Return(nil)
+
+	s, err := f.uc.ShipOrder(context.Background(), "order1", "reseller1", carrier.LocalCarrierName, "TRK123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, shipment.StatusInTransit, s.Status)
+	assert.Equal(t, testNow.Add(3*time.Minute), *s.EstimatedDelivery)
+	assert.Equal(t, 1, f.uow.calls)
+	if assert.Len(t, f.scheduler.jobs, 1) {
+		assert.Equal(t, job.TypeTrackShipment, f.scheduler.jobs[0].Type)
+		assert.Equal(t, "order1", f.scheduler.jobs[0].Payload["order_id"])
+	}
+	f.orders.AssertExpectations(t)
+	f.shipments.AssertExpectations(t)
+}
+
+func TestShipOrder_UntrackedCarrier(t *testing.T) {
+	f := newFixture(testNow)
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(order.OrderStatusPending), nil)
+	f.orders.On("TransitionStatus", mock.Anything, "order1", mock.MatchedBy(func(tr order.StatusTransition) bool {
+		return tr.To == order.OrderStatusShipped
+	})).Return(nil)
+	f.shipments.On("CreateShipment", mock.Anything, mock.MatchedBy(func(s *shipment.Shipment) bool {
+		return s.Carrier == "pigeon" && s.TrackingNumber == "TRK123"
+	})).Return(nil)
+
+	s, err := f.uc.ShipOrder(context.Background(), "order1", "reseller1", "pigeon", "TRK123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, shipment.StatusInTransit, s.Status)
+	assert.Nil(t, s.EstimatedDelivery)
+	// There is nobody to poll, so the consumer confirms the delivery.
+	assert.Empty(t, f.scheduler.jobs)
+	f.orders.AssertExpectations(t)
+	f.shipments.AssertExpectations(t)
+}
+
+func TestShipOrder_Rejected(t *testing.T) {
+	bundleOrder := &order.Order{ID: "order1", ResellerID: "reseller1", BundleID: "bundle1", Status: order.OrderStatusPending}
+
+	tests := []struct {
+		name       string
+		order      *order.Order
+		resellerID string
+		carrier    string
+		tracking   string
+		wantErr    error
+	}{
+		{"missing tracking number", consumerOrder(order.OrderStatusPending), "reseller1", carrier.LocalCarrierName, "", shipment.ErrTrackingNumberRequired},
+		{"missing carrier", consumerOrder(order.OrderStatusPending), "reseller1", "", "TRK123", shipment.ErrCarrierRequired},
+		{"order not found", nil, "reseller1", carrier.LocalCarrierName, "TRK123", order.ErrOrderNotFound},
+		{"bundle order", bundleOrder, "reseller1", carrier.LocalCarrierName, "TRK123", shipment.ErrNotConsumerOrder},
+		{"other reseller", consumerOrder(order.OrderStatusPending), "reseller2", carrier.LocalCarrierName, "TRK123", order.ErrNotOrderParty},
+		{"already canceled", consumerOrder(order.OrderStatusCanceled), "reseller1", carrier.LocalCarrierName, "TRK123", order.ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(testNow)
+			if tt.order != nil {
+				f.orders.On("GetOrderByID", mock.Anything, "order1").Return(tt.order, nil).Maybe()
+			} else {
+				f.orders.On("GetOrderByID", mock.Anything, "order1").Return(nil, nil).Maybe()
+			}
+
+			_, err := f.uc.ShipOrder(context.Background(), "order1", tt.resellerID, tt.carrier, tt.tracking)
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			assert.Equal(t, 0, f.uow.calls)
+			assert.Empty(t, f.scheduler.jobs)
+			f.orders.AssertNotCalled(t, "TransitionStatus", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetTracking_NotParty(t *testing.T) {
+	f := newFixture(testNow)
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(order.OrderStatusShipped), nil)
+
+	_, err := f.uc.GetTracking(context.Background(), "order1", "consumer2", user.RoleConsumer)
+
+	assert.ErrorIs(t, err, order.ErrNotOrderParty)
+	f.shipments.AssertNotCalled(t, "GetShipmentByOrder", mock.Anything, mock.Anything)
+}
+
+func TestSyncTracking_InTransitReschedules(t *testing.T) {
+	f := newFixture(testNow.Add(90 * time.Second))
+	s := &shipment.Shipment{ID: "ship1", OrderID: "order1", Carrier: carrier.LocalCarrierName, Status: shipment.StatusInTransit, ShippedAt: testNow}
+	f.shipments.On("GetShipmentByOrder", mock.Anything, "order1").Return(s, nil)
+	f.shipments.On("UpdateTracking", mock.Anything, "ship1", shipment.StatusInTransit, mock.MatchedBy(func(events []shipment.TrackingEvent) bool {
+		return len(events) == 2
+	})).Return(nil)
+
+	err := f.uc.SyncTracking(context.Background(), "order1")
+
+	assert.NoError(t, err)
+	assert.Len(t, f.scheduler.jobs, 1)
+	f.orders.AssertNotCalled(t, "GetOrderByID", mock.Anything, mock.Anything)
+	f.shipments.AssertExpectations(t)
+}
+
+func TestSyncTracking_DeliveredMarksOrder(t *testing.T) {
+	f := newFixture(testNow.Add(5 * time.Minute))
+	s := &shipment.Shipment{ID: "ship1", OrderID: "order1", Carrier: carrier.LocalCarrierName, Status: shipment.StatusOutForDelivery, ShippedAt: testNow}
+	f.shipments.On("GetShipmentByOrder", mock.Anything, "order1").Return(s, nil)
+	f.shipments.On("UpdateTracking", mock.Anything, "ship1", shipment.StatusDelivered, mock.Anything).Return(nil)
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(order.OrderStatusShipped), nil)
+	f.orders.On("TransitionStatus", mock.Anything, "order1", mock.MatchedBy(func(tr order.StatusTransition) bool {
+		return tr.To == order.OrderStatusDelivered && tr.ActorID == order.SystemActor
+	})).Return(nil)
+
+	err := f.uc.SyncTracking(context.Background(), "order1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, f.scheduler.jobs)
+	f.orders.AssertExpectations(t)
+	f.shipments.AssertExpectations(t)
+}
+
+func TestSyncTracking_OrderAlreadyDelivered(t *testing.T) {
+	f := newFixture(testNow.Add(5 * time.Minute))
+	s := &shipment.Shipment{ID: "ship1", OrderID: "order1", Carrier: carrier.LocalCarrierName, Status: shipment.StatusOutForDelivery, ShippedAt: testNow}
+	f.shipments.On("GetShipmentByOrder", mock.Anything, "order1").Return(s, nil)
+	f.shipments.On("UpdateTracking", mock.Anything, "ship1", shipment.StatusDelivered, mock.Anything).Return(nil)
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(order.OrderStatusCanceled), nil)
+
+	err := f.uc.SyncTracking(context.Background(), "order1")
+
+	assert.NoError(t, err)
+	f.orders.AssertNotCalled(t, "TransitionStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConfirmDelivery(t *testing.T) {
+	f := newFixture(testNow.Add(time.Minute))
+	s := &shipment.Shipment{ID: "ship1", OrderID: "order1", Carrier: carrier.LocalCarrierName, Status: shipment.StatusInTransit, ShippedAt: testNow}
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(order.OrderStatusShipped), nil)
+	f.shipments.On("GetShipmentByOrder", mock.Anything, "order1").Return(s, nil)
+	f.orders.On("TransitionStatus", mock.Anything, "order1", mock.MatchedBy(func(tr order.StatusTransition) bool {
+		return tr.To == order.OrderStatusDelivered && tr.ActorID == "consumer1"
+	})).Return(nil)
+	f.shipments.On("UpdateTracking", mock.Anything, "ship1", shipment.StatusDelivered, mock.Anything).Return(nil)
+
+	got, err := f.uc.ConfirmDelivery(context.Background(), "order1", "consumer1", user.RoleConsumer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, shipment.StatusDelivered, got.Status)
+	assert.Equal(t, "Delivery confirmed by recipient", got.Events[len(got.Events)-1].Description)
+	assert.Equal(t, 1, f.uow.calls)
+	f.orders.AssertExpectations(t)
+	f.shipments.AssertExpectations(t)
+}
+
+func TestConfirmDelivery_ResellerCannotConfirm(t *testing.T) {
+	f := newFixture(testNow)
+	f.orders.On("GetOrderByID", mock.Anything, "order1").Return(consumerOrder(order.OrderStatusShipped), nil)
+
+	_, err := f.uc.ConfirmDelivery(context.Background(), "order1", "reseller1", user.RoleReseller)
+
+	assert.ErrorIs(t, err, order.ErrNotOrderParty)
+	assert.Equal(t, 0, f.uow.calls)
+}
@@ -0,0 +1,122 @@
+package taxusecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/job"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type taxUsecase struct {
+	repo        tax.Repository
+	userRepo    user.Repository
+	paymentRepo payment.Repository
+	clock       job.Clock
+}
+
+// NewTaxUsecase creates the tax usecase. userRepo is used to find the
+// jurisdictions of sellers and buyers when a rule depends on them, and
+// paymentRepo to report the tax collected.
+func NewTaxUsecase(repo tax.Repository, userRepo user.Repository, paymentRepo payment.Repository, clock job.Clock) tax.Usecase {
+	return &taxUsecase{repo: repo, userRepo: userRepo, paymentRepo: paymentRepo, clock: clock}
+}
+
+func (u *taxUsecase) GetPolicy(ctx context.Context) (*tax.Policy, error) {
+	p, err := u.repo.GetCurrentPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return tax.DefaultPolicy(), nil
+	}
+	return p, nil
+}
+
+func (u *taxUsecase) UpdatePolicy(ctx context.Context, adminID string, p *tax.Policy) (*tax.Policy, error) {
+	if p.Mode == "" {
+		p.Mode = tax.ModeExclusive
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	current, err := u.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Normalize()
+	p.Version = current.Version + 1
+	p.UpdatedBy = adminID
+	p.UpdatedAt = u.clock.Now()
+	if p.Rules == nil {
+		p.Rules = []tax.Rule{}
+	}
+	if err := u.repo.SavePolicy(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (u *taxUsecase) GetPolicyHistory(ctx context.Context) ([]*tax.Policy, error) {
+	return u.repo.ListPolicies(ctx)
+}
+
+func (u *taxUsecase) Assess(ctx context.Context, s tax.Sale) (tax.Assessment, error) {
+	p, err := u.GetPolicy(ctx)
+	if err != nil {
+		return tax.Assessment{}, err
+	}
+
+	if s.SellerJurisdiction == "" && p.NeedsSeller() {
+		if s.SellerJurisdiction, err = u.jurisdiction(ctx, s.SellerID); err != nil {
+			return tax.Assessment{}, err
+		}
+	}
+	if s.BuyerJurisdiction == "" && p.NeedsBuyer() {
+		if s.BuyerJurisdiction, err = u.jurisdiction(ctx, s.BuyerID); err != nil {
+			return tax.Assessment{}, err
+		}
+	}
+	s.SellerJurisdiction = strings.ToUpper(s.SellerJurisdiction)
+	s.BuyerJurisdiction = strings.ToUpper(s.BuyerJurisdiction)
+	return p.Assess(s), nil
+}
+
+// jurisdiction is the country on a user's profile. A user who has none, or
+// has since been removed, is only taxed by rules that do not name one.
+func (u *taxUsecase) jurisdiction(ctx context.Context, userID string) (string, error) {
+	usr, err := u.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", nil
+	}
+	if err != nil || usr == nil {
+		return "", err
+	}
+	return usr.Country, nil
+}
+
+func (u *taxUsecase) GetReport(ctx context.Context, from, to time.Time, period tax.Period) (*tax.Report, error) {
+	payments, err := u.paymentRepo.ListPaymentsBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &tax.Report{From: from, To: to, Period: period, Rows: []tax.ReportRow{}}
+	for _, p := range payments {
+		r.Add(p.CreatedAt, p.TaxLines)
+	}
+	sort.SliceStable(r.Rows, func(i, j int) bool {
+		if r.Rows[i].Period != r.Rows[j].Period {
+			return r.Rows[i].Period < r.Rows[j].Period
+		}
+		return r.Rows[i].Rule < r.Rows[j].Rule
+	})
+	return r, nil
+}
@@ -0,0 +1,376 @@
+package taxusecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/tax"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MockTaxRepo struct {
+	mock.Mock
+}
+
+func (m *MockTaxRepo) GetCurrentPolicy(ctx context.Context) (*tax.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tax.Policy), args.Error(1)
+}
+
+func (m *MockTaxRepo) SavePolicy(ctx context.Context, p *tax.Policy) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockTaxRepo) ListPolicies(ctx context.Context) ([]*tax.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*tax.Policy), args.Error(1)
+}
+
+type MockUserRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserRepo) CreateUser(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByID(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) ListUsersByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
+	args := m.Called(ctx, role)
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) FindUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateTrustData(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+func (m *MockUserRepo) CountActiveUsers(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+type MockPaymentRepo struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepo) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByUser(ctx context.Context, userID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID, pType)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByOrder(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentByID(ctx context.Context, id string) (*payment.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) ReserveRefund(ctx context.Context, id string, refunded, amount money.Money) error {
+	args := m.Called(ctx, id, refunded, amount)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) GetAllPlatformFees(ctx context.Context) (float64, float64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
+}
+
+func (m *MockPaymentRepo) ListUnsettledPayments(ctx context.Context) ([]*payment.Payment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) ListPaymentsBetween(ctx context.Context, from, to time.Time) ([]*payment.Payment, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) AssignPayout(ctx context.Context, paymentIDs []string, payoutID string) error {
+	args := m.Called(ctx, paymentIDs, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) ReleasePayout(ctx context.Context, payoutID string) error {
+	args := m.Called(ctx, payoutID)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepo) UpdatePaymentStatus(ctx context.Context, id, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+// fixedClock always reports the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func testPolicy() *tax.Policy {
+	return &tax.Policy{
+		Version: 2,
+		Mode:    tax.ModeExclusive,
+		Rules: []tax.Rule{
+			{Name: "et_vat", SellerJurisdiction: "ET", Rate: 0.15},
+			{Name: "et_b2b", SaleType: tax.SaleB2B, SellerJurisdiction: "ET", BuyerJurisdiction: "ET", Rate: 0.1},
+			{Name: "export", SellerJurisdiction: "ET", BuyerJurisdiction: "KE", Rate: 0},
+		},
+	}
+}
+
+func TestAssess(t *testing.T) {
+	tests := []struct {
+		name     string
+		sale     tax.Sale
+		wantRule string
+		wantTax  float64
+	}{
+		{"seller rule", tax.Sale{Type: tax.SaleB2C, SellerID: "reseller1", BuyerJurisdiction: "et", Amount: 100}, "et_vat", 15},
+		{"most specific rule wins", tax.Sale{Type: tax.SaleB2B, SellerID: "reseller1", BuyerID: "buyer1", Amount: 100}, "et_b2b", 10},
+		{"zero-rated export", tax.Sale{Type: tax.SaleB2C, SellerID: "reseller1", BuyerJurisdiction: "KE", Amount: 100}, "", 0},
+		{"seller without a country", tax.Sale{Type: tax.SaleB2C, SellerID: "reseller2", BuyerJurisdiction: "ET", Amount: 100}, "", 0},
+		{"removed seller", tax.Sale{Type: tax.SaleB2C, SellerID: "reseller3", BuyerJurisdiction: "ET", Amount: 100}, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTaxRepo)
+			users := new(MockUserRepo)
+			uc := NewTaxUsecase(repo, users, new(MockPaymentRepo), fixedClock{now: testNow})
+			repo.On("GetCurrentPolicy", mock.Anything).Return(testPolicy(), nil)
+			users.On("GetByID", mock.Anything, "reseller1").Return(&user.User{ID: "reseller1", Country: "ET"}, nil)
+			users.On("GetByID", mock.Anything, "reseller2").Return(&user.User{ID: "reseller2"}, nil)
+			users.On("GetByID", mock.Anything, "reseller3").Return(nil, mongo.ErrNoDocuments)
+			users.On("GetByID", mock.Anything, "buyer1").Return(&user.User{ID: "buyer1", Country: "et"}, nil)
+
+			a, err := uc.Assess(context.Background(), tt.sale)
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.wantTax, a.Tax, 1e-9)
+			assert.InDelta(t, 100+tt.wantTax, a.Gross, 1e-9)
+			assert.Equal(t, 100.0, a.Net)
+			if tt.wantRule == "" {
+				assert.Empty(t, a.Lines)
+				return
+			}
+			if assert.Len(t, a.Lines, 1) {
+				assert.Equal(t, tt.wantRule, a.Lines[0].Rule)
+				assert.Equal(t, 2, a.Lines[0].PolicyVersion)
+			}
+		})
+	}
+}
+
+func TestAssess_Inclusive(t *testing.T) {
+	repo := new(MockTaxRepo)
+	uc := NewTaxUsecase(repo, new(MockUserRepo), new(MockPaymentRepo), fixedClock{now: testNow})
+	repo.On("GetCurrentPolicy", mock.Anything).Return(&tax.Policy{Mode: tax.ModeInclusive, Rules: []tax.Rule{{Name: "vat", Rate: 0.15}}}, nil)
+
+	a, err := uc.Assess(context.Background(), tax.Sale{Type: tax.SaleB2C, Amount: 115})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 115.0, a.Gross)
+	assert.InDelta(t, 100, a.Net, 1e-9)
+	assert.InDelta(t, 15, a.Tax, 1e-9)
+	assert.True(t, a.Lines[0].Inclusive)
+}
+
+func TestAssess_RoundsToCents(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        tax.Mode
+		amount      float64
+		wantNet     float64
+		wantTax     float64
+		wantGross   float64
+		wantTaxable float64
+	}{
+		{"exclusive", tax.ModeExclusive, 19.99, 19.99, 3, 22.99, 19.99},
+		{"inclusive", tax.ModeInclusive, 19.99, 17.38, 2.61, 19.99, 17.38},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTaxRepo)
+			uc := NewTaxUsecase(repo, new(MockUserRepo), new(MockPaymentRepo), fixedClock{now: testNow})
+			repo.On("GetCurrentPolicy", mock.Anything).Return(&tax.Policy{Mode: tt.mode, Rules: []tax.Rule{{Name: "vat", Rate: 0.15}}}, nil)
+
+			a, err := uc.Assess(context.Background(), tax.Sale{Type: tax.SaleB2C, Amount: tt.amount})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNet, a.Net)
+			assert.Equal(t, tt.wantTax, a.Tax)
+			assert.Equal(t, tt.wantGross, a.Gross)
+			assert.Equal(t, tt.wantTaxable, a.Lines[0].Taxable)
+			assert.Equal(t, tt.wantTax, a.Lines[0].Amount)
+		})
+	}
+}
+
+func TestAssess_DefaultPolicySkipsUserLookup(t *testing.T) {
+	repo := new(MockTaxRepo)
+	users := new(MockUserRepo)
+	uc := NewTaxUsecase(repo, users, new(MockPaymentRepo), fixedClock{now: testNow})
+	repo.On("GetCurrentPolicy", mock.Anything).Return(nil, nil)
+
+	a, err := uc.Assess(context.Background(), tax.Sale{Type: tax.SaleB2B, SellerID: "supplier1", BuyerID: "reseller1", Amount: 100})
+
+	assert.NoError(t, err)
+	assert.Equal(t, tax.Assessment{Net: 100, Gross: 100}, a)
+	users.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestUpdatePolicy_SavesNextVersion(t *testing.T) {
+	repo := new(MockTaxRepo)
+	uc := NewTaxUsecase(repo, new(MockUserRepo), new(MockPaymentRepo), fixedClock{now: testNow})
+	repo.On("GetCurrentPolicy", mock.Anything).Return(testPolicy(), nil)
+	repo.On("SavePolicy", mock.Anything, mock.MatchedBy(func(p *tax.Policy) bool {
+		return p.Version == 3 && p.Mode == tax.ModeExclusive && p.UpdatedBy == "admin1" && p.UpdatedAt.Equal(testNow) &&
+			p.Rules[0].BuyerJurisdiction == "ET"
+	})).Return(nil)
+
+	p, err := uc.UpdatePolicy(context.Background(), "admin1", &tax.Policy{
+		Rules: []tax.Rule{{Name: "vat", BuyerJurisdiction: " et ", Rate: 0.15}},
+		Note:  "standard VAT",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, p.Version)
+	repo.AssertExpectations(t)
+}
+
+func TestUpdatePolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy tax.Policy
+	}{
+		{"unknown mode", tax.Policy{Mode: "gross"}},
+		{"unnamed rule", tax.Policy{Rules: []tax.Rule{{Rate: 0.1}}}},
+		{"duplicate rule", tax.Policy{Rules: []tax.Rule{{Name: "vat", Rate: 0.1}, {Name: "vat", Rate: 0.2}}}},
+		{"unknown sale type", tax.Policy{Rules: []tax.Rule{{Name: "vat", SaleType: "c2c", Rate: 0.1}}}},
+		{"rate of 100%", tax.Policy{Rules: []tax.Rule{{Name: "vat", Rate: 1}}}},
+		{"negative rate", tax.Policy{Rules: []tax.Rule{{Name: "vat", Rate: -0.1}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTaxRepo)
+			uc := NewTaxUsecase(repo, new(MockUserRepo), new(MockPaymentRepo), fixedClock{now: testNow})
+
+			_, err := uc.UpdatePolicy(context.Background(), "admin1", &tt.policy)
+
+			assert.ErrorIs(t, err, tax.ErrInvalidPolicy)
+			repo.AssertNotCalled(t, "SavePolicy", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetReport(t *testing.T) {
+	payments := new(MockPaymentRepo)
+	uc := NewTaxUsecase(new(MockTaxRepo), new(MockUserRepo), payments, fixedClock{now: testNow})
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	vat := tax.Line{Rule: "et_vat", SaleType: tax.SaleB2C, SellerJurisdiction: "ET", BuyerJurisdiction: "ET", Rate: 0.15, Taxable: 100, Amount: 15}
+	b2b := tax.Line{Rule: "et_b2b", SaleType: tax.SaleB2B, SellerJurisdiction: "ET", BuyerJurisdiction: "ET", Rate: 0.1, Taxable: 200, Amount: 20}
+	payments.On("ListPaymentsBetween", mock.Anything, from, to).Return([]*payment.Payment{
+		{ID: "p1", CreatedAt: time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), TaxLines: []tax.Line{vat}},
+		{ID: "p2", CreatedAt: time.Date(2025, 5, 20, 10, 0, 0, 0, time.UTC), TaxLines: []tax.Line{b2b}},
+		{ID: "p3", CreatedAt: time.Date(2025, 6, 10, 10, 0, 0, 0, time.UTC), TaxLines: []tax.Line{vat}},
+		// A refund of half of p3.
+		{ID: "p4", CreatedAt: time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC), RefundOf: "p3", TaxLines: tax.ScaleLines([]tax.Line{vat}, -0.5)},
+		{ID: "p5", CreatedAt: time.Date(2025, 6, 12, 10, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	r, err := uc.GetReport(context.Background(), from, to, tax.PeriodMonth)
+
+	assert.NoError(t, err)
+	if assert.Len(t, r.Rows, 2) {
+		assert.Equal(t, "2025-05", r.Rows[0].Period)
+		assert.Equal(t, 20.0, r.Rows[0].Tax)
+		assert.Equal(t, "2025-06", r.Rows[1].Period)
+		assert.Equal(t, "et_vat", r.Rows[1].Rule)
+		assert.Equal(t, 150.0, r.Rows[1].Taxable)
+		assert.Equal(t, 22.5, r.Rows[1].Tax)
+	}
+	assert.Equal(t, 42.5, r.TotalTax)
+}
@@ -2,7 +2,6 @@ package trustusecase
 
 import (
 	"context"
-	"fmt"
 	"math"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
@@ -34,23 +33,33 @@ func (uc *trustUsecase) UpdateSupplierTrustScoreOnNewRating(
 	declaredRating float64,
 	productRating float64,
 ) error {
-	fmt.Println("🔥 TRUST UPDATE CALLED")
-	fmt.Println("➡️ Supplier ID:", supplierID)
-	fmt.Println("➡️ Declared Rating:", declaredRating)
-	fmt.Println("➡️ Product Rating:", productRating)
-
 	// Step 1: Fetch the supplier user
 	supplier, err := uc.userRepo.GetByID(ctx, supplierID)
 	if err != nil {
-		fmt.Println("❌ Failed to fetch supplier:", err)
 		return err
 	}
 
-	fmt.Println("✅ Supplier Found:", supplier.ID)
-
 	// Step 2: Calculate absolute difference
 	diff := math.Abs(productRating - declaredRating)
 
+	return uc.recordError(ctx, supplier, diff)
+}
+
+// disputePenalty is the rating error a lost dispute counts as, the same as a
+// product rated 25 points below what the supplier declared.
+const disputePenalty = 25.0
+
+func (uc *trustUsecase) RecordLostDispute(ctx context.Context, supplierID string) error {
+	supplier, err := uc.userRepo.GetByID(ctx, supplierID)
+	if err != nil {
+		return err
+	}
+	return uc.recordError(ctx, supplier, disputePenalty)
+}
+
+// recordError adds one rated item with the given error to the supplier's
+// running average and stores the resulting trust score.
+func (uc *trustUsecase) recordError(ctx context.Context, supplier *user.User, diff float64) error {
 	// Step 3: Update cumulative error and count
 	newTotalError := supplier.TrustTotalError + diff
 	newRatedCount := supplier.TrustRatedCount + 1
@@ -63,29 +72,20 @@ func (uc *trustUsecase) UpdateSupplierTrustScoreOnNewRating(
 		newTrust = 100
 	}
 	if newTrust < 40 {
-		fmt.Println("⚠️ Supplier trust score below threshold — blacklisting")
 		supplier.IsBlacklisted = true
 	} else {
 		supplier.IsBlacklisted = false // Optional: recover if they improve
 	}
 
-	fmt.Println("📊 TRUST SCORE CALCULATION")
-	fmt.Println("➡️ Previous Score:", supplier.TrustScore)
-	fmt.Println("➡️ New Total Error:", newTotalError)
-	fmt.Println("➡️ New Rated Count:", newRatedCount)
-	fmt.Println("➡️ New Trust Score (calculated):", newTrust)
-
 	// Step 5: Persist the changes
 	supplier.TrustScore = int(newTrust)
 	supplier.TrustRatedCount = newRatedCount
 	supplier.TrustTotalError = newTotalError
 
-	err = uc.userRepo.UpdateTrustData(ctx, supplier)
-	if err != nil {
-		fmt.Println("❌ Failed to update supplier trust data:", err)
-	} else {
-		fmt.Println("✅ Supplier trust data updated successfully")
+	if err := uc.userRepo.UpdateTrustData(ctx, supplier); err != nil {
+		return err
 	}
 
-	return err
+	// Bundle searches filter and sort on the copy of the score kept on each bundle.
+	return uc.bundleRepo.UpdateSupplierTrust(ctx, supplier.ID, supplier.TrustScore)
 }
@@ -2,22 +2,58 @@ package warehouse_usecase
 
 import (
 	"context"
+	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/escrow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/uow"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 )
 
 type WarehouseUseCase interface {
 	GetWarehouseItems(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error)
+	MarkItemArrived(ctx context.Context, itemID, actorID string, role user.Role) (*warehouse.WarehouseItem, error)
 }
 
 type warehouseUseCaseImpl struct {
 	warehouseRepo warehouse.Repository
+	escrow        escrow.ArrivalReleaser
+	unitOfWork    uow.UnitOfWork
 }
 
-func NewWarehouseUseCase(repo warehouse.Repository) WarehouseUseCase {
-	return &warehouseUseCaseImpl{warehouseRepo: repo}
+func NewWarehouseUseCase(repo warehouse.Repository, releaser escrow.ArrivalReleaser, unitOfWork uow.UnitOfWork) WarehouseUseCase {
+	return &warehouseUseCaseImpl{warehouseRepo: repo, escrow: releaser, unitOfWork: unitOfWork}
 }
 
 func (uc *warehouseUseCaseImpl) GetWarehouseItems(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error) {
 	return uc.warehouseRepo.GetItemsByReseller(ctx, resellerID)
 }
+
+// MarkItemArrived records the arrival and releases the bundle's payment
+// together, so a failed release leaves the item to be marked again.
+func (uc *warehouseUseCaseImpl) MarkItemArrived(ctx context.Context, itemID, actorID string, role user.Role) (*warehouse.WarehouseItem, error) {
+	item, err := uc.warehouseRepo.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if role != user.RoleAdmin && item.ResellerID != actorID {
+		// Other resellers' items are not revealed.
+		return nil, warehouse.ErrItemNotFound
+	}
+	if item.ArrivedAt != nil {
+		return nil, warehouse.ErrAlreadyArrived
+	}
+
+	now := time.Now()
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := uc.warehouseRepo.MarkItemAsArrived(ctx, item.ID, now); err != nil {
+			return err
+		}
+		return uc.escrow.ReleaseOnArrival(ctx, item.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	item.ArrivedAt = &now
+	return item, nil
+}
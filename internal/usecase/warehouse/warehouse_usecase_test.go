@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -21,6 +22,14 @@ func (m *MockRepository) AddItem(ctx context.Context, item *warehouse.WarehouseI
 	return args.Error(0)
 }
 
+func (m *MockRepository) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
 func (m *MockRepository) GetItemsByReseller(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error) {
 	args := m.Called(ctx, resellerID)
 	if args.Get(0) == nil {
@@ -47,6 +56,11 @@ func (m *MockRepository) MarkItemAsSkipped(ctx context.Context, itemID string) e
 	return args.Error(0)
 }
 
+func (m *MockRepository) MarkItemAsArrived(ctx context.Context, itemID string, at time.Time) error {
+	args := m.Called(ctx, itemID, at)
+	return args.Error(0)
+}
+
 func (m *MockRepository) DeleteItem(ctx context.Context, itemID string) error {
 	args := m.Called(ctx, itemID)
 	return args.Error(0)
@@ -61,12 +75,33 @@ func (m *MockRepository) CountByStatus(ctx context.Context, status string) (int,
 	return args.Int(0), args.Error(1)
 }
 
+// recordingReleaser records the items whose escrow it was asked to release.
+type recordingReleaser struct {
+	items []string
+	err   error
+}
+
+func (r *recordingReleaser) ReleaseOnArrival(ctx context.Context, warehouseItemID string) error {
+	r.items = append(r.items, warehouseItemID)
+	return r.err
+}
+
+// passthroughUnitOfWork runs fn directly and records whether it was used.
+type passthroughUnitOfWork struct {
+	calls int
+}
+
+func (u *passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	return fn(ctx)
+}
+
 func TestNewWarehouseUseCase(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockRepository)
 
 	// Act
-	useCase := NewWarehouseUseCase(mockRepo)
+	useCase := NewWarehouseUseCase(mockRepo, &recordingReleaser{}, &passthroughUnitOfWork{})
 
 	// Assert
 	assert.NotNil(t, useCase)
@@ -116,7 +151,7 @@ func TestGetWarehouseItems(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			mockRepo := new(MockRepository)
-			useCase := NewWarehouseUseCase(mockRepo)
+			useCase := NewWarehouseUseCase(mockRepo, &recordingReleaser{}, &passthroughUnitOfWork{})
 			ctx := context.Background()
 
 			mockRepo.On("GetItemsByReseller", ctx, tt.resellerID).Return(tt.mockItems, tt.mockError)
@@ -136,3 +171,63 @@ func TestGetWarehouseItems(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkItemArrived_ReleasesEscrow(t *testing.T) {
+	mockRepo := new(MockRepository)
+	releaser := &recordingReleaser{}
+	unitOfWork := &passthroughUnitOfWork{}
+	useCase := NewWarehouseUseCase(mockRepo, releaser, unitOfWork)
+	ctx := context.Background()
+	mockRepo.On("GetItemByID", ctx, "item1").Return(&warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", Status: "listed"}, nil)
+	mockRepo.On("MarkItemAsArrived", ctx, "item1", mock.AnythingOfType("time.Time")).Return(nil)
+
+	item, err := useCase.MarkItemArrived(ctx, "item1", "reseller1", user.RoleReseller)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, item.ArrivedAt)
+	assert.Equal(t, []string{"item1"}, releaser.items)
+	assert.Equal(t, 1, unitOfWork.calls)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMarkItemArrived_ReleaseFails(t *testing.T) {
+	mockRepo := new(MockRepository)
+	releaseErr := errors.New("ledger down")
+	useCase := NewWarehouseUseCase(mockRepo, &recordingReleaser{err: releaseErr}, &passthroughUnitOfWork{})
+	ctx := context.Background()
+	mockRepo.On("GetItemByID", ctx, "item1").Return(&warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1"}, nil)
+	mockRepo.On("MarkItemAsArrived", ctx, "item1", mock.AnythingOfType("time.Time")).Return(nil)
+
+	_, err := useCase.MarkItemArrived(ctx, "item1", "admin1", user.RoleAdmin)
+
+	assert.ErrorIs(t, err, releaseErr)
+}
+
+func TestMarkItemArrived_Rejected(t *testing.T) {
+	arrived := time.Now()
+	tests := []struct {
+		name    string
+		item    *warehouse.WarehouseItem
+		actorID string
+		wantErr error
+	}{
+		{"another reseller's item", &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller2"}, "reseller1", warehouse.ErrItemNotFound},
+		{"already arrived", &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", ArrivedAt: &arrived}, "reseller1", warehouse.ErrAlreadyArrived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			releaser := &recordingReleaser{}
+			useCase := NewWarehouseUseCase(mockRepo, releaser, &passthroughUnitOfWork{})
+			ctx := context.Background()
+			mockRepo.On("GetItemByID", ctx, "item1").Return(tt.item, nil)
+
+			_, err := useCase.MarkItemArrived(ctx, "item1", tt.actorID, user.RoleReseller)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, releaser.items)
+			mockRepo.AssertNotCalled(t, "MarkItemAsArrived", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
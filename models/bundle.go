@@ -1,11 +1,14 @@
 package models
 
+import "time"
+
 type CreateBundleRequest struct {
 	Title              string         `json:"title" binding:"required"`
 	SampleImage        string         `json:"sample_image"`
 	NumberOfItems      int            `json:"number_of_items" binding:"required"`
 	Grade              string         `json:"grade" binding:"required"`
 	Price              float64        `json:"price" binding:"required"`
+	Currency           string         `json:"currency"` // defaults to the settlement currency
 	Description        string         `json:"description"`
 	SizeRange          string         `json:"size_range"`
 	ClothingTypes      []string       `json:"clothing_types"`
@@ -15,29 +18,31 @@ type CreateBundleRequest struct {
 }
 
 type BundleResponse struct {
-	ID     string  `json:"id"`
-	Title  string  `json:"title"`
-	Grade  string  `json:"grade"`
-	Price  float64 `json:"price"`
-	Type   string  `json:"type"`
-	Status string  `json:"status"`
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Grade    string  `json:"grade"`
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency"`
+	Type     string  `json:"type"`
+	Status   string  `json:"status"`
 }
 
 type BundleDetailResponse struct {
 	Bundle struct {
 		ID                 string         `json:"id"`
-		Title             string         `json:"title"`
-		Description       string         `json:"description"`
-		SampleImage       string         `json:"sample_image"`
-		Quantity          int            `json:"quantity"`
-		Grade             string         `json:"grade"`
-		SortingLevel      string         `json:"sorting_level"`
+		Title              string         `json:"title"`
+		Description        string         `json:"description"`
+		SampleImage        string         `json:"sample_image"`
+		Quantity           int            `json:"quantity"`
+		Grade              string         `json:"grade"`
+		SortingLevel       string         `json:"sorting_level"`
 		EstimatedBreakdown map[string]int `json:"estimated_breakdown"`
-		Type              string         `json:"type"`
-		Price             float64        `json:"price"`
-		Status            string         `json:"status"`
-		DeclaredRating    int            `json:"declared_rating"`
-		RemainingItemCount int           `json:"remaining_item_count"`
+		Type               string         `json:"type"`
+		Price              float64        `json:"price"`
+		Currency           string         `json:"currency"`
+		Status             string         `json:"status"`
+		DeclaredRating     int            `json:"declared_rating"`
+		RemainingItemCount int            `json:"remaining_item_count"`
 	} `json:"bundle"`
 	Supplier struct {
 		ID     string  `json:"id"`
@@ -45,3 +50,32 @@ type BundleDetailResponse struct {
 		Rating float64 `json:"rating"`
 	} `json:"supplier"`
 }
+
+// BundleListingResponse is an available bundle as resellers browse it, with
+// its price shown in the currency the search asked for.
+type BundleListingResponse struct {
+	ID                 string    `json:"id"`
+	SupplierID         string    `json:"supplier_id"`
+	Title              string    `json:"title"`
+	Description        string    `json:"description"`
+	SampleImage        string    `json:"sample_image"`
+	Quantity           int       `json:"quantity"`
+	Grade              string    `json:"grade"`
+	SortingLevel       string    `json:"sorting_level"`
+	Type               string    `json:"type"`
+	Price              float64   `json:"price"`
+	Currency           string    `json:"currency"`
+	Status             string    `json:"status"`
+	ListingMode        string    `json:"listing_mode,omitempty"`
+	DeclaredRating     int       `json:"declared_rating"`
+	RemainingItemCount int       `json:"remaining_item_count"`
+	SupplierTrust      int       `json:"supplier_trust"`
+	DateListed         time.Time `json:"date_listed"`
+}
+
+// BundlePageResponse is one page of a bundle search. NextCursor is empty on
+// the last page.
+type BundlePageResponse struct {
+	Bundles    []BundleListingResponse `json:"bundles"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
@@ -1,15 +1,32 @@
 package models
 
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+)
+
 type CreateCartItemRequest struct {
 	ListingID string `json:"listing_id" binding:"required"`
 }
 
+// CheckoutRequest picks the address book entry to ship to. Without one the
+// consumer's default address is used. PromoCode is optional, and UseCredit
+// pays as much as the consumer's store credit covers before charging them.
+// Currency is what the consumer pays in, the settlement currency by default.
+type CheckoutRequest struct {
+	AddressID string `json:"address_id"`
+	PromoCode string `json:"promo_code"`
+	UseCredit bool   `json:"use_credit"`
+	Currency  string `json:"currency"`
+}
+
 // CartItemResponse remains the same.
 type CartItemResponse struct {
 	ID        string  `json:"id"`
 	ListingID string  `json:"listing_id"`
 	Title     string  `json:"title"`
 	Price     float64 `json:"price"`
+	Currency  string  `json:"currency"`
 	ImageURL  string  `json:"image_url"`
 	Grade     string  `json:"grade"`
 	CreatedAt string  `json:"created_at"`
@@ -19,15 +36,41 @@ type CheckoutItemResponse struct {
 	ListingID string  `json:"listingId"`
 	Title     string  `json:"title"`
 	Price     float64 `json:"price"`
+	Discount  float64 `json:"discount,omitempty"`
 	SellerID  string  `json:"sellerId"`
 	Status    string  `json:"status"` // "available", "sold"
 }
 
+// CheckoutOrderResponse is the part of a checkout fulfilled and paid out to
+// a single reseller.
+type CheckoutOrderResponse struct {
+	OrderID       string                 `json:"orderId"`
+	SellerID      string                 `json:"sellerId"`
+	Items         []CheckoutItemResponse `json:"items"`
+	TotalAmount   float64                `json:"totalAmount"`
+	Charged       money.Money            `json:"charged"` // TotalAmount in the currency paid in
+	Discount      float64                `json:"discount"`
+	CreditUsed    float64                `json:"creditUsed,omitempty"`
+	PlatformFee   float64                `json:"platformFee"`
+	Tax           float64                `json:"tax"`
+	SellerEarning float64                `json:"sellerEarning"`
+}
+
+// CheckoutResponse amounts are in Currency, the settlement currency.
 type CheckoutResponse struct {
-	TotalAmount float64                `json:"totalAmount"`
-	Items       []CheckoutItemResponse `json:"items"`
-	PlatformFee float64                `json:"platformFee"` // 2%
-	NetPayable  float64                `json:"netPayable"`  // Total - fee
+	Currency    money.Currency          `json:"currency"`
+	TotalAmount float64                 `json:"totalAmount"` // after discount
+	Charged     money.Money             `json:"charged"`     // TotalAmount in the currency paid in
+	Discount    float64                 `json:"discount"`
+	CreditUsed  float64                 `json:"creditUsed,omitempty"` // part of TotalAmount paid from store credit
+	PromoCode   string                  `json:"promoCode,omitempty"`
+	Items       []CheckoutItemResponse  `json:"items"`
+	Orders      []CheckoutOrderResponse `json:"orders"`      // one per reseller
+	PlatformFee float64                 `json:"platformFee"` // 2%
+	Tax         float64                 `json:"tax"`         // part of TotalAmount
+	NetPayable  float64                 `json:"netPayable"`  // Total - tax - fee
+
+	ShippingAddress *order.ShippingAddress `json:"shippingAddress"`
 }
 
 type PaymentRecord struct {
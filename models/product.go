@@ -1,5 +1,10 @@
 package models
 
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/offer"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+)
+
 type ProductListingRequest struct {
 	ID          string  `json:"id,omitempty"` 
 	Photo       string  `json:"photo" binding:"required"`
@@ -21,3 +26,47 @@ type ProductResponse struct {
 	Status   string  `json:"status"`
 	SellerID string  `json:"seller_id"`
 }
+
+// CreateProductRequest lists a product unpacked from a bundle.
+type CreateProductRequest struct {
+	BundleID    string  `json:"bundle_id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Size        string  `json:"size"`
+	Type        string  `json:"type"`
+	Grade       string  `json:"grade"`
+	Price       float64 `json:"price"`
+	Currency    string  `json:"currency"` // defaults to the settlement currency
+	ImageURL    string  `json:"image_url"`
+	Rating      float64 `json:"rating"`
+}
+
+// ProductListingResponse is a product as the catalog shows it, with its
+// price in the currency the request asked for.
+type ProductListingResponse struct {
+	ID          string             `json:"id"`
+	ResellerID  string             `json:"reseller_id"`
+	SupplierID  string             `json:"supplier_id"`
+	BundleID    string             `json:"bundle_id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Size        string             `json:"size"`
+	Type        string             `json:"type"`
+	Grade       string             `json:"grade"`
+	Price       float64            `json:"price"`
+	Currency    string             `json:"currency"`
+	Status      string             `json:"status"`
+	ImageURL    string             `json:"image_url"`
+	CreatedAt   string             `json:"created_at"`
+	Rating      float64            `json:"rating"`
+	Reservation *offer.Reservation `json:"reservation,omitempty"`
+}
+
+// ProductSearchResponse is one page of a catalog search.
+type ProductSearchResponse struct {
+	Products []ProductListingResponse `json:"products"`
+	Total    int                      `json:"total"`
+	Page     int                      `json:"page"`
+	Limit    int                      `json:"limit"`
+	Facets   product.Facets           `json:"facets"`
+}